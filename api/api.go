@@ -7,7 +7,7 @@ import (
 	"time"
 )
 
-//go:generate go tool mockgen -package api -destination mock.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentLimiter,CurrentGetter,PhaseSwitcher,PhaseGetter,Identifier,Meter,MeterEnergy,PhaseCurrents,Vehicle,ChargeRater,Battery,Tariff,BatteryController,Circuit
+//go:generate go tool mockgen -package api -destination mock.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentLimiter,CurrentGetter,PhaseSwitcher,PhaseGetter,Identifier,Meter,MeterEnergy,PhaseCurrents,Vehicle,ChargeRater,Battery,Tariff,BatteryController,Circuit,PowerController
 
 // Meter provides total active power in W
 type Meter interface {
@@ -19,6 +19,11 @@ type MeterEnergy interface {
 	TotalEnergy() (float64, error)
 }
 
+// MeterGas provides the total volume of a gas meter attached to the meter in m³
+type MeterGas interface {
+	TotalGas() (float64, error)
+}
+
 // PhaseCurrents provides per-phase current A
 type PhaseCurrents interface {
 	Currents() (float64, float64, float64, error)
@@ -49,6 +54,18 @@ type MaxACPowerGetter interface {
 	MaxACPower() float64
 }
 
+// PowerLimiter limits the inverter's AC output power in W, e.g. for feed-in limitation.
+// A zero limit curtails output completely, RemovePowerLimit restores unlimited output.
+type PowerLimiter interface {
+	SetPowerLimit(power float64) error
+	RemovePowerLimit() error
+}
+
+// InverterStandby puts the inverter into standby during periods without production and wakes it up
+type InverterStandby interface {
+	SetStandby(standby bool) error
+}
+
 // ChargeState provides current charging status
 type ChargeState interface {
 	Status() (ChargeStatus, error)
@@ -86,6 +103,16 @@ type ChargerEx interface {
 	MaxCurrentMillis(current float64) error
 }
 
+// PowerController provides charge power control in W for chargers exposing a power instead of a current setpoint, e.g. DC wallboxes
+type PowerController interface {
+	MaxPower(power float64) error
+}
+
+// StatusNotifier signals charger status changes, triggering an immediate loadpoint update instead of waiting for the next interval
+type StatusNotifier interface {
+	StatusChanged() <-chan struct{}
+}
+
 // PhaseSwitcher provides 1p3p switching
 type PhaseSwitcher interface {
 	Phases1p3p(phases int) error
@@ -95,6 +122,17 @@ type PhaseGetter interface {
 	GetPhases() (int, error)
 }
 
+// Fault is an active device fault
+type Fault struct {
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+// FaultReporter reports active device faults, e.g. from inverter fault registers
+type FaultReporter interface {
+	Faults() ([]Fault, error)
+}
+
 // Diagnosis is a helper interface that allows to dump diagnostic data to console
 type Diagnosis interface {
 	Diagnose()
@@ -115,6 +153,27 @@ type Identifier interface {
 	Identify() (string, error)
 }
 
+// SignedMeterValues are the signed meter readings (e.g. OCMF) at transaction begin and end
+type SignedMeterValues struct {
+	Start, Stop string
+	Verified    *bool // nil if no public key is configured
+}
+
+// SignedMeterValuer provides signed meter readings of the current or last transaction for calibration law compliant billing
+type SignedMeterValuer interface {
+	SignedMeterValues() (SignedMeterValues, error)
+}
+
+// ConnectorUnlocker unlocks the charger connector to release the cable
+type ConnectorUnlocker interface {
+	UnlockConnector() error
+}
+
+// ConnectorLocker permanently locks or unlocks the charger connector
+type ConnectorLocker interface {
+	LockConnector(lock bool) error
+}
+
 // Authorizer authorizes a charging session by supplying RFID credentials
 type Authorizer interface {
 	Authorize(key string) error
@@ -150,6 +209,20 @@ type VehicleRange interface {
 	Range() (int64, error)
 }
 
+// VehicleAuxiliary provides soc (%) and remaining km range of a vehicle's secondary energy store,
+// e.g. the fuel tank of a range extender or an auxiliary battery. Soc and charge plans always refer to the traction battery.
+type VehicleAuxiliary interface {
+	Auxiliary() (float64, int64, error)
+}
+
+// SocTracker is a vehicle without soc of its own, tracking soc from the energy charged
+type SocTracker interface {
+	// SetSoc corrects the tracked soc (%)
+	SetSoc(soc float64) error
+	// AddEnergy accounts energy charged into the vehicle (kWh)
+	AddEnergy(energy float64)
+}
+
 // VehicleClimater provides climatisation data
 type VehicleClimater interface {
 	Climater() (bool, error)
@@ -165,6 +238,20 @@ type VehiclePosition interface {
 	Position() (float64, float64, error)
 }
 
+// ChargingRecord is a charging session reported by the vehicle, e.g. at public chargers
+type ChargingRecord struct {
+	Start    time.Time `json:"start"`
+	End      time.Time `json:"end"`
+	Energy   float64   `json:"energy"`             // kWh
+	Price    *float64  `json:"price,omitempty"`    // total cost
+	Location string    `json:"location,omitempty"` // charging location
+}
+
+// VehicleChargingHistory provides the vehicle's charging sessions since given time
+type VehicleChargingHistory interface {
+	ChargingHistory(from time.Time) ([]ChargingRecord, error)
+}
+
 // CurrentLimiter returns the current limits
 type CurrentLimiter interface {
 	GetMinMaxCurrent() (float64, float64, error)
@@ -175,6 +262,37 @@ type SocLimiter interface {
 	GetLimitSoc() (int64, error)
 }
 
+// HeatStorage is a heating device with thermal storage
+type HeatStorage interface {
+	// HeatDemand returns the energy in kWh required to guarantee the minimum storage temperature at the returned time
+	HeatDemand() (time.Time, float64, error)
+}
+
+// Runtimer is a device requiring a minimum daily runtime
+type Runtimer interface {
+	// Runtime returns today's and the required runtime which must be reached at the returned time
+	Runtime() (time.Duration, time.Duration, time.Time, error)
+}
+
+// Price levels for status display
+const (
+	PriceLevelLow    = "low"
+	PriceLevelNormal = "normal"
+	PriceLevelHigh   = "high"
+)
+
+// DisplayStatus is the loadpoint status shown at the charger
+type DisplayStatus struct {
+	Mode       ChargeMode
+	PlanActive bool
+	PriceLevel string // empty if no dynamic tariff
+}
+
+// StatusDisplay shows loadpoint status at the charger using LEDs or display
+type StatusDisplay interface {
+	Display(DisplayStatus) error
+}
+
 // ChargeController allows to start/stop the charging session on the vehicle side
 type ChargeController interface {
 	ChargeEnable(bool) error
@@ -191,6 +309,50 @@ type Tariff interface {
 	Type() TariffType
 }
 
+// RatesSetter is a tariff receiving its rates from an external source
+type RatesSetter interface {
+	SetRates(Rates) error
+}
+
+// ProductionRecorder is a solar forecast learning from measured production
+type ProductionRecorder interface {
+	RecordProduction(time.Time, float64)
+}
+
+// ForecastProviders exposes the individual forecasts of a merged solar forecast by name
+type ForecastProviders interface {
+	Providers() map[string]Tariff
+}
+
+// SolarPlanes exposes the individual forecasts of a solar tariff summed over multiple pv planes
+type SolarPlanes interface {
+	Planes() []Tariff
+}
+
+// DemandTariff bills the peak grid demand of a billing period in addition to energy prices
+type DemandTariff interface {
+	// RecordDemand records the grid import power (W)
+	RecordDemand(time.Time, float64)
+	// DemandPeak returns the peak average demand of the current billing period (W)
+	DemandPeak() float64
+	// DemandSurcharge returns the additional price per kWh for importing the given power (W) now
+	DemandSurcharge(float64) float64
+}
+
+// PriceComposer provides the raw price a tariff's price is composed from by adding surcharges and taxes
+type PriceComposer interface {
+	// RawPrice returns the raw price of the rate containing the given time
+	RawPrice(time.Time) (float64, error)
+}
+
+// TieredTariff prices grid energy by the cumulative grid import within a billing period
+type TieredTariff interface {
+	// TierPeriod returns the start of the billing period containing the given time
+	TierPeriod(time.Time) time.Time
+	// SetTierConsumption sets the grid import of the current billing period (kWh)
+	SetTierConsumption(float64)
+}
+
 // AuthProvider is the ability to provide OAuth authentication through the ui
 type AuthProvider interface {
 	SetCallbackParams(baseURL, redirectURL string, authenticated chan<- bool)
@@ -208,6 +370,11 @@ type FeatureDescriber interface {
 	Features() []Feature
 }
 
+// LoadpointRestricter optionally restricts the loadpoints a vehicle may charge at
+type LoadpointRestricter interface {
+	Loadpoints() []string
+}
+
 // CsvWriter converts to csv
 type CsvWriter interface {
 	WriteCsv(context.Context, io.Writer) error
@@ -11,4 +11,8 @@ const (
 	Heating
 	Retryable
 	WelcomeCharge
+	PowerLimit
+	Standby
+	ForcedDischarge
+	Auxiliary
 )
@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _FeatureName = "OfflineCoarseCurrentIntegratedDeviceHeatingRetryableWelcomeCharge"
+const _FeatureName = "OfflineCoarseCurrentIntegratedDeviceHeatingRetryableWelcomeChargePowerLimitStandbyForcedDischargeAuxiliary"
 
-var _FeatureIndex = [...]uint8{0, 7, 20, 36, 43, 52, 65}
+var _FeatureIndex = [...]uint8{0, 7, 20, 36, 43, 52, 65, 75, 82, 97, 106}
 
-const _FeatureLowerName = "offlinecoarsecurrentintegrateddeviceheatingretryablewelcomecharge"
+const _FeatureLowerName = "offlinecoarsecurrentintegrateddeviceheatingretryablewelcomechargepowerlimitstandbyforceddischargeauxiliary"
 
 func (i Feature) String() string {
 	i -= 1
@@ -31,23 +31,35 @@ func _FeatureNoOp() {
 	_ = x[Heating-(4)]
 	_ = x[Retryable-(5)]
 	_ = x[WelcomeCharge-(6)]
+	_ = x[PowerLimit-(7)]
+	_ = x[Standby-(8)]
+	_ = x[ForcedDischarge-(9)]
+	_ = x[Auxiliary-(10)]
 }
 
-var _FeatureValues = []Feature{Offline, CoarseCurrent, IntegratedDevice, Heating, Retryable, WelcomeCharge}
+var _FeatureValues = []Feature{Offline, CoarseCurrent, IntegratedDevice, Heating, Retryable, WelcomeCharge, PowerLimit, Standby, ForcedDischarge, Auxiliary}
 
 var _FeatureNameToValueMap = map[string]Feature{
-	_FeatureName[0:7]:        Offline,
-	_FeatureLowerName[0:7]:   Offline,
-	_FeatureName[7:20]:       CoarseCurrent,
-	_FeatureLowerName[7:20]:  CoarseCurrent,
-	_FeatureName[20:36]:      IntegratedDevice,
-	_FeatureLowerName[20:36]: IntegratedDevice,
-	_FeatureName[36:43]:      Heating,
-	_FeatureLowerName[36:43]: Heating,
-	_FeatureName[43:52]:      Retryable,
-	_FeatureLowerName[43:52]: Retryable,
-	_FeatureName[52:65]:      WelcomeCharge,
-	_FeatureLowerName[52:65]: WelcomeCharge,
+	_FeatureName[0:7]:         Offline,
+	_FeatureLowerName[0:7]:    Offline,
+	_FeatureName[7:20]:        CoarseCurrent,
+	_FeatureLowerName[7:20]:   CoarseCurrent,
+	_FeatureName[20:36]:       IntegratedDevice,
+	_FeatureLowerName[20:36]:  IntegratedDevice,
+	_FeatureName[36:43]:       Heating,
+	_FeatureLowerName[36:43]:  Heating,
+	_FeatureName[43:52]:       Retryable,
+	_FeatureLowerName[43:52]:  Retryable,
+	_FeatureName[52:65]:       WelcomeCharge,
+	_FeatureLowerName[52:65]:  WelcomeCharge,
+	_FeatureName[65:75]:       PowerLimit,
+	_FeatureLowerName[65:75]:  PowerLimit,
+	_FeatureName[75:82]:       Standby,
+	_FeatureLowerName[75:82]:  Standby,
+	_FeatureName[82:97]:       ForcedDischarge,
+	_FeatureLowerName[82:97]:  ForcedDischarge,
+	_FeatureName[97:106]:      Auxiliary,
+	_FeatureLowerName[97:106]: Auxiliary,
 }
 
 var _FeatureNames = []string{
@@ -57,6 +69,10 @@ var _FeatureNames = []string{
 	_FeatureName[36:43],
 	_FeatureName[43:52],
 	_FeatureName[52:65],
+	_FeatureName[65:75],
+	_FeatureName[75:82],
+	_FeatureName[82:97],
+	_FeatureName[97:106],
 }
 
 // FeatureString retrieves an enum value from the enum constants string name.
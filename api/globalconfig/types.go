@@ -30,6 +30,7 @@ type All struct {
 	Javascript   []Javascript
 	Go           []Go
 	Influx       Influx
+	Status       Status
 	EEBus        eebus.Config
 	HEMS         Hems
 	Messaging    Messaging
@@ -75,6 +76,7 @@ var _ api.Redactor = (*Mqtt)(nil)
 type Mqtt struct {
 	mqtt.Config `mapstructure:",squash"`
 	Topic       string `json:"topic"`
+	Compact     bool   `json:"compact"` // publish rates as compact [offset, price] tuples
 }
 
 // Redacted implements the redactor interface used by the tee publisher
@@ -124,14 +126,28 @@ func (c Influx) Redacted() any {
 	}
 }
 
+// Status is the public status page configuration
+type Status struct {
+	Title  string        // page title, defaults to site title
+	Keys   []string      // state keys exposed without authentication, e.g. pvPower or loadpoints.0.chargePower
+	MaxAge time.Duration // client cache duration
+}
+
+// Configured returns true if the public status is enabled
+func (c Status) Configured() bool {
+	return len(c.Keys) > 0
+}
+
 type DB struct {
-	Type string
-	Dsn  string
+	Type           string
+	Dsn            string
+	EventRetention time.Duration // event log retention
 }
 
 type Messaging struct {
-	Events   map[string]push.EventTemplateConfig
-	Services []config.Typed
+	Events      map[string]push.EventTemplateConfig
+	Services    []config.Typed
+	ExternalUrl string // public url for notification action links, defaults to network url
 }
 
 func (c Messaging) Configured() bool {
@@ -139,12 +155,27 @@ func (c Messaging) Configured() bool {
 }
 
 type Tariffs struct {
-	Currency string
-	Grid     config.Typed
-	FeedIn   config.Typed
-	Co2      config.Typed
-	Planner  config.Typed
-	Solar    []config.Typed
+	Currency    string
+	Grid        config.Typed
+	GridFee     config.Typed // time-variable network charges added to the grid tariff
+	FeedIn      config.Typed
+	Co2         config.Typed
+	Planner     config.Typed
+	Solar       []config.Typed
+	GridState   config.Typed
+	Temperature config.Typed
+
+	Location Location // site location for detecting regional tariff zones
+
+	Co2Marginal  config.Typed // marginal co2 intensity
+	Co2Intensity string       // co2 intensity used for effective co2 and planning, average (default) or marginal
+
+	Comparison []config.Named // alternative grid tariffs, e.g. for evaluating a contract switch
+}
+
+// Location is a geographic location
+type Location struct {
+	Lat, Lon float64
 }
 
 type Network struct {
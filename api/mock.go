@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/evcc-io/evcc/api (interfaces: Charger,ChargeState,CurrentLimiter,CurrentGetter,PhaseSwitcher,PhaseGetter,Identifier,Meter,MeterEnergy,PhaseCurrents,Vehicle,ChargeRater,Battery,Tariff,BatteryController,Circuit)
+// Source: github.com/evcc-io/evcc/api (interfaces: Charger,ChargeState,CurrentLimiter,CurrentGetter,PhaseSwitcher,PhaseGetter,Identifier,Meter,MeterEnergy,PhaseCurrents,Vehicle,ChargeRater,Battery,Tariff,BatteryController,Circuit,PowerController)
 //
 // Generated by this command:
 //
-//	mockgen -package api -destination mock.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentLimiter,CurrentGetter,PhaseSwitcher,PhaseGetter,Identifier,Meter,MeterEnergy,PhaseCurrents,Vehicle,ChargeRater,Battery,Tariff,BatteryController,Circuit
+//	mockgen -package api -destination mock.go github.com/evcc-io/evcc/api Charger,ChargeState,CurrentLimiter,CurrentGetter,PhaseSwitcher,PhaseGetter,Identifier,Meter,MeterEnergy,PhaseCurrents,Vehicle,ChargeRater,Battery,Tariff,BatteryController,Circuit,PowerController
 //
 
 // Package api is a generated GoMock package.
@@ -993,3 +993,41 @@ func (mr *MockCircuitMockRecorder) Wrap(parent any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Wrap", reflect.TypeOf((*MockCircuit)(nil).Wrap), parent)
 }
+
+// MockPowerController is a mock of PowerController interface.
+type MockPowerController struct {
+	ctrl     *gomock.Controller
+	recorder *MockPowerControllerMockRecorder
+	isgomock struct{}
+}
+
+// MockPowerControllerMockRecorder is the mock recorder for MockPowerController.
+type MockPowerControllerMockRecorder struct {
+	mock *MockPowerController
+}
+
+// NewMockPowerController creates a new mock instance.
+func NewMockPowerController(ctrl *gomock.Controller) *MockPowerController {
+	mock := &MockPowerController{ctrl: ctrl}
+	mock.recorder = &MockPowerControllerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPowerController) EXPECT() *MockPowerControllerMockRecorder {
+	return m.recorder
+}
+
+// MaxPower mocks base method.
+func (m *MockPowerController) MaxPower(power float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MaxPower", power)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MaxPower indicates an expected call of MaxPower.
+func (mr *MockPowerControllerMockRecorder) MaxPower(power any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MaxPower", reflect.TypeOf((*MockPowerController)(nil).MaxPower), power)
+}
@@ -7,3 +7,14 @@ type RepeatingPlanStruct struct {
 	Soc      int    `json:"soc"`
 	Active   bool   `json:"active"`
 }
+
+// VehicleProfile is a named set of vehicle settings
+type VehicleProfile struct {
+	Name             string   `json:"name"`
+	MinSoc           *int     `json:"minSoc,omitempty"`
+	LimitSoc         *int     `json:"limitSoc,omitempty"`
+	PlanSoc          int      `json:"planSoc,omitempty"`
+	PlanTime         string   `json:"planTime,omitempty"` // HH:MM, next occurrence
+	SmartCostLimit   *float64 `json:"smartCostLimit,omitempty"`
+	DisableSmartCost bool     `json:"disableSmartCost,omitempty"`
+}
@@ -51,3 +51,51 @@ func (rr Rates) At(ts time.Time) (Rate, error) {
 func (r Rates) MarshalMQTT() ([]byte, error) {
 	return json.Marshal(r)
 }
+
+// CompactRates is a bandwidth-saving encoding of contiguous rates as [offset, price] tuples relative
+// to a shared base timestamp. Each rate ends where the next one starts, the last rate ends at base+end.
+type CompactRates struct {
+	Base   int64        `json:"base"`   // unix timestamp of first rate start
+	End    int64        `json:"end"`    // offset of last rate end (s)
+	Values [][2]float64 `json:"values"` // offset of rate start (s), price
+}
+
+// Compact returns the compact encoding of sorted rates. Gaps between rates are not preserved.
+func (rr Rates) Compact() CompactRates {
+	res := CompactRates{
+		Values: make([][2]float64, 0, len(rr)),
+	}
+
+	if len(rr) == 0 {
+		return res
+	}
+
+	res.Base = rr[0].Start.Unix()
+	res.End = rr[len(rr)-1].End.Unix() - res.Base
+
+	for _, r := range rr {
+		res.Values = append(res.Values, [2]float64{float64(r.Start.Unix() - res.Base), r.Price})
+	}
+
+	return res
+}
+
+// Rates returns the rates of the compact encoding
+func (c CompactRates) Rates() Rates {
+	res := make(Rates, 0, len(c.Values))
+
+	for i, v := range c.Values {
+		end := c.End
+		if i < len(c.Values)-1 {
+			end = int64(c.Values[i+1][0])
+		}
+
+		res = append(res, Rate{
+			Start: time.Unix(c.Base+int64(v[0]), 0),
+			End:   time.Unix(c.Base+end, 0),
+			Price: v[1],
+		})
+	}
+
+	return res
+}
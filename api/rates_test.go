@@ -36,3 +36,26 @@ func TestRates(t *testing.T) {
 	_, err = rr.At(clock.Now().Add(5 * time.Hour))
 	assert.Error(t, err)
 }
+
+func TestRatesCompact(t *testing.T) {
+	base := time.Unix(1735689600, 0)
+
+	var rr Rates
+	for i, price := range []float64{0.1, 0.2, 0.3} {
+		rr = append(rr, Rate{
+			Start: base.Add(time.Duration(i) * 15 * time.Minute),
+			End:   base.Add(time.Duration(i+1) * 15 * time.Minute),
+			Price: price,
+		})
+	}
+
+	c := rr.Compact()
+	assert.Equal(t, CompactRates{
+		Base:   base.Unix(),
+		End:    2700,
+		Values: [][2]float64{{0, 0.1}, {900, 0.2}, {1800, 0.3}},
+	}, c)
+
+	assert.Equal(t, rr, c.Rates())
+	assert.Empty(t, Rates(nil).Compact().Rates())
+}
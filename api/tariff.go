@@ -12,6 +12,8 @@ const (
 	TariffTypePriceForecast
 	TariffTypeCo2
 	TariffTypeSolar
+	TariffTypeGridState
+	TariffTypeTemperature
 )
 
 type TariffUsage int
@@ -23,4 +25,7 @@ const (
 	TariffUsageGrid
 	TariffUsagePlanner
 	TariffUsageSolar
+	TariffUsageGridState
+	TariffUsageTemperature
+	TariffUsageCo2Marginal
 )
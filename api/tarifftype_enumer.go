@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _TariffTypeName = "pricestaticpricedynamicpriceforecastco2solar"
+const _TariffTypeName = "pricestaticpricedynamicpriceforecastco2solargridstatetemperature"
 
-var _TariffTypeIndex = [...]uint8{0, 11, 23, 36, 39, 44}
+var _TariffTypeIndex = [...]uint8{0, 11, 23, 36, 39, 44, 53, 64}
 
-const _TariffTypeLowerName = "pricestaticpricedynamicpriceforecastco2solar"
+const _TariffTypeLowerName = "pricestaticpricedynamicpriceforecastco2solargridstatetemperature"
 
 func (i TariffType) String() string {
 	i -= 1
@@ -30,9 +30,11 @@ func _TariffTypeNoOp() {
 	_ = x[TariffTypePriceForecast-(3)]
 	_ = x[TariffTypeCo2-(4)]
 	_ = x[TariffTypeSolar-(5)]
+	_ = x[TariffTypeGridState-(6)]
+	_ = x[TariffTypeTemperature-(7)]
 }
 
-var _TariffTypeValues = []TariffType{TariffTypePriceStatic, TariffTypePriceDynamic, TariffTypePriceForecast, TariffTypeCo2, TariffTypeSolar}
+var _TariffTypeValues = []TariffType{TariffTypePriceStatic, TariffTypePriceDynamic, TariffTypePriceForecast, TariffTypeCo2, TariffTypeSolar, TariffTypeGridState, TariffTypeTemperature}
 
 var _TariffTypeNameToValueMap = map[string]TariffType{
 	_TariffTypeName[0:11]:       TariffTypePriceStatic,
@@ -45,6 +47,10 @@ var _TariffTypeNameToValueMap = map[string]TariffType{
 	_TariffTypeLowerName[36:39]: TariffTypeCo2,
 	_TariffTypeName[39:44]:      TariffTypeSolar,
 	_TariffTypeLowerName[39:44]: TariffTypeSolar,
+	_TariffTypeName[44:53]:      TariffTypeGridState,
+	_TariffTypeLowerName[44:53]: TariffTypeGridState,
+	_TariffTypeName[53:64]:      TariffTypeTemperature,
+	_TariffTypeLowerName[53:64]: TariffTypeTemperature,
 }
 
 var _TariffTypeNames = []string{
@@ -53,6 +59,8 @@ var _TariffTypeNames = []string{
 	_TariffTypeName[23:36],
 	_TariffTypeName[36:39],
 	_TariffTypeName[39:44],
+	_TariffTypeName[44:53],
+	_TariffTypeName[53:64],
 }
 
 // TariffTypeString retrieves an enum value from the enum constants string name.
@@ -7,11 +7,11 @@ import (
 	"strings"
 )
 
-const _TariffUsageName = "co2feedingridplannersolar"
+const _TariffUsageName = "co2feedingridplannersolargridstatetemperatureco2marginal"
 
-var _TariffUsageIndex = [...]uint8{0, 3, 9, 13, 20, 25}
+var _TariffUsageIndex = [...]uint8{0, 3, 9, 13, 20, 25, 34, 45, 56}
 
-const _TariffUsageLowerName = "co2feedingridplannersolar"
+const _TariffUsageLowerName = "co2feedingridplannersolargridstatetemperatureco2marginal"
 
 func (i TariffUsage) String() string {
 	i -= 1
@@ -30,9 +30,12 @@ func _TariffUsageNoOp() {
 	_ = x[TariffUsageGrid-(3)]
 	_ = x[TariffUsagePlanner-(4)]
 	_ = x[TariffUsageSolar-(5)]
+	_ = x[TariffUsageGridState-(6)]
+	_ = x[TariffUsageTemperature-(7)]
+	_ = x[TariffUsageCo2Marginal-(8)]
 }
 
-var _TariffUsageValues = []TariffUsage{TariffUsageCo2, TariffUsageFeedIn, TariffUsageGrid, TariffUsagePlanner, TariffUsageSolar}
+var _TariffUsageValues = []TariffUsage{TariffUsageCo2, TariffUsageFeedIn, TariffUsageGrid, TariffUsagePlanner, TariffUsageSolar, TariffUsageGridState, TariffUsageTemperature, TariffUsageCo2Marginal}
 
 var _TariffUsageNameToValueMap = map[string]TariffUsage{
 	_TariffUsageName[0:3]:        TariffUsageCo2,
@@ -45,6 +48,12 @@ var _TariffUsageNameToValueMap = map[string]TariffUsage{
 	_TariffUsageLowerName[13:20]: TariffUsagePlanner,
 	_TariffUsageName[20:25]:      TariffUsageSolar,
 	_TariffUsageLowerName[20:25]: TariffUsageSolar,
+	_TariffUsageName[25:34]:      TariffUsageGridState,
+	_TariffUsageLowerName[25:34]: TariffUsageGridState,
+	_TariffUsageName[34:45]:      TariffUsageTemperature,
+	_TariffUsageLowerName[34:45]: TariffUsageTemperature,
+	_TariffUsageName[45:56]:      TariffUsageCo2Marginal,
+	_TariffUsageLowerName[45:56]: TariffUsageCo2Marginal,
 }
 
 var _TariffUsageNames = []string{
@@ -53,6 +62,9 @@ var _TariffUsageNames = []string{
 	_TariffUsageName[9:13],
 	_TariffUsageName[13:20],
 	_TariffUsageName[20:25],
+	_TariffUsageName[25:34],
+	_TariffUsageName[34:45],
+	_TariffUsageName[45:56],
 }
 
 // TariffUsageString retrieves an enum value from the enum constants string name.
@@ -19,6 +19,7 @@ type Charger struct {
 	enabledG    func() (bool, error)
 	enableS     func(bool) error
 	maxCurrentS func(int64) error
+	maxPowerS   func(float64) error
 }
 
 func init() {
@@ -33,6 +34,7 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 		embed                               `mapstructure:",squash"`
 		Status, Enable, Enabled, MaxCurrent plugin.Config
 		MaxCurrentMillis                    *plugin.Config
+		MaxPower                            *plugin.Config
 		Identify, Phases1p3p                *plugin.Config
 		Wakeup                              *plugin.Config
 		Soc                                 *plugin.Config
@@ -61,9 +63,13 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 		return nil, fmt.Errorf("enable: %w", err)
 	}
 
-	maxcurrent, err := cc.MaxCurrent.IntSetter(ctx, "maxcurrent")
-	if err != nil {
-		return nil, fmt.Errorf("maxcurrent: %w", err)
+	// power controlled chargers don't require current control
+	maxcurrent := func(int64) error { return nil }
+	if cc.MaxPower == nil || cc.MaxCurrent.Source != "" {
+		maxcurrent, err = cc.MaxCurrent.IntSetter(ctx, "maxcurrent")
+		if err != nil {
+			return nil, fmt.Errorf("maxcurrent: %w", err)
+		}
 	}
 
 	c, err := NewConfigurable(status, enabled, enable, maxcurrent)
@@ -78,6 +84,11 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 		return nil, fmt.Errorf("maxcurrentmillis: %w", err)
 	}
 
+	c.maxPowerS, err = cc.MaxPower.FloatSetter(ctx, "maxpower")
+	if err != nil {
+		return nil, fmt.Errorf("maxpower: %w", err)
+	}
+
 	// decorate phases
 	var phases1p3p func(int) error
 	if cc.Phases1p3p != nil {
@@ -182,3 +193,13 @@ func (m *Charger) Enable(enable bool) error {
 func (m *Charger) MaxCurrent(current int64) error {
 	return m.maxCurrentS(current)
 }
+
+var _ api.PowerController = (*Charger)(nil)
+
+// MaxPower implements the api.PowerController interface
+func (m *Charger) MaxPower(power float64) error {
+	if m.maxPowerS == nil {
+		return api.ErrNotAvailable
+	}
+	return m.maxPowerS(power)
+}
@@ -0,0 +1,209 @@
+package charger
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/plugin"
+	"github.com/evcc-io/evcc/util"
+)
+
+// waterHeatCapacity is the heat capacity of water in Wh/(l*K)
+const waterHeatCapacity = 1.163
+
+// Dhw is a domestic hot water heating element with tank model
+type Dhw struct {
+	*switchSocket
+	mu      sync.Mutex
+	clock   clock.Clock
+	enable  func(bool) error
+	enabled func() (bool, error)
+	tempG   func() (float64, error)
+
+	capacity float64 // Wh/K
+	setpoint float64 // °C
+	minTemp  float64 // °C
+	minTime  time.Duration
+	losses   float64 // W
+
+	temp    float64
+	updated time.Time
+}
+
+func init() {
+	registry.AddCtx("dhw", NewDhwFromConfig)
+}
+
+// NewDhwFromConfig creates a domestic hot water charger from generic config
+func NewDhwFromConfig(ctx context.Context, other map[string]interface{}) (api.Charger, error) {
+	cc := struct {
+		embed        `mapstructure:",squash"`
+		Enabled      plugin.Config
+		Enable       plugin.Config
+		Power        *plugin.Config // optional
+		Temp         *plugin.Config // optional
+		HeaterPower  float64
+		Volume       float64
+		Setpoint     float64
+		MinTemp      float64
+		MinTime      string
+		Losses       float64
+		StandbyPower float64
+	}{
+		embed: embed{
+			Icon_:     "waterheater",
+			Features_: []api.Feature{api.Heating, api.IntegratedDevice},
+		},
+		Setpoint: 60,
+		MinTemp:  45,
+		MinTime:  "06:00",
+		Losses:   60,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.HeaterPower <= 0 || cc.Volume <= 0 {
+		return nil, errors.New("missing heater power or volume")
+	}
+
+	if cc.MinTemp > cc.Setpoint {
+		return nil, errors.New("minimum temperature must not exceed setpoint")
+	}
+
+	minTime, err := time.Parse("15:04", cc.MinTime)
+	if err != nil {
+		return nil, fmt.Errorf("mintime: %w", err)
+	}
+
+	enabled, err := cc.Enabled.BoolGetter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	enable, err := cc.Enable.BoolSetter(ctx, "enable")
+	if err != nil {
+		return nil, err
+	}
+
+	powerG, err := cc.Power.FloatGetter(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("power: %w", err)
+	}
+
+	tempG, err := cc.Temp.FloatGetter(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("temp: %w", err)
+	}
+
+	// without power measurement assume static heater power
+	if powerG == nil {
+		cc.StandbyPower = -cc.HeaterPower
+	}
+
+	c := NewDhw(&cc.embed, enabled, enable, powerG, cc.StandbyPower, cc.Volume, cc.Setpoint, cc.MinTemp, cc.Losses)
+	c.minTime = time.Duration(minTime.Hour())*time.Hour + time.Duration(minTime.Minute())*time.Minute
+	c.tempG = tempG
+
+	return c, nil
+}
+
+// NewDhw creates a domestic hot water charger
+func NewDhw(
+	embed *embed,
+	enabled func() (bool, error),
+	enable func(bool) error,
+	powerG func() (float64, error),
+	standbyPower, volume, setpoint, minTemp, losses float64,
+) *Dhw {
+	return &Dhw{
+		switchSocket: NewSwitchSocket(embed, enabled, powerG, standbyPower),
+		clock:        clock.New(),
+		enabled:      enabled,
+		enable:       enable,
+		capacity:     volume * waterHeatCapacity,
+		setpoint:     setpoint,
+		minTemp:      minTemp,
+		losses:       losses,
+		temp:         minTemp, // assume worst case until measured or heated
+	}
+}
+
+// Enabled implements the api.Charger interface
+func (c *Dhw) Enabled() (bool, error) {
+	return c.enabled()
+}
+
+// Enable implements the api.Charger interface
+func (c *Dhw) Enable(enable bool) error {
+	return c.enable(enable)
+}
+
+// temperature updates and returns the tank temperature, measured or modeled from heating energy and losses
+func (c *Dhw) temperature() (float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.tempG != nil {
+		temp, err := c.tempG()
+		if err == nil {
+			c.temp = temp
+		}
+		return temp, err
+	}
+
+	now := c.clock.Now()
+	if !c.updated.IsZero() {
+		power, err := c.switchSocket.CurrentPower()
+		if err != nil {
+			return 0, err
+		}
+
+		energy := (power - c.losses) * now.Sub(c.updated).Hours()
+		c.temp = min(c.setpoint, c.temp+energy/c.capacity)
+	}
+	c.updated = now
+
+	return c.temp, nil
+}
+
+var _ api.Battery = (*Dhw)(nil)
+
+// Soc implements the api.Battery interface
+func (c *Dhw) Soc() (float64, error) {
+	return c.temperature()
+}
+
+var _ api.SocLimiter = (*Dhw)(nil)
+
+// GetLimitSoc implements the api.SocLimiter interface
+func (c *Dhw) GetLimitSoc() (int64, error) {
+	return int64(c.setpoint), nil
+}
+
+var _ api.HeatStorage = (*Dhw)(nil)
+
+// HeatDemand implements the api.HeatStorage interface
+func (c *Dhw) HeatDemand() (time.Time, float64, error) {
+	temp, err := c.temperature()
+	if err != nil {
+		return time.Time{}, 0, err
+	}
+
+	now := c.clock.Now()
+	ts := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).Add(c.minTime)
+	if !ts.After(now) {
+		ts = ts.AddDate(0, 0, 1)
+	}
+
+	// expected temperature at deadline without heating
+	expected := temp - c.losses*ts.Sub(now).Hours()/c.capacity
+
+	return ts, max(0, c.minTemp-expected) * c.capacity / 1e3, nil
+}
@@ -0,0 +1,50 @@
+package charger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDhwTankModel(t *testing.T) {
+	var on bool
+	enabled := func() (bool, error) { return on, nil }
+	enable := func(b bool) error { on = b; return nil }
+
+	// 100l tank, 1kW heater, 50W losses
+	c := NewDhw(&embed{}, enabled, enable, nil, -1000, 100, 60, 45, 50)
+	clk := clock.NewMock()
+	clk.Set(time.Date(2026, 1, 1, 20, 0, 0, 0, time.Local))
+	c.clock = clk
+	c.minTime = 6 * time.Hour
+
+	temp, err := c.Soc()
+	require.NoError(t, err)
+	assert.Equal(t, 45.0, temp)
+
+	// 10h of losses until 06:00
+	ts, demand, err := c.HeatDemand()
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 1, 2, 6, 0, 0, 0, time.Local), ts)
+	assert.InDelta(t, 0.5, demand, 1e-6)
+
+	// heat for 1h
+	require.NoError(t, c.Enable(true))
+	clk.Add(time.Hour)
+	temp, err = c.Soc()
+	require.NoError(t, err)
+	assert.InDelta(t, 45+950/116.3, temp, 1e-6)
+
+	_, demand, err = c.HeatDemand()
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, demand)
+
+	// limited by setpoint
+	clk.Add(10 * time.Hour)
+	temp, err = c.Soc()
+	require.NoError(t, err)
+	assert.Equal(t, 60.0, temp)
+}
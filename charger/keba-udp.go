@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -317,3 +318,25 @@ func (c *KebaUdp) Diagnose() {
 		fmt.Printf("%+v\n", kr)
 	}
 }
+
+var _ api.StatusDisplay = (*KebaUdp)(nil)
+
+// Display implements the api.StatusDisplay interface
+func (c *KebaUdp) Display(status api.DisplayStatus) error {
+	text := string(status.Mode)
+	if status.PlanActive {
+		text += " plan"
+	}
+	if status.PriceLevel != "" {
+		text += " price " + status.PriceLevel
+	}
+
+	// display supports 23 characters, spaces are encoded as $
+	if len(text) > 23 {
+		text = text[:23]
+	}
+	text = strings.ReplaceAll(text, " ", "$")
+
+	var resp string
+	return c.roundtrip(fmt.Sprintf("display 0 0 0 0 %s", text), 0, &resp)
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/evcc-io/evcc/charger/ocpp"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/ocmf"
 	"github.com/evcc-io/evcc/util/sponsor"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
@@ -45,6 +46,7 @@ type OCPP struct {
 	current float64
 
 	stackLevelZero bool
+	publicKey      string
 	lp             loadpoint.API
 }
 
@@ -73,6 +75,8 @@ func NewOCPPFromConfig(ctx context.Context, other map[string]interface{}) (api.C
 
 		StackLevelZero *bool
 		RemoteStart    bool
+		AuthList       []string
+		PublicKey      string // meter public key for signed meter value verification
 	}{
 		Connector:      1,
 		MeterInterval:  10 * time.Second,
@@ -98,6 +102,20 @@ func NewOCPPFromConfig(ctx context.Context, other map[string]interface{}) (api.C
 		return nil, api.ErrSponsorRequired
 	}
 
+	c.publicKey = cc.PublicKey
+
+	if len(cc.AuthList) > 0 {
+		c.cp.SetAuthList(cc.AuthList)
+
+		if err := c.cp.ChangeConfigurationRequest(ocpp.KeyLocalAuthListEnabled, "true"); err != nil {
+			c.log.DEBUG.Printf("failed enabling local authorization list: %v", err)
+		}
+
+		if err := c.cp.SendLocalListRequest(); err != nil {
+			c.log.WARN.Printf("failed sending local authorization list: %v", err)
+		}
+	}
+
 	var (
 		powerG, totalEnergyG, socG func() (float64, error)
 		currentsG, voltagesG       func() (float64, float64, float64, error)
@@ -227,6 +245,13 @@ func (c *OCPP) Status() (api.ChargeStatus, error) {
 	}
 }
 
+var _ api.StatusNotifier = (*OCPP)(nil)
+
+// StatusChanged implements the api.StatusNotifier interface
+func (c *OCPP) StatusChanged() <-chan struct{} {
+	return c.conn.StatusChanged()
+}
+
 var _ api.StatusReasoner = (*OCPP)(nil)
 
 func (c *OCPP) StatusReason() (api.Reason, error) {
@@ -387,6 +412,52 @@ func (c *OCPP) Identify() (string, error) {
 	return c.conn.IdTag(), nil
 }
 
+var _ api.SignedMeterValuer = (*OCPP)(nil)
+
+// SignedMeterValues implements the api.SignedMeterValuer interface
+func (c *OCPP) SignedMeterValues() (api.SignedMeterValues, error) {
+	var res api.SignedMeterValues
+
+	res.Start, res.Stop = c.conn.SignedMeterValues()
+	if res.Start == "" && res.Stop == "" {
+		return res, api.ErrNotAvailable
+	}
+
+	if c.publicKey != "" {
+		verified := true
+		for _, s := range []string{res.Start, res.Stop} {
+			if s == "" {
+				continue
+			}
+			if err := ocmf.Verify(s, c.publicKey); err != nil {
+				c.log.WARN.Printf("signed meter value: %v", err)
+				verified = false
+			}
+		}
+		res.Verified = &verified
+	}
+
+	return res, nil
+}
+
+var _ api.ConnectorUnlocker = (*OCPP)(nil)
+
+// UnlockConnector implements the api.ConnectorUnlocker interface
+func (c *OCPP) UnlockConnector() error {
+	return c.conn.UnlockConnectorRequest()
+}
+
+var _ api.ConnectorLocker = (*OCPP)(nil)
+
+// LockConnector implements the api.ConnectorLocker interface.
+// OCPP 1.6 has no means to permanently lock the cable, only unlocking is supported.
+func (c *OCPP) LockConnector(lock bool) error {
+	if lock {
+		return api.ErrNotAvailable
+	}
+	return c.conn.UnlockConnectorRequest()
+}
+
 var _ api.Diagnosis = (*OCPP)(nil)
 
 // Diagnose implements the api.Diagnosis interface
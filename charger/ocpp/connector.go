@@ -25,12 +25,16 @@ type Connector struct {
 	status  *core.StatusNotificationRequest
 	statusC chan struct{}
 
+	changedC chan struct{} // signals status changes
+
 	meterUpdated time.Time
 	measurements map[types.Measurand]types.SampledValue
 
 	txnId int
 	idTag string
 
+	signedStart, signedStop string // signed meter values of current or last transaction
+
 	remoteIdTag string
 
 	meterInterval time.Duration
@@ -43,6 +47,7 @@ func NewConnector(log *util.Logger, id int, cp *CP, idTag string, meterInterval
 		id:           id,
 		clock:        clock.New(),
 		statusC:      make(chan struct{}, 1),
+		changedC:     make(chan struct{}, 1),
 		measurements: make(map[types.Measurand]types.SampledValue),
 
 		remoteIdTag:   idTag,
@@ -87,6 +92,13 @@ func (conn *Connector) IdTag() string {
 	return conn.idTag
 }
 
+// SignedMeterValues returns the signed meter values of the current or last transaction
+func (conn *Connector) SignedMeterValues() (string, string) {
+	conn.mu.Lock()
+	defer conn.mu.Unlock()
+	return conn.signedStart, conn.signedStop
+}
+
 // getScheduleLimit queries the current or power limit the charge point is currently set to offer
 func (conn *Connector) GetScheduleLimit(duration int) (float64, error) {
 	schedule, err := conn.cp.GetCompositeScheduleRequest(conn.id, duration)
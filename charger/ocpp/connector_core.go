@@ -32,6 +32,9 @@ func (conn *Connector) OnStatusNotification(request *core.StatusNotificationRequ
 		conn.status = request
 		close(conn.statusC) // signal initial status received
 	} else if request.Timestamp == nil || conn.timestampValid(request.Timestamp.Time) {
+		if request.Status != conn.status.Status {
+			conn.statusChanged()
+		}
 		conn.status = request
 	} else {
 		conn.log.TRACE.Printf("ignoring status: %s < %s", request.Timestamp.Time, conn.status.Timestamp)
@@ -48,6 +51,19 @@ func (conn *Connector) OnStatusNotification(request *core.StatusNotificationRequ
 	return new(core.StatusNotificationConfirmation), nil
 }
 
+// statusChanged signals a status change without blocking, pending signals are coalesced
+func (conn *Connector) statusChanged() {
+	select {
+	case conn.changedC <- struct{}{}:
+	default:
+	}
+}
+
+// StatusChanged returns a channel signalling status changes
+func (conn *Connector) StatusChanged() <-chan struct{} {
+	return conn.changedC
+}
+
 func getSampleKey(s types.SampledValue) types.Measurand {
 	if s.Phase != "" {
 		return s.Measurand + types.Measurand("."+string(s.Phase))
@@ -56,6 +72,15 @@ func getSampleKey(s types.SampledValue) types.Measurand {
 	return s.Measurand
 }
 
+// updateSignedMeterValue stores signed transaction begin or latest meter value
+func (conn *Connector) updateSignedMeterValue(sample types.SampledValue) {
+	if sample.Context == types.ReadingContextTransactionBegin {
+		conn.signedStart = strings.TrimSpace(sample.Value)
+	} else {
+		conn.signedStop = strings.TrimSpace(sample.Value)
+	}
+}
+
 func (conn *Connector) OnMeterValues(request *core.MeterValuesRequest) (*core.MeterValuesConfirmation, error) {
 	conn.mu.Lock()
 	defer conn.mu.Unlock()
@@ -79,6 +104,12 @@ func (conn *Connector) OnMeterValues(request *core.MeterValuesRequest) (*core.Me
 		if !meterValue.Timestamp.Time.Before(conn.meterUpdated) {
 			for _, sample := range meterValue.SampledValue {
 				sample.Value = strings.TrimSpace(sample.Value)
+
+				if sample.Format == types.ValueFormatSignedData {
+					conn.updateSignedMeterValue(sample)
+					continue
+				}
+
 				conn.measurements[getSampleKey(sample)] = sample
 				conn.meterUpdated = meterValue.Timestamp.Time
 			}
@@ -93,11 +124,26 @@ func (conn *Connector) OnStartTransaction(request *core.StartTransactionRequest)
 	defer conn.mu.Unlock()
 
 	conn.txnId = int(instance.txnId.Add(1))
-	conn.idTag = request.IdTag
+	conn.signedStart, conn.signedStop = "", ""
+
+	conn.cp.recordStart(conn.txnId, request)
+
+	// id tags used for remote start are always accepted
+	status := types.AuthorizationStatusAccepted
+	if request.IdTag != conn.remoteIdTag {
+		status = conn.cp.Authorize(request.IdTag)
+	}
+
+	conn.idTag = ""
+	if status == types.AuthorizationStatusAccepted {
+		conn.idTag = request.IdTag
+	} else {
+		conn.log.WARN.Printf("start transaction %d: id tag %s %s", conn.txnId, request.IdTag, strings.ToLower(string(status)))
+	}
 
 	res := &core.StartTransactionConfirmation{
 		IdTagInfo: &types.IdTagInfo{
-			Status: types.AuthorizationStatusAccepted,
+			Status: status,
 		},
 		TransactionId: conn.txnId,
 	}
@@ -132,6 +178,14 @@ func (conn *Connector) OnStopTransaction(request *core.StopTransactionRequest) (
 	conn.txnId = 0
 	conn.idTag = ""
 
+	for _, meterValue := range request.TransactionData {
+		for _, sample := range meterValue.SampledValue {
+			if sample.Format == types.ValueFormatSignedData {
+				conn.updateSignedMeterValue(sample)
+			}
+		}
+	}
+
 	res := &core.StopTransactionConfirmation{
 		IdTagInfo: &types.IdTagInfo{
 			Status: types.AuthorizationStatusAccepted, // accept
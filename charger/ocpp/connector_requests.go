@@ -23,6 +23,10 @@ func (conn *Connector) SetChargingProfileRequest(profile *types.ChargingProfile)
 	return conn.cp.SetChargingProfileRequest(conn.id, profile)
 }
 
+func (conn *Connector) UnlockConnectorRequest() error {
+	return conn.cp.UnlockConnectorRequest(conn.id)
+}
+
 func (conn *Connector) TriggerMessageRequest(requestedMessage remotetrigger.MessageTrigger) error {
 	return conn.cp.TriggerMessageRequest(conn.id, requestedMessage)
 }
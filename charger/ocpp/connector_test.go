@@ -7,6 +7,7 @@ import (
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
 	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
 	"github.com/stretchr/testify/suite"
 )
@@ -151,3 +152,68 @@ func (suite *connTestSuite) TestConnectorMeasurementsRunningTxn() {
 	_, _, _, err = suite.conn.Voltages()
 	suite.NoError(err, "Voltages")
 }
+
+func (suite *connTestSuite) TestConnectorSignedMeterValues() {
+	_, err := suite.conn.OnMeterValues(&core.MeterValuesRequest{
+		MeterValue: []types.MeterValue{{
+			Timestamp: types.NewDateTime(suite.clock.Now()),
+			SampledValue: []types.SampledValue{
+				{Value: "1000", Measurand: types.MeasurandEnergyActiveImportRegister},
+				{Value: "OCMF|begin|sig", Measurand: types.MeasurandEnergyActiveImportRegister, Format: types.ValueFormatSignedData, Context: types.ReadingContextTransactionBegin},
+			},
+		}},
+	})
+	suite.NoError(err)
+
+	// signed value must not replace measurement
+	suite.Equal("1000", suite.conn.measurements[types.MeasurandEnergyActiveImportRegister].Value)
+
+	suite.conn.txnId = 1
+	_, err = suite.conn.OnStopTransaction(&core.StopTransactionRequest{
+		TransactionData: []types.MeterValue{{
+			SampledValue: []types.SampledValue{
+				{Value: "OCMF|end|sig", Format: types.ValueFormatSignedData, Context: types.ReadingContextTransactionEnd},
+			},
+		}},
+	})
+	suite.NoError(err)
+
+	start, stop := suite.conn.SignedMeterValues()
+	suite.Equal("OCMF|begin|sig", start)
+	suite.Equal("OCMF|end|sig", stop)
+}
+
+func (suite *connTestSuite) TestConnectorStatusChanged() {
+	status := func(s core.ChargePointStatus) {
+		_, err := suite.conn.OnStatusNotification(&core.StatusNotificationRequest{Status: s})
+		suite.NoError(err)
+	}
+
+	// initial status is not a change
+	status(core.ChargePointStatusAvailable)
+	suite.Empty(suite.conn.StatusChanged())
+
+	status(core.ChargePointStatusPreparing)
+	status(core.ChargePointStatusSuspendedEV)
+	suite.Len(suite.conn.StatusChanged(), 1, "changes are coalesced")
+	<-suite.conn.StatusChanged()
+
+	status(core.ChargePointStatusSuspendedEV)
+	suite.Empty(suite.conn.StatusChanged())
+}
+
+func (suite *connTestSuite) TestConnectorStartTransactionAuthorization() {
+	start := func(idTag string) types.AuthorizationStatus {
+		res, err := suite.conn.OnStartTransaction(&core.StartTransactionRequest{ConnectorId: 1, IdTag: idTag})
+		suite.NoError(err)
+		return res.IdTagInfo.Status
+	}
+
+	suite.cp.SetAuthList([]string{"tag"})
+
+	suite.Equal(types.AuthorizationStatusAccepted, start("tag"))
+	suite.Equal("tag", suite.conn.IdTag())
+
+	suite.Equal(types.AuthorizationStatusInvalid, start("other"))
+	suite.Empty(suite.conn.IdTag(), "rejected tag not used for identification")
+}
@@ -10,6 +10,7 @@ const (
 	KeyMeterValuesSampledData          = "MeterValuesSampledData"
 	KeyMeterValuesSampledDataMaxLength = "MeterValuesSampledDataMaxLength"
 	KeyNumberOfConnectors              = "NumberOfConnectors"
+	KeyLocalAuthListEnabled            = "LocalAuthListEnabled"
 	KeySupportedFeatureProfiles        = "SupportedFeatureProfiles"
 	KeyWebSocketPingInterval           = "WebSocketPingInterval"
 
@@ -37,6 +37,10 @@ type CP struct {
 	BootNotificationResult   *core.BootNotificationRequest
 
 	connectors map[int]*Connector
+
+	authList        []string
+	authListVersion int
+	transactions    []Transaction
 }
 
 func NewChargePoint(log *util.Logger, id string) *CP {
@@ -0,0 +1,70 @@
+package ocpp
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/evcc-io/evcc/core/rfid"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/localauth"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/samber/lo"
+)
+
+// SetAuthList sets the local authorization list. An empty list accepts all id tags.
+func (cp *CP) SetAuthList(idTags []string) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	cp.authList = slices.Clone(idTags)
+}
+
+// Authorize checks the id tag against the local authorization list and managed RFID tags.
+// Loadpoint restrictions are enforced once the loadpoint identifies the tag.
+func (cp *CP) Authorize(idTag string) types.AuthorizationStatus {
+	cp.mu.RLock()
+	listed := len(cp.authList) == 0 || slices.Contains(cp.authList, idTag)
+	cp.mu.RUnlock()
+
+	if !listed {
+		return types.AuthorizationStatusInvalid
+	}
+
+	if err := rfid.Validate(idTag); err != nil {
+		cp.log.DEBUG.Printf("authorize %s: %v", idTag, err)
+
+		if errors.Is(err, rfid.ErrTagNotValid) {
+			return types.AuthorizationStatusExpired
+		}
+		return types.AuthorizationStatusInvalid
+	}
+
+	return types.AuthorizationStatusAccepted
+}
+
+// SendLocalListRequest pushes the local authorization list to the charge point to allow authorization while offline
+func (cp *CP) SendLocalListRequest() error {
+	cp.mu.Lock()
+	cp.authListVersion++
+	version := cp.authListVersion
+	list := lo.Map(cp.authList, func(idTag string, _ int) localauth.AuthorizationData {
+		return localauth.AuthorizationData{
+			IdTag:     idTag,
+			IdTagInfo: &types.IdTagInfo{Status: types.AuthorizationStatusAccepted},
+		}
+	})
+	cp.mu.Unlock()
+
+	rc := make(chan error, 1)
+
+	err := Instance().SendLocalList(cp.id, func(request *localauth.SendLocalListConfirmation, err error) {
+		if err == nil && request != nil && request.Status != localauth.UpdateStatusAccepted {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, version, localauth.UpdateTypeFull, func(request *localauth.SendLocalListRequest) {
+		request.LocalAuthorizationList = list
+	})
+
+	return wait(err, rc)
+}
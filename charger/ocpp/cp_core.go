@@ -80,6 +80,8 @@ func (cp *CP) OnStopTransaction(request *core.StopTransactionRequest) (*core.Sto
 		return nil, ErrInvalidRequest
 	}
 
+	cp.recordStop(request)
+
 	if conn := cp.connectorByTransactionID(request.TransactionId); conn != nil {
 		return conn.OnStopTransaction(request)
 	}
@@ -57,6 +57,20 @@ func (cp *CP) RemoteStartTransactionRequest(connectorId int, idTag string) error
 	return wait(err, rc)
 }
 
+func (cp *CP) UnlockConnectorRequest(connectorId int) error {
+	rc := make(chan error, 1)
+
+	err := Instance().UnlockConnector(cp.id, func(request *core.UnlockConnectorConfirmation, err error) {
+		if err == nil && request != nil && request.Status != core.UnlockStatusUnlocked {
+			err = errors.New(string(request.Status))
+		}
+
+		rc <- err
+	}, connectorId)
+
+	return wait(err, rc)
+}
+
 func (cp *CP) SetChargingProfileRequest(connectorId int, profile *types.ChargingProfile) error {
 	rc := make(chan error, 1)
 
@@ -0,0 +1,149 @@
+package ocpp
+
+import (
+	"slices"
+	"time"
+
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"gorm.io/gorm"
+)
+
+// maxTransactions is the number of transactions kept in memory per charge point
+const maxTransactions = 100
+
+// Transaction is a charging transaction as reported by the charge point
+type Transaction struct {
+	Station    string    `json:"station" gorm:"primaryKey"`
+	ID         int       `json:"id" gorm:"primaryKey;autoIncrement:false"`
+	Connector  int       `json:"connector"`
+	IdTag      string    `json:"idTag"`
+	Start      time.Time `json:"start"`
+	Stop       time.Time `json:"stop,omitzero"`
+	MeterStart int       `json:"meterStart"`          // Wh
+	MeterStop  *int      `json:"meterStop,omitempty"` // Wh
+	Offline    bool      `json:"offline"`             // replayed from charge point offline queue
+}
+
+// TableName returns the database table name
+func (Transaction) TableName() string {
+	return "ocpp_transactions"
+}
+
+var db *gorm.DB
+
+// Init sets up persistence for charge point transactions
+func Init(instance *gorm.DB) error {
+	db = instance
+	return db.AutoMigrate(new(Transaction))
+}
+
+// Transactions returns the most recent persisted transactions, optionally filtered by station
+func Transactions(station string, limit int) ([]Transaction, error) {
+	var res []Transaction
+	if db == nil {
+		return res, nil
+	}
+
+	tx := db.Order("start desc").Limit(limit)
+	if station != "" {
+		tx = tx.Where(&Transaction{Station: station})
+	}
+
+	return res, tx.Find(&res).Error
+}
+
+// offline determines if a message timestamp indicates it was queued by the charge point while offline
+func offline(ts time.Time, now time.Time) bool {
+	return !ts.IsZero() && now.Sub(ts) > Timeout
+}
+
+// persist stores the transaction if a database is available
+func (cp *CP) persist(txn Transaction) {
+	if db == nil {
+		return
+	}
+
+	if err := db.Save(&txn).Error; err != nil {
+		cp.log.ERROR.Printf("transaction %d: %v", txn.ID, err)
+	}
+}
+
+// recordStart records a started transaction
+func (cp *CP) recordStart(txnId int, request *core.StartTransactionRequest) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	txn := Transaction{
+		Station:    cp.id,
+		ID:         txnId,
+		Connector:  request.ConnectorId,
+		IdTag:      request.IdTag,
+		MeterStart: request.MeterStart,
+	}
+
+	if request.Timestamp != nil {
+		txn.Start = request.Timestamp.Time
+	}
+
+	if txn.Offline = offline(txn.Start, time.Now()); txn.Offline {
+		cp.log.INFO.Printf("replayed offline transaction %d started at %v", txnId, txn.Start.Local())
+	}
+
+	cp.transactions = append(cp.transactions, txn)
+	if len(cp.transactions) > maxTransactions {
+		cp.transactions = cp.transactions[len(cp.transactions)-maxTransactions:]
+	}
+
+	cp.persist(txn)
+}
+
+// recordStop records a stopped transaction. Unknown transactions are recorded as well to keep them billable.
+func (cp *CP) recordStop(request *core.StopTransactionRequest) {
+	cp.mu.Lock()
+	defer cp.mu.Unlock()
+
+	var stop time.Time
+	if request.Timestamp != nil {
+		stop = request.Timestamp.Time
+	}
+
+	idx := slices.IndexFunc(cp.transactions, func(txn Transaction) bool {
+		return txn.ID == request.TransactionId
+	})
+
+	if idx < 0 {
+		txn := Transaction{
+			Station: cp.id,
+			ID:      request.TransactionId,
+			IdTag:   request.IdTag,
+			Offline: true,
+		}
+
+		// transaction may have been started before restart
+		if db != nil && db.Where(&Transaction{Station: cp.id, ID: request.TransactionId}).Take(&txn).Error == nil {
+			txn.Offline = false
+		}
+
+		cp.transactions = append(cp.transactions, txn)
+		idx = len(cp.transactions) - 1
+	}
+
+	txn := &cp.transactions[idx]
+	txn.Stop = stop
+	txn.MeterStop = &request.MeterStop
+	txn.Offline = txn.Offline || offline(stop, time.Now())
+
+	if txn.Offline {
+		cp.log.INFO.Printf("replayed offline transaction %d stopped at %v: %dWh", txn.ID, stop.Local(), request.MeterStop-txn.MeterStart)
+	}
+
+	cp.persist(*txn)
+}
+
+// Transactions returns the recent transactions
+func (cp *CP) Transactions() []Transaction {
+	cp.mu.RLock()
+	defer cp.mu.RUnlock()
+
+	return slices.Clone(cp.transactions)
+}
@@ -0,0 +1,125 @@
+package ocpp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/core/rfid"
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/util"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/core"
+	"github.com/lorenzodonini/ocpp-go/ocpp1.6/types"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOfflineTransactions(t *testing.T) {
+	cp := NewChargePoint(util.NewLogger("foo"), "abc")
+
+	start := time.Now().Add(-time.Hour)
+	cp.recordStart(1, &core.StartTransactionRequest{
+		ConnectorId: 1,
+		IdTag:       "tag",
+		MeterStart:  1000,
+		Timestamp:   types.NewDateTime(start),
+	})
+	cp.recordStop(&core.StopTransactionRequest{
+		TransactionId: 1,
+		MeterStop:     3000,
+		Timestamp:     types.NewDateTime(start.Add(30 * time.Minute)),
+	})
+
+	// unknown transaction
+	cp.recordStop(&core.StopTransactionRequest{
+		TransactionId: 2,
+		MeterStop:     5000,
+		Timestamp:     types.NewDateTime(time.Now()),
+	})
+
+	txns := cp.Transactions()
+	require.Len(t, txns, 2)
+
+	assert.True(t, txns[0].Offline)
+	assert.Equal(t, "tag", txns[0].IdTag)
+	assert.Equal(t, 3000, *txns[0].MeterStop)
+
+	assert.True(t, txns[1].Offline)
+	assert.Equal(t, 5000, *txns[1].MeterStop)
+}
+
+func TestPersistedTransactions(t *testing.T) {
+	instance, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+	require.NoError(t, Init(instance))
+	t.Cleanup(func() { db = nil })
+
+	start := time.Now().Add(-time.Minute)
+	cp := NewChargePoint(util.NewLogger("foo"), "abc")
+	cp.recordStart(1, &core.StartTransactionRequest{
+		ConnectorId: 1,
+		IdTag:       "tag",
+		MeterStart:  1000,
+		Timestamp:   types.NewDateTime(start),
+	})
+
+	// stop after restart
+	cp = NewChargePoint(util.NewLogger("foo"), "abc")
+	cp.recordStop(&core.StopTransactionRequest{
+		TransactionId: 1,
+		MeterStop:     3000,
+		Timestamp:     types.NewDateTime(time.Now()),
+	})
+
+	txns, err := Transactions("abc", 10)
+	require.NoError(t, err)
+	require.Len(t, txns, 1)
+
+	assert.False(t, txns[0].Offline)
+	assert.Equal(t, "tag", txns[0].IdTag)
+	assert.Equal(t, 1000, txns[0].MeterStart)
+	assert.Equal(t, 3000, *txns[0].MeterStop)
+
+	txns, err = Transactions("other", 10)
+	require.NoError(t, err)
+	assert.Empty(t, txns)
+}
+
+func TestAuthorize(t *testing.T) {
+	cp := NewChargePoint(util.NewLogger("foo"), "abc")
+	assert.Equal(t, types.AuthorizationStatusAccepted, cp.Authorize("any"))
+
+	cp.SetAuthList([]string{"tag"})
+	assert.Equal(t, types.AuthorizationStatusAccepted, cp.Authorize("tag"))
+	assert.Equal(t, types.AuthorizationStatusInvalid, cp.Authorize("other"))
+}
+
+func TestAuthorizeManagedTags(t *testing.T) {
+	instance, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+	require.NoError(t, rfid.Init(instance))
+
+	tag, err := rfid.AddTag(rfid.Tag{IdTag: "managed"})
+	require.NoError(t, err)
+	expired, err := rfid.AddTag(rfid.Tag{IdTag: "expired", ValidUntil: lo.ToPtr(time.Now().Add(-time.Hour))})
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		// no managed tags accept all
+		require.NoError(t, rfid.DeleteTag(tag.ID))
+		require.NoError(t, rfid.DeleteTag(expired.ID))
+	})
+
+	cp := NewChargePoint(util.NewLogger("foo"), "abc")
+	assert.Equal(t, types.AuthorizationStatusAccepted, cp.Authorize("managed"))
+	assert.Equal(t, types.AuthorizationStatusExpired, cp.Authorize("expired"))
+	assert.Equal(t, types.AuthorizationStatusInvalid, cp.Authorize("unknown"))
+
+	// transactions of unknown tags are rejected
+	conn, err := NewConnector(util.NewLogger("foo"), 1, cp, "", Timeout)
+	require.NoError(t, err)
+
+	res, err := conn.OnStartTransaction(&core.StartTransactionRequest{ConnectorId: 1, IdTag: "unknown"})
+	require.NoError(t, err)
+	assert.Equal(t, types.AuthorizationStatusInvalid, res.IdTagInfo.Status)
+}
@@ -8,11 +8,14 @@ import (
 // cp actions
 
 func (cs *CS) OnAuthorize(id string, request *core.AuthorizeRequest) (*core.AuthorizeConfirmation, error) {
-	// no cp handler
+	status := types.AuthorizationStatusAccepted
+	if cp, err := cs.ChargepointByID(id); err == nil && request != nil {
+		status = cp.Authorize(request.IdTag)
+	}
 
 	res := &core.AuthorizeConfirmation{
 		IdTagInfo: &types.IdTagInfo{
-			Status: types.AuthorizationStatusAccepted,
+			Status: status,
 		},
 	}
 
@@ -237,6 +237,20 @@ func (suite *ocppTestSuite) TestAutoStart() {
 	suite.Require().NoError(err)
 }
 
+func (suite *ocppTestSuite) TestLockConnector() {
+	// 1st charge point- remote
+	cp1, _ := suite.startChargePoint("test-5", 1)
+	suite.Require().NoError(cp1.Start(ocppTestUrl))
+	suite.Require().True(cp1.IsConnected())
+
+	// 1st charge point- local
+	c1, err := NewOCPP(context.TODO(), "test-5", 1, "", "", 0, false, false, ocppTestConnectTimeout)
+	suite.Require().NoError(err)
+
+	suite.Require().ErrorIs(c1.LockConnector(true), api.ErrNotAvailable)
+	suite.Require().NoError(c1.LockConnector(false))
+}
+
 func (suite *ocppTestSuite) TestTimeout() {
 	// 1st charge point- remote
 	cp1, ocppjClient := suite.startChargePoint("test-4", 1)
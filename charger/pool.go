@@ -0,0 +1,219 @@
+package charger
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/plugin"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util"
+)
+
+// Pool is a pool pump or filtration switch with required daily runtime
+type Pool struct {
+	*switchSocket
+	log     *util.Logger
+	mu      sync.Mutex
+	clock   clock.Clock
+	enable  func(bool) error
+	enabled func() (bool, error)
+
+	required time.Duration // daily runtime
+	until    time.Duration // time of day the runtime must be reached
+
+	runtime time.Duration
+	updated time.Time
+	key     string // settings key persisting today's runtime
+}
+
+// poolRuntime is today's runtime persisted across restarts
+type poolRuntime struct {
+	Day     time.Time     `json:"day"`
+	Runtime time.Duration `json:"runtime"`
+}
+
+func init() {
+	registry.AddCtx("pool", NewPoolFromConfig)
+}
+
+// NewPoolFromConfig creates a pool pump charger from generic config
+func NewPoolFromConfig(ctx context.Context, other map[string]interface{}) (api.Charger, error) {
+	cc := struct {
+		embed        `mapstructure:",squash"`
+		Enabled      plugin.Config
+		Enable       plugin.Config
+		Power        *plugin.Config // optional
+		PumpPower    float64
+		Runtime      time.Duration
+		Until        string
+		StandbyPower float64
+	}{
+		embed: embed{
+			Icon_:     "pump",
+			Features_: []api.Feature{api.IntegratedDevice},
+		},
+		Runtime: 6 * time.Hour,
+		Until:   "20:00",
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.PumpPower <= 0 {
+		return nil, errors.New("missing pump power")
+	}
+
+	until, err := time.Parse("15:04", cc.Until)
+	if err != nil {
+		return nil, fmt.Errorf("until: %w", err)
+	}
+
+	enabled, err := cc.Enabled.BoolGetter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	enable, err := cc.Enable.BoolSetter(ctx, "enable")
+	if err != nil {
+		return nil, err
+	}
+
+	powerG, err := cc.Power.FloatGetter(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("power: %w", err)
+	}
+
+	// without power measurement assume static pump power
+	if powerG == nil {
+		cc.StandbyPower = -cc.PumpPower
+	}
+
+	c := NewPool(&cc.embed, enabled, enable, powerG, cc.StandbyPower, cc.Runtime)
+	c.until = time.Duration(until.Hour())*time.Hour + time.Duration(until.Minute())*time.Minute
+
+	// identify the pump by its switch
+	b, err := json.Marshal(cc.Enable)
+	if err != nil {
+		return nil, err
+	}
+	c.restore(fmt.Sprintf("charger.pool.%x", sha256.Sum256(b)))
+
+	return c, nil
+}
+
+// NewPool creates a pool pump charger
+func NewPool(
+	embed *embed,
+	enabled func() (bool, error),
+	enable func(bool) error,
+	powerG func() (float64, error),
+	standbyPower float64,
+	runtime time.Duration,
+) *Pool {
+	return &Pool{
+		switchSocket: NewSwitchSocket(embed, enabled, powerG, standbyPower),
+		log:          util.NewLogger("pool"),
+		clock:        clock.New(),
+		enabled:      enabled,
+		enable:       enable,
+		required:     runtime,
+		until:        24 * time.Hour,
+	}
+}
+
+// Enabled implements the api.Charger interface
+func (c *Pool) Enabled() (bool, error) {
+	return c.enabled()
+}
+
+// Enable implements the api.Charger interface
+func (c *Pool) Enable(enable bool) error {
+	if _, err := c.updateRuntime(); err != nil {
+		return err
+	}
+	return c.enable(enable)
+}
+
+// restore loads today's runtime persisted under given key. Downtime is not accounted.
+func (c *Pool) restore(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.key = key
+
+	var res poolRuntime
+	if err := settings.Json(key, &res); err != nil {
+		if !errors.Is(err, settings.ErrNotFound) {
+			c.log.WARN.Println("runtime:", err)
+		}
+		return
+	}
+
+	now := c.clock.Now()
+	if midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()); res.Day.Equal(midnight) {
+		c.runtime = res.Runtime
+		c.updated = now
+	}
+}
+
+// updateRuntime accumulates today's runtime
+func (c *Pool) updateRuntime() (time.Duration, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	on, err := c.enabled()
+	if err != nil {
+		return 0, err
+	}
+
+	now := c.clock.Now()
+	midnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch {
+	case c.updated.Before(midnight):
+		// new day, count running time since midnight only
+		c.runtime = 0
+		if on && !c.updated.IsZero() {
+			c.runtime = now.Sub(midnight)
+		}
+	case on:
+		c.runtime += now.Sub(c.updated)
+	}
+	c.updated = now
+
+	if c.key != "" {
+		if err := settings.SetJson(c.key, poolRuntime{Day: midnight, Runtime: c.runtime}); err != nil {
+			c.log.ERROR.Println("runtime:", err)
+		}
+	}
+
+	return c.runtime, nil
+}
+
+var _ api.Runtimer = (*Pool)(nil)
+
+// Runtime implements the api.Runtimer interface
+func (c *Pool) Runtime() (time.Duration, time.Duration, time.Time, error) {
+	runtime, err := c.updateRuntime()
+	if err != nil {
+		return 0, 0, time.Time{}, err
+	}
+
+	now := c.clock.Now()
+	ts := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).Add(c.until)
+
+	// target passed for today, start collecting tomorrow's runtime after midnight
+	if !ts.After(now) {
+		return runtime, runtime, ts.AddDate(0, 0, 1), nil
+	}
+
+	return runtime, c.required, ts, nil
+}
@@ -0,0 +1,88 @@
+package charger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPoolRuntime(t *testing.T) {
+	var on bool
+	enabled := func() (bool, error) { return on, nil }
+	enable := func(b bool) error { on = b; return nil }
+
+	c := NewPool(&embed{}, enabled, enable, nil, -800, 6*time.Hour)
+	clk := clock.NewMock()
+	clk.Set(time.Date(2026, 7, 1, 9, 0, 0, 0, time.Local))
+	c.clock = clk
+	c.until = 20 * time.Hour
+
+	runtime, required, ts, err := c.Runtime()
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), runtime)
+	assert.Equal(t, 6*time.Hour, required)
+	assert.Equal(t, time.Date(2026, 7, 1, 20, 0, 0, 0, time.Local), ts)
+
+	require.NoError(t, c.Enable(true))
+	clk.Add(2 * time.Hour)
+	require.NoError(t, c.Enable(false))
+	clk.Add(time.Hour)
+
+	runtime, _, _, err = c.Runtime()
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, runtime)
+
+	// target passed, no further demand today
+	clk.Add(9 * time.Hour)
+	runtime, required, ts, err = c.Runtime()
+	require.NoError(t, err)
+	assert.Equal(t, runtime, required)
+	assert.Equal(t, time.Date(2026, 7, 2, 20, 0, 0, 0, time.Local), ts)
+
+	// next day resets runtime
+	clk.Add(12 * time.Hour)
+	runtime, required, _, err = c.Runtime()
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), runtime)
+	assert.Equal(t, 6*time.Hour, required)
+}
+
+func TestPoolRuntimeRestore(t *testing.T) {
+	var on bool
+	enabled := func() (bool, error) { return on, nil }
+	enable := func(b bool) error { on = b; return nil }
+
+	clk := clock.NewMock()
+	clk.Set(time.Date(2026, 7, 1, 9, 0, 0, 0, time.Local))
+
+	c := NewPool(&embed{}, enabled, enable, nil, -800, 6*time.Hour)
+	c.clock = clk
+	c.restore("charger.pool.test")
+
+	require.NoError(t, c.Enable(true))
+	clk.Add(2 * time.Hour)
+	require.NoError(t, c.Enable(false))
+
+	// restart
+	clk.Add(time.Hour)
+	c = NewPool(&embed{}, enabled, enable, nil, -800, 6*time.Hour)
+	c.clock = clk
+	c.restore("charger.pool.test")
+
+	runtime, _, _, err := c.Runtime()
+	require.NoError(t, err)
+	assert.Equal(t, 2*time.Hour, runtime)
+
+	// restart next day
+	clk.Add(24 * time.Hour)
+	c = NewPool(&embed{}, enabled, enable, nil, -800, 6*time.Hour)
+	c.clock = clk
+	c.restore("charger.pool.test")
+
+	runtime, _, _, err = c.Runtime()
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), runtime)
+}
@@ -52,6 +52,9 @@ const (
 	flagDigits = "digits"
 	flagDelay  = "delay"
 	flagForce  = "force"
+	flagDryRun = "dry-run"
+	flagFrom   = "from"
+	flagTo     = "to"
 )
 
 func bind(cmd *cobra.Command, key string, flagName ...string) {
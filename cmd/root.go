@@ -20,6 +20,7 @@ import (
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/auth"
 	"github.com/evcc-io/evcc/util/config"
+	"github.com/evcc-io/evcc/util/oauth"
 	"github.com/evcc-io/evcc/util/pipe"
 	"github.com/evcc-io/evcc/util/sponsor"
 	"github.com/evcc-io/evcc/util/telemetry"
@@ -231,6 +232,7 @@ func runRoot(cmd *cobra.Command, args []string) {
 		var mqtt *server.MQTT
 		mqtt, err = server.NewMQTT(strings.Trim(conf.Mqtt.Topic, "/"), site)
 		if err == nil {
+			mqtt.Compact = conf.Mqtt.Compact
 			go mqtt.Run(site, pipe.NewDropper(append(ignoreMqtt, ignoreEmpty)...).Pipe(tee.Attach()))
 		}
 	}
@@ -248,7 +250,7 @@ func runRoot(cmd *cobra.Command, args []string) {
 	// setup messaging
 	var pushChan chan push.Event
 	if err == nil {
-		pushChan, err = configureMessengers(&conf.Messaging, site.Vehicles(), valueChan, cache)
+		pushChan, err = configureMessengers(&conf.Messaging, conf.Network, site, valueChan, cache, httpd)
 		err = wrapErrorWithClass(ClassMessenger, err)
 	}
 
@@ -292,6 +294,10 @@ func runRoot(cmd *cobra.Command, args []string) {
 	// allow web access for vehicles
 	configureAuth(conf.Network, config.Instances(config.Vehicles().Devices()), httpd.Router(), valueChan)
 
+	// refresh oauth tokens ahead of expiry, re-authenticate by browser login
+	oauth.SetRedirectURL(conf.Network.URI() + "/oauth/callback")
+	go oauth.Run(valueChan)
+
 	auth := auth.New()
 	if ok, _ := cmd.Flags().GetBool(flagDisableAuth); ok {
 		log.WARN.Println("❗❗❗ Authentication is disabled. This is dangerous. Your data and credentials are not protected.")
@@ -304,6 +310,11 @@ func runRoot(cmd *cobra.Command, args []string) {
 		once.Do(func() { close(stopC) })     // signal loop to end
 	})
 
+	// read-only public status
+	if conf.Status.Configured() {
+		httpd.RegisterPublicStatusHandler(cache, conf.Status)
+	}
+
 	// show and check version, reduce api load during development
 	if server.Version != server.DevVersion {
 		valueChan <- util.Param{Key: keys.Version, Val: server.FormattedVersion()}
@@ -316,7 +327,7 @@ func runRoot(cmd *cobra.Command, args []string) {
 		site.DumpConfig()
 		site.Prepare(valueChan, pushChan)
 
-		httpd.RegisterSiteHandlers(site, valueChan)
+		httpd.RegisterSiteHandlers(site, valueChan, auth)
 
 		go func() {
 			site.Run(stopC, conf.Interval)
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/evcc-io/evcc/core/pricelog"
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/spf13/cobra"
+)
+
+// sessionsRecomputeCmd represents the sessions recompute command
+var sessionsRecomputeCmd = &cobra.Command{
+	Use:   "recompute",
+	Short: "Compute missing session cost from the price history",
+	Long: `Compute the cost of sessions without cost from the price history and the energy recorded per charge interval.
+Sessions priced while charging are never changed.`,
+	Run:  runSessionsRecompute,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	sessionsCmd.AddCommand(sessionsRecomputeCmd)
+	sessionsRecomputeCmd.Flags().Bool(flagDryRun, false, "Show recomputed cost without updating sessions")
+}
+
+func runSessionsRecompute(cmd *cobra.Command, args []string) {
+	// load config
+	if err := loadConfigFile(&conf, !cmd.Flag(flagIgnoreDatabase).Changed); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	// setup persistence
+	if err := configureDatabase(conf.Database); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	if err := pricelog.Init(db.Instance); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	if err := db.Instance.AutoMigrate(new(session.Session)); err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	dryRun, _ := cmd.Flags().GetBool(flagDryRun)
+
+	var sessions session.Sessions
+	if err := db.Instance.Where("finished > created AND charged_kwh > 0 AND price IS NULL AND away IS NOT TRUE").Order("created").Find(&sessions).Error; err != nil {
+		log.FATAL.Fatal(err)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintln(w, "Session\tLoadpoint\tCreated\tEnergy (kWh)\tRecomputed")
+
+	var updated int
+	for _, s := range sessions {
+		price, err := pricelog.SessionPrice(s.Loadpoint, s.Created, s.Finished)
+		if errors.Is(err, pricelog.ErrNoHistory) {
+			continue
+		} else if err != nil {
+			log.FATAL.Fatal(err)
+		}
+
+		fmt.Fprintf(w, "%d\t%s\t%s\t%.2f\t%.2f\n", s.ID, s.Loadpoint, s.Created.Local().Format("2006-01-02 15:04"), s.ChargedEnergy, price)
+
+		if dryRun {
+			continue
+		}
+
+		perKWh := price / s.ChargedEnergy
+		if err := db.Instance.Model(&session.Session{}).Where("id = ? AND price IS NULL", s.ID).Updates(map[string]any{
+			"price":         price,
+			"price_per_kwh": perKWh,
+		}).Error; err != nil {
+			log.FATAL.Fatal(err)
+		}
+
+		updated++
+	}
+
+	w.Flush()
+
+	if !dryRun {
+		fmt.Printf("%d sessions updated\n", updated)
+	}
+}
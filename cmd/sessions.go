@@ -0,0 +1,15 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// sessionsCmd represents the sessions command
+var sessionsCmd = &cobra.Command{
+	Use:   "sessions",
+	Short: "Manage charging sessions",
+}
+
+func init() {
+	rootCmd.AddCommand(sessionsCmd)
+}
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
 	"slices"
 	"strconv"
@@ -18,11 +19,15 @@ import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/api/globalconfig"
 	"github.com/evcc-io/evcc/charger"
+	"github.com/evcc-io/evcc/charger/ocpp"
 	"github.com/evcc-io/evcc/cmd/shutdown"
 	"github.com/evcc-io/evcc/core"
 	"github.com/evcc-io/evcc/core/circuit"
+	"github.com/evcc-io/evcc/core/eventlog"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/pricelog"
+	"github.com/evcc-io/evcc/core/rfid"
 	coresettings "github.com/evcc-io/evcc/core/settings"
 	"github.com/evcc-io/evcc/hems"
 	"github.com/evcc-io/evcc/meter"
@@ -37,10 +42,13 @@ import (
 	"github.com/evcc-io/evcc/server/modbus"
 	"github.com/evcc-io/evcc/server/oauth2redirect"
 	"github.com/evcc-io/evcc/tariff"
+	"github.com/evcc-io/evcc/tariff/zone"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/auth"
 	"github.com/evcc-io/evcc/util/config"
 	"github.com/evcc-io/evcc/util/locale"
 	"github.com/evcc-io/evcc/util/machine"
+	"github.com/evcc-io/evcc/util/oauth"
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/util/sponsor"
 	"github.com/evcc-io/evcc/util/templates"
@@ -48,6 +56,7 @@ import (
 	"github.com/gorilla/handlers"
 	"github.com/gorilla/mux"
 	"github.com/libp2p/zeroconf/v2"
+	"github.com/mitchellh/go-homedir"
 	"github.com/samber/lo"
 	"github.com/spf13/cast"
 	"github.com/spf13/cobra"
@@ -519,6 +528,26 @@ func configureEnvironment(cmd *cobra.Command, conf *globalconfig.All) (err error
 		err = config.Init(db.Instance)
 	}
 
+	// setup rfid tags
+	if err == nil {
+		err = rfid.Init(db.Instance)
+	}
+
+	// setup ocpp transaction log
+	if err == nil {
+		err = ocpp.Init(db.Instance)
+	}
+
+	// setup event log
+	if err == nil {
+		err = eventlog.Init(db.Instance, conf.Database.EventRetention)
+	}
+
+	// setup price history
+	if err == nil {
+		err = pricelog.Init(db.Instance)
+	}
+
 	return
 }
 
@@ -536,6 +565,11 @@ func configureDatabase(conf globalconfig.DB) error {
 		return err
 	}
 
+	// keep the oauth token encryption key next to, but outside of the database
+	if file, err := homedir.Expand(conf.Dsn); err == nil {
+		oauth.SetKeyFile(filepath.Join(filepath.Dir(file), "oauth.key"))
+	}
+
 	persistSettings := func() {
 		if err := settings.Persist(); err != nil {
 			log.ERROR.Println("cannot save settings:", err)
@@ -711,7 +745,7 @@ func configureEEBus(conf *eebus.Config) error {
 }
 
 // setup messaging
-func configureMessengers(conf *globalconfig.Messaging, vehicles push.Vehicles, valueChan chan<- util.Param, cache *util.ParamCache) (chan push.Event, error) {
+func configureMessengers(conf *globalconfig.Messaging, network globalconfig.Network, site *core.Site, valueChan chan<- util.Param, cache *util.ParamCache, httpd *server.HTTPd) (chan push.Event, error) {
 	// migrate settings
 	if settings.Exists(keys.Messaging) {
 		*conf = globalconfig.Messaging{}
@@ -722,11 +756,24 @@ func configureMessengers(conf *globalconfig.Messaging, vehicles push.Vehicles, v
 
 	messageChan := make(chan push.Event, 1)
 
-	messageHub, err := push.NewHub(conf.Events, vehicles, cache)
+	messageHub, err := push.NewHub(conf.Events, site.Vehicles(), cache)
 	if err != nil {
 		return messageChan, fmt.Errorf("failed configuring push services: %w", err)
 	}
 
+	// notification actions call the api server-side using a short-lived admin token
+	messageHub.SetApi(network.URI(), conf.ExternalUrl, func(lifetime time.Duration) (string, error) {
+		if a := auth.New(); a.IsAdminPasswordConfigured() {
+			return a.GenerateJwtToken(lifetime)
+		}
+		return "", nil
+	})
+
+	httpd.RegisterPushActionHandler(messageHub)
+
+	// snapshots and messenger commands use the site directly
+	messageHub.SetSite(site)
+
 	for _, service := range conf.Services {
 		impl, err := push.NewFromConfig(context.TODO(), service.Type, service.Other)
 		if err != nil {
@@ -741,37 +788,106 @@ func configureMessengers(conf *globalconfig.Messaging, vehicles push.Vehicles, v
 }
 
 func tariffInstance(name string, conf config.Typed) (api.Tariff, error) {
-	ctx := util.WithLogger(context.TODO(), util.NewLogger(name))
+	return tariff.NewInstance(log, name, conf)
+}
 
-	instance, err := tariff.NewFromConfig(ctx, conf.Type, conf.Other)
-	if err != nil {
-		if ce := new(util.ConfigError); errors.As(err, &ce) {
-			return nil, err
+// tariffExchangeRate extracts the tariff's exchange currency from the configuration and returns the conversion rate to the site currency.
+// Dedicated keys are used to not consume tariff or template parameters like currency.
+func tariffExchangeRate(conf *config.Typed, site currency.Unit) (func() (float64, error), error) {
+	var cc struct {
+		ExchangeCurrency string
+		ExchangeRate     float64
+	}
+
+	other := make(map[string]any, len(conf.Other))
+	conv := make(map[string]any)
+
+	for k, v := range conf.Other {
+		switch strings.ToLower(k) {
+		case "exchangecurrency", "exchangerate":
+			conv[k] = v
+		default:
+			other[k] = v
 		}
+	}
 
-		// wrap non-config tariff errors to prevent fatals
-		log.ERROR.Printf("creating tariff %s failed: %v", name, err)
-		instance = tariff.NewWrapper(conf.Type, conf.Other, err)
+	if len(conv) == 0 {
+		return nil, nil
 	}
 
-	return instance, nil
+	if err := util.DecodeOther(conv, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.ExchangeCurrency == "" {
+		return nil, errors.New("exchange rate requires exchangeCurrency")
+	}
+
+	from, err := currency.ParseISO(cc.ExchangeCurrency)
+	if err != nil {
+		return nil, err
+	}
+
+	conf.Other = other
+
+	return tariff.ExchangeRate(from, site, cc.ExchangeRate), nil
 }
 
-func configureTariff(u api.TariffUsage, conf config.Typed, t *api.Tariff) error {
+func configureTariff(u api.TariffUsage, conf config.Typed, cur currency.Unit, t *api.Tariff) error {
 	if conf.Type == "" {
 		return nil
 	}
 
 	name := u.String()
+
+	rate, err := tariffExchangeRate(&conf, cur)
+	if err != nil {
+		return &DeviceError{name, err}
+	}
+
 	res, err := tariffInstance(name, conf)
 	if err != nil {
 		return &DeviceError{name, err}
 	}
 
+	if rate != nil {
+		res = tariff.NewConverted(res, rate)
+	}
+
 	*t = res
 	return nil
 }
 
+// configureGridFee adds time-variable network charges to the grid tariff
+func configureGridFee(conf config.Typed, cur currency.Unit, grid *api.Tariff) error {
+	if conf.Type == "" {
+		return nil
+	}
+
+	if *grid == nil {
+		return errors.New("grid fee requires grid tariff")
+	}
+
+	const name = "gridfee"
+
+	rate, err := tariffExchangeRate(&conf, cur)
+	if err != nil {
+		return &DeviceError{name, err}
+	}
+
+	fee, err := tariffInstance(name, conf)
+	if err != nil {
+		return &DeviceError{name, err}
+	}
+
+	if rate != nil {
+		fee = tariff.NewConverted(fee, rate)
+	}
+
+	*grid = tariff.NewGridFee(*grid, fee)
+	return nil
+}
+
 func configureSolarTariff(conf []config.Typed, t *api.Tariff) error {
 	var eg errgroup.Group
 	tt := make([]api.Tariff, len(conf))
@@ -818,13 +934,33 @@ func configureTariffs(conf *globalconfig.Tariffs) (*tariff.Tariffs, error) {
 		tariffs.Currency = currency.MustParseISO(conf.Currency)
 	}
 
+	zone.SetLocation(conf.Location.Lat, conf.Location.Lon)
+
+	switch conf.Co2Intensity {
+	case "", tariff.Co2IntensityAverage:
+	case tariff.Co2IntensityMarginal:
+		if conf.Co2Marginal.Type == "" {
+			return nil, &ClassError{ClassTariff, errors.New("marginal co2 intensity requires co2Marginal tariff")}
+		}
+		tariffs.Co2Intensity = conf.Co2Intensity
+	default:
+		return nil, &ClassError{ClassTariff, fmt.Errorf("invalid co2 intensity: %s", conf.Co2Intensity)}
+	}
+
 	var eg errgroup.Group
-	eg.Go(func() error { return configureTariff(api.TariffUsageGrid, conf.Grid, &tariffs.Grid) })
-	eg.Go(func() error { return configureTariff(api.TariffUsageFeedIn, conf.FeedIn, &tariffs.FeedIn) })
-	eg.Go(func() error { return configureTariff(api.TariffUsageCo2, conf.Co2, &tariffs.Co2) })
-	eg.Go(func() error { return configureTariff(api.TariffUsagePlanner, conf.Planner, &tariffs.Planner) })
+	eg.Go(func() error { return configureTariff(api.TariffUsageGrid, conf.Grid, tariffs.Currency, &tariffs.Grid) })
+	eg.Go(func() error { return configureTariff(api.TariffUsageFeedIn, conf.FeedIn, tariffs.Currency, &tariffs.FeedIn) })
+	eg.Go(func() error { return configureTariff(api.TariffUsageCo2, conf.Co2, tariffs.Currency, &tariffs.Co2) })
+	eg.Go(func() error {
+		return configureTariff(api.TariffUsageCo2Marginal, conf.Co2Marginal, tariffs.Currency, &tariffs.Co2Marginal)
+	})
+	eg.Go(func() error { return configureTariff(api.TariffUsagePlanner, conf.Planner, tariffs.Currency, &tariffs.Planner) })
+	eg.Go(func() error { return configureTariff(api.TariffUsageGridState, conf.GridState, tariffs.Currency, &tariffs.GridState) })
+	eg.Go(func() error {
+		return configureTariff(api.TariffUsageTemperature, conf.Temperature, tariffs.Currency, &tariffs.Temperature)
+	})
 	if len(conf.Solar) == 1 {
-		eg.Go(func() error { return configureTariff(api.TariffUsageSolar, conf.Solar[0], &tariffs.Solar) })
+		eg.Go(func() error { return configureTariff(api.TariffUsageSolar, conf.Solar[0], tariffs.Currency, &tariffs.Solar) })
 	} else {
 		eg.Go(func() error { return configureSolarTariff(conf.Solar, &tariffs.Solar) })
 	}
@@ -833,9 +969,56 @@ func configureTariffs(conf *globalconfig.Tariffs) (*tariff.Tariffs, error) {
 		return nil, &ClassError{ClassTariff, err}
 	}
 
+	if err := configureGridFee(conf.GridFee, tariffs.Currency, &tariffs.Grid); err != nil {
+		return nil, &ClassError{ClassTariff, err}
+	}
+
+	if err := configureComparisonTariffs(conf.Comparison, tariffs.Currency, &tariffs.Comparison); err != nil {
+		return nil, &ClassError{ClassTariff, err}
+	}
+
 	return &tariffs, nil
 }
 
+// configureComparisonTariffs creates alternative grid tariffs for comparison by name
+func configureComparisonTariffs(conf []config.Named, cur currency.Unit, res *map[string]api.Tariff) error {
+	if len(conf) == 0 {
+		return nil
+	}
+
+	*res = make(map[string]api.Tariff, len(conf))
+
+	for i, cc := range conf {
+		if cc.Name == "" {
+			return fmt.Errorf("comparison tariff %d: missing name", i+1)
+		}
+
+		if _, ok := (*res)[cc.Name]; ok {
+			return fmt.Errorf("comparison tariff %d: duplicate name: %s", i+1, cc.Name)
+		}
+
+		typed := config.Typed{Type: cc.Type, Other: cc.Other}
+
+		rate, err := tariffExchangeRate(&typed, cur)
+		if err != nil {
+			return &DeviceError{cc.Name, err}
+		}
+
+		t, err := tariffInstance(cc.Name, typed)
+		if err != nil {
+			return &DeviceError{cc.Name, err}
+		}
+
+		if rate != nil {
+			t = tariff.NewConverted(t, rate)
+		}
+
+		(*res)[cc.Name] = t
+	}
+
+	return nil
+}
+
 func configureDevices(conf globalconfig.All) error {
 	// collect references for filtering used devices
 	if err := collectRefs(conf); err != nil {
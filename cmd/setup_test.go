@@ -8,6 +8,8 @@ import (
 	"github.com/evcc-io/evcc/api/globalconfig"
 	"github.com/evcc-io/evcc/core"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+	"golang.org/x/text/currency"
 )
 
 func TestYamlOff(t *testing.T) {
@@ -32,3 +34,32 @@ func TestYamlOff(t *testing.T) {
 		t.Errorf("expected `off`, got %s", lp.DefaultMode)
 	}
 }
+
+func TestTariffExchangeRate(t *testing.T) {
+	conf := config.Typed{
+		Type: "template",
+		Other: map[string]any{
+			"template":         "nordpool",
+			"currency":         "SEK",
+			"exchangeCurrency": "DKK",
+			"exchangeRate":     0.134,
+		},
+	}
+
+	rate, err := tariffExchangeRate(&conf, currency.EUR)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if res, err := rate(); err != nil || res != 0.134 {
+		t.Errorf("unexpected rate: %v %v", res, err)
+	}
+
+	if conf.Other["currency"] != "SEK" {
+		t.Error("template currency consumed")
+	}
+
+	if _, ok := conf.Other["exchangeCurrency"]; ok {
+		t.Error("exchange currency not consumed")
+	}
+}
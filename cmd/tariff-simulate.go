@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/pricelog"
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/spf13/cobra"
+)
+
+// tariffSimulateCmd represents the tariff simulate command
+var tariffSimulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Replay charging sessions against the configured tariff",
+	Long: `Replay stored charging sessions against the configured grid and feed-in tariffs and compare with their actual cost.
+Use a separate configuration file containing the candidate tariffs to compare contracts before switching.
+Fixed tariffs are evaluated for each session including month and holiday zones, other tariffs are simulated
+at the recorded price history. Sessions not covered by prices are listed but excluded from the totals.`,
+	Run:  runTariffSimulate,
+	Args: cobra.NoArgs,
+}
+
+func init() {
+	tariffCmd.AddCommand(tariffSimulateCmd)
+	tariffSimulateCmd.Flags().String(flagFrom, "", "Start date (YYYY-MM-DD), default 30 days ago")
+	tariffSimulateCmd.Flags().String(flagTo, "", "End date (YYYY-MM-DD), default now")
+}
+
+func parseDate(cmd *cobra.Command, flag string, def time.Time) (time.Time, error) {
+	s, _ := cmd.Flags().GetString(flag)
+	if s == "" {
+		return def, nil
+	}
+	return time.ParseInLocation(time.DateOnly, s, time.Local)
+}
+
+// simulatedRates returns the tariff's rates covering the period. Tariffs deriving their rates from
+// configuration are evaluated for the period, other tariffs use the price history of given usage.
+func simulatedRates(t api.Tariff, usage string, from, to time.Time) (api.Rates, error) {
+	if pr, ok := tariff.As[tariff.PeriodRates](t); ok {
+		return pr.RatesBetween(from, to)
+	}
+
+	rr, err := pricelog.Rates(usage, from, to)
+	if errors.Is(err, pricelog.ErrNoHistory) {
+		err = tariff.ErrNotCovered
+	}
+
+	return rr, err
+}
+
+// simulatedCost returns the session's cost at the grid tariff and the forgone feed-in compensation
+func simulatedCost(grid, feedin api.Tariff, s session.Session, solarShare float64) (float64, error) {
+	rr, err := simulatedRates(grid, pricelog.UsageGrid, s.Created, s.Finished)
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := tariff.EnergyCost(rr, s.Created, s.Finished, s.ChargedEnergy*(1-solarShare))
+	if err != nil {
+		return 0, err
+	}
+
+	// solar energy is priced at the forgone feed-in compensation
+	if feedin != nil && solarShare > 0 {
+		rr, err := simulatedRates(feedin, pricelog.UsageFeedIn, s.Created, s.Finished)
+		if err != nil {
+			return 0, err
+		}
+
+		compensation, err := tariff.EnergyCost(rr, s.Created, s.Finished, s.ChargedEnergy*solarShare)
+		if err != nil {
+			return 0, err
+		}
+
+		res += compensation
+	}
+
+	return res, nil
+}
+
+func runTariffSimulate(cmd *cobra.Command, args []string) {
+	// load config
+	if err := loadConfigFile(&conf, !cmd.Flag(flagIgnoreDatabase).Changed); err != nil {
+		fatal(err)
+	}
+
+	// setup environment
+	if err := configureEnvironment(cmd, &conf); err != nil {
+		fatal(err)
+	}
+
+	if err := db.Instance.AutoMigrate(new(session.Session)); err != nil {
+		fatal(err)
+	}
+
+	tariffs, err := configureTariffs(&conf.Tariffs)
+	if err != nil {
+		fatal(err)
+	}
+
+	if tariffs.Grid == nil {
+		fatal(errors.New("missing grid tariff"))
+	}
+
+	to, err := parseDate(cmd, flagTo, time.Now())
+	if err != nil {
+		fatal(err)
+	}
+
+	from, err := parseDate(cmd, flagFrom, to.AddDate(0, 0, -30))
+	if err != nil {
+		fatal(err)
+	}
+
+	var sessions session.Sessions
+	if err := db.Instance.Where("finished > created AND charged_kwh > 0 AND away IS NOT TRUE AND created >= ? AND created < ?", from, to).Order("created").Find(&sessions).Error; err != nil {
+		fatal(err)
+	}
+
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 1, ' ', 0)
+	fmt.Fprintln(tw, "Session\tLoadpoint\tCreated\tEnergy (kWh)\tActual\tSimulated")
+
+	var energy, actual, simulated float64
+	var skipped int
+
+	for _, s := range sessions {
+		var solarShare float64
+		if s.SolarPercentage != nil {
+			solarShare = *s.SolarPercentage / 100
+		}
+
+		created := s.Created.Local().Format("2006-01-02 15:04")
+
+		price, err := simulatedCost(tariffs.Grid, tariffs.FeedIn, s, solarShare)
+		if errors.Is(err, tariff.ErrNotCovered) {
+			fmt.Fprintf(tw, "%d\t%s\t%s\t%.2f\t-\tno prices\n", s.ID, s.Loadpoint, created, s.ChargedEnergy)
+			skipped++
+			continue
+		} else if err != nil {
+			fatal(fmt.Errorf("session %d: %w", s.ID, err))
+		}
+
+		// actual cost from the session or the price history
+		old := s.Price
+		if old == nil {
+			if p, err := pricelog.SessionPrice(s.Loadpoint, s.Created, s.Finished); err == nil {
+				old = &p
+			}
+		}
+
+		oldS := "-"
+		if old != nil {
+			oldS = fmt.Sprintf("%.2f", *old)
+			actual += *old
+		}
+
+		energy += s.ChargedEnergy
+		simulated += price
+
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%.2f\t%s\t%.2f\n", s.ID, s.Loadpoint, created, s.ChargedEnergy, oldS, price)
+	}
+
+	fmt.Fprintf(tw, "Total\t\t\t%.2f\t%.2f\t%.2f\n", energy, actual, simulated)
+	tw.Flush()
+
+	if skipped > 0 {
+		fmt.Printf("%d sessions without price coverage excluded from the totals\n", skipped)
+	}
+}
@@ -43,11 +43,14 @@ func runTariff(cmd *cobra.Command, args []string) {
 	}
 
 	for u, tf := range map[api.TariffUsage]api.Tariff{
-		api.TariffUsageGrid:    tariffs.Grid,
-		api.TariffUsageFeedIn:  tariffs.FeedIn,
-		api.TariffUsageCo2:     tariffs.Co2,
-		api.TariffUsagePlanner: tariffs.Planner,
-		api.TariffUsageSolar:   tariffs.Solar,
+		api.TariffUsageGrid:        tariffs.Grid,
+		api.TariffUsageFeedIn:      tariffs.FeedIn,
+		api.TariffUsageCo2:         tariffs.Co2,
+		api.TariffUsagePlanner:     tariffs.Planner,
+		api.TariffUsageSolar:       tariffs.Solar,
+		api.TariffUsageGridState:   tariffs.GridState,
+		api.TariffUsageTemperature: tariffs.Temperature,
+		api.TariffUsageCo2Marginal: tariffs.Co2Marginal,
 	} {
 		key := u.String()
 		if name != "" && key != name {
@@ -73,6 +76,10 @@ func runTariff(cmd *cobra.Command, args []string) {
 			unit += "Footprint (gCO2/kWh)"
 		case api.TariffTypeSolar:
 			unit = "Yield (W)"
+		case api.TariffTypeGridState:
+			unit = "Grid state"
+		case api.TariffTypeTemperature:
+			unit = "Temperature (°C)"
 		default:
 			if c := conf.Tariffs.Currency; c != "" {
 				unit += fmt.Sprintf(" (%s/kWh)", c)
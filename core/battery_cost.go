@@ -0,0 +1,76 @@
+package core
+
+import (
+	"fmt"
+	"time"
+)
+
+// BatteryCostConfig configures the pricing of energy discharged from the home battery
+type BatteryCostConfig struct {
+	Efficiency float64 `mapstructure:"efficiency"` // battery round-trip efficiency (0..1), defaults to 1
+}
+
+// Validate validates the battery cost configuration
+func (c BatteryCostConfig) Validate() error {
+	if c.Efficiency < 0 || c.Efficiency > 1 {
+		return fmt.Errorf("invalid battery efficiency: %v", c.Efficiency)
+	}
+	return nil
+}
+
+// BatteryCost tracks the energy stored in the home battery and its average price
+type BatteryCost struct {
+	Energy     float64 `json:"energy"` // Stored energy (kWh)
+	Cost       float64 `json:"cost"`   // Cost of stored energy (Currency)
+	updated    time.Time
+	efficiency float64 // Round-trip efficiency
+}
+
+// Update accounts battery charging or discharging since the last update.
+// Charging energy drawn from grid is priced at gridPrice, the remainder at feedinPrice.
+// Discharging reduces the stored energy keeping its average price.
+// Stored energy is capped at the battery capacity (kWh) if known.
+func (bc *BatteryCost) Update(now time.Time, batteryPower, gridPower, gridPrice, feedinPrice, capacity float64) {
+	defer func() { bc.updated = now }()
+
+	if bc.updated.IsZero() {
+		return
+	}
+
+	hours := now.Sub(bc.updated).Hours()
+
+	switch {
+	case batteryPower < 0:
+		chargePower := -batteryPower
+		fromGrid := min(max(0, gridPower), chargePower)
+
+		bc.Energy += chargePower * hours / 1e3
+		bc.Cost += (fromGrid*gridPrice + (chargePower-fromGrid)*feedinPrice) * hours / 1e3
+
+	case batteryPower > 0 && bc.Energy > 0:
+		discharged := min(bc.Energy, batteryPower*hours/1e3)
+
+		bc.Cost -= bc.Cost / bc.Energy * discharged
+		bc.Energy -= discharged
+	}
+
+	if capacity > 0 && bc.Energy > capacity {
+		bc.Cost *= capacity / bc.Energy
+		bc.Energy = capacity
+	}
+}
+
+// Price returns the average price of the stored energy when discharged.
+// Round-trip losses raise the price of the energy that can actually be discharged.
+func (bc *BatteryCost) Price() (float64, bool) {
+	if bc.Energy <= 0 {
+		return 0, false
+	}
+
+	price := bc.Cost / bc.Energy
+	if bc.efficiency > 0 {
+		price /= bc.efficiency
+	}
+
+	return price, true
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatteryCost(t *testing.T) {
+	var bc BatteryCost
+	now := time.Now()
+
+	bc.Update(now, 0, 0, 0.3, 0.1, 0)
+	_, ok := bc.Price()
+	assert.False(t, ok)
+
+	// 1kWh from grid
+	now = now.Add(time.Hour)
+	bc.Update(now, -1000, 1000, 0.3, 0.1, 0)
+	price, ok := bc.Price()
+	assert.True(t, ok)
+	assert.InDelta(t, 0.3, price, 1e-6)
+
+	// 1kWh from pv
+	now = now.Add(time.Hour)
+	bc.Update(now, -1000, -500, 0.3, 0.1, 0)
+	price, _ = bc.Price()
+	assert.InDelta(t, 0.2, price, 1e-6)
+
+	// discharge keeps average price
+	now = now.Add(time.Hour)
+	bc.Update(now, 1000, 0, 0.3, 0.1, 0)
+	price, _ = bc.Price()
+	assert.InDelta(t, 0.2, price, 1e-6)
+	assert.InDelta(t, 1, bc.Energy, 1e-6)
+
+	// full discharge
+	now = now.Add(2 * time.Hour)
+	bc.Update(now, 1000, 0, 0.3, 0.1, 0)
+	_, ok = bc.Price()
+	assert.False(t, ok)
+}
+
+func TestBatteryCostEfficiency(t *testing.T) {
+	bc := BatteryCost{efficiency: 0.8}
+	now := time.Now()
+
+	bc.Update(now, 0, 0, 0.3, 0.1, 0)
+
+	// 1kWh from grid
+	now = now.Add(time.Hour)
+	bc.Update(now, -1000, 1000, 0.2, 0.1, 0)
+	price, ok := bc.Price()
+	assert.True(t, ok)
+	assert.InDelta(t, 0.25, price, 1e-6)
+
+	assert.Error(t, BatteryCostConfig{Efficiency: 1.1}.Validate())
+	assert.NoError(t, BatteryCostConfig{}.Validate())
+}
+
+func TestBatteryCostCapacity(t *testing.T) {
+	var bc BatteryCost
+	now := time.Now()
+
+	bc.Update(now, 0, 0, 0.3, 0.1, 2)
+
+	// 3kWh from grid into 2kWh battery
+	now = now.Add(time.Hour)
+	bc.Update(now, -3000, 3000, 0.3, 0.1, 2)
+	assert.InDelta(t, 2, bc.Energy, 1e-6)
+
+	price, ok := bc.Price()
+	assert.True(t, ok)
+	assert.InDelta(t, 0.3, price, 1e-6)
+}
+
+func TestBatteryCostRestore(t *testing.T) {
+	var bc BatteryCost
+	now := time.Now()
+
+	bc.Update(now, 0, 0, 0.3, 0.1, 0)
+	bc.Update(now.Add(time.Hour), -1000, 1000, 0.3, 0.1, 0)
+
+	b, err := json.Marshal(bc)
+	require.NoError(t, err)
+
+	// downtime is not accounted after restart
+	restored := BatteryCost{efficiency: 0.8}
+	require.NoError(t, json.Unmarshal(b, &restored))
+	restored.Update(now.Add(3*time.Hour), -1000, 1000, 0.3, 0.1, 0)
+
+	assert.Equal(t, bc.Energy, restored.Energy)
+	price, ok := restored.Price()
+	assert.True(t, ok)
+	assert.InDelta(t, 0.375, price, 1e-6)
+}
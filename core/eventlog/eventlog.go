@@ -0,0 +1,147 @@
+package eventlog
+
+import (
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"gorm.io/gorm"
+)
+
+const (
+	CategoryDevice  = "device"  // device online/offline
+	CategoryMode    = "mode"    // charge mode changes
+	CategoryPlan    = "plan"    // charge plan activation and outcome
+	CategorySession = "session" // vehicle connect, disconnect, charge start and stop
+	CategoryAlert   = "alert"   // site alerts like faults or exhausted budgets
+
+	// DefaultRetention is the event retention if not configured
+	DefaultRetention = 90 * 24 * time.Hour
+
+	purgeInterval = time.Hour
+)
+
+// Entry is an event log entry
+type Entry struct {
+	ID       uint      `json:"id" gorm:"primarykey"`
+	Created  time.Time `json:"created" gorm:"index"`
+	Category string    `json:"category" gorm:"index"`
+	Device   string    `json:"device" gorm:"index"`
+	Event    string    `json:"event"`
+	Message  string    `json:"message,omitempty"`
+}
+
+// TableName returns the database table name
+func (Entry) TableName() string {
+	return "events"
+}
+
+// Filter restricts the returned entries. Zero values match all entries.
+type Filter struct {
+	Category string
+	Device   string
+	From, To time.Time
+	Limit    int
+}
+
+var (
+	db  *gorm.DB
+	log = util.NewLogger("eventlog")
+
+	mu        sync.Mutex
+	retention time.Duration
+	purged    time.Time
+)
+
+// Init migrates the event table and sets the retention period
+func Init(instance *gorm.DB, ret time.Duration) error {
+	if ret <= 0 {
+		ret = DefaultRetention
+	}
+
+	mu.Lock()
+	db = instance
+	retention = ret
+	purged = time.Time{}
+	mu.Unlock()
+
+	return db.AutoMigrate(new(Entry))
+}
+
+// Record adds an entry to the event log and removes entries exceeding the retention period
+func Record(category, device, event, message string) {
+	if db == nil {
+		return
+	}
+
+	entry := Entry{
+		Created:  time.Now(),
+		Category: category,
+		Device:   device,
+		Event:    event,
+		Message:  message,
+	}
+
+	if err := db.Create(&entry).Error; err != nil {
+		log.ERROR.Printf("record: %v", err)
+		return
+	}
+
+	purge(entry.Created)
+}
+
+// purge removes expired entries at most once per purge interval
+func purge(ts time.Time) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if ts.Sub(purged) < purgeInterval {
+		return
+	}
+	purged = ts
+
+	if err := db.Where("created < ?", ts.Add(-retention)).Delete(new(Entry)).Error; err != nil {
+		log.ERROR.Printf("purge: %v", err)
+	}
+}
+
+// Entries returns the most recent entries matching the filter
+func Entries(f Filter) ([]Entry, error) {
+	res := []Entry{}
+	if db == nil {
+		return res, nil
+	}
+
+	tx := db.Order("id desc")
+	if f.Limit > 0 {
+		tx = tx.Limit(f.Limit)
+	}
+	if f.Category != "" || f.Device != "" {
+		tx = tx.Where(&Entry{Category: f.Category, Device: f.Device})
+	}
+	if !f.From.IsZero() {
+		tx = tx.Where("created >= ?", f.From)
+	}
+	if !f.To.IsZero() {
+		tx = tx.Where("created < ?", f.To)
+	}
+
+	return res, tx.Find(&res).Error
+}
+
+// Availability records online/offline transitions of a device
+type Availability struct {
+	offline bool
+}
+
+// Update records the device going offline on error and coming back online once it recovers
+func (a *Availability) Update(device string, err error) {
+	switch {
+	case err != nil && !a.offline:
+		a.offline = true
+		Record(CategoryDevice, device, "offline", err.Error())
+	case err == nil && a.offline:
+		a.offline = false
+		Record(CategoryDevice, device, "online", "")
+	}
+}
@@ -0,0 +1,43 @@
+package eventlog
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventLog(t *testing.T) {
+	instance, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+	require.NoError(t, Init(instance, time.Hour))
+
+	// expired entry
+	require.NoError(t, db.Create(&Entry{Created: time.Now().Add(-2 * time.Hour), Category: CategoryMode, Device: "garage", Event: "pv"}).Error)
+
+	var a Availability
+	a.Update("wallbox", errors.New("timeout"))
+	a.Update("wallbox", errors.New("timeout"))
+	a.Update("wallbox", nil)
+
+	Record(CategoryMode, "garage", "now", "from pv")
+
+	res, err := Entries(Filter{})
+	require.NoError(t, err)
+	require.Len(t, res, 3)
+	assert.Equal(t, "now", res[0].Event)
+
+	res, err = Entries(Filter{Category: CategoryDevice, Device: "wallbox"})
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, "online", res[0].Event)
+	assert.Equal(t, "offline", res[1].Event)
+	assert.Equal(t, "timeout", res[1].Message)
+
+	res, err = Entries(Filter{To: time.Now().Add(-time.Minute)})
+	require.NoError(t, err)
+	assert.Empty(t, res)
+}
@@ -2,9 +2,11 @@ package core
 
 import (
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/api"
 )
 
 var (
@@ -36,6 +38,16 @@ func currentToPower(current float64, phases int) float64 {
 	return current * float64(phases) * Voltage
 }
 
+// deviceFeature returns the device's optional interface T if supported. Devices describing their features
+// must also advertise the feature, since configurable devices implement the interface regardless of configuration.
+func deviceFeature[T any](dev any, f api.Feature) (T, bool) {
+	res, ok := dev.(T)
+	if fd, describes := dev.(api.FeatureDescriber); ok && describes {
+		ok = slices.Contains(fd.Features(), f)
+	}
+	return res, ok
+}
+
 // printPtr returns a string representation of a pointer value
 func printPtr[T any](format string, v *T) string {
 	if v == nil {
@@ -18,6 +18,7 @@ const (
 	EnableDelay      = "enableDelay"
 	DisableDelay     = "disableDelay"
 	BatteryBoost     = "batteryBoost"
+	SmartCost        = "smartCost"
 
 	PhasesConfigured = "phasesConfigured" // desired phase mode (0/1/3, 0 = automatic), user selection
 	PhasesActive     = "phasesActive"     // active phases as used by vehicle (1/2/3)
@@ -34,9 +35,12 @@ const (
 	Charging  = "charging"  // charging
 
 	// smart charging
-	SmartCostActive    = "smartCostActive"    // smart cost active
-	SmartCostLimit     = "smartCostLimit"     // smart cost limit
-	SmartCostNextStart = "smartCostNextStart" // smart cost next start
+	SmartCostActive      = "smartCostActive"      // smart cost active
+	SmartCostLimit       = "smartCostLimit"       // smart cost limit
+	SmartCostHysteresis  = "smartCostHysteresis"  // smart cost hysteresis
+	SmartCostMinDuration = "smartCostMinDuration" // smart cost minimum duration
+	SmartCostNextStart   = "smartCostNextStart"   // smart cost next start
+	Recommendation       = "recommendation"       // recommended plug-in time for typical session energy
 
 	// effective values
 	EffectivePriority   = "effectivePriority"   // effective priority
@@ -46,6 +50,7 @@ const (
 	EffectiveMinCurrent = "effectiveMinCurrent" // effective min current
 	EffectiveMaxCurrent = "effectiveMaxCurrent" // effective max current
 	EffectiveLimitSoc   = "effectiveLimitSoc"   // effective limit soc
+	EffectiveSurplus    = "effectiveSurplus"    // surplus driving pv charging decisions
 
 	// measurements
 	ChargeCurrent     = "chargeCurrent"     // charge current
@@ -56,10 +61,27 @@ const (
 	ChargeDuration    = "chargeDuration"    // charge duration
 	ChargeTotalImport = "chargeTotalImport" // charge meter total import
 
+	// energy mix of allocated charge power
+	GreenShare     = "greenShare"     // green share
+	EffectivePrice = "effectivePrice" // effective price
+	EffectiveCo2   = "effectiveCo2"   // effective co2
+
 	// session
 	ConnectedDuration       = "connectedDuration"       // connected duration
 	ChargeRemainingDuration = "chargeRemainingDuration" // charge remaining duration
 	ChargeRemainingEnergy   = "chargeRemainingEnergy"   // charge remaining energy
+	ChargeEstimateMinSoc    = "chargeEstimateMinSoc"    // estimated time to reach min soc by scenario
+	ChargeEstimateLimitSoc  = "chargeEstimateLimitSoc"  // estimated time to reach limit soc by scenario
+	ChargeEstimatePlanSoc   = "chargeEstimatePlanSoc"   // estimated time to reach plan soc by scenario
+	Runtime                 = "runtime"                 // device runtime today
+
+	// guest session
+	GuestSession     = "guestSession"     // guest session caps
+	GuestSummary     = "guestSummary"     // guest session summary
+	GuestPaymentLink = "guestPaymentLink" // guest session payment link
+
+	// sessions
+	Session = "session" // completed charging session
 
 	// plan
 	PlanTime           = "planTime"           // charge plan finish time goal
@@ -69,10 +91,15 @@ const (
 	PlanProjectedStart = "planProjectedStart" // charge plan start time (earliest slot)
 	PlanProjectedEnd   = "planProjectedEnd"   // charge plan ends (end of last slot)
 	PlanOverrun        = "planOverrun"        // charge plan goal not reachable in time
+	PlanHistory        = "planHistory"        // outcomes of past charge plans
+	PlanSlot           = "planSlot"           // executed charge plan slot
 
 	// repeating plans
 	RepeatingPlans = "repeatingPlans" // key to access all repeating plans in db
 
+	// vehicle profiles
+	Profiles = "profiles" // key to access all vehicle settings profiles in db
+
 	// remote control
 	RemoteDisabled       = "remoteDisabled"       // remote disabled
 	RemoteDisabledSource = "remoteDisabledSource" // remote disabled source
@@ -83,8 +110,13 @@ const (
 	VehicleDetectionActive = "vehicleDetectionActive" // vehicle detection active
 	VehicleOdometer        = "vehicleOdometer"        // vehicle odometer
 	VehicleRange           = "vehicleRange"           // vehicle range
+	VehicleAuxiliarySoc    = "vehicleAuxiliarySoc"    // vehicle secondary energy store soc
+	VehicleAuxiliaryRange  = "vehicleAuxiliaryRange"  // vehicle secondary energy store range
+	VehicleCombinedRange   = "vehicleCombinedRange"   // vehicle range including secondary energy store
 	VehicleSoc             = "vehicleSoc"             // vehicle soc
 	VehicleLimitSoc        = "vehicleLimitSoc"        // vehicle api soc limit
 	VehicleClimaterActive  = "vehicleClimaterActive"  // vehicle climater active
 	VehicleWelcomeActive   = "vehicleWelcomeActive"   // vehicle might need welcome charge
+	VehicleIdleSince       = "vehicleIdleSince"       // vehicle connected after charging completed
+	VehicleFull            = "vehicleFull"            // vehicle assumed full by plug-in hybrid heuristics
 )
@@ -19,15 +19,52 @@ const (
 	SiteTitle             = "siteTitle"
 	SmartCostType         = "smartCostType"
 	Statistics            = "statistics"
+	Surplus               = "surplus"
+	SurplusRaw            = "surplusRaw"
 	Forecast              = "forecast"
+	GridState             = "gridState"
+	GridBudget            = "gridBudget"
+	GridBudgetEnergy      = "gridBudgetEnergy"
+	GridBudgetExceeded    = "gridBudgetExceeded"
+	Co2Budget             = "co2Budget"
+	Co2BudgetMass         = "co2BudgetMass"
+	Co2BudgetScale        = "co2BudgetScale"
+	DemandPeak            = "demandPeak"
+	TierConsumption       = "tierConsumption"
+	BatteryExport         = "batteryExport"
+	BatteryCost           = "batteryCost"
+	PvAnomaly             = "pvAnomaly"
+	ForecastScale         = "forecastScale"
+	ForecastScaleClamped  = "forecastScaleClamped"
+	ForecastedToday       = "forecastedToday"
+	YieldToday            = "yieldToday"
+	SolarRemainingToday   = "solarRemainingToday"
+	SurplusWindowStart    = "surplusWindowStart"
+	SurplusWindowEnd      = "surplusWindowEnd"
+	SolarForecast         = "solarForecast"
+	SolarForecastPlanes   = "solarForecastPlanes"
+	SolarCorrection       = "solarCorrection"
+	ForecastPlaneScales   = "forecastPlaneScales"
+	ForecastAccuracy      = "forecastAccuracy"
+	PanelsCovered         = "panelsCovered"
+	ExportLimit           = "exportLimit"
+	ExportLimitActive     = "exportLimitActive"
+	InverterStandby       = "inverterStandby"
+	InverterSavings       = "inverterSavings"
+	NegativePriceCharge   = "negativePriceCharge"
+	NegativePriceFeedIn   = "negativePriceFeedIn"
+	Faults                = "faults"
+	FaultMessage          = "faultMessage"
 	TariffCo2             = "tariffCo2"
 	TariffCo2Home         = "tariffCo2Home"
 	TariffCo2Loadpoints   = "tariffCo2Loadpoints"
 	TariffFeedIn          = "tariffFeedIn"
 	TariffGrid            = "tariffGrid"
+	TariffGridRaw         = "tariffGridRaw"
 	TariffPriceHome       = "tariffPriceHome"
 	TariffPriceLoadpoints = "tariffPriceLoadpoints"
 	TariffSolar           = "tariffSolar"
+	TariffDigest          = "tariffDigest"
 	Vehicles              = "vehicles"
 
 	// meters
@@ -36,19 +73,27 @@ const (
 	BatteryMeters = "batteryMeters"
 	ExtMeters     = "extMeters"
 	AuxMeters     = "auxMeters"
+	MeterOffsets  = "meterOffsets"
 
 	// battery settings
 	BatteryCapacity         = "batteryCapacity"
 	BatteryDischargeControl = "batteryDischargeControl"
 	BatteryGridChargeLimit  = "batteryGridChargeLimit"
 	BatteryGridChargeActive = "batteryGridChargeActive"
+	BatteryExportLimit      = "batteryExportLimit"
 	BufferSoc               = "bufferSoc"
 	BufferStartSoc          = "bufferStartSoc"
 
 	// battery status
-	Battery       = "battery"
-	BatteryEnergy = "batteryEnergy"
-	BatteryMode   = "batteryMode"
-	BatteryPower  = "batteryPower"
-	BatterySoc    = "batterySoc"
+	Battery              = "battery"
+	BatteryEnergy        = "batteryEnergy"
+	BatteryMode          = "batteryMode"
+	BatteryPower         = "batteryPower"
+	BatteryPrice         = "batteryPrice"
+	BatterySoc           = "batterySoc"
+	BatteryExportActive  = "batteryExportActive"
+	BatteryExportEnergy  = "batteryExportEnergy"
+	BatteryExportRevenue = "batteryExportRevenue"
+	BatteryWarranty      = "batteryWarranty"
+	StorageGreen         = "storageGreen"
 )
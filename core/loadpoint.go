@@ -6,6 +6,7 @@ import (
 	"math"
 	"reflect"
 	"slices"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -15,15 +16,18 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/coordinator"
+	"github.com/evcc-io/evcc/core/eventlog"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/planner"
+	"github.com/evcc-io/evcc/core/pricelog"
 	"github.com/evcc-io/evcc/core/session"
 	"github.com/evcc-io/evcc/core/settings"
 	"github.com/evcc-io/evcc/core/soc"
 	"github.com/evcc-io/evcc/core/vehicle"
 	"github.com/evcc-io/evcc/core/wrapper"
 	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/config"
 	"github.com/evcc-io/evcc/util/telemetry"
@@ -94,9 +98,17 @@ type Loadpoint struct {
 	Enable, Disable loadpoint.ThresholdConfig
 
 	// from yaml
-	DefaultMode api.ChargeMode `mapstructure:"mode"`     // Default charge mode, used for disconnect
-	Title       string         `mapstructure:"title"`    // UI title
-	Priority    int            `mapstructure:"priority"` // Priority
+	DefaultMode api.ChargeMode              `mapstructure:"mode"`        // Default charge mode, used for disconnect
+	Title       string                      `mapstructure:"title"`       // UI title
+	Priority    int                         `mapstructure:"priority"`    // Priority
+	Cop         loadpoint.CopConfig         `mapstructure:"cop"`         // Heat pump efficiency for smart heating
+	Guest       loadpoint.GuestConfig       `mapstructure:"guest"`       // Guest charging payment
+	Tariff      *config.Typed               `mapstructure:"tariff"`      // Loadpoint specific grid tariff
+	Idle        loadpoint.IdleConfig        `mapstructure:"idle"`        // Idle vehicle reminder
+	Phev        loadpoint.PhevConfig        `mapstructure:"phev"`        // Plug-in hybrid completion heuristics
+	LowPower    loadpoint.LowPowerConfig    `mapstructure:"lowPower"`    // Low power device charging via switchable socket
+	Reservation loadpoint.ReservationConfig `mapstructure:"reservation"` // Reserved share of the forecasted solar power
+	SmartCost   loadpoint.SmartCostConfig   `mapstructure:"smartCost"`   // Smart cost charging hysteresis
 
 	// from yaml, deprecated
 	GuardDuration_ time.Duration `mapstructure:"guardduration"` // ignored, present for compatibility
@@ -104,6 +116,8 @@ type Loadpoint struct {
 	MinCurrent_    float64       `mapstructure:"minCurrent"`    // ignored, present for compatibility
 	MaxCurrent_    float64       `mapstructure:"maxCurrent"`    // ignored, present for compatibility
 
+	id               int      // 1-based loadpoint id as used by api and mqtt
+	name             string   // configured device name
 	title            string   // UI title
 	priority         int      // Priority
 	minCurrent       float64  // PV mode: start current	Min+PV mode: min current
@@ -122,9 +136,23 @@ type Loadpoint struct {
 	socUpdated          time.Time // Soc updated timestamp (poll: connected)
 	vehicleDetect       time.Time // Vehicle connected timestamp
 	chargerSwitched     time.Time // Charger enabled/disabled timestamp
+	currentAdjusted     time.Time // Charge current adjusted timestamp
 	phasesSwitched      time.Time // Phase switch timestamp
 	vehicleDetectTicker *clock.Ticker
 	vehicleIdentifier   string
+	rfidRejected        bool               // charging disabled by rejected rfid tag
+	pairingRejected     bool               // charging disabled by vehicle not allowed at loadpoint
+	demandPlan          bool               // plan created from device demand
+	displayStatus       *api.DisplayStatus // status last shown at charger
+	gridBudgetExceeded  bool               // site grid budget exhausted, pv charging only
+	co2BudgetScale      *float64           // smart co2 limit share left by the site co2 budget, not applied if nil
+	negativePriceCharge bool               // site grid price negative, charge from grid
+	demandLimit         float64            // charge power not creating a new demand peak (W), unlimited if zero
+	guest               *guestSession      // active guest session
+	idle                idleState          // vehicle idle after charging
+	vehicleFull         bool               // vehicle assumed full by plug-in hybrid heuristics
+	lowPowerFinished    bool               // low power device finished charging, session to be restarted
+	smartCost           smartCostState     // smart cost charging state for hysteresis
 
 	charger          api.Charger
 	chargeTimer      api.ChargeTimer
@@ -132,6 +160,7 @@ type Loadpoint struct {
 	chargedAtStartup float64 // session energy at startup
 
 	circuit        api.Circuit // Circuit
+	tariff         api.Tariff  // Grid tariff override
 	chargeMeter    api.Meter   // Charger usage meter
 	vehicle        api.Vehicle // Currently active vehicle
 	defaultVehicle api.Vehicle // Default vehicle (disables detection)
@@ -143,22 +172,32 @@ type Loadpoint struct {
 	planTime    time.Time // time goal
 	planEnergy  float64   // Plan charge energy in kWh (dumb vehicles)
 	planSlotEnd time.Time // current plan slot end time
+	planSlots   api.Rates // current plan slots
 	planActive  bool      // charge plan exists and has a currently active slot
+	planTracker planTracker
+	planHistory []loadpoint.PlanOutcome // outcomes of past plans
+	planSlot    *planSlot               // currently executed plan slot
+	comparison  map[string]api.Tariff   // alternative grid tariffs for plan cost comparison
+
+	chargerAvailability eventlog.Availability // charger online/offline
 
 	// cached state
-	status         api.ChargeStatus       // Charger status
-	remoteDemand   loadpoint.RemoteDemand // External status demand
-	chargePower    float64                // Charging power
-	chargeCurrents []float64              // Phase currents
-	connectedTime  time.Time              // Time when vehicle was connected
-	pvTimer        time.Time              // PV enabled/disable timer
-	phaseTimer     time.Time              // 1p3p switch timer
-	wakeUpTimer    *Timer                 // Vehicle wake-up timeout
+	status         api.ChargeStatus                  // Charger status
+	remoteDemand   loadpoint.RemoteDemand            // External status demand
+	remoteSource   string                            // External status demand source
+	remoteDemands  map[string]loadpoint.RemoteDemand // External status demand per independent source
+	chargePower    float64                           // Charging power
+	chargeCurrents []float64                         // Phase currents
+	connectedTime  time.Time                         // Time when vehicle was connected
+	pvTimer        time.Time                         // PV enabled/disable timer
+	phaseTimer     time.Time                         // 1p3p switch timer
+	wakeUpTimer    *Timer                            // Vehicle wake-up timeout
 
 	// charge progress
 	vehicleSoc              float64       // Vehicle Soc
 	chargeDuration          time.Duration // Charge duration
 	energyMetrics           EnergyMetrics // Stats for charged energy by session
+	trackedEnergy           float64       // Charged energy accounted to soc tracking vehicle in Wh
 	chargeRemainingDuration time.Duration // Remaining charge duration
 	chargeRemainingEnergy   float64       // Remaining charge energy in Wh
 	progress                *Progress     // Step-wise progress indicator
@@ -196,6 +235,14 @@ func NewLoadpointFromConfig(log *util.Logger, settings settings.Settings, other
 		lp.circuit = dev.Instance()
 	}
 
+	if lp.Tariff != nil {
+		t, err := tariff.NewInstance(lp.log, strings.TrimSpace("loadpoint "+lp.Title), *lp.Tariff)
+		if err != nil {
+			return nil, fmt.Errorf("tariff: %w", err)
+		}
+		lp.tariff = t
+	}
+
 	if lp.MeterRef != "" {
 		dev, err := config.Meters().ByName(lp.MeterRef)
 		if err != nil {
@@ -333,16 +380,31 @@ func (lp *Loadpoint) restoreSettings() {
 		lp.setThresholds(thresholds)
 	}
 
+	var smartCost loadpoint.SmartCostConfig
+	if err := lp.settings.Json(keys.SmartCost, &smartCost); err == nil {
+		lp.setSmartCostConfig(smartCost)
+	}
+
 	var socConfig loadpoint.SocConfig
 	if err := lp.settings.Json(keys.Soc, &socConfig); err == nil {
 		lp.setSocConfig(socConfig)
 	}
 
+	var guest *guestSession
+	if err := lp.settings.Json(keys.GuestSession, &guest); err == nil && guest != nil {
+		lp.setGuestSession(guest)
+	}
+
 	t, err1 := lp.settings.Time(keys.PlanTime)
 	v, err2 := lp.settings.Float(keys.PlanEnergy)
 	if err1 == nil && err2 == nil {
 		lp.setPlanEnergy(t, v)
 	}
+
+	var history []loadpoint.PlanOutcome
+	if err := lp.settings.Json(keys.PlanHistory, &history); err == nil {
+		lp.planHistory = history
+	}
 }
 
 // requestUpdate requests site to update this loadpoint
@@ -353,6 +415,14 @@ func (lp *Loadpoint) requestUpdate() {
 	}
 }
 
+// watchStatus requests a loadpoint update on each charger status change
+func (lp *Loadpoint) watchStatus(statusC <-chan struct{}) {
+	for range statusC {
+		lp.log.DEBUG.Println("charger status changed")
+		lp.lpChan <- lp
+	}
+}
+
 // configureChargerType ensures that chargeMeter, Rate and Timer can use charger capabilities
 func (lp *Loadpoint) configureChargerType(charger api.Charger) {
 	var integrated bool
@@ -407,6 +477,9 @@ func (lp *Loadpoint) configureChargerType(charger api.Charger) {
 
 // pushEvent sends push messages to clients
 func (lp *Loadpoint) pushEvent(event string) {
+	if event != evVehicleSoc {
+		eventlog.Record(eventlog.CategorySession, lp.title, event, "")
+	}
 	lp.pushChan <- push.Event{Event: event}
 }
 
@@ -456,21 +529,39 @@ func (lp *Loadpoint) evChargeStopHandler() {
 		lp.resetPVTimer()
 	}
 
+	// vehicle stopped although charger is enabled
+	if lp.Phev.Finished && lp.enabled {
+		lp.log.DEBUG.Println("phev: vehicle finished charging")
+		lp.setVehicleFull(true)
+	}
+
+	// low power device stopped although socket is enabled
+	if lp.LowPower.Power > 0 && lp.enabled {
+		lp.lowPowerFinished = true
+	}
+
 	lp.stopSession()
 }
 
-// evVehicleConnectHandler sends external start event
-func (lp *Loadpoint) evVehicleConnectHandler() {
-	lp.log.INFO.Printf("car connected")
-
+// resetSessionMetrics resets charged energy and connected duration for a new session
+func (lp *Loadpoint) resetSessionMetrics() {
 	// energy
 	lp.energyMetrics.Reset()
+	lp.trackedEnergy = 0
 	lp.energyMetrics.Publish("session", lp)
 	lp.publish(keys.ChargedEnergy, lp.GetChargedEnergy())
 
 	// duration
 	lp.connectedTime = lp.clock.Now()
 	lp.publish(keys.ConnectedDuration, time.Duration(0))
+}
+
+// evVehicleConnectHandler sends external start event
+func (lp *Loadpoint) evVehicleConnectHandler() {
+	lp.log.INFO.Printf("car connected")
+
+	// energy and duration
+	lp.resetSessionMetrics()
 
 	// soc update reset
 	lp.socUpdated = time.Time{}
@@ -488,6 +579,9 @@ func (lp *Loadpoint) evVehicleConnectHandler() {
 	// immediately allow pv mode activity
 	lp.elapsePVTimer()
 
+	// forget plug-in hybrid completion
+	lp.setVehicleFull(false)
+
 	// create charging session
 	lp.createSession()
 }
@@ -496,6 +590,16 @@ func (lp *Loadpoint) evVehicleConnectHandler() {
 func (lp *Loadpoint) evVehicleDisconnectHandler() {
 	lp.log.INFO.Println("car disconnected")
 
+	// plan abandoned before reaching its goal
+	if lp.clock.Now().Before(lp.planTracker.time) {
+		lp.recordPlanOutcome(false, loadpoint.PlanCauseDisconnected)
+	} else {
+		lp.recordPlanOutcome(false, "")
+	}
+
+	// summarize guest session before clearing session energy
+	lp.finishGuestSession()
+
 	// session is persisted during evChargeStopHandler which runs before
 	lp.clearSession()
 
@@ -513,6 +617,9 @@ func (lp *Loadpoint) evVehicleDisconnectHandler() {
 	// forget startup energy offset
 	lp.chargedAtStartup = 0
 
+	// forget plug-in hybrid completion
+	lp.setVehicleFull(false)
+
 	// remove charger vehicle id and stop potential detection
 	lp.setVehicleIdentifier("")
 	lp.stopVehicleDetection()
@@ -522,6 +629,7 @@ func (lp *Loadpoint) evVehicleDisconnectHandler() {
 
 	// set default vehicle (may be nil)
 	lp.setActiveVehicle(lp.defaultVehicle)
+	lp.authorizeVehicle(nil, false)
 
 	// soc update reset
 	lp.socUpdated = time.Time{}
@@ -597,6 +705,11 @@ func (lp *Loadpoint) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Even
 	_ = lp.bus.Subscribe(evChargeCurrent, lp.evChargeCurrentHandler)
 	_ = lp.bus.Subscribe(evVehicleSoc, lp.evVehicleSocProgressHandler)
 
+	// react to charger status changes without waiting for the next interval
+	if sn, ok := lp.charger.(api.StatusNotifier); ok {
+		go lp.watchStatus(sn.StatusChanged())
+	}
+
 	// restore settings
 	lp.restoreSettings()
 
@@ -625,6 +738,8 @@ func (lp *Loadpoint) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Even
 	lp.publish(keys.ChargerSinglePhase, lp.getChargerPhysicalPhases() == 1)
 	lp.publish(keys.PhasesActive, lp.ActivePhases())
 	lp.publish(keys.SmartCostLimit, lp.smartCostLimit)
+	lp.publish(keys.SmartCostHysteresis, lp.SmartCost.Hysteresis)
+	lp.publish(keys.SmartCostMinDuration, lp.SmartCost.MinDuration)
 	lp.publishTimer(phaseTimer, 0, timerInactive)
 	lp.publishTimer(pvTimer, 0, timerInactive)
 
@@ -831,7 +946,8 @@ func (lp *Loadpoint) roundedCurrent(chargeCurrent float64) float64 {
 
 // setLimit applies charger current limits and enables/disables accordingly
 func (lp *Loadpoint) setLimit(chargeCurrent float64) error {
-	chargeCurrent = lp.roundedCurrent(chargeCurrent)
+	// avoid creating a new demand peak in any mode
+	chargeCurrent = lp.roundedCurrent(lp.demandCurrent(chargeCurrent))
 
 	// apply circuit limits
 	if lp.circuit != nil {
@@ -852,11 +968,22 @@ func (lp *Loadpoint) setLimit(chargeCurrent float64) error {
 
 	// set current
 	if chargeCurrent != lp.chargeCurrent && chargeCurrent >= effMinCurrent {
-		var err error
-		if charger, ok := lp.charger.(api.ChargerEx); ok {
-			err = charger.MaxCurrentMillis(chargeCurrent)
-		} else {
-			err = lp.charger.MaxCurrent(int64(chargeCurrent))
+		err := api.ErrNotAvailable
+
+		// power controlled chargers like DC wallboxes
+		if charger, ok := lp.charger.(api.PowerController); ok {
+			power := chargeCurrent * lp.EffectiveStepPower()
+			if err = charger.MaxPower(power); err == nil {
+				lp.log.DEBUG.Printf("max charge power: %.0fW", power)
+			}
+		}
+
+		if errors.Is(err, api.ErrNotAvailable) {
+			if charger, ok := lp.charger.(api.ChargerEx); ok {
+				err = charger.MaxCurrentMillis(chargeCurrent)
+			} else {
+				err = lp.charger.MaxCurrent(int64(chargeCurrent))
+			}
 		}
 
 		if err != nil {
@@ -875,6 +1002,7 @@ func (lp *Loadpoint) setLimit(chargeCurrent float64) error {
 
 		lp.log.DEBUG.Printf("max charge current: %.3gA", chargeCurrent)
 		lp.chargeCurrent = chargeCurrent
+		lp.currentAdjusted = lp.clock.Now()
 		lp.bus.Publish(evChargeCurrent, chargeCurrent)
 	}
 
@@ -896,6 +1024,7 @@ func (lp *Loadpoint) setLimit(chargeCurrent float64) error {
 
 		lp.setAndPublishEnabled(enabled)
 		lp.chargerSwitched = lp.clock.Now()
+		lp.currentAdjusted = lp.chargerSwitched
 
 		// ensure we always re-set current when enabling charger
 		if !enabled {
@@ -1016,7 +1145,8 @@ func (lp *Loadpoint) remoteControlled(demand loadpoint.RemoteDemand) bool {
 	lp.Lock()
 	defer lp.Unlock()
 
-	return lp.remoteDemand == demand
+	res, _ := lp.effectiveRemoteDemand()
+	return res == demand
 }
 
 // statusEvents converts the observed charger status change into a logical sequence of events
@@ -1344,6 +1474,9 @@ func (lp *Loadpoint) pvMaxCurrent(mode api.ChargeMode, sitePower, batteryBoostPo
 	// push demand to drain battery
 	sitePower -= lp.boostPower(batteryBoostPower)
 
+	// surplus available to this loadpoint after reservations of other loadpoints and battery boost
+	lp.publish(keys.EffectiveSurplus, -sitePower)
+
 	// switch phases up/down
 	var scaledTo int
 	if lp.hasPhaseSwitching() && lp.phaseSwitchCompleted() {
@@ -1361,6 +1494,11 @@ func (lp *Loadpoint) pvMaxCurrent(mode api.ChargeMode, sitePower, batteryBoostPo
 	deltaCurrent := powerToCurrent(-sitePower, activePhases)
 	targetCurrent := max(effectiveCurrent+deltaCurrent, 0)
 
+	// low power devices are switched by surplus power regardless of current
+	if lp.LowPower.Power > 0 {
+		targetCurrent = lp.lowPowerCurrent(sitePower, minCurrent)
+	}
+
 	// in MinPV mode or under special conditions return at least minCurrent
 	if battery := batteryStart || batteryBuffered && lp.charging(); (mode == api.ModeMinPV || battery) && targetCurrent < minCurrent {
 		lp.log.DEBUG.Printf("pv charge current: min %.3gA > %.3gA (%.0fW @ %dp, battery: %t)", minCurrent, targetCurrent, sitePower, activePhases, battery)
@@ -1573,6 +1711,11 @@ func (lp *Loadpoint) publishChargeProgress() {
 			if telemetry.Enabled() && added > 0 {
 				telemetry.UpdateEnergy(added, addedGreen)
 			}
+
+			// energy per interval for pricing the session from the price history
+			if lp.db != nil {
+				pricelog.RecordCharge(lp.clock.Now(), lp.db.Name(), added, addedGreen)
+			}
 		}
 	} else {
 		lp.log.ERROR.Printf("charge rater: %v", err)
@@ -1597,6 +1740,8 @@ func (lp *Loadpoint) publishChargeProgress() {
 
 // publish state of charge, remaining charge duration and range
 func (lp *Loadpoint) publishSocAndRange() {
+	lp.trackVehicleSoc()
+
 	soc, err := lp.chargerSoc()
 
 	// guard for socEstimator removed by api
@@ -1665,8 +1810,14 @@ func (lp *Loadpoint) publishSocAndRange() {
 		lp.SetRemainingEnergy(1e3 * socEstimator.RemainingChargeEnergy(limitSoc))
 
 		// range
+		var (
+			rng   int64
+			rngOk bool
+		)
 		if vs, ok := lp.GetVehicle().(api.VehicleRange); ok {
-			if rng, err := vs.Range(); err == nil {
+			var err error
+			if rng, err = vs.Range(); err == nil {
+				rngOk = true
 				lp.log.DEBUG.Printf("vehicle range: %dkm", rng)
 				lp.publish(keys.VehicleRange, rng)
 			} else {
@@ -1674,6 +1825,24 @@ func (lp *Loadpoint) publishSocAndRange() {
 			}
 		}
 
+		// secondary energy store
+		if vs, ok := lp.GetVehicle().(api.VehicleAuxiliary); ok {
+			soc, auxRng, err := vs.Auxiliary()
+			switch {
+			case err == nil:
+				lp.log.DEBUG.Printf("vehicle auxiliary soc: %.0f%%, range: %dkm", soc, auxRng)
+				lp.publish(keys.VehicleAuxiliarySoc, soc)
+				lp.publish(keys.VehicleAuxiliaryRange, auxRng)
+
+				// combined range requires the traction range
+				if rngOk {
+					lp.publish(keys.VehicleCombinedRange, rng+auxRng)
+				}
+			case !errors.Is(err, api.ErrNotAvailable):
+				lp.log.ERROR.Printf("vehicle auxiliary: %v", err)
+			}
+		}
+
 		// trigger message after variables are updated
 		lp.bus.Publish(evVehicleSoc, f)
 	}
@@ -1730,8 +1899,10 @@ func (lp *Loadpoint) phaseSwitchCompleted() bool {
 
 // Update is the main control function. It reevaluates meters and charger state
 func (lp *Loadpoint) Update(sitePower, batteryBoostPower float64, rates api.Rates, batteryBuffered, batteryStart bool, greenShare float64, effPrice, effCo2 *float64) {
-	// smart cost
+	// smart cost, negative grid prices are always cheap enough
 	smartCostActive := lp.smartCostActive(rates)
+	lp.smartCost.update(time.Now(), smartCostActive)
+	smartCostActive = smartCostActive || lp.negativePriceCharge
 	lp.publish(keys.SmartCostActive, smartCostActive)
 
 	var smartCostNextStart time.Time
@@ -1748,6 +1919,9 @@ func (lp *Loadpoint) Update(sitePower, batteryBoostPower float64, rates api.Rate
 	lp.phasesFromChargeCurrents()
 
 	lp.energyMetrics.SetEnvironment(greenShare, effPrice, effCo2)
+	lp.publish(keys.GreenShare, greenShare)
+	lp.publish(keys.EffectivePrice, effPrice)
+	lp.publish(keys.EffectiveCo2, effCo2)
 
 	// update ChargeRater here to make sure initial meter update is caught
 	lp.bus.Publish(evChargeCurrent, lp.chargeCurrent)
@@ -1759,11 +1933,15 @@ func (lp *Loadpoint) Update(sitePower, batteryBoostPower float64, rates api.Rate
 
 	// read and publish status
 	welcomeCharge, err := lp.updateChargerStatus()
+	lp.chargerAvailability.Update(lp.ChargerRef, err)
 	if err != nil {
 		lp.log.ERROR.Println(err)
 		return
 	}
 
+	// start new session once low power device has finished
+	lp.restartLowPowerSession()
+
 	lp.publish(keys.VehicleWelcomeActive, welcomeCharge)
 	lp.publish(keys.Connected, lp.connected())
 	lp.publish(keys.Charging, lp.charging())
@@ -1800,12 +1978,35 @@ func (lp *Loadpoint) Update(sitePower, batteryBoostPower float64, rates api.Rate
 	// track if remote disabled is actually active
 	remoteDisabled := loadpoint.RemoteEnable
 
+	// enforce guest session cost cap
+	lp.updateGuestSession()
+
+	// remind or release vehicles blocking the loadpoint after charging
+	lp.updateIdle()
+
 	mode := lp.GetMode()
 	lp.publish(keys.Mode, mode)
 
+	// guarantee heat storage temperature or device runtime
+	lp.updateDeviceDemand()
+
 	// update and publish plan without being short-circuited by modes etc.
 	plannerActive := lp.plannerActive()
 
+	// publish estimated times to reach soc goals
+	lp.publishChargeEstimates(sitePower)
+
+	// fall back to pv charging once the site's daily grid budget is exhausted
+	if lp.gridBudgetExceeded && mode != api.ModeOff {
+		lp.planTracker.budget = lp.planTracker.budget || plannerActive
+		mode = api.ModePV
+		plannerActive = false
+		smartCostActive = false
+	}
+
+	// show status at charger
+	lp.updateDisplay(mode, plannerActive, rates)
+
 	// execute loading strategy
 	switch {
 	case !lp.connected():
@@ -1813,6 +2014,10 @@ func (lp *Loadpoint) Update(sitePower, batteryBoostPower float64, rates api.Rate
 		// https://github.com/evcc-io/evcc/issues/105
 		err = lp.setLimit(0)
 
+	// idle vehicle released, don't enable again until reconnected
+	case lp.idle.unlocked:
+		err = lp.setLimit(0)
+
 	case lp.scalePhasesRequired():
 		err = lp.scalePhases(lp.phasesConfigured)
 
@@ -1827,8 +2032,13 @@ func (lp *Loadpoint) Update(sitePower, batteryBoostPower float64, rates api.Rate
 		}
 		err = lp.setLimit(current)
 
+	// plug-in hybrid full, don't enable again until reconnected
+	case lp.phevFull():
+		lp.log.DEBUG.Println("phev: vehicle full")
+		err = lp.disableUnlessClimater()
+
 	// minimum or target charging
-	case lp.minSocNotReached() || plannerActive:
+	case lp.minSocNotReached() && !lp.gridBudgetExceeded || plannerActive:
 		err = lp.fastCharging()
 		lp.resetPhaseTimer()
 		lp.elapsePVTimer() // let PV mode disable immediately afterwards
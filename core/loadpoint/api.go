@@ -83,6 +83,10 @@ type API interface {
 	GetLimitEnergy() float64
 	// SetLimitEnergy sets the session limit energy
 	SetLimitEnergy(energy float64)
+	// StartGuestSession enables the loadpoint for a one-off guest session with energy (kWh) and cost caps
+	StartGuestSession(energy, cost float64)
+	// StopGuestSession cancels the guest session
+	StopGuestSession()
 
 	//
 	// effective values
@@ -117,6 +121,12 @@ type API interface {
 	SocBasedPlanning() bool
 	// GetPlan creates a charging plan
 	GetPlan(targetTime time.Time, requiredDuration time.Duration) api.Rates
+	// GetPlanComparison returns the plan cost under each comparison tariff
+	GetPlanComparison(targetTime time.Time, requiredDuration time.Duration, power float64) map[string]float64
+	// GetSolarReservation returns the share of the forecasted solar power reserved for the loadpoint at given time
+	GetSolarReservation(time.Time) float64
+	// GetPlanHistory returns the outcomes of past charge plans
+	GetPlanHistory() []PlanOutcome
 
 	// GetSocConfig returns the soc poll settings
 	GetSocConfig() SocConfig
@@ -152,6 +162,13 @@ type API interface {
 
 	// RemoteControl sets remote status demand
 	RemoteControl(string, RemoteDemand)
+	// RemoteControlSource sets remote status demand of the given source independently of other sources
+	RemoteControlSource(string, RemoteDemand)
+
+	// UnlockConnector unlocks the charger connector to release the cable
+	UnlockConnector() error
+	// LockConnector permanently locks or unlocks the charger connector
+	LockConnector(lock bool) error
 
 	//
 	// smart grid charging
@@ -161,6 +178,18 @@ type API interface {
 	GetSmartCostLimit() *float64
 	// SetSmartCostLimit sets the smart cost limit
 	SetSmartCostLimit(limit *float64)
+	// GetSmartCostConfig returns the smart cost hysteresis settings
+	GetSmartCostConfig() SmartCostConfig
+	// SetSmartCostConfig sets the smart cost hysteresis settings
+	SetSmartCostConfig(SmartCostConfig)
+	// GetSmartCostHysteresis gets the smart cost hysteresis
+	GetSmartCostHysteresis() float64
+	// SetSmartCostHysteresis sets the smart cost hysteresis
+	SetSmartCostHysteresis(hysteresis float64)
+	// GetSmartCostMinDuration gets the smart cost minimum duration
+	GetSmartCostMinDuration() time.Duration
+	// SetSmartCostMinDuration sets the smart cost minimum duration
+	SetSmartCostMinDuration(duration time.Duration)
 
 	//
 	// power and energy
@@ -194,6 +223,8 @@ type API interface {
 	GetVehicle() api.Vehicle
 	// SetVehicle sets the active vehicle
 	SetVehicle(vehicle api.Vehicle)
+	// GetVehicleSoc returns the active vehicle's soc, zero if unknown
+	GetVehicleSoc() float64
 	// StartVehicleDetection allows triggering vehicle detection for debugging purposes
 	StartVehicleDetection()
 }
@@ -433,6 +433,20 @@ func (mr *MockAPIMockRecorder) GetPlan(targetTime, requiredDuration any) *gomock
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlan", reflect.TypeOf((*MockAPI)(nil).GetPlan), targetTime, requiredDuration)
 }
 
+// GetPlanComparison mocks base method.
+func (m *MockAPI) GetPlanComparison(targetTime time.Time, requiredDuration time.Duration, power float64) map[string]float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlanComparison", targetTime, requiredDuration, power)
+	ret0, _ := ret[0].(map[string]float64)
+	return ret0
+}
+
+// GetPlanComparison indicates an expected call of GetPlanComparison.
+func (mr *MockAPIMockRecorder) GetPlanComparison(targetTime, requiredDuration, power any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlanComparison", reflect.TypeOf((*MockAPI)(nil).GetPlanComparison), targetTime, requiredDuration, power)
+}
+
 // GetPlanEnergy mocks base method.
 func (m *MockAPI) GetPlanEnergy() (time.Time, float64) {
 	m.ctrl.T.Helper()
@@ -463,6 +477,20 @@ func (mr *MockAPIMockRecorder) GetPlanGoal() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlanGoal", reflect.TypeOf((*MockAPI)(nil).GetPlanGoal))
 }
 
+// GetPlanHistory mocks base method.
+func (m *MockAPI) GetPlanHistory() []PlanOutcome {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlanHistory")
+	ret0, _ := ret[0].([]PlanOutcome)
+	return ret0
+}
+
+// GetPlanHistory indicates an expected call of GetPlanHistory.
+func (mr *MockAPIMockRecorder) GetPlanHistory() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlanHistory", reflect.TypeOf((*MockAPI)(nil).GetPlanHistory))
+}
+
 // GetPlanRequiredDuration mocks base method.
 func (m *MockAPI) GetPlanRequiredDuration(goal, maxPower float64) time.Duration {
 	m.ctrl.T.Helper()
@@ -519,6 +547,34 @@ func (mr *MockAPIMockRecorder) GetRemainingEnergy() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRemainingEnergy", reflect.TypeOf((*MockAPI)(nil).GetRemainingEnergy))
 }
 
+// GetSmartCostConfig mocks base method.
+func (m *MockAPI) GetSmartCostConfig() SmartCostConfig {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSmartCostConfig")
+	ret0, _ := ret[0].(SmartCostConfig)
+	return ret0
+}
+
+// GetSmartCostConfig indicates an expected call of GetSmartCostConfig.
+func (mr *MockAPIMockRecorder) GetSmartCostConfig() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmartCostConfig", reflect.TypeOf((*MockAPI)(nil).GetSmartCostConfig))
+}
+
+// GetSmartCostHysteresis mocks base method.
+func (m *MockAPI) GetSmartCostHysteresis() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSmartCostHysteresis")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetSmartCostHysteresis indicates an expected call of GetSmartCostHysteresis.
+func (mr *MockAPIMockRecorder) GetSmartCostHysteresis() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmartCostHysteresis", reflect.TypeOf((*MockAPI)(nil).GetSmartCostHysteresis))
+}
+
 // GetSmartCostLimit mocks base method.
 func (m *MockAPI) GetSmartCostLimit() *float64 {
 	m.ctrl.T.Helper()
@@ -533,6 +589,20 @@ func (mr *MockAPIMockRecorder) GetSmartCostLimit() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmartCostLimit", reflect.TypeOf((*MockAPI)(nil).GetSmartCostLimit))
 }
 
+// GetSmartCostMinDuration mocks base method.
+func (m *MockAPI) GetSmartCostMinDuration() time.Duration {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSmartCostMinDuration")
+	ret0, _ := ret[0].(time.Duration)
+	return ret0
+}
+
+// GetSmartCostMinDuration indicates an expected call of GetSmartCostMinDuration.
+func (mr *MockAPIMockRecorder) GetSmartCostMinDuration() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmartCostMinDuration", reflect.TypeOf((*MockAPI)(nil).GetSmartCostMinDuration))
+}
+
 // GetSocConfig mocks base method.
 func (m *MockAPI) GetSocConfig() SocConfig {
 	m.ctrl.T.Helper()
@@ -547,6 +617,20 @@ func (mr *MockAPIMockRecorder) GetSocConfig() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSocConfig", reflect.TypeOf((*MockAPI)(nil).GetSocConfig))
 }
 
+// GetSolarReservation mocks base method.
+func (m *MockAPI) GetSolarReservation(arg0 time.Time) float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSolarReservation", arg0)
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetSolarReservation indicates an expected call of GetSolarReservation.
+func (mr *MockAPIMockRecorder) GetSolarReservation(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSolarReservation", reflect.TypeOf((*MockAPI)(nil).GetSolarReservation), arg0)
+}
+
 // GetStatus mocks base method.
 func (m *MockAPI) GetStatus() api.ChargeStatus {
 	m.ctrl.T.Helper()
@@ -603,6 +687,20 @@ func (mr *MockAPIMockRecorder) GetVehicle() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicle", reflect.TypeOf((*MockAPI)(nil).GetVehicle))
 }
 
+// GetVehicleSoc mocks base method.
+func (m *MockAPI) GetVehicleSoc() float64 {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVehicleSoc")
+	ret0, _ := ret[0].(float64)
+	return ret0
+}
+
+// GetVehicleSoc indicates an expected call of GetVehicleSoc.
+func (mr *MockAPIMockRecorder) GetVehicleSoc() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVehicleSoc", reflect.TypeOf((*MockAPI)(nil).GetVehicleSoc))
+}
+
 // HasChargeMeter mocks base method.
 func (m *MockAPI) HasChargeMeter() bool {
 	m.ctrl.T.Helper()
@@ -631,6 +729,20 @@ func (mr *MockAPIMockRecorder) IsFastChargingActive() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsFastChargingActive", reflect.TypeOf((*MockAPI)(nil).IsFastChargingActive))
 }
 
+// LockConnector mocks base method.
+func (m *MockAPI) LockConnector(lock bool) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LockConnector", lock)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LockConnector indicates an expected call of LockConnector.
+func (mr *MockAPIMockRecorder) LockConnector(lock any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LockConnector", reflect.TypeOf((*MockAPI)(nil).LockConnector), lock)
+}
+
 // PublishEffectiveValues mocks base method.
 func (m *MockAPI) PublishEffectiveValues() {
 	m.ctrl.T.Helper()
@@ -655,6 +767,18 @@ func (mr *MockAPIMockRecorder) RemoteControl(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteControl", reflect.TypeOf((*MockAPI)(nil).RemoteControl), arg0, arg1)
 }
 
+// RemoteControlSource mocks base method.
+func (m *MockAPI) RemoteControlSource(arg0 string, arg1 RemoteDemand) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RemoteControlSource", arg0, arg1)
+}
+
+// RemoteControlSource indicates an expected call of RemoteControlSource.
+func (mr *MockAPIMockRecorder) RemoteControlSource(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoteControlSource", reflect.TypeOf((*MockAPI)(nil).RemoteControlSource), arg0, arg1)
+}
+
 // SetBatteryBoost mocks base method.
 func (m *MockAPI) SetBatteryBoost(enable bool) error {
 	m.ctrl.T.Helper()
@@ -833,6 +957,30 @@ func (mr *MockAPIMockRecorder) SetPriority(arg0 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPriority", reflect.TypeOf((*MockAPI)(nil).SetPriority), arg0)
 }
 
+// SetSmartCostConfig mocks base method.
+func (m *MockAPI) SetSmartCostConfig(arg0 SmartCostConfig) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSmartCostConfig", arg0)
+}
+
+// SetSmartCostConfig indicates an expected call of SetSmartCostConfig.
+func (mr *MockAPIMockRecorder) SetSmartCostConfig(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSmartCostConfig", reflect.TypeOf((*MockAPI)(nil).SetSmartCostConfig), arg0)
+}
+
+// SetSmartCostHysteresis mocks base method.
+func (m *MockAPI) SetSmartCostHysteresis(hysteresis float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSmartCostHysteresis", hysteresis)
+}
+
+// SetSmartCostHysteresis indicates an expected call of SetSmartCostHysteresis.
+func (mr *MockAPIMockRecorder) SetSmartCostHysteresis(hysteresis any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSmartCostHysteresis", reflect.TypeOf((*MockAPI)(nil).SetSmartCostHysteresis), hysteresis)
+}
+
 // SetSmartCostLimit mocks base method.
 func (m *MockAPI) SetSmartCostLimit(limit *float64) {
 	m.ctrl.T.Helper()
@@ -845,6 +993,18 @@ func (mr *MockAPIMockRecorder) SetSmartCostLimit(limit any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSmartCostLimit", reflect.TypeOf((*MockAPI)(nil).SetSmartCostLimit), limit)
 }
 
+// SetSmartCostMinDuration mocks base method.
+func (m *MockAPI) SetSmartCostMinDuration(duration time.Duration) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SetSmartCostMinDuration", duration)
+}
+
+// SetSmartCostMinDuration indicates an expected call of SetSmartCostMinDuration.
+func (mr *MockAPIMockRecorder) SetSmartCostMinDuration(duration any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSmartCostMinDuration", reflect.TypeOf((*MockAPI)(nil).SetSmartCostMinDuration), duration)
+}
+
 // SetSocConfig mocks base method.
 func (m *MockAPI) SetSocConfig(soc SocConfig) {
 	m.ctrl.T.Helper()
@@ -907,6 +1067,18 @@ func (mr *MockAPIMockRecorder) SocBasedPlanning() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SocBasedPlanning", reflect.TypeOf((*MockAPI)(nil).SocBasedPlanning))
 }
 
+// StartGuestSession mocks base method.
+func (m *MockAPI) StartGuestSession(energy, cost float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StartGuestSession", energy, cost)
+}
+
+// StartGuestSession indicates an expected call of StartGuestSession.
+func (mr *MockAPIMockRecorder) StartGuestSession(energy, cost any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartGuestSession", reflect.TypeOf((*MockAPI)(nil).StartGuestSession), energy, cost)
+}
+
 // StartVehicleDetection mocks base method.
 func (m *MockAPI) StartVehicleDetection() {
 	m.ctrl.T.Helper()
@@ -918,3 +1090,29 @@ func (mr *MockAPIMockRecorder) StartVehicleDetection() *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StartVehicleDetection", reflect.TypeOf((*MockAPI)(nil).StartVehicleDetection))
 }
+
+// StopGuestSession mocks base method.
+func (m *MockAPI) StopGuestSession() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "StopGuestSession")
+}
+
+// StopGuestSession indicates an expected call of StopGuestSession.
+func (mr *MockAPIMockRecorder) StopGuestSession() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopGuestSession", reflect.TypeOf((*MockAPI)(nil).StopGuestSession))
+}
+
+// UnlockConnector mocks base method.
+func (m *MockAPI) UnlockConnector() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockConnector")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UnlockConnector indicates an expected call of UnlockConnector.
+func (mr *MockAPIMockRecorder) UnlockConnector() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockConnector", reflect.TypeOf((*MockAPI)(nil).UnlockConnector))
+}
@@ -22,6 +22,84 @@ type SocConfig struct {
 	Estimate *bool      `json:"estimate"`
 }
 
+// CopConfig defines the heat pump coefficient of performance as linear function of outdoor temperature
+type CopConfig struct {
+	Nominal   float64 `json:"nominal"`   // cop at reference temperature
+	Reference float64 `json:"reference"` // reference outdoor temperature in °C
+	Slope     float64 `json:"slope"`     // cop change per °C
+}
+
+// Cop returns the expected cop at given outdoor temperature, never below 1
+func (c CopConfig) Cop(temp float64) float64 {
+	return max(1, c.Nominal+c.Slope*(temp-c.Reference))
+}
+
+// GuestConfig defines the guest charging payment webhook
+type GuestConfig struct {
+	Webhook string `json:"webhook"` // receives the session summary and may return a payment link
+}
+
+// IdleConfig defines reminders and unlocking for vehicles remaining plugged in after charging
+type IdleConfig struct {
+	Reminder time.Duration `json:"reminder"` // notify after vehicle remains idle for this duration
+	Unlock   time.Duration `json:"unlock"`   // end session and unlock connector after this grace period following the reminder
+}
+
+// PhevConfig handles plug-in hybrids with small batteries whose charge completion is often misreported
+type PhevConfig struct {
+	Energy   float64 `json:"energy"`   // assume vehicle full after charging this energy per session (kWh)
+	Finished bool    `json:"finished"` // assume vehicle full once it stops charging on its own
+}
+
+// LowPowerConfig defines devices like e-bikes or motorcycles charging below minimum current via switchable sockets
+type LowPowerConfig struct {
+	Power float64 `json:"power"` // device charge power (W), switched on once surplus covers this power
+}
+
+// SmartCostConfig avoids toggling smart cost charging while the price or co2 oscillates around the limit
+type SmartCostConfig struct {
+	Hysteresis  float64       `json:"hysteresis"`  // continue charging until the limit is exceeded by this value (price or gCO2/kWh)
+	MinDuration time.Duration `json:"minDuration"` // minimum duration before smart cost charging is switched on or off again
+}
+
+// ReservationConfig reserves a share of the forecasted solar power for a loadpoint
+type ReservationConfig struct {
+	Share    float64 `json:"share"`    // reserved share of the forecasted solar power (%)
+	Weekdays []int   `json:"weekdays"` // 0-6 (Sunday-Saturday), reservation applies on all days if empty
+}
+
+// PlanOutcome records whether a charge plan reached its goal in time
+type PlanOutcome struct {
+	Time      time.Time `json:"time"`            // plan target time
+	Goal      float64   `json:"goal"`            // soc (%) or energy (kWh) goal
+	SocBased  bool      `json:"socBased"`        // goal is soc based
+	Finished  time.Time `json:"finished"`        // time the plan was met or abandoned
+	Met       bool      `json:"met"`             // goal reached before target time
+	Shortfall float64   `json:"shortfall"`       // missing soc (%) or energy (kWh)
+	Cause     PlanCause `json:"cause,omitempty"` // likely cause of a missed goal
+}
+
+// PlanCause describes why a charge plan missed its goal
+type PlanCause string
+
+// Plan causes
+const (
+	PlanCauseDisconnected PlanCause = "disconnected" // vehicle disconnected before target time
+	PlanCauseSurplus      PlanCause = "surplus"      // grid budget exhausted, insufficient solar surplus
+	PlanCausePriceCap     PlanCause = "pricecap"     // charging stopped by cost cap during the plan
+	PlanCauseVehicle      PlanCause = "vehicle"      // vehicle did not charge during active plan slots
+	PlanCauseTime         PlanCause = "time"         // not enough time left to reach the goal at maximum power
+	PlanCauseUnknown      PlanCause = "unknown"
+)
+
+// PlanSlot records an executed slot of a charge plan
+type PlanSlot struct {
+	Start  time.Time `json:"start"`  // slot execution start
+	End    time.Time `json:"end"`    // slot execution end
+	Price  float64   `json:"price"`  // planned slot price
+	Energy float64   `json:"energy"` // energy charged during the slot (kWh)
+}
+
 // PollConfig defines the vehicle polling mode and interval
 type PollConfig struct {
 	Mode     PollMode      `json:"mode"`     // polling mode charging (default), connected, always
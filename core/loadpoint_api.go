@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/eventlog"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/wrapper"
@@ -94,6 +95,7 @@ func (lp *Loadpoint) SetMode(mode api.ChargeMode) {
 
 	// apply immediately
 	if lp.mode != mode {
+		eventlog.Record(eventlog.CategoryMode, lp.title, string(mode), "from "+string(lp.mode))
 		lp.setMode(mode)
 
 		lp.batteryBoost = boostDisabled
@@ -506,22 +508,56 @@ func (lp *Loadpoint) SetBatteryBoost(enable bool) error {
 	return nil
 }
 
-// RemoteControl sets remote status demand
+// RemoteControl sets remote status demand. Enabling releases the demand regardless of the source that set it.
 func (lp *Loadpoint) RemoteControl(source string, demand loadpoint.RemoteDemand) {
 	lp.Lock()
 	defer lp.Unlock()
 
-	lp.log.DEBUG.Println("remote demand:", demand)
+	lp.setRemoteDemand(source, demand, func() {
+		lp.remoteDemand, lp.remoteSource = demand, source
+	})
+}
 
-	// apply immediately
-	if lp.remoteDemand != demand {
-		lp.remoteDemand = demand
+// RemoteControlSource sets remote status demand of the given source. Enabling releases only this source's demand.
+func (lp *Loadpoint) RemoteControlSource(source string, demand loadpoint.RemoteDemand) {
+	lp.Lock()
+	defer lp.Unlock()
 
-		lp.publish(keys.RemoteDisabled, demand)
-		lp.publish(keys.RemoteDisabledSource, source)
+	lp.setRemoteDemand(source, demand, func() {
+		if demand == loadpoint.RemoteEnable {
+			delete(lp.remoteDemands, source)
+			return
+		}
 
-		lp.requestUpdate()
+		if lp.remoteDemands == nil {
+			lp.remoteDemands = make(map[string]loadpoint.RemoteDemand)
+		}
+		lp.remoteDemands[source] = demand
+	})
+}
+
+// UnlockConnector unlocks the charger connector to release the cable
+func (lp *Loadpoint) UnlockConnector() error {
+	c, ok := lp.charger.(api.ConnectorUnlocker)
+	if !ok {
+		return api.ErrNotAvailable
 	}
+
+	lp.log.DEBUG.Println("unlock connector")
+
+	return c.UnlockConnector()
+}
+
+// LockConnector permanently locks or unlocks the charger connector
+func (lp *Loadpoint) LockConnector(lock bool) error {
+	c, ok := lp.charger.(api.ConnectorLocker)
+	if !ok {
+		return api.ErrNotAvailable
+	}
+
+	lp.log.DEBUG.Println("lock connector:", lock)
+
+	return c.LockConnector(lock)
 }
 
 // HasChargeMeter determines if a physical charge meter is attached
@@ -706,6 +742,7 @@ func (lp *Loadpoint) GetVehicle() api.Vehicle {
 func (lp *Loadpoint) SetVehicle(vehicle api.Vehicle) {
 	// set desired vehicle (protected by lock, no locking here)
 	lp.setActiveVehicle(vehicle)
+	lp.authorizeVehicle(vehicle, true)
 
 	lp.vmu.Lock()
 	defer lp.vmu.Unlock()
@@ -714,6 +751,13 @@ func (lp *Loadpoint) SetVehicle(vehicle api.Vehicle) {
 	lp.stopVehicleDetection()
 }
 
+// GetVehicleSoc returns the active vehicle's soc, zero if unknown
+func (lp *Loadpoint) GetVehicleSoc() float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.vehicleSoc
+}
+
 // StartVehicleDetection allows triggering vehicle detection for debugging purposes
 func (lp *Loadpoint) StartVehicleDetection() {
 	// reset vehicle
@@ -753,6 +797,72 @@ func (lp *Loadpoint) SetSmartCostLimit(val *float64) {
 	}
 }
 
+// GetSmartCostConfig returns the smart cost hysteresis settings
+func (lp *Loadpoint) GetSmartCostConfig() loadpoint.SmartCostConfig {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.SmartCost
+}
+
+func (lp *Loadpoint) setSmartCostConfig(conf loadpoint.SmartCostConfig) {
+	lp.SmartCost = conf
+	lp.publish(keys.SmartCostHysteresis, conf.Hysteresis)
+	lp.publish(keys.SmartCostMinDuration, conf.MinDuration)
+	lp.settings.SetJson(keys.SmartCost, conf)
+}
+
+// SetSmartCostConfig sets the smart cost hysteresis settings
+func (lp *Loadpoint) SetSmartCostConfig(conf loadpoint.SmartCostConfig) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Printf("set smart cost: %+v", conf)
+
+	lp.setSmartCostConfig(conf)
+}
+
+// GetSmartCostHysteresis gets the smart cost hysteresis
+func (lp *Loadpoint) GetSmartCostHysteresis() float64 {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.SmartCost.Hysteresis
+}
+
+// SetSmartCostHysteresis sets the smart cost hysteresis
+func (lp *Loadpoint) SetSmartCostHysteresis(hysteresis float64) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Println("set smart cost hysteresis:", hysteresis)
+
+	if lp.SmartCost.Hysteresis != hysteresis {
+		conf := lp.SmartCost
+		conf.Hysteresis = hysteresis
+		lp.setSmartCostConfig(conf)
+	}
+}
+
+// GetSmartCostMinDuration gets the smart cost minimum duration
+func (lp *Loadpoint) GetSmartCostMinDuration() time.Duration {
+	lp.RLock()
+	defer lp.RUnlock()
+	return lp.SmartCost.MinDuration
+}
+
+// SetSmartCostMinDuration sets the smart cost minimum duration
+func (lp *Loadpoint) SetSmartCostMinDuration(duration time.Duration) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Println("set smart cost min duration:", duration)
+
+	if lp.SmartCost.MinDuration != duration {
+		conf := lp.SmartCost
+		conf.MinDuration = duration
+		lp.setSmartCostConfig(conf)
+	}
+}
+
 // GetCircuit returns the assigned circuit
 func (lp *Loadpoint) GetCircuit() api.Circuit {
 	lp.RLock()
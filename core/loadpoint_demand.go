@@ -0,0 +1,69 @@
+package core
+
+import (
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// deviceDemand returns the energy in kWh the device requires until the returned time
+func (lp *Loadpoint) deviceDemand() (time.Time, float64, bool, error) {
+	switch dev := lp.charger.(type) {
+	case api.HeatStorage:
+		ts, demand, err := dev.HeatDemand()
+		return ts, demand, true, err
+
+	case api.Runtimer:
+		runtime, required, ts, err := dev.Runtime()
+		if err != nil {
+			return ts, 0, true, err
+		}
+
+		lp.publish(keys.Runtime, runtime)
+
+		remaining := max(0, required-runtime)
+		return ts, remaining.Hours() * lp.EffectiveMaxPower() / 1e3, true, nil
+	}
+
+	return time.Time{}, 0, false, nil
+}
+
+// updateDeviceDemand maintains an energy plan guaranteeing the demand of heat storage
+// or runtime devices unless a plan has been set by the user
+func (lp *Loadpoint) updateDeviceDemand() {
+	ts, demand, ok, err := lp.deviceDemand()
+	if !ok {
+		return
+	}
+	if err != nil {
+		lp.log.ERROR.Printf("device demand: %v", err)
+		return
+	}
+
+	lp.Lock()
+	defer lp.Unlock()
+
+	planTime, planEnergy := lp.getPlanEnergy()
+	if planEnergy > 0 && !lp.demandPlan {
+		return
+	}
+
+	if demand <= 0 {
+		if lp.demandPlan {
+			lp.setPlanEnergy(ts, 0)
+			lp.demandPlan = false
+		}
+		return
+	}
+
+	energy := lp.getChargedEnergy()/1e3 + demand
+	if lp.demandPlan && planTime.Equal(ts) && math.Abs(planEnergy-energy) < 0.1 {
+		return
+	}
+
+	lp.log.DEBUG.Printf("device demand: %.1fkWh until %v", demand, ts.Round(0))
+	lp.setPlanEnergy(ts, energy)
+	lp.demandPlan = true
+}
@@ -0,0 +1,27 @@
+package core
+
+// demandCurrent limits the charge current to avoid creating a new demand peak, but not below min current
+func (lp *Loadpoint) demandCurrent(current float64) float64 {
+	if lp.demandLimit <= 0 {
+		return current
+	}
+
+	limit := powerToCurrent(lp.demandLimit, lp.ActivePhases())
+	return min(current, max(limit, lp.effectiveMinCurrent()))
+}
+
+// demandMaxPower limits the planner's charge power to avoid creating a new demand peak
+func (lp *Loadpoint) demandMaxPower(maxPower float64) float64 {
+	if lp.demandLimit <= 0 {
+		return maxPower
+	}
+
+	minCurrent := lp.effectiveMinCurrent()
+
+	lp.RLock()
+	phases := lp.maxActivePhases()
+	lp.RUnlock()
+
+	minPower := Voltage * minCurrent * float64(phases)
+	return min(maxPower, max(lp.demandLimit, minPower))
+}
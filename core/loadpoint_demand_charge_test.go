@@ -0,0 +1,63 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+// setVoltage sets the nominal voltage for the duration of the test
+func setVoltage(t *testing.T, v float64) {
+	t.Helper()
+
+	prev := Voltage
+	Voltage = v
+	t.Cleanup(func() { Voltage = prev })
+}
+
+func TestDemandLimit(t *testing.T) {
+	setVoltage(t, 230)
+	lp := &Loadpoint{
+		log:        util.NewLogger("foo"),
+		minCurrent: 6,
+		maxCurrent: 16,
+		phases:     3,
+	}
+
+	// unlimited
+	assert.Equal(t, 16.0, lp.demandCurrent(16))
+	assert.Equal(t, 11040.0, lp.demandMaxPower(11040))
+
+	// limited to headroom
+	lp.demandLimit = 6900
+	assert.InDelta(t, 10.0, lp.demandCurrent(16), 1e-6)
+	assert.Equal(t, 6900.0, lp.demandMaxPower(11040))
+
+	// not below min current
+	lp.demandLimit = 1
+	assert.Equal(t, 6.0, lp.demandCurrent(16))
+	assert.Equal(t, 4140.0, lp.demandMaxPower(11040))
+}
+
+func TestSetLimitDemand(t *testing.T) {
+	setVoltage(t, 230)
+
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.charger = charger
+	lp.minCurrent = 6
+	lp.maxCurrent = 16
+	lp.phases = 3
+	lp.enabled = true
+
+	// pv mode target current limited to headroom
+	lp.demandLimit = 6900
+	charger.EXPECT().MaxCurrent(int64(10))
+	require.NoError(t, lp.setLimit(16))
+}
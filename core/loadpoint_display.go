@@ -0,0 +1,57 @@
+package core
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// priceLevel classifies the current price within the known rates by thirds
+func priceLevel(rr api.Rates, price float64) string {
+	minR := slices.MinFunc(rr, func(a, b api.Rate) int { return cmp.Compare(a.Price, b.Price) })
+	maxR := slices.MaxFunc(rr, func(a, b api.Rate) int { return cmp.Compare(a.Price, b.Price) })
+
+	third := (maxR.Price - minR.Price) / 3
+
+	switch {
+	case third == 0:
+		return api.PriceLevelNormal
+	case price < minR.Price+third:
+		return api.PriceLevelLow
+	case price > maxR.Price-third:
+		return api.PriceLevelHigh
+	default:
+		return api.PriceLevelNormal
+	}
+}
+
+// updateDisplay pushes loadpoint status to chargers with status display on change
+func (lp *Loadpoint) updateDisplay(mode api.ChargeMode, planActive bool, rates api.Rates) {
+	sd, ok := lp.charger.(api.StatusDisplay)
+	if !ok {
+		return
+	}
+
+	status := api.DisplayStatus{
+		Mode:       mode,
+		PlanActive: planActive,
+	}
+
+	if len(rates) > 0 {
+		if r, err := rates.At(lp.clock.Now()); err == nil {
+			status.PriceLevel = priceLevel(rates, r.Price)
+		}
+	}
+
+	if lp.displayStatus != nil && *lp.displayStatus == status {
+		return
+	}
+
+	if err := sd.Display(status); err != nil {
+		lp.log.ERROR.Printf("display: %v", err)
+		return
+	}
+
+	lp.displayStatus = &status
+}
@@ -0,0 +1,27 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPriceLevel(t *testing.T) {
+	now := time.Now()
+
+	var rr api.Rates
+	for i, price := range []float64{0.1, 0.2, 0.3, 0.4} {
+		rr = append(rr, api.Rate{
+			Start: now.Add(time.Duration(i) * time.Hour),
+			End:   now.Add(time.Duration(i+1) * time.Hour),
+			Price: price,
+		})
+	}
+
+	assert.Equal(t, api.PriceLevelLow, priceLevel(rr, 0.1))
+	assert.Equal(t, api.PriceLevelNormal, priceLevel(rr, 0.25))
+	assert.Equal(t, api.PriceLevelHigh, priceLevel(rr, 0.4))
+	assert.Equal(t, api.PriceLevelNormal, priceLevel(rr[:1], 0.1))
+}
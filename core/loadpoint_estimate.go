@@ -0,0 +1,83 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/vehicle"
+)
+
+// chargeEstimate is the estimated time to reach a soc goal by charging scenario
+type chargeEstimate struct {
+	Now     time.Time `json:"now,omitempty"`     // charging at maximum power
+	PV      time.Time `json:"pv,omitempty"`      // charging at current pv surplus
+	Planned time.Time `json:"planned,omitempty"` // charging according to the active plan
+}
+
+// publishChargeEstimates publishes the estimated times to reach min, limit and plan soc
+func (lp *Loadpoint) publishChargeEstimates(sitePower float64) {
+	var minSoc, planSoc int
+	limitSoc := lp.EffectiveLimitSoc()
+
+	if v := lp.GetVehicle(); v != nil {
+		minSoc = vehicle.Settings(lp.log, v).GetMinSoc()
+		_, planSoc, _ = lp.nextVehiclePlan()
+	}
+
+	lp.publish(keys.ChargeEstimateMinSoc, lp.chargeEstimate(minSoc, sitePower))
+	lp.publish(keys.ChargeEstimateLimitSoc, lp.chargeEstimate(limitSoc, sitePower))
+	lp.publish(keys.ChargeEstimatePlanSoc, lp.chargeEstimate(planSoc, sitePower))
+}
+
+// chargeEstimate returns the estimated times to reach the soc goal or nil if not applicable
+func (lp *Loadpoint) chargeEstimate(goal int, sitePower float64) *chargeEstimate {
+	if goal <= 0 || !lp.connected() || lp.socEstimator == nil || !lp.socBasedPlanning() || lp.vehicleSoc >= float64(goal) {
+		return nil
+	}
+
+	now := lp.clock.Now()
+	maxPower := lp.EffectiveMaxPower()
+
+	var res chargeEstimate
+
+	if maxPower > 0 {
+		res.Now = now.Add(lp.socEstimator.RemainingChargeDuration(goal, maxPower))
+	}
+
+	// pv surplus including the current charge power
+	if pvPower := min(lp.chargePower-sitePower, maxPower); pvPower > 0 && pvPower >= lp.EffectiveMinPower() {
+		res.PV = now.Add(lp.socEstimator.RemainingChargeDuration(goal, pvPower))
+	}
+
+	if len(lp.planSlots) > 0 {
+		required := lp.socEstimator.RemainingChargeDuration(goal, lp.demandMaxPower(maxPower))
+		res.Planned = planCompletion(lp.planSlots, now, required)
+	}
+
+	return &res
+}
+
+// planCompletion returns the time at which the plan slots have provided the required charging duration
+// or zero time if the plan does not cover the required duration
+func planCompletion(plan api.Rates, now time.Time, required time.Duration) time.Time {
+	for _, slot := range plan {
+		if !slot.End.After(now) {
+			continue
+		}
+
+		start := slot.Start
+		if start.Before(now) {
+			start = now
+		}
+
+		if d := slot.End.Sub(start); d < required {
+			required -= d
+			continue
+		}
+
+		return start.Add(required)
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,34 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanCompletion(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	plan := api.Rates{
+		{Start: now.Add(-30 * time.Minute), End: now.Add(30 * time.Minute)},
+		{Start: now.Add(2 * time.Hour), End: now.Add(3 * time.Hour)},
+	}
+
+	for _, tc := range []struct {
+		required time.Duration
+		expected time.Time
+	}{
+		{15 * time.Minute, now.Add(15 * time.Minute)},
+		{30 * time.Minute, now.Add(30 * time.Minute)},
+		{time.Hour, now.Add(2*time.Hour + 30*time.Minute)},
+		{90 * time.Minute, now.Add(3 * time.Hour)},
+		{2 * time.Hour, time.Time{}},
+	} {
+		assert.Equal(t, tc.expected, planCompletion(plan, now, tc.required), tc.required)
+	}
+
+	// expired slots are ignored
+	assert.Equal(t, now.Add(2*time.Hour+15*time.Minute), planCompletion(plan, now.Add(time.Hour), 15*time.Minute))
+}
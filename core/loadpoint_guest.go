@@ -0,0 +1,205 @@
+package core
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+const evGuestSession = "guestsession" // guest session finished
+
+// guestSession holds the caps of a one-off guest charging session
+type guestSession struct {
+	Energy  float64   `json:"energy"` // kWh, 0 for unlimited
+	Cost    float64   `json:"cost"`   // currency, 0 for unlimited
+	Started time.Time `json:"started"`
+
+	// session energy (Wh) and cost charged before the guest session started
+	ChargedEnergy float64 `json:"chargedEnergy"`
+	ChargedPrice  float64 `json:"chargedPrice"`
+
+	// loadpoint settings restored when the session ends
+	Mode        api.ChargeMode `json:"mode"`
+	LimitEnergy float64        `json:"limitEnergy"`
+}
+
+// guestSummary is the result of a guest charging session
+type guestSummary struct {
+	Loadpoint string    `json:"loadpoint"`
+	Started   time.Time `json:"started"`
+	Finished  time.Time `json:"finished"`
+	Energy    float64   `json:"energy"`         // kWh
+	Price     *float64  `json:"price"`          // total cost
+	PaymentID string    `json:"paymentId"`      // reference for reimbursement
+	Link      string    `json:"link,omitempty"` // payment link returned by webhook
+}
+
+// StartGuestSession enables the loadpoint for a one-off guest session with energy (kWh) and cost caps
+func (lp *Loadpoint) StartGuestSession(energy, cost float64) {
+	lp.Lock()
+	defer lp.Unlock()
+
+	lp.log.DEBUG.Printf("start guest session: %.1fkWh, %.2f", energy, cost)
+
+	guest := &guestSession{
+		Energy:        energy,
+		Cost:          cost,
+		Started:       lp.clock.Now(),
+		ChargedEnergy: lp.energyMetrics.TotalWh(),
+		Mode:          lp.mode,
+		LimitEnergy:   lp.limitEnergy,
+	}
+
+	if price := lp.energyMetrics.Price(); price != nil {
+		guest.ChargedPrice = *price
+	}
+
+	// keep settings of a running guest session
+	if lp.guest != nil {
+		guest.Mode = lp.guest.Mode
+		guest.LimitEnergy = lp.guest.LimitEnergy
+	}
+
+	lp.setGuestSession(guest)
+	lp.publish(keys.GuestPaymentLink, "")
+
+	// session energy limit includes energy charged before the guest session
+	if energy > 0 {
+		energy += guest.ChargedEnergy / 1e3
+	}
+
+	lp.setLimitEnergy(energy)
+	lp.setMode(api.ModeNow)
+	lp.requestUpdate()
+}
+
+// StopGuestSession cancels the guest session
+func (lp *Loadpoint) StopGuestSession() {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if lp.guest == nil {
+		return
+	}
+
+	lp.log.DEBUG.Println("stop guest session")
+
+	guest := lp.guest
+	lp.setGuestSession(nil)
+
+	lp.setLimitEnergy(guest.LimitEnergy)
+	if guest.Mode != "" {
+		lp.setMode(guest.Mode)
+	}
+	lp.requestUpdate()
+}
+
+// price returns the cost of energy charged since the guest session started
+func (guest *guestSession) price(em *EnergyMetrics) *float64 {
+	price := em.Price()
+	if price == nil {
+		return nil
+	}
+
+	res := *price - guest.ChargedPrice
+	return &res
+}
+
+// setGuestSession publishes and persists the guest session
+func (lp *Loadpoint) setGuestSession(guest *guestSession) {
+	lp.guest = guest
+	lp.publish(keys.GuestSession, guest)
+
+	if err := lp.settings.SetJson(keys.GuestSession, guest); err != nil {
+		lp.log.ERROR.Printf("guest session: %v", err)
+	}
+}
+
+// updateGuestSession stops charging once the guest session cost cap is reached
+func (lp *Loadpoint) updateGuestSession() {
+	lp.Lock()
+	defer lp.Unlock()
+
+	if lp.guest == nil || lp.guest.Cost <= 0 || lp.mode == api.ModeOff {
+		return
+	}
+
+	if price := lp.guest.price(&lp.energyMetrics); price != nil && *price >= lp.guest.Cost {
+		lp.log.INFO.Printf("guest session: cost limit %.2f reached", lp.guest.Cost)
+		lp.planTracker.priceCap = true
+		lp.setMode(api.ModeOff)
+	}
+}
+
+// finishGuestSession summarizes the guest session and requests a payment link
+func (lp *Loadpoint) finishGuestSession() {
+	lp.Lock()
+	guest := lp.guest
+	if guest != nil {
+		lp.setGuestSession(nil)
+	}
+	lp.Unlock()
+
+	if guest == nil {
+		return
+	}
+
+	// restore previous mode, session energy limit is reset on disconnect
+	if guest.Mode != "" {
+		lp.SetMode(guest.Mode)
+	}
+
+	now := lp.clock.Now()
+	res := guestSummary{
+		Loadpoint: lp.GetTitle(),
+		Started:   guest.Started,
+		Finished:  now,
+		Energy:    (lp.energyMetrics.TotalWh() - guest.ChargedEnergy) / 1e3,
+		Price:     guest.price(&lp.energyMetrics),
+		PaymentID: guest.Started.Format("20060102150405"),
+	}
+
+	lp.log.INFO.Printf("guest session: %.1fkWh", res.Energy)
+
+	go func() {
+		if uri := lp.Guest.Webhook; uri != "" {
+			link, err := lp.guestPaymentLink(uri, res)
+			if err != nil {
+				lp.log.ERROR.Printf("guest payment: %v", err)
+			}
+			res.Link = link
+		}
+
+		lp.publish(keys.GuestSummary, res)
+		lp.publish(keys.GuestPaymentLink, res.Link)
+		lp.pushEvent(evGuestSession)
+	}()
+}
+
+// guestPaymentLink sends the summary to the payment webhook which may return a payment link
+func (lp *Loadpoint) guestPaymentLink(uri string, summary guestSummary) (string, error) {
+	req, err := request.New(http.MethodPost, uri, request.MarshalJSON(summary), request.JSONEncoding)
+	if err != nil {
+		return "", err
+	}
+
+	b, err := request.NewHelper(lp.log).DoBody(req)
+	if err != nil {
+		return "", err
+	}
+
+	var res struct {
+		Link string `json:"link"`
+	}
+
+	// webhooks without link response are valid
+	if json.Unmarshal(b, &res) != nil {
+		return "", nil
+	}
+
+	return res.Link, nil
+}
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/settings"
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGuestSessionRestoresMode(t *testing.T) {
+	pushChan := make(chan push.Event, 1)
+
+	lp := NewLoadpoint(util.NewLogger("foo"), settings.NewDatabaseSettingsAdapter("guest"))
+	lp.pushChan = pushChan
+	lp.setMode(api.ModePV)
+	lp.setLimitEnergy(5)
+
+	lp.StartGuestSession(10, 0)
+	assert.Equal(t, api.ModeNow, lp.GetMode())
+	assert.Equal(t, 10.0, lp.GetLimitEnergy())
+
+	// restarting keeps the original settings
+	lp.StartGuestSession(20, 0)
+
+	// persisted
+	var guest *guestSession
+	require.NoError(t, lp.settings.Json(keys.GuestSession, &guest))
+	require.NotNil(t, guest)
+	assert.Equal(t, 20.0, guest.Energy)
+	assert.Equal(t, api.ModePV, guest.Mode)
+
+	lp.finishGuestSession()
+	assert.Equal(t, api.ModePV, lp.GetMode())
+	assert.Equal(t, evGuestSession, (<-pushChan).Event)
+
+	guest = nil
+	require.NoError(t, lp.settings.Json(keys.GuestSession, &guest))
+	assert.Nil(t, guest)
+}
+
+func TestGuestSessionStop(t *testing.T) {
+	lp := NewLoadpoint(util.NewLogger("foo"), settings.NewDatabaseSettingsAdapter("guest-stop"))
+	lp.setMode(api.ModeMinPV)
+	lp.setLimitEnergy(5)
+
+	lp.StartGuestSession(10, 0)
+	lp.StopGuestSession()
+
+	assert.Equal(t, api.ModeMinPV, lp.GetMode())
+	assert.Equal(t, 5.0, lp.GetLimitEnergy())
+	assert.Nil(t, lp.guest)
+}
+
+func TestGuestSessionExcludesPreviousEnergy(t *testing.T) {
+	pushChan := make(chan push.Event, 1)
+	uiChan := make(chan util.Param, 32)
+
+	lp := NewLoadpoint(util.NewLogger("foo"), settings.NewDatabaseSettingsAdapter("guest-energy"))
+	lp.pushChan = pushChan
+
+	price := 0.3
+	lp.energyMetrics.SetEnvironment(0, &price, nil)
+	lp.energyMetrics.Update(2)
+
+	lp.StartGuestSession(10, 5)
+	assert.Equal(t, 12.0, lp.GetLimitEnergy())
+
+	lp.energyMetrics.Update(6)
+
+	lp.uiChan = uiChan
+	lp.finishGuestSession()
+	<-pushChan
+	close(uiChan)
+
+	var summary *guestSummary
+	for p := range uiChan {
+		if res, ok := p.Val.(guestSummary); ok && p.Key == keys.GuestSummary {
+			summary = &res
+		}
+	}
+
+	require.NotNil(t, summary)
+	assert.Equal(t, 4.0, summary.Energy)
+	require.NotNil(t, summary.Price)
+	assert.InDelta(t, 1.2, *summary.Price, 1e-6)
+}
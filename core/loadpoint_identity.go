@@ -0,0 +1,39 @@
+package core
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+// loadpointName returns the configured device name of the loadpoint
+func loadpointName(lp *Loadpoint) string {
+	for _, dev := range config.Loadpoints().Devices() {
+		if dev.Instance() == loadpoint.API(lp) {
+			return dev.Config().Name
+		}
+	}
+	return ""
+}
+
+// identifiedBy checks if any of the references matches the loadpoint's configured name or id.
+// An empty list of references matches all loadpoints.
+func (lp *Loadpoint) identifiedBy(refs []string) bool {
+	return len(refs) == 0 || slices.ContainsFunc(refs, func(ref string) bool {
+		return lp.name != "" && ref == lp.name || lp.id > 0 && ref == strconv.Itoa(lp.id)
+	})
+}
+
+// identifiers returns the loadpoint's configured name and id
+func (lp *Loadpoint) identifiers() []string {
+	var res []string
+	if lp.name != "" {
+		res = append(res, lp.name)
+	}
+	if lp.id > 0 {
+		res = append(res, strconv.Itoa(lp.id))
+	}
+	return res
+}
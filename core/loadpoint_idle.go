@@ -0,0 +1,90 @@
+package core
+
+import (
+	"errors"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+const evVehicleIdle = "idle" // vehicle remains plugged in after charging
+
+// idleState tracks a vehicle remaining connected after charging has completed
+type idleState struct {
+	since    time.Time
+	reminded bool
+	unlocked bool
+}
+
+// update returns if the reminder is due or the vehicle should be released
+func (is *idleState) update(ts time.Time, idle bool, conf loadpoint.IdleConfig) (remind, unlock bool) {
+	if !idle {
+		*is = idleState{}
+		return false, false
+	}
+
+	if is.since.IsZero() {
+		is.since = ts
+	}
+
+	elapsed := ts.Sub(is.since)
+
+	if !is.reminded && elapsed >= conf.Reminder {
+		is.reminded = true
+		remind = true
+	}
+
+	if !is.unlocked && conf.Unlock > 0 && elapsed >= conf.Reminder+conf.Unlock {
+		is.unlocked = true
+		unlock = true
+	}
+
+	return remind, unlock
+}
+
+// vehicleIdle determines if the vehicle remains connected although charging has completed
+func (lp *Loadpoint) vehicleIdle() bool {
+	if lp.GetStatus() != api.StatusB || lp.GetChargedEnergy() == 0 {
+		return false
+	}
+
+	// released vehicles remain idle until disconnected,
+	// enabled but not charging means the vehicle has stopped charging
+	return lp.idle.unlocked || lp.enabled || lp.LimitSocReached() || lp.LimitEnergyReached()
+}
+
+// updateIdle notifies about vehicles blocking the loadpoint after charging and optionally releases them
+func (lp *Loadpoint) updateIdle() {
+	if lp.Idle.Reminder <= 0 {
+		return
+	}
+
+	idle := lp.vehicleIdle()
+	remind, unlock := lp.idle.update(lp.clock.Now(), idle, lp.Idle)
+
+	var since *time.Time
+	if idle {
+		since = &lp.idle.since
+	}
+	lp.publish(keys.VehicleIdleSince, since)
+
+	if remind {
+		lp.log.INFO.Printf("vehicle idle for %v", lp.Idle.Reminder)
+		lp.pushEvent(evVehicleIdle)
+	}
+
+	if unlock {
+		lp.log.INFO.Println("vehicle idle: disabling charger and unlocking connector")
+
+		// session is closed on disconnect
+		if err := lp.setLimit(0); err != nil {
+			lp.log.ERROR.Println("charger:", err)
+		}
+
+		if err := lp.UnlockConnector(); err != nil && !errors.Is(err, api.ErrNotAvailable) {
+			lp.log.ERROR.Println("unlock connector:", err)
+		}
+	}
+}
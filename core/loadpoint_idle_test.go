@@ -0,0 +1,88 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func TestIdleState(t *testing.T) {
+	conf := loadpoint.IdleConfig{Reminder: time.Hour, Unlock: 30 * time.Minute}
+	ts := time.Now()
+
+	var is idleState
+
+	remind, unlock := is.update(ts, true, conf)
+	assert.False(t, remind)
+	assert.False(t, unlock)
+
+	remind, unlock = is.update(ts.Add(time.Hour), true, conf)
+	assert.True(t, remind)
+	assert.False(t, unlock)
+
+	// remind only once
+	remind, unlock = is.update(ts.Add(80*time.Minute), true, conf)
+	assert.False(t, remind)
+	assert.False(t, unlock)
+
+	remind, unlock = is.update(ts.Add(90*time.Minute), true, conf)
+	assert.False(t, remind)
+	assert.True(t, unlock)
+
+	// reset when vehicle is no longer idle
+	is.update(ts.Add(2*time.Hour), false, conf)
+	assert.True(t, is.since.IsZero())
+
+	// no unlock unless configured
+	remind, unlock = is.update(ts.Add(5*time.Hour), true, loadpoint.IdleConfig{Reminder: time.Hour})
+	assert.False(t, remind)
+	assert.False(t, unlock)
+
+	remind, unlock = is.update(ts.Add(8*time.Hour), true, loadpoint.IdleConfig{Reminder: time.Hour})
+	assert.True(t, remind)
+	assert.False(t, unlock)
+}
+
+func TestIdleUnlockKeepsSession(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	charger := api.NewMockCharger(ctrl)
+	clock := clock.NewMock()
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.clock = clock
+	lp.charger = charger
+	lp.wakeUpTimer = NewTimer()
+	lp.pushChan = make(chan push.Event, 1)
+	lp.status = api.StatusB
+	lp.enabled = true
+	lp.energyMetrics.Update(5)
+	lp.Idle = loadpoint.IdleConfig{Reminder: time.Hour, Unlock: time.Minute}
+
+	lp.updateIdle()
+
+	// charger is disabled before unlocking
+	charger.EXPECT().Enable(false)
+	clock.Add(time.Hour + time.Minute)
+	lp.updateIdle()
+
+	assert.False(t, lp.enabled)
+	assert.True(t, lp.idle.unlocked)
+	assert.Equal(t, 5000.0, lp.GetChargedEnergy(), "session kept until disconnect")
+
+	// remains released while connected
+	clock.Add(time.Minute)
+	lp.updateIdle()
+	assert.True(t, lp.idle.unlocked)
+
+	// reset on disconnect
+	lp.status = api.StatusA
+	lp.updateIdle()
+	assert.False(t, lp.idle.unlocked)
+}
@@ -0,0 +1,49 @@
+package core
+
+import "github.com/evcc-io/evcc/core/wrapper"
+
+// lowPowerCurrent returns min current if the surplus covers the low power device's charge power, zero otherwise.
+// Devices are switched on and off only, the current itself is not controlled.
+func (lp *Loadpoint) lowPowerCurrent(sitePower, minCurrent float64) float64 {
+	// site power includes device consumption while charging
+	surplus := -sitePower
+	if lp.charging() {
+		surplus += lp.chargePower
+	}
+
+	if surplus >= lp.LowPower.Power {
+		return minCurrent
+	}
+
+	return 0
+}
+
+// restartLowPowerSession completes the session once a low power device has finished charging.
+// Switchable sockets cannot detect unplugging, hence each completed charge starts a new session.
+func (lp *Loadpoint) restartLowPowerSession() {
+	if !lp.lowPowerFinished {
+		return
+	}
+
+	lp.lowPowerFinished = false
+
+	if !lp.connected() {
+		return
+	}
+
+	lp.log.INFO.Println("device finished charging")
+
+	// session has been persisted when charging stopped
+	lp.clearSession()
+
+	// restart meter based energy and duration tracking
+	if rt, ok := lp.chargeRater.(*wrapper.ChargeRater); ok {
+		rt.StartCharge(false)
+	}
+	if ct, ok := lp.chargeTimer.(*wrapper.ChargeTimer); ok {
+		ct.StartCharge(false)
+	}
+
+	lp.resetSessionMetrics()
+	lp.createSession()
+}
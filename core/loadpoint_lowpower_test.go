@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLowPowerCurrent(t *testing.T) {
+	lp := &Loadpoint{
+		status:   api.StatusB,
+		LowPower: loadpoint.LowPowerConfig{Power: 300},
+	}
+
+	// not charging
+	assert.Equal(t, 0.0, lp.lowPowerCurrent(-200, 6))
+	assert.Equal(t, 6.0, lp.lowPowerCurrent(-300, 6))
+
+	// charging, site power includes device consumption
+	lp.status = api.StatusC
+	lp.chargePower = 250
+	assert.Equal(t, 6.0, lp.lowPowerCurrent(-50, 6))
+	assert.Equal(t, 0.0, lp.lowPowerCurrent(100, 6))
+}
+
+func TestRestartLowPowerSession(t *testing.T) {
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.status = api.StatusB
+	lp.lowPowerFinished = true
+	lp.energyMetrics.Update(1)
+
+	var events int
+	require.NoError(t, lp.bus.Subscribe(evVehicleConnect, func() { events++ }))
+	require.NoError(t, lp.bus.Subscribe(evVehicleDisconnect, func() { events++ }))
+
+	lp.restartLowPowerSession()
+	lp.bus.WaitAsync()
+
+	assert.False(t, lp.lowPowerFinished)
+	assert.Equal(t, 0.0, lp.GetChargedEnergy(), "new session")
+	assert.Zero(t, events, "no reconnect reported")
+}
@@ -0,0 +1,53 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/eventlog"
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+const (
+	pairingSource = "pairing"
+
+	evPairingRejected = "pairingRejected" // identified vehicle not allowed at loadpoint
+	evPairingOverride = "pairingOverride" // vehicle manually selected despite restriction
+)
+
+// vehicleAllowed checks if the vehicle may charge at the loadpoint
+func (lp *Loadpoint) vehicleAllowed(v api.Vehicle) bool {
+	vr, ok := v.(api.LoadpointRestricter)
+	if !ok {
+		return true
+	}
+
+	return lp.identifiedBy(vr.Loadpoints())
+}
+
+// authorizeVehicle disables charging while an identified vehicle is not allowed at the loadpoint.
+// Manually selecting the vehicle overrides the restriction and is recorded in the event log.
+func (lp *Loadpoint) authorizeVehicle(v api.Vehicle, manual bool) {
+	rejected := v != nil && !lp.vehicleAllowed(v)
+
+	if rejected && manual {
+		lp.log.WARN.Printf("vehicle %s: not allowed at loadpoint, manual override", v.Title())
+		eventlog.Record(eventlog.CategorySession, lp.GetTitle(), evPairingOverride, v.Title())
+		rejected = false
+	}
+
+	if rejected == lp.pairingRejected {
+		return
+	}
+	lp.pairingRejected = rejected
+
+	demand := loadpoint.RemoteEnable
+	if rejected {
+		msg := fmt.Sprintf("vehicle %s not allowed at loadpoint", v.Title())
+		lp.log.WARN.Println(msg)
+		eventlog.Record(eventlog.CategorySession, lp.GetTitle(), evPairingRejected, v.Title())
+
+		demand = loadpoint.RemoteHardDisable
+	}
+	lp.RemoteControlSource(pairingSource, demand)
+}
@@ -0,0 +1,92 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type restrictedVehicle struct {
+	api.Vehicle
+	loadpoints []string
+}
+
+func (v *restrictedVehicle) Loadpoints() []string {
+	return v.loadpoints
+}
+
+func TestVehiclePairing(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	mv := api.NewMockVehicle(ctrl)
+	mv.EXPECT().Title().Return("company car").AnyTimes()
+	v := &restrictedVehicle{Vehicle: mv, loadpoints: []string{"lp-2", "3"}}
+
+	lp := &Loadpoint{log: util.NewLogger("foo"), id: 1, name: "lp-1", title: "Garage"}
+	assert.False(t, lp.vehicleAllowed(v))
+	assert.True(t, lp.vehicleAllowed(mv), "unrestricted")
+
+	// identified vehicle is rejected
+	lp.authorizeVehicle(v, false)
+	assert.True(t, lp.pairingRejected)
+	demand, source := lp.effectiveRemoteDemand()
+	assert.Equal(t, loadpoint.RemoteHardDisable, demand)
+	assert.Equal(t, pairingSource, source)
+
+	// manual override
+	lp.authorizeVehicle(v, true)
+	assert.False(t, lp.pairingRejected)
+	demand, _ = lp.effectiveRemoteDemand()
+	assert.Equal(t, loadpoint.RemoteEnable, demand)
+
+	// allowed loadpoint by name
+	lp.name = "lp-2"
+	lp.authorizeVehicle(v, false)
+	assert.False(t, lp.pairingRejected)
+
+	// allowed loadpoint by id, title is ignored
+	lp.id, lp.name, lp.title = 3, "", "lp-2"
+	assert.True(t, lp.vehicleAllowed(v))
+	lp.id = 4
+	assert.False(t, lp.vehicleAllowed(v))
+}
+
+func TestRemoteControlSources(t *testing.T) {
+	lp := &Loadpoint{log: util.NewLogger("foo")}
+
+	lp.RemoteControlSource("flex", loadpoint.RemoteSoftDisable)
+	lp.RemoteControlSource(pairingSource, loadpoint.RemoteHardDisable)
+
+	demand, source := lp.effectiveRemoteDemand()
+	assert.Equal(t, loadpoint.RemoteHardDisable, demand, "most restrictive")
+	assert.Equal(t, pairingSource, source)
+
+	// releasing one source keeps the other's demand
+	lp.RemoteControlSource(pairingSource, loadpoint.RemoteEnable)
+	demand, source = lp.effectiveRemoteDemand()
+	assert.Equal(t, loadpoint.RemoteSoftDisable, demand)
+	assert.Equal(t, "flex", source)
+
+	// releasing an unset source is a no-op
+	lp.RemoteControlSource(rfidSource, loadpoint.RemoteEnable)
+	assert.True(t, lp.remoteControlled(loadpoint.RemoteSoftDisable))
+
+	lp.RemoteControlSource("flex", loadpoint.RemoteEnable)
+	assert.True(t, lp.remoteControlled(loadpoint.RemoteEnable))
+
+	// legacy demand is released by any source
+	lp.RemoteControl("semp", loadpoint.RemoteSoftDisable)
+	lp.RemoteControlSource(rfidSource, loadpoint.RemoteHardDisable)
+	lp.RemoteControl("api", loadpoint.RemoteEnable)
+
+	demand, source = lp.effectiveRemoteDemand()
+	assert.Equal(t, loadpoint.RemoteHardDisable, demand, "independent source retained")
+	assert.Equal(t, rfidSource, source)
+
+	lp.RemoteControlSource(rfidSource, loadpoint.RemoteEnable)
+	assert.True(t, lp.remoteControlled(loadpoint.RemoteEnable))
+}
@@ -0,0 +1,22 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+// setVehicleFull sets and publishes the plug-in hybrid completion state
+func (lp *Loadpoint) setVehicleFull(full bool) {
+	lp.vehicleFull = full
+	lp.publish(keys.VehicleFull, full)
+}
+
+// phevFull determines if the vehicle is assumed full. Plug-in hybrids are considered full
+// once they stopped charging on their own or have received the configured session energy.
+func (lp *Loadpoint) phevFull() bool {
+	if !lp.vehicleFull && lp.Phev.Energy > 0 && lp.GetChargedEnergy()/1e3 >= lp.Phev.Energy {
+		lp.log.DEBUG.Printf("phev: %.1fkWh charged", lp.GetChargedEnergy()/1e3)
+		lp.setVehicleFull(true)
+	}
+
+	return lp.vehicleFull
+}
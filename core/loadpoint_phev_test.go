@@ -0,0 +1,36 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhevFull(t *testing.T) {
+	lp := &Loadpoint{
+		log:  util.NewLogger("foo"),
+		Phev: loadpoint.PhevConfig{Energy: 10},
+	}
+
+	assert.False(t, lp.phevFull())
+
+	lp.energyMetrics.Update(9.9)
+	assert.False(t, lp.phevFull())
+
+	lp.energyMetrics.Update(10)
+	assert.True(t, lp.phevFull())
+
+	// remains full until reset
+	lp.energyMetrics.Reset()
+	assert.True(t, lp.phevFull())
+
+	lp.setVehicleFull(false)
+	assert.False(t, lp.phevFull())
+
+	// no energy heuristic unless configured
+	lp.Phev = loadpoint.PhevConfig{}
+	lp.energyMetrics.Update(50)
+	assert.False(t, lp.phevFull())
+}
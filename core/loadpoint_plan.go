@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/eventlog"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/planner"
 	"github.com/evcc-io/evcc/core/vehicle"
@@ -25,6 +26,12 @@ func (lp *Loadpoint) setPlanActive(active bool) {
 	if lp.planActive != active {
 		lp.planActive = active
 		lp.publish(keys.PlanActive, lp.planActive)
+
+		event := "inactive"
+		if active {
+			event = "active"
+		}
+		eventlog.Record(eventlog.CategoryPlan, lp.title, event, "")
 	}
 }
 
@@ -89,14 +96,19 @@ func (lp *Loadpoint) GetPlan(targetTime time.Time, requiredDuration time.Duratio
 
 // plannerActive checks if the charging plan has a currently active slot
 func (lp *Loadpoint) plannerActive() (active bool) {
+	var activeSlot api.Rate
+
 	defer func() {
 		lp.setPlanActive(active)
+		lp.trackPlanSlot(active, activeSlot)
 	}()
 
 	var planStart, planEnd time.Time
 	var planOverrun time.Duration
+	var plan api.Rates
 
 	defer func() {
+		lp.planSlots = plan
 		lp.publish(keys.PlanProjectedStart, planStart)
 		lp.publish(keys.PlanProjectedEnd, planEnd)
 		lp.publish(keys.PlanOverrun, planOverrun)
@@ -115,24 +127,28 @@ func (lp *Loadpoint) plannerActive() (active bool) {
 	// keep overrunning plans as long as a vehicle is connected
 	if lp.clock.Until(planTime) < 0 && (!lp.planActive || !lp.connected()) {
 		lp.log.DEBUG.Println("plan: deleting expired plan")
+		lp.recordPlanOutcome(false, "")
 		lp.finishPlan()
 		return false
 	}
 
 	goal, isSocBased := lp.GetPlanGoal()
-	maxPower := lp.EffectiveMaxPower()
+	maxPower := lp.demandMaxPower(lp.EffectiveMaxPower())
 	requiredDuration := lp.GetPlanRequiredDuration(goal, maxPower)
+	lp.trackPlan(planTime, goal, isSocBased, requiredDuration > lp.clock.Until(planTime))
+
 	if requiredDuration <= 0 {
 		// continue a 100% plan as long as the vehicle is charging
 		if lp.planActive && isSocBased && goal == 100 && lp.charging() {
 			return true
 		}
 
+		lp.recordPlanOutcome(true, "")
 		lp.finishPlan()
 		return false
 	}
 
-	plan := lp.GetPlan(planTime, requiredDuration)
+	plan = lp.GetPlan(planTime, requiredDuration)
 	if plan == nil {
 		return false
 	}
@@ -154,7 +170,7 @@ func (lp *Loadpoint) plannerActive() (active bool) {
 		lp.log.TRACE.Printf("  slot from: %v to %v cost %.3f", slot.Start.Round(time.Second).Local(), slot.End.Round(time.Second).Local(), slot.Price)
 	}
 
-	activeSlot := planner.SlotAt(lp.clock.Now(), plan)
+	activeSlot = planner.SlotAt(lp.clock.Now(), plan)
 	active = !activeSlot.End.IsZero()
 
 	if active {
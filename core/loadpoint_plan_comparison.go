@@ -0,0 +1,63 @@
+package core
+
+import (
+	"maps"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/planner"
+)
+
+// comparisonTariffs returns the alternative grid tariffs including the loadpoint's current grid tariff as grid
+func (site *Site) comparisonTariffs(lp *Loadpoint) map[string]api.Tariff {
+	if site.tariffs == nil || len(site.tariffs.Comparison) == 0 {
+		return nil
+	}
+
+	res := maps.Clone(site.tariffs.Comparison)
+
+	grid := site.GetTariff(api.TariffUsageGrid)
+	if lp.tariff != nil {
+		grid = lp.tariff
+	}
+
+	if _, ok := res[api.TariffUsageGrid.String()]; !ok && grid != nil {
+		res[api.TariffUsageGrid.String()] = grid
+	}
+
+	return res
+}
+
+// GetPlanComparison returns the cost of charging the required duration until target time with given power (W)
+// under each comparison tariff. Each tariff is planned individually, tariffs not covering the plan are omitted.
+func (lp *Loadpoint) GetPlanComparison(targetTime time.Time, requiredDuration time.Duration, power float64) map[string]float64 {
+	if len(lp.comparison) == 0 || targetTime.IsZero() || requiredDuration <= 0 {
+		return nil
+	}
+
+	res := make(map[string]float64, len(lp.comparison))
+
+	for name, t := range lp.comparison {
+		rates, err := t.Rates()
+		if err != nil {
+			lp.log.DEBUG.Printf("plan comparison %s: %v", name, err)
+			continue
+		}
+
+		// rates ending before the plan leave it unplanned
+		plan := planner.New(lp.log, t).Plan(requiredDuration, targetTime)
+		if planner.Duration(plan) < requiredDuration {
+			continue
+		}
+
+		cost, err := planner.Cost(plan, rates, power)
+		if err != nil {
+			lp.log.DEBUG.Printf("plan comparison %s: %v", name, err)
+			continue
+		}
+
+		res[name] = cost
+	}
+
+	return res
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/evcc-io/evcc/util"
+	"github.com/jinzhu/now"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanComparison(t *testing.T) {
+	start := now.BeginningOfHour().Add(time.Hour)
+
+	// hourly rates for the next 4 hours
+	rates := func(prices ...float64) planeTariff {
+		var res planeTariff
+		for i, p := range prices {
+			ts := start.Add(time.Duration(i) * time.Hour)
+			res = append(res, api.Rate{Start: ts, End: ts.Add(time.Hour), Price: p})
+		}
+		return res
+	}
+
+	site := &Site{
+		tariffs: &tariff.Tariffs{
+			Grid: rates(0.3, 0.3, 0.3, 0.3),
+			Comparison: map[string]api.Tariff{
+				"dynamic": rates(0.4, 0.1, 0.2, 0.5),
+				"short":   rates(0.1),
+			},
+		},
+	}
+
+	lp := &Loadpoint{log: util.NewLogger("foo")}
+	lp.comparison = site.comparisonTariffs(lp)
+	require.Len(t, lp.comparison, 3)
+
+	// 2 hours at 10kW
+	res := lp.GetPlanComparison(start.Add(4*time.Hour), 2*time.Hour, 10e3)
+	assert.InDelta(t, 6.0, res["grid"], 1e-6)
+	assert.InDelta(t, 3.0, res["dynamic"], 1e-6)
+	assert.NotContains(t, res, "short", "rates do not cover plan")
+
+	// without comparison tariffs
+	site.tariffs.Comparison = nil
+	assert.Nil(t, site.comparisonTariffs(lp))
+}
@@ -0,0 +1,134 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/core/eventlog"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+const (
+	planHistorySize    = 50              // number of plan outcomes kept
+	planVehicleTimeout = 5 * time.Minute // charger enabled without vehicle charging during active plan slot
+)
+
+// planTracker observes the progress of the current charge plan
+type planTracker struct {
+	time      time.Time
+	goal      float64
+	socBased  bool
+	recorded  bool    // outcome already recorded
+	late      bool    // target time passed before goal was reached
+	shortfall float64 // shortfall at target time
+	overrun   bool    // goal not reachable in time at maximum power
+	idle      bool    // vehicle not charging during active plan slot
+	budget    bool    // grid budget exhausted during active plan slot
+	priceCap  bool    // charging stopped by cost cap
+}
+
+// cause determines the likely cause of a missed goal
+func (t planTracker) cause() loadpoint.PlanCause {
+	switch {
+	case t.priceCap:
+		return loadpoint.PlanCausePriceCap
+	case t.budget:
+		return loadpoint.PlanCauseSurplus
+	case t.idle:
+		return loadpoint.PlanCauseVehicle
+	case t.overrun:
+		return loadpoint.PlanCauseTime
+	default:
+		return loadpoint.PlanCauseUnknown
+	}
+}
+
+// trackPlan starts tracking a plan or updates the observations of the current plan
+func (lp *Loadpoint) trackPlan(planTime time.Time, goal float64, socBased bool, overrun bool) {
+	t := &lp.planTracker
+	if !t.time.Equal(planTime) || t.goal != goal || t.socBased != socBased {
+		*t = planTracker{time: planTime, goal: goal, socBased: socBased}
+	}
+
+	if t.recorded {
+		return
+	}
+
+	t.overrun = t.overrun || overrun
+
+	if lp.planActive && lp.enabled && !lp.charging() && lp.clock.Since(lp.chargerSwitched) > planVehicleTimeout {
+		t.idle = true
+	}
+
+	if !t.late && lp.clock.Now().After(planTime) {
+		t.late = true
+		t.shortfall = lp.planShortfall()
+	}
+}
+
+// planShortfall returns the missing soc (%) or energy (kWh) of the tracked plan
+func (lp *Loadpoint) planShortfall() float64 {
+	if lp.planTracker.socBased {
+		return max(0, lp.planTracker.goal-lp.vehicleSoc)
+	}
+	return lp.remainingPlanEnergy(lp.planTracker.goal)
+}
+
+// recordPlanOutcome records the outcome of the tracked plan. Goals reached after target time are considered missed.
+func (lp *Loadpoint) recordPlanOutcome(reached bool, cause loadpoint.PlanCause) {
+	t := &lp.planTracker
+	if t.time.IsZero() || t.recorded {
+		return
+	}
+	t.recorded = true
+
+	res := loadpoint.PlanOutcome{
+		Time:     t.time,
+		Goal:     t.goal,
+		SocBased: t.socBased,
+		Finished: lp.clock.Now(),
+		Met:      reached && !t.late,
+	}
+
+	if !res.Met {
+		res.Shortfall = t.shortfall
+		if !t.late {
+			res.Shortfall = lp.planShortfall()
+		}
+
+		res.Cause = cause
+		if res.Cause == "" {
+			res.Cause = t.cause()
+		}
+
+		unit := "kWh"
+		if t.socBased {
+			unit = "%"
+		}
+		lp.log.WARN.Printf("plan: goal missed by %.1f%s (%s)", res.Shortfall, unit, res.Cause)
+		eventlog.Record(eventlog.CategoryPlan, lp.title, "missed", fmt.Sprintf("%.1f%s short (%s)", res.Shortfall, unit, res.Cause))
+	} else {
+		eventlog.Record(eventlog.CategoryPlan, lp.title, "met", "")
+	}
+
+	lp.Lock()
+	lp.planHistory = append(lp.planHistory, res)
+	if len(lp.planHistory) > planHistorySize {
+		lp.planHistory = slices.Clone(lp.planHistory[len(lp.planHistory)-planHistorySize:])
+	}
+	history := slices.Clone(lp.planHistory)
+	lp.Unlock()
+
+	if err := lp.settings.SetJson(keys.PlanHistory, history); err != nil {
+		lp.log.ERROR.Printf("plan history: %v", err)
+	}
+}
+
+// GetPlanHistory returns the outcomes of past charge plans
+func (lp *Loadpoint) GetPlanHistory() []loadpoint.PlanOutcome {
+	lp.RLock()
+	defer lp.RUnlock()
+	return slices.Clone(lp.planHistory)
+}
@@ -0,0 +1,111 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/settings"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanHistory(t *testing.T) {
+	clock := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:      util.NewLogger("foo"),
+		clock:    clock,
+		settings: settings.NewDatabaseSettingsAdapter("foo"),
+		status:   api.StatusB,
+	}
+
+	// goal reached in time
+	planTime := clock.Now().Add(4 * time.Hour)
+	lp.trackPlan(planTime, 80, true, false)
+	lp.recordPlanOutcome(true, "")
+
+	// repeated recording is ignored
+	lp.trackPlan(planTime, 80, true, false)
+	lp.recordPlanOutcome(true, "")
+
+	res := lp.GetPlanHistory()
+	require.Len(t, res, 1)
+	assert.True(t, res[0].Met)
+	assert.Equal(t, 0.0, res[0].Shortfall)
+	assert.Empty(t, res[0].Cause)
+
+	// goal reached late, shortfall at target time
+	planTime = clock.Now().Add(time.Hour)
+	lp.vehicleSoc = 50
+	lp.trackPlan(planTime, 80, true, true)
+
+	clock.Add(2 * time.Hour)
+	lp.trackPlan(planTime, 80, true, true)
+
+	lp.vehicleSoc = 80
+	lp.recordPlanOutcome(true, "")
+
+	res = lp.GetPlanHistory()
+	require.Len(t, res, 2)
+	assert.False(t, res[1].Met)
+	assert.Equal(t, 30.0, res[1].Shortfall)
+	assert.Equal(t, loadpoint.PlanCauseTime, res[1].Cause)
+
+	// vehicle not charging during active slot
+	planTime = clock.Now().Add(time.Hour)
+	lp.vehicleSoc = 60
+	lp.planActive = true
+	lp.enabled = true
+	lp.trackPlan(planTime, 70, true, false)
+
+	clock.Add(planVehicleTimeout + time.Minute)
+	lp.trackPlan(planTime, 70, true, false)
+
+	lp.recordPlanOutcome(false, "")
+
+	res = lp.GetPlanHistory()
+	require.Len(t, res, 3)
+	assert.Equal(t, 10.0, res[2].Shortfall)
+	assert.Equal(t, loadpoint.PlanCauseVehicle, res[2].Cause)
+
+	// explicit cause takes precedence
+	lp.trackPlan(clock.Now().Add(time.Hour), 90, true, false)
+	lp.recordPlanOutcome(false, loadpoint.PlanCauseDisconnected)
+
+	res = lp.GetPlanHistory()
+	require.Len(t, res, 4)
+	assert.Equal(t, loadpoint.PlanCauseDisconnected, res[3].Cause)
+
+	// charging stopped by cost cap
+	lp.trackPlan(clock.Now().Add(2*time.Hour), 90, true, false)
+	lp.planTracker.budget = true
+	lp.planTracker.priceCap = true
+	lp.recordPlanOutcome(false, "")
+
+	res = lp.GetPlanHistory()
+	require.Len(t, res, 5)
+	assert.Equal(t, loadpoint.PlanCausePriceCap, res[4].Cause)
+}
+
+func TestPlanHistorySize(t *testing.T) {
+	clock := clock.NewMock()
+
+	lp := &Loadpoint{
+		log:      util.NewLogger("foo"),
+		clock:    clock,
+		settings: settings.NewDatabaseSettingsAdapter("foo"),
+	}
+
+	for i := range planHistorySize + 5 {
+		lp.trackPlan(clock.Now().Add(time.Duration(i+1)*time.Hour), 80, true, false)
+		lp.recordPlanOutcome(true, "")
+	}
+
+	res := lp.GetPlanHistory()
+	require.Len(t, res, planHistorySize)
+	assert.Equal(t, clock.Now().Add(6*time.Hour), res[0].Time)
+}
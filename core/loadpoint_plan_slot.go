@@ -0,0 +1,37 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+// planSlot is the plan slot currently executed
+type planSlot struct {
+	loadpoint.PlanSlot
+	slot        api.Rate // planned slot
+	energyStart float64  // charged energy at slot start (Wh)
+}
+
+// trackPlanSlot tracks the execution of plan slots and publishes each slot once finished.
+// Plans continuing without active slot, e.g. after target time, extend the current slot.
+func (lp *Loadpoint) trackPlanSlot(active bool, slot api.Rate) {
+	if cur := lp.planSlot; cur != nil && (!active || (!slot.End.IsZero() && !slot.Start.Equal(cur.slot.Start))) {
+		cur.End = lp.clock.Now()
+		cur.Energy = max(0, lp.energyMetrics.TotalWh()-cur.energyStart) / 1e3
+
+		lp.publish(keys.PlanSlot, cur.PlanSlot)
+		lp.planSlot = nil
+	}
+
+	if active && lp.planSlot == nil {
+		lp.planSlot = &planSlot{
+			PlanSlot: loadpoint.PlanSlot{
+				Start: lp.clock.Now(),
+				Price: slot.Price,
+			},
+			slot:        slot,
+			energyStart: lp.energyMetrics.TotalWh(),
+		}
+	}
+}
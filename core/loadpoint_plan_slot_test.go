@@ -0,0 +1,53 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackPlanSlot(t *testing.T) {
+	clock := clock.NewMock()
+	uiChan := make(chan util.Param, 10)
+
+	lp := &Loadpoint{
+		log:    util.NewLogger("foo"),
+		clock:  clock,
+		uiChan: uiChan,
+	}
+
+	start := clock.Now()
+	slot := api.Rate{Start: start, End: start.Add(time.Hour), Price: 0.2}
+
+	lp.trackPlanSlot(true, slot)
+	require.NotNil(t, lp.planSlot)
+
+	clock.Add(30 * time.Minute)
+	lp.energyMetrics.Update(5)
+	lp.trackPlanSlot(true, slot)
+
+	// continuing without active slot extends the current slot
+	clock.Add(30 * time.Minute)
+	lp.trackPlanSlot(true, api.Rate{})
+	assert.Empty(t, uiChan)
+
+	lp.trackPlanSlot(false, api.Rate{})
+	assert.Nil(t, lp.planSlot)
+
+	require.Len(t, uiChan, 1)
+	p := <-uiChan
+	assert.Equal(t, keys.PlanSlot, p.Key)
+	assert.Equal(t, loadpoint.PlanSlot{
+		Start:  start,
+		End:    start.Add(time.Hour),
+		Price:  0.2,
+		Energy: 5,
+	}, p.Val)
+}
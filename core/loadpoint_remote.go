@@ -0,0 +1,40 @@
+package core
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+// remoteDemandPriority orders remote demands from least to most restrictive
+var remoteDemandPriority = []loadpoint.RemoteDemand{loadpoint.RemoteEnable, loadpoint.RemoteSoftDisable, loadpoint.RemoteHardDisable}
+
+// effectiveRemoteDemand returns the most restrictive remote demand and its source
+func (lp *Loadpoint) effectiveRemoteDemand() (loadpoint.RemoteDemand, string) {
+	res, source := lp.remoteDemand, lp.remoteSource
+
+	for _, s := range slices.Sorted(maps.Keys(lp.remoteDemands)) {
+		if demand := lp.remoteDemands[s]; slices.Index(remoteDemandPriority, demand) > slices.Index(remoteDemandPriority, res) {
+			res, source = demand, s
+		}
+	}
+
+	return res, source
+}
+
+// setRemoteDemand updates the remote demand using fn and applies changes of the effective demand immediately
+func (lp *Loadpoint) setRemoteDemand(source string, demand loadpoint.RemoteDemand, fn func()) {
+	lp.log.DEBUG.Printf("remote demand: %s (%s)", demand, source)
+
+	prev, prevSource := lp.effectiveRemoteDemand()
+	fn()
+
+	if res, resSource := lp.effectiveRemoteDemand(); res != prev || resSource != prevSource {
+		lp.publish(keys.RemoteDisabled, res)
+		lp.publish(keys.RemoteDisabledSource, resSource)
+
+		lp.requestUpdate()
+	}
+}
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/core/loadpoint"
+)
+
+// GetSolarReservation returns the share of the forecasted solar power reserved for the loadpoint at given time
+func (lp *Loadpoint) GetSolarReservation(ts time.Time) float64 {
+	res := lp.Reservation
+	if res.Share <= 0 {
+		return 0
+	}
+
+	if len(res.Weekdays) > 0 && !slices.Contains(res.Weekdays, int(ts.Weekday())) {
+		return 0
+	}
+
+	return min(res.Share, 100) / 100
+}
+
+// acceptsPower determines if the loadpoint can take power, i.e. vehicle connected and neither full nor disabled
+func (lp *Loadpoint) acceptsPower() bool {
+	if !lp.connected() || lp.vehicleFull || lp.idle.unlocked || lp.LimitSocReached() || lp.LimitEnergyReached() {
+		return false
+	}
+
+	lp.RLock()
+	defer lp.RUnlock()
+
+	demand, _ := lp.effectiveRemoteDemand()
+	return demand == loadpoint.RemoteEnable
+}
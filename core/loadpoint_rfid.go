@@ -0,0 +1,36 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/rfid"
+)
+
+const rfidSource = "rfid"
+
+// authorizeIdentifier checks the charger-provided id against the managed RFID tags
+// and disables charging while a rejected tag is presented
+func (lp *Loadpoint) authorizeIdentifier(id string) {
+	var rejected bool
+
+	if id != "" {
+		tag, err := rfid.Authorize(id, lp.identifiers(), "loadpoint")
+		if err != nil {
+			lp.log.WARN.Printf("rfid %s: %v", id, err)
+			rejected = true
+		} else if tag != nil {
+			lp.log.DEBUG.Printf("rfid %s: accepted (%s)", id, tag.Label)
+		}
+	}
+
+	// only release remote demand if set by rfid
+	if rejected == lp.rfidRejected {
+		return
+	}
+	lp.rfidRejected = rejected
+
+	demand := loadpoint.RemoteEnable
+	if rejected {
+		demand = loadpoint.RemoteHardDisable
+	}
+	lp.RemoteControlSource(rfidSource, demand)
+}
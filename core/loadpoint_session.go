@@ -2,6 +2,8 @@ package core
 
 import (
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/pricelog"
 	"github.com/evcc-io/evcc/core/session"
 	"github.com/samber/lo"
 )
@@ -64,17 +66,29 @@ func (lp *Loadpoint) stopSession() {
 	}
 
 	if chargedEnergy := lp.GetChargedEnergy() / 1e3; chargedEnergy > s.ChargedEnergy {
-		lp.energyMetrics.Update(chargedEnergy)
+		added, addedGreen := lp.energyMetrics.Update(chargedEnergy)
+		pricelog.RecordCharge(lp.clock.Now(), lp.db.Name(), added, addedGreen)
 	}
+	pricelog.FlushCharge(lp.db.Name())
 
 	s.SolarPercentage = lo.ToPtr(lp.energyMetrics.SolarPercentage())
+	s.Green = lp.db.Green(*s.SolarPercentage)
 	s.Price = lp.energyMetrics.Price()
 	s.PricePerKWh = lp.energyMetrics.PricePerKWh()
 	s.Co2PerKWh = lp.energyMetrics.Co2PerKWh()
 	s.ChargedEnergy = lp.energyMetrics.TotalWh() / 1e3
 	s.ChargeDuration = lo.ToPtr(lp.chargeDuration.Abs())
 
+	if c, ok := lp.charger.(api.SignedMeterValuer); ok {
+		if res, err := c.SignedMeterValues(); err == nil {
+			s.SignedMeterStart = res.Start
+			s.SignedMeterStop = res.Stop
+			s.SignatureVerified = res.Verified
+		}
+	}
+
 	lp.db.Persist(s)
+	lp.publish(keys.Session, *s)
 }
 
 type sessionOption func(*session.Session)
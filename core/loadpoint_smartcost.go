@@ -4,17 +4,60 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
 )
 
-func (lp *Loadpoint) smartCostActive(rates api.Rates) bool {
-	rate, err := rates.At(time.Now())
+// smartCostState tracks smart cost charging to apply hysteresis and minimum duration
+type smartCostState struct {
+	active   bool
+	switched time.Time
+}
+
+// next determines if smart cost charging is active at given price and limit
+func (s smartCostState) next(ts time.Time, price, limit float64, conf loadpoint.SmartCostConfig) bool {
+	active := price <= limit || s.active && price <= limit+conf.Hysteresis
+
+	if active != s.active && !s.switched.IsZero() && ts.Sub(s.switched) < conf.MinDuration {
+		return s.active
+	}
+
+	return active
+}
+
+// update records the smart cost charging state
+func (s *smartCostState) update(ts time.Time, active bool) {
+	if active != s.active {
+		s.active = active
+		s.switched = ts
+	}
+}
+
+// effectiveSmartCostLimit returns the smart cost limit tightened by the site co2 budget
+func (lp *Loadpoint) effectiveSmartCostLimit() *float64 {
 	limit := lp.GetSmartCostLimit()
-	return err == nil && limit != nil && rate.Price <= *limit
+	if limit == nil || lp.co2BudgetScale == nil {
+		return limit
+	}
+
+	res := *limit * *lp.co2BudgetScale
+	return &res
+}
+
+// smartCostActive determines if the current rate is below the smart cost limit, applying the configured hysteresis
+func (lp *Loadpoint) smartCostActive(rates api.Rates) bool {
+	now := time.Now()
+	rate, err := rates.At(now)
+	limit := lp.effectiveSmartCostLimit()
+	if err != nil || limit == nil {
+		return false
+	}
+
+	return lp.smartCost.next(now, rate.Price, *limit, lp.SmartCost)
 }
 
 // smartCostNextStart returns the next start time for a smart cost rate below the limit
 func (lp *Loadpoint) smartCostNextStart(rates api.Rates) time.Time {
-	limit := lp.GetSmartCostLimit()
+	limit := lp.effectiveSmartCostLimit()
 	if limit == nil || rates == nil {
 		return time.Time{}
 	}
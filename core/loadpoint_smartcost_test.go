@@ -0,0 +1,49 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/settings"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSmartCostHysteresis(t *testing.T) {
+	ts := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	conf := loadpoint.SmartCostConfig{Hysteresis: 0.02, MinDuration: 30 * time.Minute}
+
+	var s smartCostState
+	assert.False(t, s.next(ts, 0.21, 0.2, conf))
+	assert.True(t, s.next(ts, 0.2, 0.2, conf))
+
+	s.update(ts, true)
+	assert.True(t, s.next(ts.Add(15*time.Minute), 0.21, 0.2, conf), "hysteresis")
+	assert.True(t, s.next(ts.Add(15*time.Minute), 0.3, 0.2, conf), "min duration")
+	assert.False(t, s.next(ts.Add(30*time.Minute), 0.3, 0.2, conf))
+
+	s.update(ts.Add(30*time.Minute), false)
+	assert.False(t, s.next(ts.Add(45*time.Minute), 0.21, 0.2, conf), "hysteresis only while active")
+	assert.False(t, s.next(ts.Add(45*time.Minute), 0.1, 0.2, conf), "min duration")
+	assert.True(t, s.next(ts.Add(time.Hour), 0.1, 0.2, conf))
+}
+
+func TestSmartCostSettings(t *testing.T) {
+	lp := NewLoadpoint(util.NewLogger("foo"), settings.NewDatabaseSettingsAdapter("smartcost"))
+	lp.SmartCost = loadpoint.SmartCostConfig{Hysteresis: 0.02}
+
+	lp.SetSmartCostMinDuration(30 * time.Minute)
+	assert.Equal(t, loadpoint.SmartCostConfig{Hysteresis: 0.02, MinDuration: 30 * time.Minute}, lp.GetSmartCostConfig())
+
+	// persisted settings override the configuration
+	var res loadpoint.SmartCostConfig
+	require.NoError(t, lp.settings.Json(keys.SmartCost, &res))
+	assert.Equal(t, lp.GetSmartCostConfig(), res)
+
+	lp.SetSmartCostConfig(loadpoint.SmartCostConfig{Hysteresis: 0.05})
+	assert.Equal(t, 0.05, lp.GetSmartCostHysteresis())
+	assert.Equal(t, time.Duration(0), lp.GetSmartCostMinDuration())
+}
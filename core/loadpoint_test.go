@@ -13,6 +13,7 @@ import (
 	"github.com/evcc-io/evcc/push"
 	"github.com/evcc-io/evcc/util"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/mock/gomock"
 )
 
@@ -763,3 +764,35 @@ func TestPVHysteresisAfterPhaseSwitch(t *testing.T) {
 		ctrl.Finish()
 	}
 }
+
+func TestSetLimitPowerController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	pc := api.NewMockPowerController(ctrl)
+	charger := struct {
+		*api.MockCharger
+		*api.MockPowerController
+	}{
+		api.NewMockCharger(ctrl), pc,
+	}
+
+	lp := NewLoadpoint(util.NewLogger("foo"), nil)
+	lp.charger = charger
+	lp.minCurrent = minA
+	lp.maxCurrent = maxA
+	lp.phases = 3
+	lp.enabled = true
+
+	// power setpoint replaces current control
+	pc.EXPECT().MaxPower(10 * lp.EffectiveStepPower())
+	require.NoError(t, lp.setLimit(10))
+	assert.Equal(t, 10.0, lp.chargeCurrent)
+
+	// fallback to current control if power control is not configured
+	pc.EXPECT().MaxPower(gomock.Any()).Return(api.ErrNotAvailable)
+	charger.MockCharger.EXPECT().MaxCurrent(int64(12))
+	require.NoError(t, lp.setLimit(12))
+	assert.Equal(t, 12.0, lp.chargeCurrent)
+
+	ctrl.Finish()
+}
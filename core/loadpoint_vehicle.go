@@ -64,6 +64,7 @@ func (lp *Loadpoint) identifyVehicle() {
 
 	// vehicle found or removed
 	lp.setVehicleIdentifier(id)
+	lp.authorizeIdentifier(id)
 
 	if id != "" {
 		lp.log.DEBUG.Println("charger vehicle id:", id)
@@ -71,6 +72,7 @@ func (lp *Loadpoint) identifyVehicle() {
 		if vehicle := lp.selectVehicleByID(id); vehicle != nil {
 			lp.stopVehicleDetection()
 			lp.setActiveVehicle(vehicle)
+			lp.authorizeVehicle(vehicle, false)
 		}
 	}
 }
@@ -138,6 +140,13 @@ func (lp *Loadpoint) setActiveVehicle(v api.Vehicle) {
 		if lp.Soc.Estimate == nil || *lp.Soc.Estimate {
 			estimate = true
 		}
+
+		// soc tracking vehicles account charged energy themselves
+		if _, ok := v.(api.SocTracker); ok {
+			estimate = false
+			lp.trackedEnergy = lp.GetChargedEnergy()
+		}
+
 		lp.socEstimator = soc.NewEstimator(lp.log, lp.charger, v, estimate)
 
 		lp.publish(keys.VehicleName, vehicle.Settings(lp.log, v).Name())
@@ -201,6 +210,9 @@ func (lp *Loadpoint) unpublishVehicle() {
 	lp.publish(keys.VehicleSoc, 0.0)
 	lp.publish(keys.VehicleRange, int64(0))
 	lp.publish(keys.VehicleLimitSoc, 0.0)
+	lp.publish(keys.VehicleAuxiliarySoc, nil)
+	lp.publish(keys.VehicleAuxiliaryRange, nil)
+	lp.publish(keys.VehicleCombinedRange, nil)
 
 	lp.setRemainingEnergy(0)
 	lp.setRemainingDuration(0)
@@ -285,6 +297,7 @@ func (lp *Loadpoint) identifyVehicleByStatus() {
 	if vehicle := lp.coordinator.IdentifyVehicleByStatus(); vehicle != nil {
 		lp.stopVehicleDetection()
 		lp.setActiveVehicle(vehicle)
+		lp.authorizeVehicle(vehicle, false)
 		return
 	}
 
@@ -325,8 +338,8 @@ func (lp *Loadpoint) vehicleClimatePollAllowed() bool {
 
 // vehicleSocPollAllowed validates charging state against polling mode
 func (lp *Loadpoint) vehicleSocPollAllowed() bool {
-	// always update soc when charging
-	if lp.charging() {
+	// always update soc when charging or tracked locally
+	if _, ok := lp.GetVehicle().(api.SocTracker); ok || lp.charging() {
 		return true
 	}
 
@@ -371,3 +384,24 @@ func (lp *Loadpoint) vehicleClimateActive() bool {
 
 	return false
 }
+
+// trackVehicleSoc accounts energy charged since the last update to vehicles tracking soc from charged energy
+func (lp *Loadpoint) trackVehicleSoc() {
+	v := lp.GetVehicle()
+	if _, ok := v.(api.SocTracker); !ok {
+		return
+	}
+
+	energy := lp.GetChargedEnergy()
+
+	// charged energy has been reset
+	if energy < lp.trackedEnergy {
+		lp.trackedEnergy = 0
+	}
+
+	if delta := energy - lp.trackedEnergy; delta > 0 {
+		vehicle.Settings(lp.log, v).AddEnergy(delta / 1e3)
+	}
+
+	lp.trackedEnergy = energy
+}
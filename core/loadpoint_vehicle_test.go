@@ -291,3 +291,45 @@ func TestReconnectVehicle(t *testing.T) {
 		})
 	}
 }
+
+type socTrackerVehicle struct {
+	api.Vehicle
+	energy float64
+}
+
+func (v *socTrackerVehicle) SetSoc(soc float64) error {
+	return nil
+}
+
+func (v *socTrackerVehicle) AddEnergy(energy float64) {
+	v.energy += energy
+}
+
+func TestTrackVehicleSoc(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	vehicle := &socTrackerVehicle{Vehicle: api.NewMockVehicle(ctrl)}
+
+	lp := &Loadpoint{
+		log:     util.NewLogger("foo"),
+		vehicle: vehicle,
+	}
+
+	lp.energyMetrics.Update(2)
+	lp.trackVehicleSoc()
+	assert.Equal(t, 2.0, vehicle.energy)
+
+	// no energy charged
+	lp.trackVehicleSoc()
+	assert.Equal(t, 2.0, vehicle.energy)
+
+	lp.energyMetrics.Update(3.5)
+	lp.trackVehicleSoc()
+	assert.Equal(t, 3.5, vehicle.energy)
+
+	// new session
+	lp.energyMetrics.Reset()
+	lp.energyMetrics.Update(1)
+	lp.trackVehicleSoc()
+	assert.Equal(t, 4.5, vehicle.energy)
+}
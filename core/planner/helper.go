@@ -1,6 +1,7 @@
 package planner
 
 import (
+	"errors"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -80,3 +81,33 @@ func IsFirst(r api.Rate, plan api.Rates) bool {
 	}
 	return true
 }
+
+// Cost returns the cost of charging with given power (W) during plan based on rates.
+// Cost is returned per kWh unit of rates, i.e. currency or grams of CO2.
+// Plan periods not covered by rates are reported as error.
+func Cost(plan, rates api.Rates, power float64) (float64, error) {
+	var (
+		cost    float64
+		covered time.Duration
+	)
+
+	for _, slot := range plan {
+		for _, r := range rates {
+			start := max(slot.Start.UnixNano(), r.Start.UnixNano())
+			end := min(slot.End.UnixNano(), r.End.UnixNano())
+			if end <= start {
+				continue
+			}
+
+			d := time.Duration(end - start)
+			covered += d
+			cost += power / 1e3 * d.Hours() * r.Price
+		}
+	}
+
+	if covered < Duration(plan) {
+		return cost, errors.New("plan exceeds available rates")
+	}
+
+	return cost, nil
+}
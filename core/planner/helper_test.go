@@ -47,3 +47,23 @@ func TestIsFirst(t *testing.T) {
 	// ensure single slot is always first
 	require.True(t, IsFirst(first, []api.Rate{first}))
 }
+
+func TestCost(t *testing.T) {
+	clock := clock.NewMock()
+	rr := rates([]float64{0.2, 0.4, 0.1}, clock.Now(), time.Hour)
+
+	// 11kW for 1.5h starting 30min into first slot
+	plan := api.Rates{{
+		Start: clock.Now().Add(30 * time.Minute),
+		End:   clock.Now().Add(2 * time.Hour),
+	}}
+
+	cost, err := Cost(plan, rr, 11e3)
+	require.NoError(t, err)
+	require.InDelta(t, 11*0.5*0.2+11*1*0.4, cost, 1e-9)
+
+	// beyond available rates
+	plan[0].End = clock.Now().Add(4 * time.Hour)
+	_, err = Cost(plan, rr, 11e3)
+	require.Error(t, err)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/benbjohnson/clock"
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -317,3 +318,27 @@ func TestContinuousPlanOutsideRates(t *testing.T) {
 	// 3-slot plan
 	assert.Len(t, plan, 1)
 }
+
+func TestFlatPriceWindow(t *testing.T) {
+	clock := clock.NewMock()
+	ctrl := gomock.NewController(t)
+
+	// cheap flat window after two expensive hours
+	rr, err := tariff.Normalize(rates([]float64{30, 30, 10, 10, 10, 10}, clock.Now(), time.Hour))
+	require.NoError(t, err)
+
+	trf := api.NewMockTariff(ctrl)
+	trf.EXPECT().Rates().AnyTimes().Return(rr, nil)
+
+	p := &Planner{
+		log:    util.NewLogger("foo"),
+		clock:  clock,
+		tariff: trf,
+	}
+
+	// latest slots of the flat window are used
+	plan := p.Plan(2*time.Hour, clock.Now().Add(6*time.Hour))
+	assert.True(t, SlotAt(clock.Now().Add(3*time.Hour), plan).IsZero(), "should not charge at start of flat window")
+	assert.False(t, SlotAt(clock.Now().Add(4*time.Hour), plan).IsZero(), "should charge at end of flat window")
+	assert.False(t, SlotAt(clock.Now().Add(5*time.Hour), plan).IsZero(), "should charge at end of flat window")
+}
@@ -0,0 +1,267 @@
+package pricelog
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"gorm.io/gorm"
+)
+
+const (
+	UsageGrid   = "grid"   // grid import price
+	UsageFeedIn = "feedin" // feed-in compensation
+)
+
+// ChargeInterval is the interval charged energy is recorded in
+const ChargeInterval = 15 * time.Minute
+
+// ErrNoHistory is returned if the price history does not cover the requested period
+var ErrNoHistory = errors.New("no price history")
+
+// Entry is a price valid from its creation until the next entry of the same usage
+type Entry struct {
+	ID      uint      `json:"id" gorm:"primarykey"`
+	Created time.Time `json:"created" gorm:"index"`
+	Usage   string    `json:"usage" gorm:"index"`
+	Price   float64   `json:"price"`
+}
+
+// TableName returns the database table name
+func (Entry) TableName() string {
+	return "prices"
+}
+
+// Charge is the energy charged at a loadpoint within the interval starting at its creation
+type Charge struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	Created   time.Time `json:"created" gorm:"index"`
+	Loadpoint string    `json:"loadpoint" gorm:"index"`
+	Energy    float64   `json:"energy"` // charged energy (kWh)
+	Green     float64   `json:"green"`  // green part of the charged energy (kWh)
+}
+
+// TableName returns the database table name
+func (Charge) TableName() string {
+	return "price_charges"
+}
+
+var (
+	db  *gorm.DB
+	log = util.NewLogger("pricelog")
+
+	mu      sync.Mutex
+	last    map[string]float64 // last recorded price by usage
+	pending map[string]*Charge // charge of the current interval by loadpoint
+)
+
+// Init migrates the price tables
+func Init(instance *gorm.DB) error {
+	mu.Lock()
+	db = instance
+	last = make(map[string]float64)
+	pending = make(map[string]*Charge)
+	mu.Unlock()
+
+	return db.AutoMigrate(new(Entry), new(Charge))
+}
+
+// Record adds the current price to the price history if it changed
+func Record(usage string, price float64) {
+	record(time.Now(), usage, price)
+}
+
+func record(ts time.Time, usage string, price float64) {
+	if db == nil {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if v, ok := last[usage]; ok && v == price {
+		return
+	}
+
+	entry := Entry{
+		Created: ts,
+		Usage:   usage,
+		Price:   price,
+	}
+
+	if err := db.Create(&entry).Error; err != nil {
+		log.ERROR.Printf("record: %v", err)
+		return
+	}
+
+	last[usage] = price
+}
+
+// RecordCharge adds energy charged at the loadpoint to the charge of the current interval.
+// The charge is persisted once the interval ends.
+func RecordCharge(ts time.Time, loadpoint string, energy, green float64) {
+	if db == nil || energy <= 0 {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	start := ts.Truncate(ChargeInterval)
+
+	c, ok := pending[loadpoint]
+	if ok && !c.Created.Equal(start) {
+		flushCharge(loadpoint)
+		ok = false
+	}
+
+	if !ok {
+		c = &Charge{Created: start, Loadpoint: loadpoint}
+		pending[loadpoint] = c
+	}
+
+	c.Energy += energy
+	c.Green += green
+}
+
+// FlushCharge persists the charge of the current interval, e.g. when the session ends
+func FlushCharge(loadpoint string) {
+	if db == nil {
+		return
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	flushCharge(loadpoint)
+}
+
+func flushCharge(loadpoint string) {
+	c, ok := pending[loadpoint]
+	if !ok {
+		return
+	}
+
+	delete(pending, loadpoint)
+
+	if err := db.Create(c).Error; err != nil {
+		log.ERROR.Printf("record charge: %v", err)
+	}
+}
+
+// Charges returns the charges recorded at the loadpoint within the period
+func Charges(loadpoint string, from, to time.Time) ([]Charge, error) {
+	if db == nil {
+		return nil, ErrNoHistory
+	}
+
+	var res []Charge
+	if err := db.Where("loadpoint = ? AND created >= ? AND created < ?", loadpoint, from.Truncate(ChargeInterval), to).Order("created").Find(&res).Error; err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// Series returns the prices of given usage valid within the period, starting with the price valid at from
+func Series(usage string, from, to time.Time) ([]Entry, error) {
+	if db == nil {
+		return nil, ErrNoHistory
+	}
+
+	var first Entry
+	if tx := db.Where("usage = ? AND created <= ?", usage, from).Order("created desc").Limit(1).Find(&first); tx.Error != nil {
+		return nil, tx.Error
+	} else if tx.RowsAffected == 0 {
+		return nil, ErrNoHistory
+	}
+
+	var res []Entry
+	if err := db.Where("usage = ? AND created > ? AND created < ?", usage, from, to).Order("created").Find(&res).Error; err != nil {
+		return nil, err
+	}
+
+	return append([]Entry{first}, res...), nil
+}
+
+// Rates returns the prices of given usage valid within the period as rates ending at to
+func Rates(usage string, from, to time.Time) (api.Rates, error) {
+	series, err := Series(usage, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(api.Rates, 0, len(series))
+	for i, e := range series {
+		end := to
+		if i+1 < len(series) {
+			end = series[i+1].Created
+		}
+
+		res = append(res, api.Rate{Start: e.Created, End: end, Price: e.Price})
+	}
+
+	return res, nil
+}
+
+// AveragePrice returns the time-weighted average price of given usage within the period
+func AveragePrice(usage string, from, to time.Time) (float64, error) {
+	if !to.After(from) {
+		return 0, errors.New("invalid period")
+	}
+
+	series, err := Series(usage, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	var sum float64
+	for i, e := range series {
+		start, end := e.Created, to
+		if start.Before(from) {
+			start = from
+		}
+		if i+1 < len(series) {
+			end = series[i+1].Created
+		}
+
+		sum += e.Price * end.Sub(start).Seconds()
+	}
+
+	return sum / to.Sub(from).Seconds(), nil
+}
+
+// SessionPrice returns the cost of the energy charged at the loadpoint within the period at the historic prices.
+// Energy is priced per recorded charge interval, its green part at the feed-in compensation forgone which is
+// assumed zero without feed-in history. Sessions without recorded charges have no price history.
+func SessionPrice(loadpoint string, from, to time.Time) (float64, error) {
+	charges, err := Charges(loadpoint, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(charges) == 0 {
+		return 0, ErrNoHistory
+	}
+
+	var res float64
+	for _, c := range charges {
+		end := c.Created.Add(ChargeInterval)
+
+		grid, err := AveragePrice(UsageGrid, c.Created, end)
+		if err != nil {
+			return 0, err
+		}
+
+		feedin, err := AveragePrice(UsageFeedIn, c.Created, end)
+		if err != nil && !errors.Is(err, ErrNoHistory) {
+			return 0, err
+		}
+
+		res += (c.Energy-c.Green)*grid + c.Green*feedin
+	}
+
+	return res, nil
+}
@@ -0,0 +1,79 @@
+package pricelog
+
+import (
+	"testing"
+	"time"
+
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceLog(t *testing.T) {
+	instance, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+	require.NoError(t, Init(instance))
+
+	ts := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	record(ts, UsageGrid, 0.2)
+	record(ts.Add(30*time.Minute), UsageGrid, 0.2) // unchanged
+	record(ts.Add(time.Hour), UsageGrid, 0.4)
+	record(ts, UsageFeedIn, 0.1)
+
+	res, err := Series(UsageGrid, ts.Add(-time.Minute), ts.Add(2*time.Hour))
+	assert.ErrorIs(t, err, ErrNoHistory)
+	assert.Empty(t, res)
+
+	res, err = Series(UsageGrid, ts.Add(30*time.Minute), ts.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+	assert.Equal(t, 0.2, res[0].Price)
+	assert.Equal(t, 0.4, res[1].Price)
+
+	rates, err := Rates(UsageGrid, ts.Add(30*time.Minute), ts.Add(2*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, rates, 2)
+	assert.Equal(t, ts, rates[0].Start)
+	assert.Equal(t, ts.Add(time.Hour), rates[0].End)
+	assert.Equal(t, ts.Add(2*time.Hour), rates[1].End)
+	assert.Equal(t, 0.4, rates[1].Price)
+
+	// half hour each at 0.2 and 0.4
+	avg, err := AveragePrice(UsageGrid, ts.Add(30*time.Minute), ts.Add(90*time.Minute))
+	require.NoError(t, err)
+	assert.InDelta(t, 0.3, avg, 1e-9)
+
+	// no charges recorded
+	_, err = SessionPrice("lp", ts, ts.Add(2*time.Hour))
+	assert.ErrorIs(t, err, ErrNoHistory)
+}
+
+func TestSessionPrice(t *testing.T) {
+	instance, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+	require.NoError(t, Init(instance))
+
+	ts := time.Date(2025, 1, 1, 18, 0, 0, 0, time.UTC)
+
+	// expensive evening, cheap night
+	record(ts, UsageGrid, 0.4)
+	record(ts.Add(8*time.Hour), UsageGrid, 0.1)
+	record(ts, UsageFeedIn, 0.08)
+
+	// plugged in at 18:00, charged 10kWh at 02:00 with 2kWh green
+	RecordCharge(ts.Add(8*time.Hour), "lp", 6, 1)
+	RecordCharge(ts.Add(8*time.Hour+10*time.Minute), "lp", 2, 0)
+	RecordCharge(ts.Add(8*time.Hour+20*time.Minute), "lp", 2, 1)
+	RecordCharge(ts.Add(8*time.Hour), "other", 10, 0)
+	FlushCharge("lp")
+	FlushCharge("other")
+
+	charges, err := Charges("lp", ts, ts.Add(9*time.Hour))
+	require.NoError(t, err)
+	require.Len(t, charges, 2, "one charge per interval")
+
+	price, err := SessionPrice("lp", ts, ts.Add(9*time.Hour))
+	require.NoError(t, err)
+	assert.InDelta(t, 8*0.1+2*0.08, price, 1e-9)
+}
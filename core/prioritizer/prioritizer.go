@@ -3,22 +3,52 @@ package prioritizer
 import (
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/util"
 )
 
+// Objective selects the loadpoint receiving surplus among loadpoints of equal priority
+type Objective string
+
+const (
+	ObjectivePriority  Objective = ""          // static priority only
+	ObjectiveSoc       Objective = "soc"       // lowest vehicle soc first
+	ObjectiveDeparture Objective = "departure" // earliest plan departure first
+	ObjectiveEnergy    Objective = "energy"    // least remaining energy first
+)
+
+// ranking margins avoid moving surplus between loadpoints of similar state back and forth
+const (
+	socMargin       = 5 // %
+	departureMargin = 30 * time.Minute
+	energyMargin    = 1e3 // Wh
+)
+
+// Validate checks for a known allocation objective
+func (o Objective) Validate() error {
+	switch o {
+	case ObjectivePriority, ObjectiveSoc, ObjectiveDeparture, ObjectiveEnergy:
+		return nil
+	default:
+		return fmt.Errorf("invalid allocation objective: %s", o)
+	}
+}
+
 type Prioritizer struct {
-	mu     sync.Mutex
-	log    *util.Logger
-	demand map[loadpoint.API]float64
+	mu        sync.Mutex
+	log       *util.Logger
+	demand    map[loadpoint.API]float64
+	objective Objective
 }
 
-func New(log *util.Logger) *Prioritizer {
+func New(log *util.Logger, objective Objective) *Prioritizer {
 	return &Prioritizer{
-		log:    log,
-		demand: make(map[loadpoint.API]float64),
+		log:       log,
+		demand:    make(map[loadpoint.API]float64),
+		objective: objective,
 	}
 }
 
@@ -38,10 +68,10 @@ func (p *Prioritizer) GetChargePowerFlexibility(lp loadpoint.API) float64 {
 		msg      string
 	)
 
-	for lp, power := range p.demand {
-		if lp.EffectivePriority() < prio && power > 0 {
+	for other, power := range p.demand {
+		if other != lp && power > 0 && p.outranks(lp, prio, other) {
 			reduceBy += power
-			msg += fmt.Sprintf("%.0fW from %s at prio %d, ", power, lp.GetTitle(), lp.EffectivePriority())
+			msg += fmt.Sprintf("%.0fW from %s at prio %d, ", power, other.GetTitle(), other.EffectivePriority())
 		}
 	}
 
@@ -51,3 +81,32 @@ func (p *Prioritizer) GetChargePowerFlexibility(lp loadpoint.API) float64 {
 
 	return reduceBy
 }
+
+// outranks determines if the loadpoint at given priority may take power from the other loadpoint.
+// Loadpoints of equal priority are ranked by the allocation objective if their difference exceeds the objective's margin.
+func (p *Prioritizer) outranks(lp loadpoint.API, prio int, other loadpoint.API) bool {
+	if otherPrio := other.EffectivePriority(); otherPrio != prio || p.objective == ObjectivePriority {
+		return otherPrio < prio
+	}
+
+	switch p.objective {
+	case ObjectiveSoc:
+		soc, otherSoc := lp.GetVehicleSoc(), other.GetVehicleSoc()
+		return soc > 0 && otherSoc > 0 && soc+socMargin < otherSoc
+
+	case ObjectiveDeparture:
+		ts, otherTs := lp.EffectivePlanTime(), other.EffectivePlanTime()
+		return !ts.IsZero() && (otherTs.IsZero() || earlier(ts.Add(departureMargin), otherTs))
+
+	case ObjectiveEnergy:
+		energy, otherEnergy := lp.GetRemainingEnergy(), other.GetRemainingEnergy()
+		return energy > 0 && (otherEnergy <= 0 || energy+energyMargin < otherEnergy)
+	}
+
+	return false
+}
+
+// earlier determines if ts is before other, treating zero time as no departure
+func earlier(ts, other time.Time) bool {
+	return !ts.IsZero() && (other.IsZero() || ts.Before(other))
+}
@@ -2,6 +2,7 @@ package prioritizer
 
 import (
 	"testing"
+	"time"
 
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/stretchr/testify/assert"
@@ -11,7 +12,7 @@ import (
 func TestPrioritzer(t *testing.T) {
 	ctrl := gomock.NewController(t)
 
-	p := New(nil)
+	p := New(nil, ObjectivePriority)
 
 	lo := loadpoint.NewMockAPI(ctrl)
 	lo.EXPECT().GetTitle().AnyTimes()
@@ -38,3 +39,78 @@ func TestPrioritzer(t *testing.T) {
 	p.UpdateChargePowerFlexibility(lo, nil)
 	assert.Equal(t, 0.0, p.GetChargePowerFlexibility(hi))
 }
+
+func TestPrioritzerObjective(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	p := New(nil, ObjectiveSoc)
+
+	empty := loadpoint.NewMockAPI(ctrl)
+	empty.EXPECT().GetTitle().AnyTimes()
+	empty.EXPECT().EffectivePriority().Return(0).AnyTimes()
+	empty.EXPECT().GetVehicleSoc().Return(20.0).AnyTimes()
+
+	full := loadpoint.NewMockAPI(ctrl)
+	full.EXPECT().GetTitle().AnyTimes()
+	full.EXPECT().EffectivePriority().Return(0).AnyTimes()
+	full.EXPECT().GetVehicleSoc().Return(70.0).AnyTimes()
+
+	full.EXPECT().GetChargePowerFlexibility(nil).Return(2e3)
+	p.UpdateChargePowerFlexibility(full, nil)
+	empty.EXPECT().GetChargePowerFlexibility(nil).Return(1e3)
+	p.UpdateChargePowerFlexibility(empty, nil)
+
+	// lowest soc receives surplus at equal priority
+	assert.Equal(t, 2e3, p.GetChargePowerFlexibility(empty))
+	assert.Equal(t, 0.0, p.GetChargePowerFlexibility(full))
+
+	// static priority takes precedence
+	hi := loadpoint.NewMockAPI(ctrl)
+	hi.EXPECT().GetTitle().AnyTimes()
+	hi.EXPECT().EffectivePriority().Return(1).AnyTimes()
+	assert.Equal(t, 3e3, p.GetChargePowerFlexibility(hi))
+}
+
+func TestObjectiveDeparture(t *testing.T) {
+	ts := time.Now()
+	assert.True(t, earlier(ts, time.Time{}))
+	assert.True(t, earlier(ts, ts.Add(time.Hour)))
+	assert.False(t, earlier(time.Time{}, ts))
+	assert.False(t, earlier(time.Time{}, time.Time{}))
+}
+
+func TestObjectiveMargin(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().EffectivePriority().Return(0).AnyTimes()
+	other := loadpoint.NewMockAPI(ctrl)
+	other.EXPECT().EffectivePriority().Return(0).AnyTimes()
+
+	// soc
+	p := New(nil, ObjectiveSoc)
+	lp.EXPECT().GetVehicleSoc().Return(50.0).Times(2)
+	other.EXPECT().GetVehicleSoc().Return(53.0)
+	assert.False(t, p.outranks(lp, 0, other), "within margin")
+	other.EXPECT().GetVehicleSoc().Return(60.0)
+	assert.True(t, p.outranks(lp, 0, other))
+
+	// departure
+	ts := time.Now()
+	p = New(nil, ObjectiveDeparture)
+	lp.EXPECT().EffectivePlanTime().Return(ts).Times(3)
+	other.EXPECT().EffectivePlanTime().Return(ts.Add(10 * time.Minute))
+	assert.False(t, p.outranks(lp, 0, other), "within margin")
+	other.EXPECT().EffectivePlanTime().Return(ts.Add(time.Hour))
+	assert.True(t, p.outranks(lp, 0, other))
+	other.EXPECT().EffectivePlanTime().Return(time.Time{})
+	assert.True(t, p.outranks(lp, 0, other), "no departure")
+
+	// remaining energy
+	p = New(nil, ObjectiveEnergy)
+	lp.EXPECT().GetRemainingEnergy().Return(5e3).Times(2)
+	other.EXPECT().GetRemainingEnergy().Return(5.5e3)
+	assert.False(t, p.outranks(lp, 0, other), "within margin")
+	other.EXPECT().GetRemainingEnergy().Return(10e3)
+	assert.True(t, p.outranks(lp, 0, other))
+}
@@ -0,0 +1,220 @@
+package rfid
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Tag is a managed RFID tag
+type Tag struct {
+	ID         uint       `json:"id" gorm:"primarykey"`
+	IdTag      string     `json:"idTag" gorm:"uniqueIndex"`
+	Label      string     `json:"label"`
+	Vehicle    string     `json:"vehicle"`
+	Driver     string     `json:"driver"`
+	ValidFrom  *time.Time `json:"validFrom"`
+	ValidUntil *time.Time `json:"validUntil"`
+	Loadpoints []string   `json:"loadpoints" gorm:"type:string;serializer:json"` // allowed loadpoint names or ids, empty for all
+}
+
+// TableName returns the database table name
+func (Tag) TableName() string {
+	return "rfid_tags"
+}
+
+// Usage is a tag authorization log entry
+type Usage struct {
+	ID        uint      `json:"id" gorm:"primarykey"`
+	Created   time.Time `json:"created"`
+	IdTag     string    `json:"idTag" gorm:"index"`
+	Loadpoint string    `json:"loadpoint"`
+	Source    string    `json:"source"`
+	Accepted  bool      `json:"accepted"`
+	Reason    string    `json:"reason,omitempty"`
+}
+
+// TableName returns the database table name
+func (Usage) TableName() string {
+	return "rfid_usages"
+}
+
+var (
+	db *gorm.DB
+
+	ErrUnknownTag        = errors.New("unknown tag")
+	ErrTagNotValid       = errors.New("tag not valid")
+	ErrLoadpointDisabled = errors.New("tag not allowed at loadpoint")
+)
+
+func Init(instance *gorm.DB) error {
+	db = instance
+	return db.AutoMigrate(new(Tag), new(Usage))
+}
+
+// Tags returns all tags
+func Tags() ([]Tag, error) {
+	var res []Tag
+	if db == nil {
+		return res, nil
+	}
+	tx := db.Order("id").Find(&res)
+	return res, tx.Error
+}
+
+// AddTag adds a new tag
+func AddTag(tag Tag) (Tag, error) {
+	tag.ID = 0
+	tag.IdTag = strings.TrimSpace(tag.IdTag)
+
+	if tag.IdTag == "" {
+		return Tag{}, errors.New("missing id tag")
+	}
+
+	if err := db.Create(&tag).Error; err != nil {
+		return Tag{}, err
+	}
+
+	return tag, nil
+}
+
+// UpdateTag updates an existing tag
+func UpdateTag(id uint, tag Tag) (Tag, error) {
+	var existing Tag
+	if err := db.First(&existing, id).Error; err != nil {
+		return Tag{}, err
+	}
+
+	tag.ID = id
+	tag.IdTag = strings.TrimSpace(tag.IdTag)
+
+	if tag.IdTag == "" {
+		return Tag{}, errors.New("missing id tag")
+	}
+
+	return tag, db.Save(&tag).Error
+}
+
+// DeleteTag deletes a tag
+func DeleteTag(id uint) error {
+	tx := db.Delete(new(Tag), id)
+	if tx.Error == nil && tx.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return tx.Error
+}
+
+// Usages returns the most recent tag usages, optionally filtered by tag
+func Usages(idTag string, limit int) ([]Usage, error) {
+	var res []Usage
+	if db == nil {
+		return res, nil
+	}
+
+	tx := db.Order("id desc").Limit(limit)
+	if idTag != "" {
+		tx = tx.Where(&Usage{IdTag: idTag})
+	}
+
+	return res, tx.Find(&res).Error
+}
+
+// valid checks the tag's validity period
+func (t Tag) valid(ts time.Time) error {
+	if t.ValidFrom != nil && ts.Before(*t.ValidFrom) || t.ValidUntil != nil && ts.After(*t.ValidUntil) {
+		return ErrTagNotValid
+	}
+	return nil
+}
+
+// check validates the tag for the loadpoint identified by any of the given names or ids at the given time
+func (t Tag) check(loadpoint []string, ts time.Time) error {
+	if err := t.valid(ts); err != nil {
+		return err
+	}
+
+	if len(t.Loadpoints) > 0 && !slices.ContainsFunc(loadpoint, func(lp string) bool {
+		return slices.Contains(t.Loadpoints, lp)
+	}) {
+		return ErrLoadpointDisabled
+	}
+
+	return nil
+}
+
+// find returns the managed tag. If no tags are managed, nil is returned.
+func find(idTag string) (*Tag, error) {
+	var count int64
+	if err := db.Model(new(Tag)).Count(&count).Error; err != nil || count == 0 {
+		return nil, err
+	}
+
+	var tag Tag
+	tx := db.Where(&Tag{IdTag: idTag}).Limit(1).Find(&tag)
+	switch {
+	case tx.Error != nil:
+		return nil, tx.Error
+	case tx.RowsAffected == 0:
+		return nil, ErrUnknownTag
+	default:
+		return &tag, nil
+	}
+}
+
+// Validate checks if the tag is known and valid without logging the usage, e.g. for chargers
+// authorizing tags before the loadpoint is known. If no tags are managed, all tags are accepted.
+func Validate(idTag string) error {
+	if db == nil {
+		return nil
+	}
+
+	tag, err := find(idTag)
+	if err != nil || tag == nil {
+		return err
+	}
+
+	return tag.valid(time.Now())
+}
+
+// Authorize checks if the tag may be used at the loadpoint identified by any of the given names or ids
+// and logs the usage. If no tags are managed, all tags are accepted.
+func Authorize(idTag string, loadpoint []string, source string) (*Tag, error) {
+	if db == nil {
+		return nil, nil
+	}
+
+	tag, err := find(idTag)
+	switch {
+	case err == nil && tag == nil:
+		return nil, nil
+	case err == nil:
+		err = tag.check(loadpoint, time.Now())
+	case !errors.Is(err, ErrUnknownTag):
+		return nil, err
+	}
+
+	usage := Usage{
+		Created:   time.Now(),
+		IdTag:     idTag,
+		Loadpoint: strings.Join(loadpoint, ","),
+		Source:    source,
+		Accepted:  err == nil,
+	}
+	if err != nil {
+		usage.Reason = err.Error()
+	}
+
+	if err := db.Create(&usage).Error; err != nil {
+		return nil, fmt.Errorf("usage: %w", err)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tag, nil
+}
@@ -0,0 +1,57 @@
+package rfid
+
+import (
+	"testing"
+	"time"
+
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthorize(t *testing.T) {
+	instance, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+	require.NoError(t, Init(instance))
+
+	// no tags, accept all
+	tag, err := Authorize("any", []string{"lp-1"}, "loadpoint")
+	require.NoError(t, err)
+	assert.Nil(t, tag)
+
+	_, err = AddTag(Tag{IdTag: "valid", Label: "valid", Loadpoints: []string{"lp-1", "2"}})
+	require.NoError(t, err)
+	_, err = AddTag(Tag{IdTag: "expired", ValidUntil: lo.ToPtr(time.Now().Add(-time.Hour))})
+	require.NoError(t, err)
+
+	tag, err = Authorize("valid", []string{"lp-1", "1"}, "loadpoint")
+	require.NoError(t, err)
+	assert.Equal(t, "valid", tag.Label)
+
+	// validation ignores loadpoint restrictions and doesn't log usage
+	assert.NoError(t, Validate("valid"))
+	assert.ErrorIs(t, Validate("expired"), ErrTagNotValid)
+	assert.ErrorIs(t, Validate("unknown"), ErrUnknownTag)
+
+	_, err = Authorize("valid", []string{"2"}, "loadpoint")
+	assert.NoError(t, err, "by id")
+
+	_, err = Authorize("valid", []string{"lp-3", "3"}, "loadpoint")
+	assert.ErrorIs(t, err, ErrLoadpointDisabled)
+
+	_, err = Authorize("expired", []string{"lp-1"}, "loadpoint")
+	assert.ErrorIs(t, err, ErrTagNotValid)
+
+	_, err = Authorize("any", []string{"lp-1"}, "loadpoint")
+	assert.ErrorIs(t, err, ErrUnknownTag)
+
+	usages, err := Usages("", 100)
+	require.NoError(t, err)
+	assert.Len(t, usages, 5)
+
+	usages, err = Usages("valid", 100)
+	require.NoError(t, err)
+	assert.Len(t, usages, 3)
+	assert.False(t, usages[0].Accepted)
+}
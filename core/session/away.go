@@ -0,0 +1,53 @@
+package session
+
+import (
+	"slices"
+
+	"github.com/evcc-io/evcc/api"
+	"gorm.io/gorm"
+)
+
+// ImportAway persists the vehicle's away charging records not imported before and returns the number of new sessions
+func ImportAway(db *gorm.DB, vehicle string, records []api.ChargingRecord) (int, error) {
+	if err := db.AutoMigrate(new(Session)); err != nil {
+		return 0, err
+	}
+
+	var existing Sessions
+	if err := db.Where("away = ? AND vehicle = ?", true, vehicle).Find(&existing).Error; err != nil {
+		return 0, err
+	}
+
+	var res int
+	for _, r := range records {
+		if r.Start.IsZero() || r.Energy <= 0 || slices.ContainsFunc(existing, func(s Session) bool {
+			return s.Created.Equal(r.Start)
+		}) {
+			continue
+		}
+
+		s := Session{
+			Created:       r.Start,
+			Finished:      r.End,
+			Vehicle:       vehicle,
+			ChargedEnergy: r.Energy,
+			Price:         r.Price,
+			Away:          true,
+			Location:      r.Location,
+		}
+
+		if r.Price != nil {
+			pricePerKWh := *r.Price / r.Energy
+			s.PricePerKWh = &pricePerKWh
+		}
+
+		if err := db.Create(&s).Error; err != nil {
+			return res, err
+		}
+
+		existing = append(existing, s)
+		res++
+	}
+
+	return res, nil
+}
@@ -0,0 +1,39 @@
+package session
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	serverdb "github.com/evcc-io/evcc/server/db"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportAway(t *testing.T) {
+	db, err := serverdb.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	ts := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	records := []api.ChargingRecord{
+		{Start: ts, End: ts.Add(time.Hour), Energy: 40, Price: lo.ToPtr(20.0), Location: "Highway"},
+		{Start: ts.Add(24 * time.Hour), End: ts.Add(25 * time.Hour), Energy: 0}, // ignored
+	}
+
+	n, err := ImportAway(db, "Model 3", records)
+	require.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	// already imported
+	n, err = ImportAway(db, "Model 3", records)
+	require.NoError(t, err)
+	assert.Equal(t, 0, n)
+
+	var res Sessions
+	require.NoError(t, db.Find(&res).Error)
+	require.Len(t, res, 1)
+	assert.True(t, res[0].Away)
+	assert.Equal(t, "Highway", res[0].Location)
+	assert.Equal(t, 0.5, *res[0].PricePerKWh)
+}
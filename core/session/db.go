@@ -10,6 +10,8 @@ type DB struct {
 	log  *util.Logger
 	db   *gorm.DB
 	name string
+
+	greenThreshold float64 // minimum green share (%) for green certification
 }
 
 // NewStore creates a session store
@@ -25,6 +27,11 @@ func NewStore(name string, db *gorm.DB) (*DB, error) {
 	return sessiondb, err
 }
 
+// Name returns the loadpoint name sessions are stored for
+func (s *DB) Name() string {
+	return s.name
+}
+
 // New creates a charging session
 func (s *DB) New(meter float64) *Session {
 	t := Session{
@@ -82,3 +89,23 @@ func (s *DB) ClosePendingSessionsInHistory(chargeMeterTotal float64) error {
 
 	return nil
 }
+
+// TypicalEnergy returns the average charged energy (kWh) of the loadpoint's most recent sessions
+func (s *DB) TypicalEnergy(n int) (float64, error) {
+	var res []float64
+	if tx := s.db.Model(new(Session)).Where("loadpoint = ? AND charged_kwh >= ?", s.name, 1).
+		Order("created DESC").Limit(n).Pluck("charged_kwh", &res); tx.Error != nil {
+		return 0, tx.Error
+	}
+
+	if len(res) == 0 {
+		return 0, nil
+	}
+
+	var sum float64
+	for _, v := range res {
+		sum += v
+	}
+
+	return sum / float64(len(res)), nil
+}
@@ -0,0 +1,41 @@
+package session
+
+// GreenSummary aggregates sessions certified as green charging
+type GreenSummary struct {
+	Sessions      int     `json:"sessions"`
+	Energy        float64 `json:"energy"`
+	GreenSessions int     `json:"greenSessions"`
+	GreenEnergy   float64 `json:"greenEnergy"`
+}
+
+// SetGreenThreshold sets the minimum green share (%) for sessions to be certified as green
+func (s *DB) SetGreenThreshold(threshold float64) {
+	s.greenThreshold = threshold
+}
+
+// Green returns the green certification for given green share or nil if certification is not configured
+func (s *DB) Green(greenShare float64) *bool {
+	if s.greenThreshold <= 0 {
+		return nil
+	}
+
+	res := greenShare >= s.greenThreshold
+	return &res
+}
+
+// GreenSummary returns the number and energy of all and of green certified sessions
+func (t Sessions) GreenSummary() GreenSummary {
+	var res GreenSummary
+
+	for _, s := range t {
+		res.Sessions++
+		res.Energy += s.ChargedEnergy
+
+		if s.Green != nil && *s.Green {
+			res.GreenSessions++
+			res.GreenEnergy += s.ChargedEnergy
+		}
+	}
+
+	return res
+}
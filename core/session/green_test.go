@@ -0,0 +1,33 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGreen(t *testing.T) {
+	db := new(DB)
+	assert.Nil(t, db.Green(100), "not configured")
+
+	db.SetGreenThreshold(80)
+	assert.Equal(t, lo.ToPtr(true), db.Green(80))
+	assert.Equal(t, lo.ToPtr(false), db.Green(79.9))
+}
+
+func TestGreenSummary(t *testing.T) {
+	res := Sessions{
+		{ChargedEnergy: 10, Green: lo.ToPtr(true)},
+		{ChargedEnergy: 5, Green: lo.ToPtr(false)},
+		{ChargedEnergy: 2},
+		{ChargedEnergy: 20, Green: lo.ToPtr(true)},
+	}.GreenSummary()
+
+	assert.Equal(t, GreenSummary{
+		Sessions:      4,
+		Energy:        37,
+		GreenSessions: 2,
+		GreenEnergy:   30,
+	}, res)
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"reflect"
+	"strconv"
 	"strings"
 	"time"
 
@@ -35,6 +36,18 @@ type Session struct {
 	Price           *float64       `json:"price" csv:"Price" gorm:"column:price"`
 	PricePerKWh     *float64       `json:"pricePerKWh" csv:"Price/kWh" gorm:"column:price_per_kwh"`
 	Co2PerKWh       *float64       `json:"co2PerKWh" csv:"CO2/kWh (gCO2eq)" gorm:"column:co2_per_kwh"`
+	Green           *bool          `json:"green,omitempty" csv:"Green Certified" gorm:"column:green"`
+	Notes           string         `json:"notes" csv:"Notes" gorm:"column:notes"`
+	Tags            string         `json:"tags" csv:"Tags" gorm:"column:tags"` // comma-separated, e.g. business or project codes
+
+	// signed meter values for calibration law compliant billing
+	SignedMeterStart  string `json:"signedMeterStart,omitempty" csv:"Signed Meter Start" gorm:"column:signed_meter_start"`
+	SignedMeterStop   string `json:"signedMeterStop,omitempty" csv:"Signed Meter Stop" gorm:"column:signed_meter_stop"`
+	SignatureVerified *bool  `json:"signatureVerified,omitempty" csv:"Signature Verified" gorm:"column:signature_verified"`
+
+	// sessions charged away from home, e.g. at public chargers
+	Away     bool   `json:"away,omitempty" csv:"Away"`
+	Location string `json:"location,omitempty" csv:"Location"`
 }
 
 // Sessions is a list of sessions
@@ -82,6 +95,8 @@ func formatValue(mp *message.Printer, value any, digits int) string {
 		return mp.Sprint(number.Decimal(v, number.NoSeparator(), number.MaxFractionDigits(digits)))
 	case *float64:
 		return mp.Sprint(number.Decimal(*v, number.NoSeparator(), number.MaxFractionDigits(digits)))
+	case *bool:
+		return strconv.FormatBool(*v)
 	case time.Time:
 		if v.IsZero() {
 			return ""
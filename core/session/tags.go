@@ -0,0 +1,37 @@
+package session
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ParseTags converts a comma-separated string or list of tags into a normalized, comma-separated tag list
+func ParseTags(val any) (string, error) {
+	var tags []string
+
+	switch v := val.(type) {
+	case nil:
+	case string:
+		tags = strings.Split(v, ",")
+	case []any:
+		for _, t := range v {
+			s, ok := t.(string)
+			if !ok {
+				return "", fmt.Errorf("invalid tag: %v", t)
+			}
+			tags = append(tags, s)
+		}
+	default:
+		return "", fmt.Errorf("invalid tags: %v", val)
+	}
+
+	var res []string
+	for _, t := range tags {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" && !slices.Contains(res, t) {
+			res = append(res, t)
+		}
+	}
+
+	return strings.Join(res, ","), nil
+}
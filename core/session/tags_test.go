@@ -0,0 +1,31 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTags(t *testing.T) {
+	for _, tc := range []struct {
+		in  any
+		out string
+	}{
+		{nil, ""},
+		{"", ""},
+		{"Business", "business"},
+		{" business, P-123 ,,business", "business,p-123"},
+		{[]any{"private", " Trip "}, "private,trip"},
+	} {
+		res, err := ParseTags(tc.in)
+		require.NoError(t, err)
+		assert.Equal(t, tc.out, res, tc.in)
+	}
+
+	_, err := ParseTags([]any{1})
+	assert.Error(t, err)
+
+	_, err = ParseTags(42)
+	assert.Error(t, err)
+}
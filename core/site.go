@@ -1,8 +1,11 @@
 package core
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"math"
 	"strings"
 	"sync"
@@ -14,21 +17,26 @@ import (
 	"github.com/evcc-io/evcc/cmd/shutdown"
 	"github.com/evcc-io/evcc/core/circuit"
 	"github.com/evcc-io/evcc/core/coordinator"
+	"github.com/evcc-io/evcc/core/eventlog"
 	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/planner"
+	"github.com/evcc-io/evcc/core/pricelog"
 	"github.com/evcc-io/evcc/core/prioritizer"
 	"github.com/evcc-io/evcc/core/session"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/core/soc"
 	"github.com/evcc-io/evcc/core/vehicle"
+	"github.com/evcc-io/evcc/plugin"
 	"github.com/evcc-io/evcc/push"
 	"github.com/evcc-io/evcc/server/db"
 	"github.com/evcc-io/evcc/server/db/settings"
 	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/chart"
 	"github.com/evcc-io/evcc/util/config"
 	"github.com/evcc-io/evcc/util/telemetry"
+	"github.com/jinzhu/now"
 	"github.com/samber/lo"
 	"github.com/smallnest/chanx"
 	"golang.org/x/sync/errgroup"
@@ -46,6 +54,7 @@ type updater interface {
 type measurement struct {
 	Power         float64   `json:"power"`
 	Energy        float64   `json:"energy,omitempty"`
+	Gas           float64   `json:"gas,omitempty"`
 	Powers        []float64 `json:"powers,omitempty"`
 	Currents      []float64 `json:"currents,omitempty"`
 	ExcessDCPower float64   `json:"excessdcpower,omitempty"`
@@ -59,6 +68,7 @@ var _ site.API = (*Site)(nil)
 // Site is the main configuration container. A site can host multiple loadpoints.
 type Site struct {
 	uiChan       chan<- util.Param // client push messages
+	pushChan     chan<- push.Event // notifications
 	lpUpdateChan chan *Loadpoint
 
 	*Health
@@ -75,6 +85,27 @@ type Site struct {
 	CircuitRef_                        string  `mapstructure:"circuit"`                           // Circuit reference
 	MaxGridSupplyWhileBatteryCharging_ float64 `mapstructure:"maxGridSupplyWhileBatteryCharging"` // ignore battery charging if AC consumption is above this value
 
+	BatteryExport BatteryExportConfig `mapstructure:"batteryExport"` // Battery discharge to grid
+	BatteryCost   BatteryCostConfig   `mapstructure:"batteryCost"`   // Battery energy pricing
+	PvAnomaly     PvAnomalyConfig     `mapstructure:"pvAnomaly"`     // PV production vs. forecast monitoring
+	SolarForecast SolarForecastConfig `mapstructure:"solarForecast"` // Solar forecast adjustment to actual production
+	GridBudget    GridBudgetConfig    `mapstructure:"gridBudget"`    // Daily grid energy for charging
+	Co2Budget     Co2BudgetConfig     `mapstructure:"co2Budget"`     // Monthly co2 emissions of charging
+	ExportLimit   ExportLimitConfig   `mapstructure:"exportLimit"`   // Feed-in limitation at grid connection point
+	NegativePrice NegativePriceConfig `mapstructure:"negativePrice"` // Grid charging and feed-in curtailment at negative prices
+	DemandCharge  DemandChargeConfig  `mapstructure:"demandCharge"`  // Charging limits avoiding new demand peaks
+
+	GreenCertificate GreenCertificateConfig `mapstructure:"greenCertificate"` // Green charging session tagging
+	BatteryWarranty  BatteryWarrantyConfig  `mapstructure:"batteryWarranty"`  // Battery wear by grid charging and export
+	InverterStandby  InverterStandbyConfig  `mapstructure:"inverterStandby"`  // Inverter standby without production
+	AdaptiveInterval AdaptiveIntervalConfig `mapstructure:"adaptiveInterval"` // Update interval depending on control activity
+	Allocation       prioritizer.Objective  `mapstructure:"allocation"`       // Surplus allocation objective among loadpoints of equal priority
+	CrossDischarge   CrossDischarge         `mapstructure:"crossDischarge"`   // Battery hold preventing discharge into vehicle charging
+
+	ResidualPowerSchedule []ResidualPowerPeriod `mapstructure:"residualPowerSchedule"` // Residual power by time of day
+	ResidualPowerSource   *plugin.Config        `mapstructure:"residualPowerSource"`   // Dynamic residual power
+	PanelsCoveredSource   *plugin.Config        `mapstructure:"panelsCoveredSource"`   // Sensor indicating pv panels covered e.g. by snow
+
 	// meters
 	circuit       api.Circuit // Circuit
 	gridMeter     api.Meter   // Grid usage meter
@@ -89,6 +120,7 @@ type Site struct {
 	bufferStartSoc          float64  // start charging on battery above this Soc
 	batteryDischargeControl bool     // prevent battery discharge for fast and planned charging
 	batteryGridChargeLimit  *float64 // grid charging limit
+	batteryExportLimit      *float64 // feed-in price above which battery is discharged to grid
 
 	loadpoints  []*Loadpoint             // Loadpoints
 	tariffs     *tariff.Tariffs          // Tariffs
@@ -104,6 +136,50 @@ type Site struct {
 	batteryPower  float64         // Battery power (charge negative, discharge positive)
 	batterySoc    float64         // Battery soc
 	batteryMode   api.BatteryMode // Battery mode (runtime only, not persisted)
+
+	batteryModeExternal api.BatteryMode // Battery mode requested by external system (runtime only, not persisted)
+	batteryCapacity     float64         // Battery capacity (kWh)
+	panelsCovered       bool            // PV panels covered e.g. by snow, set manually
+	panelsCoveredSensor bool            // PV panels covered e.g. by snow, set by sensor
+
+	greenPowerSamples     []greenPowerSample // pv and battery power samples for green share smoothing
+	batteryCost           BatteryCost        // price of energy stored in battery
+	batteryExport         batteryExport      // battery energy exported to grid today
+	batteryWarranty       batteryWarranty    // battery throughput counters
+	gridStressed          bool               // grid state indicates stress
+	updated               time.Time          // last control step
+	gridBudget            gridBudget         // grid energy used for charging today
+	gridBudgetExceeded    bool               // daily grid budget exhausted
+	co2Budget             co2Budget          // co2 emitted by charging this month
+	co2BudgetExceeded     bool               // monthly co2 budget exhausted
+	tariffDigestDay       time.Time          // day of last tariff digest notification
+	awaySessionsDay       time.Time          // day of last away sessions import
+	recommendationUpdated time.Time          // last plug-in recommendation update
+	forecast              []byte             // last published forecast
+	solarRates            api.Rates          // last adjusted solar forecast
+	pvAnomaly             pvAnomaly          // pv production vs. forecast
+	solarForecast         solarForecast      // pv production vs. forecast today
+	faults                []deviceFault      // active device faults
+	faultsUpdated         time.Time          // last fault register poll
+	meterOffsets          map[string]float64 // energy counter corrections of replaced meters by reference (kWh)
+	flow                  chart.Flow         // current power flow for snapshots
+
+	gridAvailability eventlog.Availability // grid meter online/offline
+	tierConsumption  tierConsumption       // grid import of tiered tariff's billing period
+	exportLimit      exportLimit           // feed-in limitation state
+	negativePrice    negativePrice         // negative price handling state
+	forecastAccuracy forecastAccuracy      // daily solar forecast error
+	inverterStandby  inverterStandby       // inverter standby state and savings
+	pvPowers         []float64             // individual pv meter powers
+	batteryPowers    []float64             // individual battery meter powers
+	batteryStored    []*float64            // individual battery stored energy (kWh), nil without capacity
+	batteryGreen     []storageGreen        // green energy stored by battery meter
+	vehicleGreen     []storageGreen        // green energy stored in vehicles by loadpoint
+	planeForecasts   []solarForecast       // pv production vs. forecast today by solar plane
+	solarCorrection  solarCorrection       // learned solar forecast error by season and hour
+
+	residualPowerG func() (float64, error) // dynamic residual power
+	panelsCoveredG func() (bool, error)    // pv panels covered sensor
 }
 
 // MetersConfig contains the site's meter configuration
@@ -124,6 +200,27 @@ func NewSiteFromConfig(other map[string]interface{}) (*Site, error) {
 		return nil, err
 	}
 
+	if err := site.configureResidualPower(context.TODO(), site.ResidualPowerSource); err != nil {
+		return nil, err
+	}
+
+	if err := site.configurePanelsCovered(context.TODO(), site.PanelsCoveredSource); err != nil {
+		return nil, err
+	}
+
+	if err := site.Allocation.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := site.CrossDischarge.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := site.BatteryCost.Validate(); err != nil {
+		return nil, err
+	}
+	site.batteryCost.efficiency = site.BatteryCost.Efficiency
+
 	// add meters from config
 	site.restoreMetersAndTitle()
 
@@ -141,8 +238,14 @@ func (site *Site) Boot(log *util.Logger, loadpoints []*Loadpoint, tariffs *tarif
 	site.coordinator = coordinator.New(log, config.Instances(handler.Devices()))
 	handler.Subscribe(site.updateVehicles)
 
-	site.prioritizer = prioritizer.New(log)
+	for _, dev := range handler.Devices() {
+		site.restoreVehicleSoc(dev)
+	}
+
+	site.prioritizer = prioritizer.New(log, site.Allocation)
 	site.stats = NewStats()
+	site.stats.co2Budget = site.Co2Budget.Mass
+	site.stats.inverterStandby = &site.inverterStandby
 
 	// upload telemetry on shutdown
 	if telemetry.Enabled() {
@@ -151,18 +254,21 @@ func (site *Site) Boot(log *util.Logger, loadpoints []*Loadpoint, tariffs *tarif
 		})
 	}
 
-	tariff := site.GetTariff(api.TariffUsagePlanner)
-
 	// give loadpoints access to vehicles and database
-	for _, lp := range loadpoints {
+	for id, lp := range loadpoints {
+		lp.id = id + 1
+		lp.name = loadpointName(lp)
+
 		lp.coordinator = coordinator.NewAdapter(lp, site.coordinator)
-		lp.planner = planner.New(lp.log, tariff)
+		lp.planner = planner.New(lp.log, site.heatingTariff(lp, site.plannerTariff(lp)))
+		lp.comparison = site.comparisonTariffs(lp)
 
 		if db.Instance != nil {
 			var err error
 			if lp.db, err = session.NewStore(lp.GetTitle(), db.Instance); err != nil {
 				return err
 			}
+			lp.db.SetGreenThreshold(site.GreenCertificate.Threshold)
 			// Fix any dangling history
 			if err := lp.db.ClosePendingSessionsInHistory(lp.chargeMeterTotal()); err != nil {
 				return err
@@ -295,6 +401,11 @@ func (site *Site) restoreSettings() error {
 			return err
 		}
 	}
+	if v, err := settings.Bool(keys.PanelsCovered); err == nil {
+		if err := site.SetPanelsCovered(v); err != nil {
+			return err
+		}
+	}
 	if v, err := settings.Bool(keys.BatteryDischargeControl); err == nil {
 		if err := site.SetBatteryDischargeControl(v); err != nil {
 			return err
@@ -308,6 +419,56 @@ func (site *Site) restoreSettings() error {
 	if v, err := settings.Float(keys.BatteryGridChargeLimit); err == nil {
 		site.SetBatteryGridChargeLimit(&v)
 	}
+	if v, err := settings.Float(keys.BatteryExportLimit); err == nil {
+		if err := site.SetBatteryExportLimit(&v); err != nil {
+			site.log.WARN.Println("battery export:", err)
+		}
+	}
+	if err := settings.Json(keys.BatteryCost, &site.batteryCost); err == nil {
+		if price, ok := site.batteryCost.Price(); ok {
+			site.publish(keys.BatteryPrice, price)
+		}
+	}
+	if err := settings.Json(keys.BatteryExport, &site.batteryExport); err == nil && site.batteryExport.Day.Equal(now.BeginningOfDay()) {
+		site.publish(keys.BatteryExportEnergy, site.batteryExport.Energy)
+		site.publish(keys.BatteryExportRevenue, site.batteryExport.Revenue)
+	}
+	if err := settings.Json(keys.GridBudget, &site.gridBudget); err == nil && site.gridBudget.Day.Equal(now.BeginningOfDay()) {
+		site.publish(keys.GridBudgetEnergy, site.gridBudget.Energy)
+	}
+	var green storageGreenState
+	if err := settings.Json(keys.StorageGreen, &green); err == nil {
+		site.batteryGreen, site.vehicleGreen = green.Battery, green.Vehicle
+	}
+	if err := settings.Json(keys.MeterOffsets, &site.meterOffsets); err == nil {
+		site.publish(keys.MeterOffsets, maps.Clone(site.meterOffsets))
+	}
+	if err := settings.Json(keys.TierConsumption, &site.tierConsumption); err == nil {
+		site.publish(keys.TierConsumption, site.tierConsumption.Energy)
+	}
+	if err := settings.Json(keys.SolarForecast, &site.solarForecast); err == nil && site.solarForecast.Day.Equal(now.BeginningOfDay()) {
+		site.publish(keys.ForecastedToday, site.solarForecast.ForecastEnergy)
+		site.publish(keys.YieldToday, site.solarForecast.PvEnergy)
+		site.publishSolarForecast()
+	}
+	if err := settings.Json(keys.SolarForecastPlanes, &site.planeForecasts); err == nil && site.SolarForecast.Planes {
+		site.publish(keys.ForecastPlaneScales, site.planeScales())
+	}
+	if err := settings.Json(keys.Co2Budget, &site.co2Budget); err == nil && site.co2Budget.Month.Equal(now.BeginningOfMonth()) {
+		site.publish(keys.Co2BudgetMass, site.co2Budget.Mass)
+	}
+	if err := settings.Json(keys.SolarCorrection, &site.solarCorrection); err != nil {
+		site.solarCorrection = solarCorrection{} // discard partially decoded data
+	}
+	if err := settings.Json(keys.BatteryWarranty, &site.batteryWarranty); err != nil {
+		site.batteryWarranty = batteryWarranty{} // discard partially decoded data
+	}
+	if err := settings.Json(keys.InverterSavings, &site.inverterStandby); err != nil {
+		site.inverterStandby = inverterStandby{} // discard partially decoded data
+	}
+	if err := settings.Json(keys.ForecastAccuracy, &site.forecastAccuracy); err == nil {
+		site.publish(keys.ForecastAccuracy, site.forecastAccuracy.metrics()[forecastEffective])
+	}
 
 	return nil
 }
@@ -375,8 +536,9 @@ func (site *Site) DumpConfig() {
 			_, status := v.(api.ChargeState)
 			_, climate := v.(api.VehicleClimater)
 			_, wakeup := v.(api.Resurrector)
-			site.log.INFO.Printf("    vehicle %d: range %s finish %s status %s climate %s wakeup %s",
-				i+1, presence[rng], presence[finish], presence[status], presence[climate], presence[wakeup],
+			_, aux := deviceFeature[api.VehicleAuxiliary](v, api.Auxiliary)
+			site.log.INFO.Printf("    vehicle %d: range %s finish %s status %s climate %s wakeup %s auxiliary %s",
+				i+1, presence[rng], presence[finish], presence[status], presence[climate], presence[wakeup], presence[aux],
 			)
 		}
 	}
@@ -391,7 +553,10 @@ func (site *Site) DumpConfig() {
 	site.log.INFO.Printf("    grid:      %s", trf(api.TariffUsageGrid))
 	site.log.INFO.Printf("    feed-in:   %s", trf(api.TariffUsageFeedIn))
 	site.log.INFO.Printf("    co2:       %s", trf(api.TariffUsageCo2))
+	site.log.INFO.Printf("    co2 marg.: %s", trf(api.TariffUsageCo2Marginal))
 	site.log.INFO.Printf("    solar:     %s", trf(api.TariffUsageSolar))
+	site.log.INFO.Printf("    gridstate: %s", trf(api.TariffUsageGridState))
+	site.log.INFO.Printf("    temp:      %s", trf(api.TariffUsageTemperature))
 
 	for i, lp := range site.loadpoints {
 		lp.log.INFO.Printf("loadpoint %d:", i+1)
@@ -416,6 +581,10 @@ func (site *Site) DumpConfig() {
 		if lp.HasChargeMeter() {
 			lp.log.INFO.Println(meterCapabilities("charge", lp.chargeMeter))
 		}
+
+		if lp.tariff != nil {
+			lp.log.INFO.Printf("  tariff:      %s", lp.tariff.Type())
+		}
 	}
 }
 
@@ -429,7 +598,7 @@ func (site *Site) publish(key string, val interface{}) {
 	site.uiChan <- util.Param{Key: key, Val: val}
 }
 
-func (site *Site) collectMeters(key string, meters []api.Meter) []measurement {
+func (site *Site) collectMeters(key string, refs []string, meters []api.Meter) []measurement {
 	var wg sync.WaitGroup
 	mm := make([]measurement, len(meters))
 
@@ -448,6 +617,8 @@ func (site *Site) collectMeters(key string, meters []api.Meter) []measurement {
 			energy, err = m.TotalEnergy()
 			if err != nil {
 				site.log.ERROR.Printf("%s %d energy: %v", key, i+1, err)
+			} else if i < len(refs) {
+				energy = site.meterEnergy(refs[i], energy)
 			}
 		}
 
@@ -474,7 +645,7 @@ func (site *Site) updatePvMeters() {
 		return
 	}
 
-	mm := site.collectMeters("pv", site.pvMeters)
+	mm := site.collectMeters("pv", site.Meters.PVMetersRef, site.pvMeters)
 
 	for i, meter := range site.pvMeters {
 		power := mm[i].Power
@@ -510,6 +681,10 @@ func (site *Site) updatePvMeters() {
 		site.log.DEBUG.Printf("pv power: %.0fW"+excessStr, site.pvPower)
 	}
 
+	site.pvPowers = lo.Map(mm, func(m measurement, _ int) float64 {
+		return max(0, m.Power)
+	})
+
 	site.publish(keys.PvPower, site.pvPower)
 	site.publish(keys.PvEnergy, totalEnergy)
 	site.publish(keys.Pv, mm)
@@ -521,7 +696,7 @@ func (site *Site) updateBatteryMeters() {
 		return
 	}
 
-	mm := site.collectMeters("battery", site.batteryMeters)
+	mm := site.collectMeters("battery", site.Meters.BatteryMetersRef, site.batteryMeters)
 
 	for i, meter := range site.batteryMeters {
 		// battery soc and capacity
@@ -559,12 +734,23 @@ func (site *Site) updateBatteryMeters() {
 		return *m.Capacity
 	})
 
+	site.batteryCapacity = totalCapacity
+
 	// convert weighed socs to total soc
 	if totalCapacity == 0 {
 		totalCapacity = float64(len(site.batteryMeters))
 	}
 	site.batterySoc = batterySocAcc / totalCapacity
 
+	site.batteryPowers = lo.Map(mm, func(m measurement, _ int) float64 {
+		return m.Power
+	})
+	site.batteryStored = lo.Map(mm, func(m measurement, _ int) *float64 {
+		if *m.Capacity > 0 {
+			return lo.ToPtr(*m.Soc * *m.Capacity / 100)
+		}
+		return nil
+	})
 	site.batteryPower = lo.SumBy(mm, func(m measurement) float64 {
 		return m.Power
 	})
@@ -591,7 +777,7 @@ func (site *Site) updateAuxMeters() {
 		return
 	}
 
-	mm := site.collectMeters("aux", site.auxMeters)
+	mm := site.collectMeters("aux", site.Meters.AuxMetersRef, site.auxMeters)
 	site.auxPower = lo.SumBy(mm, func(m measurement) float64 {
 		return m.Power
 	})
@@ -610,7 +796,7 @@ func (site *Site) updateExtMeters() {
 		return
 	}
 
-	mm := site.collectMeters("ext", site.extMeters)
+	mm := site.collectMeters("ext", site.Meters.ExtMetersRef, site.extMeters)
 	site.publish(keys.Ext, mm)
 }
 
@@ -655,12 +841,21 @@ func (site *Site) updateGridMeter() error {
 	// grid energy (import)
 	if energyMeter, ok := site.gridMeter.(api.MeterEnergy); ok {
 		if f, err := energyMeter.TotalEnergy(); err == nil {
-			mm.Energy = f
+			mm.Energy = site.meterEnergy(site.Meters.GridMeterRef, f)
 		} else {
 			site.log.ERROR.Printf("grid energy: %v", err)
 		}
 	}
 
+	// gas meter attached to smart meter
+	if gasMeter, ok := site.gridMeter.(api.MeterGas); ok {
+		if f, err := gasMeter.TotalGas(); err == nil {
+			mm.Gas = f
+		} else {
+			site.log.ERROR.Printf("grid gas: %v", err)
+		}
+	}
+
 	site.publish(keys.Grid, mm)
 
 	return nil
@@ -674,7 +869,13 @@ func (site *Site) updateMeters() error {
 	eg.Go(func() error { site.updateAuxMeters(); return nil })
 	eg.Go(func() error { site.updateExtMeters(); return nil })
 
-	eg.Go(site.updateGridMeter)
+	eg.Go(func() error {
+		err := site.updateGridMeter()
+		if site.gridMeter != nil {
+			site.gridAvailability.Update(site.Meters.GridMeterRef, err)
+		}
+		return err
+	})
 
 	return eg.Wait()
 }
@@ -695,7 +896,7 @@ func (site *Site) sitePower(totalChargePower, flexiblePower float64) (float64, b
 	}
 
 	// ensure safe default for residual power
-	residualPower := site.GetResidualPower()
+	residualPower := site.effectiveResidualPower()
 	if len(site.batteryMeters) > 0 && site.batterySoc < site.prioritySoc && residualPower <= 0 {
 		residualPower = 100 // Wsite.publish(keys.PvPower,
 	}
@@ -731,6 +932,11 @@ func (site *Site) sitePower(totalChargePower, flexiblePower float64) (float64, b
 			batteryBuffered = site.bufferSoc > 0 && site.batterySoc > site.bufferSoc
 			batteryStart = site.bufferStartSoc > 0 && site.batterySoc > site.bufferStartSoc
 		}
+
+		// battery is held while charging, don't rely on it for pv charging
+		if site.CrossDischarge == CrossDischargeAll {
+			batteryBuffered, batteryStart = false, false
+		}
 	}
 
 	sitePower := site.gridPower + batteryPower + excessDCPower + residualPower - site.auxPower - flexiblePower
@@ -743,6 +949,10 @@ func (site *Site) sitePower(totalChargePower, flexiblePower float64) (float64, b
 
 	site.log.DEBUG.Printf("site power: %.0fW"+flexStr, sitePower)
 
+	// instantaneous grid export vs. surplus after residual power, battery priority, aux and prioritized power
+	site.publish(keys.SurplusRaw, -site.gridPower)
+	site.publish(keys.Surplus, -sitePower)
+
 	return sitePower, batteryBuffered, batteryStart, nil
 }
 
@@ -750,8 +960,7 @@ func (site *Site) sitePower(totalChargePower, flexiblePower float64) (float64, b
 //   - the current green share, calculated for the part of the consumption between powerFrom and powerTo
 //     the consumption below powerFrom will get the available green power first
 func (site *Site) greenShare(powerFrom float64, powerTo float64) float64 {
-	greenPower := math.Max(0, site.pvPower) + math.Max(0, site.batteryPower)
-	greenPowerAvailable := math.Max(0, greenPower-powerFrom)
+	greenPowerAvailable := math.Max(0, site.greenPower()-powerFrom)
 
 	power := powerTo - powerFrom
 	share := math.Min(greenPowerAvailable, power) / power
@@ -768,18 +977,97 @@ func (site *Site) greenShare(powerFrom float64, powerTo float64) float64 {
 }
 
 // effectivePrice calculates the real energy price based on self-produced and grid-imported energy.
+// Energy discharged from battery is priced at the average price it was charged at including round-trip losses.
 func (site *Site) effectivePrice(greenShare float64) *float64 {
-	if grid, err := tariff.Now(site.GetTariff(api.TariffUsageGrid)); err == nil {
+	return site.effectivePriceWith(site.GetTariff(api.TariffUsageGrid), greenShare)
+}
+
+// loadpointTariff returns the loadpoint's grid tariff override if configured
+func loadpointTariff(lp updater) api.Tariff {
+	if lp, ok := lp.(*Loadpoint); ok {
+		return lp.tariff
+	}
+	return nil
+}
+
+// loadpointEffectivePrice calculates the effective price using the loadpoint's tariff override if configured
+func (site *Site) loadpointEffectivePrice(lp updater, greenShare float64) *float64 {
+	if t := loadpointTariff(lp); t != nil {
+		return site.effectivePriceWith(t, greenShare)
+	}
+	return site.effectivePrice(greenShare)
+}
+
+// effectivePriceWith calculates the effective price for given grid tariff
+func (site *Site) effectivePriceWith(gridTariff api.Tariff, greenShare float64) *float64 {
+	if grid, err := tariff.Now(gridTariff); err == nil {
+		// demand charge for raising the billing period's peak
+		if dt, ok := tariff.As[api.DemandTariff](gridTariff); ok {
+			grid += dt.DemandSurcharge(max(0, site.gridPower))
+		}
+
 		feedin, err := tariff.Now(site.GetTariff(api.TariffUsageFeedIn))
 		if err != nil {
 			feedin = 0
 		}
-		effPrice := grid*(1-greenShare) + feedin*greenShare
+
+		greenPrice := feedin
+		if batteryPrice, ok := site.batteryCost.Price(); ok {
+			if pv, battery := site.greenPowers(); pv+battery > 0 {
+				batteryShare := battery / (pv + battery)
+				greenPrice = feedin*(1-batteryShare) + batteryPrice*batteryShare
+			}
+		}
+
+		effPrice := grid*(1-greenShare) + greenPrice*greenShare
 		return &effPrice
 	}
 	return nil
 }
 
+// loadpointRates returns the loadpoint's tariff override rates if configured
+func (site *Site) loadpointRates(lp updater, rates api.Rates) api.Rates {
+	t := loadpointTariff(lp)
+	if t == nil {
+		return rates
+	}
+
+	res, err := t.Rates()
+	if err != nil {
+		site.log.ERROR.Printf("loadpoint tariff: %v", err)
+		return nil
+	}
+
+	return res
+}
+
+// updateBatteryCost accounts energy charged into or discharged from the battery
+func (site *Site) updateBatteryCost() {
+	if !site.batteryConfigured() {
+		return
+	}
+
+	grid, err := tariff.Now(site.GetTariff(api.TariffUsageGrid))
+	if err != nil {
+		return
+	}
+
+	feedin, err := tariff.Now(site.GetTariff(api.TariffUsageFeedIn))
+	if err != nil {
+		feedin = 0
+	}
+
+	site.batteryCost.Update(time.Now(), site.batteryPower, site.gridPower, grid, feedin, site.batteryCapacity)
+
+	if err := settings.SetJson(keys.BatteryCost, site.batteryCost); err != nil {
+		site.log.ERROR.Println("battery cost:", err)
+	}
+
+	if price, ok := site.batteryCost.Price(); ok {
+		site.publish(keys.BatteryPrice, price)
+	}
+}
+
 // effectiveCo2 calculates the amount of emitted co2 based on self-produced and grid-imported energy.
 func (site *Site) effectiveCo2(greenShare float64) *float64 {
 	if co2, err := tariff.Now(site.GetTariff(api.TariffUsageCo2)); err == nil {
@@ -795,9 +1083,14 @@ func (site *Site) publishTariffs(greenShareHome float64, greenShareLoadpoints fl
 
 	if v, err := tariff.Now(site.GetTariff(api.TariffUsageGrid)); err == nil {
 		site.publish(keys.TariffGrid, v)
+		pricelog.Record(pricelog.UsageGrid, v)
+	}
+	if v, err := tariff.RawNow(site.GetTariff(api.TariffUsageGrid)); err == nil {
+		site.publish(keys.TariffGridRaw, v)
 	}
 	if v, err := tariff.Now(site.GetTariff(api.TariffUsageFeedIn)); err == nil {
 		site.publish(keys.TariffFeedIn, v)
+		pricelog.Record(pricelog.UsageFeedIn, v)
 	}
 	if v, err := tariff.Now(site.GetTariff(api.TariffUsageCo2)); err == nil {
 		site.publish(keys.TariffCo2, v)
@@ -819,17 +1112,37 @@ func (site *Site) publishTariffs(greenShareHome float64, greenShareLoadpoints fl
 	}
 
 	// forecast
-	site.publish(keys.Forecast, struct {
-		Co2    api.Rates `json:"co2,omitempty"`
-		FeedIn api.Rates `json:"feedin,omitempty"`
-		Grid   api.Rates `json:"grid,omitempty"`
-		Solar  api.Rates `json:"solar,omitempty"`
+	solar, planes := site.solarForecastRates()
+
+	site.Lock()
+	site.solarRates = solar
+	site.Unlock()
+
+	site.publishSolarRemaining(solar)
+
+	fc := struct {
+		Co2       api.Rates   `json:"co2,omitempty"`
+		FeedIn    api.Rates   `json:"feedin,omitempty"`
+		Grid      api.Rates   `json:"grid,omitempty"`
+		GridRaw   api.Rates   `json:"gridRaw,omitempty"`
+		Solar     api.Rates   `json:"solar,omitempty"`
+		Planes    []api.Rates `json:"planes,omitempty"`
+		GridState api.Rates   `json:"gridState,omitempty"`
 	}{
-		Co2:    tariff.Forecast(site.GetTariff(api.TariffUsageCo2)),
-		FeedIn: tariff.Forecast(site.GetTariff(api.TariffUsageFeedIn)),
-		Grid:   tariff.Forecast(site.GetTariff(api.TariffUsageGrid)),
-		Solar:  tariff.Forecast(site.GetTariff(api.TariffUsageSolar)),
-	})
+		Co2:       tariff.Merge(tariff.Forecast(site.GetTariff(api.TariffUsageCo2))),
+		FeedIn:    tariff.Merge(tariff.Forecast(site.GetTariff(api.TariffUsageFeedIn))),
+		Grid:      tariff.Merge(tariff.Forecast(site.GetTariff(api.TariffUsageGrid))),
+		GridRaw:   tariff.Merge(tariff.RawForecast(site.GetTariff(api.TariffUsageGrid))),
+		Solar:     solar,
+		Planes:    planes,
+		GridState: tariff.Forecast(site.GetTariff(api.TariffUsageGridState)),
+	}
+
+	// publish only on change to save bandwidth
+	if b, err := json.Marshal(fc); err != nil || !bytes.Equal(b, site.forecast) {
+		site.forecast = b
+		site.publish(keys.Forecast, fc)
+	}
 }
 
 // updateLoadpoints updates all loadpoints' charge power
@@ -860,6 +1173,7 @@ func (site *Site) updateLoadpoints(rates api.Rates) float64 {
 
 func (site *Site) update(lp updater) {
 	site.log.DEBUG.Println("----")
+	site.updated = time.Now()
 
 	// smart cost and battery mode handling
 	rates, err := site.plannerRates()
@@ -867,6 +1181,9 @@ func (site *Site) update(lp updater) {
 		site.log.WARN.Println("planner:", err)
 	}
 
+	// force charging at negative prices
+	site.updateNegativePrice()
+
 	// update loadpoints
 	totalChargePower := site.updateLoadpoints(rates)
 
@@ -901,13 +1218,7 @@ func (site *Site) update(lp updater) {
 	batteryGridChargeActive := site.batteryGridChargeActive(rate)
 	site.publish(keys.BatteryGridChargeActive, batteryGridChargeActive)
 
-	if batteryMode := site.requiredBatteryMode(batteryGridChargeActive, rate); batteryMode != api.BatteryUnknown {
-		if err := site.applyBatteryMode(batteryMode); err == nil {
-			site.SetBatteryMode(batteryMode)
-		} else {
-			site.log.ERROR.Println("battery mode:", err)
-		}
-	}
+	site.updateBatteryMode(batteryGridChargeActive, rate)
 
 	if sitePower, batteryBuffered, batteryStart, err := site.sitePower(totalChargePower, flexiblePower); err == nil {
 		// ignore negative pvPower values as that means it is not an energy source but consumption
@@ -915,17 +1226,58 @@ func (site *Site) update(lp updater) {
 		homePower = max(homePower, 0)
 		site.publish(keys.HomePower, homePower)
 
+		site.setFlow(chart.Flow{
+			Pv:         max(0, site.pvPower),
+			Battery:    site.batteryPower,
+			Grid:       site.gridPower,
+			Home:       homePower,
+			Loadpoints: totalChargePower,
+		})
+
 		// add battery charging power to homePower to ignore all consumption which does not occur on loadpoints
 		// fix for: https://github.com/evcc-io/evcc/issues/11032
 		nonChargePower := homePower + max(0, -site.batteryPower)
+		site.addGreenPowerSample(time.Now())
+		site.updateStorageGreen(homePower, nonChargePower)
+		site.updateBatteryCost()
+		site.updateBatteryExport()
+		site.updateBatteryWarranty()
+		site.updateGridState()
+		site.updateGridBudget(totalChargePower)
+		site.updateExportLimit()
+		site.updateInverterStandby()
+		site.updateDemand()
+		site.updateTierConsumption()
+		site.updateTariffDigest()
+		site.updateAwaySessions()
+		site.updateRecommendations()
+		site.updatePanelsCovered()
+		site.updatePvAnomaly()
+		site.updateSolarForecast()
+		site.updateForecastAccuracy()
+		site.recordProduction()
+		site.updateFaults()
 		greenShareHome := site.greenShare(0, homePower)
 		greenShareLoadpoints := site.greenShare(nonChargePower, nonChargePower+totalChargePower)
+		greenShareLoadpoint := site.loadpointGreenShare(lp, nonChargePower)
+		site.updateCo2Budget(totalChargePower, greenShareLoadpoints)
+
+		// keep forecasted solar power reserved for other loadpoints
+		if reserved := site.reservedPower(lp, sitePower, totalChargePower); reserved > 0 {
+			site.log.DEBUG.Printf("reserved power: %.0fW", reserved)
+			sitePower += reserved
+		}
 
 		lp.Update(
-			sitePower, max(0, site.batteryPower), rates, batteryBuffered, batteryStart,
-			greenShareLoadpoints, site.effectivePrice(greenShareLoadpoints), site.effectiveCo2(greenShareLoadpoints),
+			sitePower, max(0, site.batteryPower), site.loadpointRates(lp, rates), batteryBuffered, batteryStart,
+			greenShareLoadpoint, site.loadpointEffectivePrice(lp, greenShareLoadpoint), site.effectiveCo2(greenShareLoadpoint),
 		)
 
+		// hold battery in the same cycle the loadpoint got enabled
+		if site.CrossDischarge != CrossDischargeAllow {
+			site.updateBatteryMode(batteryGridChargeActive, rate)
+		}
+
 		site.Health.Update()
 
 		site.publishTariffs(greenShareHome, greenShareLoadpoints)
@@ -959,6 +1311,7 @@ func (site *Site) prepare() {
 	site.publish(keys.BufferStartSoc, site.bufferStartSoc)
 	site.publish(keys.BatteryMode, site.batteryMode)
 	site.publish(keys.BatteryDischargeControl, site.batteryDischargeControl)
+	site.publish(keys.PanelsCovered, site.GetPanelsCovered())
 	site.publish(keys.ResidualPower, site.GetResidualPower())
 
 	site.publish(keys.Currency, site.tariffs.Currency)
@@ -982,6 +1335,7 @@ func (site *Site) Prepare(uiChan chan<- util.Param, pushChan chan<- push.Event)
 
 	// use ch.In for writing
 	site.uiChan = ch.In
+	site.pushChan = pushChan
 
 	// use ch.Out for reading
 	go func() {
@@ -1028,7 +1382,7 @@ func (site *Site) loopLoadpoints(next chan<- updater) {
 // Run is the main control loop. It reacts to trigger events by
 // updating measurements and executing control logic.
 func (site *Site) Run(stopC chan struct{}, interval time.Duration) {
-	site.Health = NewHealth(time.Minute + interval)
+	site.Health = NewHealth(time.Minute + max(interval, site.AdaptiveInterval.Idle))
 
 	if max := 30 * time.Second; interval < max {
 		site.log.WARN.Printf("interval <%.0fs can lead to unexpected behavior, see https://docs.evcc.io/docs/reference/configuration/interval", max.Seconds())
@@ -1039,10 +1393,21 @@ func (site *Site) Run(stopC chan struct{}, interval time.Duration) {
 
 	site.update(<-loadpointChan) // start immediately
 
+	regulationTick := site.regulationTicker(interval)
+
 	for tick := time.Tick(interval); ; {
 		select {
 		case <-tick:
+			// reduced control interval while idle
+			if site.idle(time.Now()) {
+				continue
+			}
 			site.update(<-loadpointChan)
+		case <-regulationTick:
+			// additional control steps while charge current is being adjusted
+			if lp := site.regulatingLoadpoint(interval); lp != nil {
+				site.update(lp)
+			}
 		case lp := <-site.lpUpdateChan:
 			site.update(lp)
 		case <-stopC:
@@ -1,6 +1,8 @@
 package site
 
 import (
+	"time"
+
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/loadpoint"
 )
@@ -23,6 +25,9 @@ type API interface {
 	GetBatteryMeterRefs() []string
 	SetBatteryMeterRefs([]string)
 
+	// SetMeterReplacement registers the replacement of a meter's energy counter
+	SetMeterReplacement(ref string, oldEnergy, newEnergy float64) error
+
 	// circuits
 	GetCircuit() api.Circuit
 	SetCircuit(api.Circuit)
@@ -42,6 +47,10 @@ type API interface {
 	GetBatteryGridChargeLimit() *float64
 	// SetBatteryGridChargeLimit sets the grid charge limit
 	SetBatteryGridChargeLimit(limit *float64)
+	// GetBatteryExportLimit gets the feed-in price limit for discharging battery to grid
+	GetBatteryExportLimit() *float64
+	// SetBatteryExportLimit sets the feed-in price limit for discharging battery to grid
+	SetBatteryExportLimit(limit *float64) error
 
 	//
 	// power and energy
@@ -57,10 +66,64 @@ type API interface {
 	// GetTariff returns the respective tariff
 	GetTariff(api.TariffUsage) api.Tariff
 
+	// GetSolarForecast returns the solar forecast adjusted to the actual production
+	GetSolarForecast() api.Rates
+
+	// GetForecastAccuracy returns the solar forecast accuracy of the effective forecast and its providers
+	GetForecastAccuracy() map[string]ForecastAccuracy
+
+	// GetChargingWindow returns the cheapest or greenest window for consuming energy (kWh) at power (W) before the deadline
+	GetChargingWindow(energy, power float64, deadline time.Time, objective string, split bool) (ChargingWindow, error)
+
+	// Snapshot renders the current power flow and upcoming grid prices as png image
+	Snapshot(width, height int) ([]byte, error)
+
 	//
 	// battery control
 	//
 
 	GetBatteryDischargeControl() bool
 	SetBatteryDischargeControl(bool) error
+
+	// GetPanelsCovered returns true if pv panels are covered e.g. by snow
+	GetPanelsCovered() bool
+	// SetPanelsCovered sets the pv panels covered manually
+	SetPanelsCovered(bool) error
+
+	// GetBatteryModeExternal returns the battery mode requested by an external system
+	GetBatteryModeExternal() api.BatteryMode
+	// GetBatteryWarranty returns the battery throughput counters
+	GetBatteryWarranty() BatteryWarranty
+	// SetBatteryModeExternal sets the battery mode requested by an external system
+	SetBatteryModeExternal(api.BatteryMode)
+	// GetBatterySoc returns the battery soc
+	GetBatterySoc() float64
+	// GetBatteryDischargeSupported returns true if the batteries support forced discharge
+	GetBatteryDischargeSupported() bool
+}
+
+// BatteryWarranty are the battery throughput counters relevant for warranty
+type BatteryWarranty struct {
+	Throughput     float64 `json:"throughput"`     // total charged and discharged energy (kWh)
+	Cycles         float64 `json:"cycles"`         // full cycles
+	Directed       float64 `json:"directed"`       // throughput of grid charging and export (kWh)
+	DirectedCycles float64 `json:"directedCycles"` // full cycles of grid charging and export
+	DirectedYear   float64 `json:"directedYear"`   // throughput of grid charging and export this year (kWh)
+}
+
+// ForecastAccuracy is the daily solar forecast accuracy over the tracked period
+type ForecastAccuracy struct {
+	Days  int     `json:"days"`  // completed days evaluated
+	MAE   float64 `json:"mae"`   // mean absolute daily error (kWh)
+	Bias  float64 `json:"bias"`  // mean daily error, positive if forecast exceeds production (kWh)
+	Error float64 `json:"error"` // absolute error relative to production (%)
+}
+
+// ChargingWindow is the suggested window for consuming energy at lowest price or co2 emissions
+type ChargingWindow struct {
+	Objective string    `json:"objective"` // price or co2
+	Start     time.Time `json:"start"`
+	End       time.Time `json:"end"`
+	Value     float64   `json:"value"` // total cost (currency) or emissions (g) of the energy
+	Slots     api.Rates `json:"slots"` // slots with effective price or co2 per kWh, non-contiguous if split
 }
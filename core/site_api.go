@@ -301,6 +301,37 @@ func (site *Site) GetBatteryGridChargeLimit() *float64 {
 	return site.batteryGridChargeLimit
 }
 
+func (site *Site) GetBatteryExportLimit() *float64 {
+	site.RLock()
+	defer site.RUnlock()
+	return site.batteryExportLimit
+}
+
+func (site *Site) SetBatteryExportLimit(val *float64) error {
+	site.log.DEBUG.Println("set battery export limit:", printPtr("%.3f", val))
+
+	if val != nil && !site.batteryDischargeSupported() {
+		return errors.New("battery does not support forced discharge")
+	}
+
+	site.Lock()
+	defer site.Unlock()
+
+	if !ptrValueEqual(site.batteryExportLimit, val) {
+		site.batteryExportLimit = val
+
+		if val == nil {
+			settings.SetString(keys.BatteryExportLimit, "")
+			site.publish(keys.BatteryExportLimit, nil)
+		} else {
+			settings.SetFloat(keys.BatteryExportLimit, *val)
+			site.publish(keys.BatteryExportLimit, *val)
+		}
+	}
+
+	return nil
+}
+
 func (site *Site) SetBatteryGridChargeLimit(val *float64) {
 	site.log.DEBUG.Println("set grid charge limit:", printPtr("%.1f", val))
 
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/util/config"
+	"github.com/jinzhu/now"
+)
+
+// awaySessionsPeriod is the period of vehicle charging history imported as away sessions
+const awaySessionsPeriod = 30 * 24 * time.Hour
+
+// updateAwaySessions imports the charging history of vehicles supporting it once per day
+func (site *Site) updateAwaySessions() {
+	if db.Instance == nil {
+		return
+	}
+
+	today := now.BeginningOfDay()
+	if site.awaySessionsDay.Equal(today) {
+		return
+	}
+	site.awaySessionsDay = today
+
+	for _, dev := range config.Vehicles().Devices() {
+		if vh, ok := dev.Instance().(api.VehicleChargingHistory); ok {
+			go site.importAwaySessions(dev.Instance().Title(), vh)
+		}
+	}
+}
+
+// importAwaySessions imports the vehicle's charging history as away sessions
+func (site *Site) importAwaySessions(title string, vh api.VehicleChargingHistory) {
+	records, err := vh.ChargingHistory(time.Now().Add(-awaySessionsPeriod))
+	if err != nil {
+		site.log.ERROR.Printf("%s charging history: %v", title, err)
+		return
+	}
+
+	n, err := session.ImportAway(db.Instance, title, records)
+	if err != nil {
+		site.log.ERROR.Printf("%s charging history: %v", title, err)
+	}
+
+	if n > 0 {
+		site.log.DEBUG.Printf("%s charging history: imported %d away sessions", title, n)
+	}
+}
@@ -41,6 +41,36 @@ func (site *Site) SetBatteryMode(batMode api.BatteryMode) {
 	}
 }
 
+// GetBatteryModeExternal returns the battery mode requested by an external system
+func (site *Site) GetBatteryModeExternal() api.BatteryMode {
+	site.RLock()
+	defer site.RUnlock()
+	return site.batteryModeExternal
+}
+
+// SetBatteryModeExternal sets the battery mode requested by an external system. Use api.BatteryUnknown to release.
+func (site *Site) SetBatteryModeExternal(batMode api.BatteryMode) {
+	site.Lock()
+	defer site.Unlock()
+
+	if site.batteryModeExternal != batMode {
+		site.log.DEBUG.Println("set external battery mode:", batMode)
+		site.batteryModeExternal = batMode
+	}
+}
+
+// GetBatterySoc returns the battery soc
+func (site *Site) GetBatterySoc() float64 {
+	site.RLock()
+	defer site.RUnlock()
+	return site.batterySoc
+}
+
+// GetBatteryDischargeSupported returns true if the batteries support forced discharge
+func (site *Site) GetBatteryDischargeSupported() bool {
+	return site.batteryDischargeSupported()
+}
+
 // requiredBatteryMode determines required battery mode based on grid charge and rate
 func (site *Site) requiredBatteryMode(batteryGridChargeActive bool, rate api.Rate) api.BatteryMode {
 	var res api.BatteryMode
@@ -53,9 +83,13 @@ func (site *Site) requiredBatteryMode(batteryGridChargeActive bool, rate api.Rat
 	switch {
 	case !site.batteryConfigured():
 		res = api.BatteryUnknown
+	case site.GetBatteryModeExternal() != api.BatteryUnknown:
+		res = mapper(site.GetBatteryModeExternal())
 	case batteryGridChargeActive:
 		res = mapper(api.BatteryCharge)
-	case site.dischargeControlActive(rate):
+	case site.batteryExportActive():
+		res = mapper(api.BatteryDischarge)
+	case site.dischargeControlActive(rate), site.crossDischargeActive(rate):
 		res = mapper(api.BatteryHold)
 	case batteryModeModified(batMode):
 		res = api.BatteryNormal
@@ -64,11 +98,23 @@ func (site *Site) requiredBatteryMode(batteryGridChargeActive bool, rate api.Rat
 	return res
 }
 
+// updateBatteryMode applies the required battery mode
+func (site *Site) updateBatteryMode(batteryGridChargeActive bool, rate api.Rate) {
+	if batteryMode := site.requiredBatteryMode(batteryGridChargeActive, rate); batteryMode != api.BatteryUnknown {
+		if err := site.applyBatteryMode(batteryMode); err == nil {
+			site.SetBatteryMode(batteryMode)
+		} else {
+			site.log.ERROR.Println("battery mode:", err)
+		}
+	}
+}
+
 // applyBatteryMode applies the mode to each battery
 func (site *Site) applyBatteryMode(mode api.BatteryMode) error {
 	for _, meter := range site.batteryMeters {
 		if batCtrl, ok := meter.(api.BatteryController); ok {
-			if err := batCtrl.SetBatteryMode(mode); err != nil && !errors.Is(err, api.ErrNotAvailable) {
+			// forced discharge must not silently fall back to the battery's current mode
+			if err := batCtrl.SetBatteryMode(mode); err != nil && (mode == api.BatteryDischarge || !errors.Is(err, api.ErrNotAvailable)) {
 				return err
 			}
 		}
@@ -92,6 +138,14 @@ func (site *Site) smartCostActive(lp loadpoint.API, rate api.Rate) bool {
 }
 
 func (site *Site) batteryGridChargeActive(rate api.Rate) bool {
+	if site.batteryWarrantyExceeded() {
+		return false
+	}
+
+	if site.negativePrice.charge {
+		return true
+	}
+
 	limit := site.GetBatteryGridChargeLimit()
 	return limit != nil && !rate.IsZero() && rate.Price <= *limit
 }
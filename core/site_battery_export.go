@@ -0,0 +1,108 @@
+package core
+
+import (
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/jinzhu/now"
+)
+
+// BatteryExportConfig limits battery discharge to grid
+type BatteryExportConfig struct {
+	Budget float64 `mapstructure:"budget"` // maximum exported battery energy per day (kWh)
+	MinSoc float64 `mapstructure:"minSoc"` // stop exporting below this soc
+}
+
+// batteryExport tracks battery energy exported to grid per day
+type batteryExport struct {
+	Day     time.Time `json:"day"`
+	Energy  float64   `json:"energy"`  // exported energy today (kWh)
+	Revenue float64   `json:"revenue"` // revenue today (Currency)
+	updated time.Time
+}
+
+// update accounts battery power exported to grid since last update
+func (be *batteryExport) update(ts time.Time, active bool, batteryPower, gridPower, price float64) {
+	if day := now.With(ts).BeginningOfDay(); !day.Equal(be.Day) {
+		*be = batteryExport{Day: day}
+	}
+
+	if active && !be.updated.IsZero() {
+		exported := min(max(0, batteryPower), max(0, -gridPower)) * ts.Sub(be.updated).Hours() / 1e3
+		be.Energy += exported
+		be.Revenue += exported * price
+	}
+
+	be.updated = ts
+}
+
+// batteryDischargeSupported checks if all controllable batteries advertise forced discharge to grid
+func (site *Site) batteryDischargeSupported() bool {
+	var res bool
+
+	for _, meter := range site.batteryMeters {
+		if _, ok := meter.(api.BatteryController); !ok {
+			continue
+		}
+
+		fd, ok := meter.(api.FeatureDescriber)
+		if !ok || !slices.Contains(fd.Features(), api.ForcedDischarge) {
+			return false
+		}
+
+		res = true
+	}
+
+	return res
+}
+
+// batteryExportActive determines if battery should be discharged to grid at current feed-in price
+func (site *Site) batteryExportActive() bool {
+	limit := site.GetBatteryExportLimit()
+	if limit == nil || !site.batteryDischargeSupported() {
+		return false
+	}
+
+	if site.batterySoc <= site.BatteryExport.MinSoc || site.batteryWarrantyExceeded() {
+		return false
+	}
+
+	if budget := site.BatteryExport.Budget; budget > 0 && site.batteryExport.Energy >= budget {
+		return false
+	}
+
+	// battery export would add to curtailed pv feed-in
+	if site.exportLimitActive() || site.negativePrice.blockFeedIn {
+		return false
+	}
+
+	feedin, err := tariff.Now(site.GetTariff(api.TariffUsageFeedIn))
+	return err == nil && feedin >= *limit
+}
+
+// updateBatteryExport accounts exported battery energy and publishes export status
+func (site *Site) updateBatteryExport() {
+	if !site.batteryConfigured() {
+		return
+	}
+
+	var price float64
+	if v, err := tariff.Now(site.GetTariff(api.TariffUsageFeedIn)); err == nil {
+		price = v
+	}
+
+	active := site.GetBatteryMode() == api.BatteryDischarge
+	site.batteryExport.update(time.Now(), active, site.batteryPower, site.gridPower, price)
+
+	if err := settings.SetJson(keys.BatteryExport, site.batteryExport); err != nil {
+		site.log.ERROR.Println("battery export:", err)
+	}
+
+	site.publish(keys.BatteryExportActive, active)
+	site.publish(keys.BatteryExportEnergy, site.batteryExport.Energy)
+	site.publish(keys.BatteryExportRevenue, site.batteryExport.Revenue)
+}
@@ -0,0 +1,97 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/server/db/settings"
+)
+
+// BatteryWarrantyConfig limits battery wear caused by grid charging and discharging to grid
+type BatteryWarrantyConfig struct {
+	MaxThroughput float64 `mapstructure:"maxThroughput"` // maximum throughput of grid charging and export per year (kWh)
+}
+
+// batteryWarranty counts battery throughput, separating grid charging and export directed by evcc from natural cycling
+type batteryWarranty struct {
+	Year         int     `json:"year"`
+	Charged      float64 `json:"charged"`      // total charged energy (kWh)
+	Discharged   float64 `json:"discharged"`   // total discharged energy (kWh)
+	Directed     float64 `json:"directed"`     // total throughput of grid charging and export (kWh)
+	DirectedYear float64 `json:"directedYear"` // throughput of grid charging and export this year (kWh)
+
+	updated time.Time
+}
+
+// update accounts battery throughput since last update
+func (bw *batteryWarranty) update(ts time.Time, batteryPower float64, mode api.BatteryMode) {
+	if bw.Year != ts.Year() {
+		bw.Year = ts.Year()
+		bw.DirectedYear = 0
+	}
+
+	if !bw.updated.IsZero() {
+		energy := batteryPower * ts.Sub(bw.updated).Hours() / 1e3
+
+		if energy < 0 {
+			bw.Charged -= energy
+		} else {
+			bw.Discharged += energy
+		}
+
+		if energy < 0 && mode == api.BatteryCharge || energy > 0 && mode == api.BatteryDischarge {
+			bw.Directed += max(energy, -energy)
+			bw.DirectedYear += max(energy, -energy)
+		}
+	}
+
+	bw.updated = ts
+}
+
+// counters returns the warranty counters for given battery capacity (kWh). A full cycle charges and discharges the capacity.
+func (bw *batteryWarranty) counters(capacity float64) site.BatteryWarranty {
+	res := site.BatteryWarranty{
+		Throughput:   bw.Charged + bw.Discharged,
+		Directed:     bw.Directed,
+		DirectedYear: bw.DirectedYear,
+	}
+
+	if capacity > 0 {
+		res.Cycles = res.Throughput / 2 / capacity
+		res.DirectedCycles = res.Directed / 2 / capacity
+	}
+
+	return res
+}
+
+// batteryWarrantyExceeded determines if the yearly throughput of grid charging and export is exhausted
+func (site *Site) batteryWarrantyExceeded() bool {
+	limit := site.BatteryWarranty.MaxThroughput
+	return limit > 0 && site.batteryWarranty.DirectedYear >= limit
+}
+
+// GetBatteryWarranty returns the battery warranty counters
+func (site *Site) GetBatteryWarranty() site.BatteryWarranty {
+	site.RLock()
+	defer site.RUnlock()
+	return site.batteryWarranty.counters(site.batteryCapacity)
+}
+
+// updateBatteryWarranty accounts battery throughput and publishes the warranty counters
+func (site *Site) updateBatteryWarranty() {
+	if !site.batteryConfigured() {
+		return
+	}
+
+	site.Lock()
+	site.batteryWarranty.update(time.Now(), site.batteryPower, site.batteryMode)
+	site.Unlock()
+
+	if err := settings.SetJson(keys.BatteryWarranty, site.batteryWarranty); err != nil {
+		site.log.ERROR.Println("battery warranty:", err)
+	}
+
+	site.publish(keys.BatteryWarranty, site.GetBatteryWarranty())
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatteryWarrantyUpdate(t *testing.T) {
+	var bw batteryWarranty
+
+	ts := time.Date(2025, 12, 31, 20, 0, 0, 0, time.Local)
+	bw.update(ts, -5000, api.BatteryNormal)
+	assert.Equal(t, 0.0, bw.Charged, "first update")
+
+	// charging from pv
+	bw.update(ts.Add(time.Hour), -5000, api.BatteryNormal)
+	assert.Equal(t, 5.0, bw.Charged)
+	assert.Equal(t, 0.0, bw.Directed)
+
+	// grid charging
+	bw.update(ts.Add(2*time.Hour), -5000, api.BatteryCharge)
+	assert.Equal(t, 10.0, bw.Charged)
+	assert.Equal(t, 5.0, bw.Directed)
+
+	// discharge to grid
+	bw.update(ts.Add(3*time.Hour), 10000, api.BatteryDischarge)
+	assert.Equal(t, 10.0, bw.Discharged)
+	assert.Equal(t, 15.0, bw.Directed)
+	assert.Equal(t, 15.0, bw.DirectedYear)
+
+	assert.Equal(t, site.BatteryWarranty{
+		Throughput:     20,
+		Cycles:         1,
+		Directed:       15,
+		DirectedCycles: 0.75,
+		DirectedYear:   15,
+	}, bw.counters(10))
+
+	// yearly counter restarts
+	bw.update(ts.Add(5*time.Hour), 0, api.BatteryNormal)
+	assert.Equal(t, 0.0, bw.DirectedYear)
+	assert.Equal(t, 15.0, bw.Directed)
+}
+
+func TestBatteryWarrantyExceeded(t *testing.T) {
+	s := &Site{BatteryWarranty: BatteryWarrantyConfig{MaxThroughput: 100}}
+	assert.False(t, s.batteryWarrantyExceeded())
+
+	s.batteryWarranty.DirectedYear = 100
+	assert.True(t, s.batteryWarrantyExceeded())
+	assert.False(t, s.batteryGridChargeActive(api.Rate{Price: 0}))
+}
@@ -0,0 +1,179 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/tariff"
+)
+
+const (
+	windowPrice = "price" // optimize charging window for grid price
+	windowCo2   = "co2"   // optimize charging window for co2 emissions
+)
+
+// effectiveRates returns the rates reduced by the share of power covered by the solar forecast.
+// Solar energy is valued at the feed-in price for price rates and considered emission-free for co2 rates.
+func effectiveRates(rr, solar, feedin api.Rates, power float64) api.Rates {
+	res := make(api.Rates, 0, len(rr))
+
+	for _, r := range rr {
+		var share float64
+		if hours := r.End.Sub(r.Start).Hours(); hours > 0 && len(solar) > 0 {
+			share = min(1, 1e3*tariff.AccumulatedEnergy(solar, r.Start, r.End)/hours/power)
+		}
+
+		var fi float64
+		if feedin != nil {
+			if f, err := feedin.At(r.Start); err == nil {
+				fi = f.Price
+			}
+		}
+
+		r.Price = r.Price*(1-share) + fi*share
+		res = append(res, r)
+	}
+
+	return res
+}
+
+// contiguousWindow returns the slots of the contiguous window of the given duration with the lowest average rate
+func contiguousWindow(rr api.Rates, d time.Duration) (api.Rates, bool) {
+	w, ok := priceWindow(rr, d, false)
+	if !ok {
+		return nil, false
+	}
+
+	end := w.Start.Add(d)
+
+	var res api.Rates
+	for _, r := range tariff.Between(rr, w.Start, end) {
+		if r.End.After(end) {
+			r.End = end
+		}
+		res = append(res, r)
+	}
+
+	return res, true
+}
+
+// splitWindow returns the cheapest slots of the given total duration, ordered by time
+func splitWindow(rr api.Rates, d time.Duration) (api.Rates, bool) {
+	sorted := slices.Clone(rr)
+	slices.SortStableFunc(sorted, func(a, b api.Rate) int {
+		if a.Price == b.Price {
+			// prefer later slots like the planner
+			return b.Start.Compare(a.Start)
+		}
+		if a.Price < b.Price {
+			return -1
+		}
+		return 1
+	})
+
+	var res api.Rates
+	for _, r := range sorted {
+		if d <= 0 {
+			break
+		}
+
+		if slot := r.End.Sub(r.Start); slot > d {
+			r.Start = r.End.Add(-d)
+		}
+
+		d -= r.End.Sub(r.Start)
+		res = append(res, r)
+	}
+
+	if d > 0 {
+		return nil, false
+	}
+
+	res.Sort()
+
+	return res, true
+}
+
+// chargingWindow returns the best window for consuming energy (kWh) at power (W) within the given rates
+func chargingWindow(rr api.Rates, energy, power float64, split bool) (site.ChargingWindow, error) {
+	d := time.Duration(energy * 1e3 / power * float64(time.Hour))
+
+	window := contiguousWindow
+	if split {
+		window = splitWindow
+	}
+
+	slots, ok := window(rr, d)
+	if !ok {
+		return site.ChargingWindow{}, errors.New("rates do not cover the required duration")
+	}
+
+	var value float64
+	for _, r := range slots {
+		value += r.Price * power / 1e3 * r.End.Sub(r.Start).Hours()
+	}
+
+	return site.ChargingWindow{
+		Start: slots[0].Start,
+		End:   slots[len(slots)-1].End,
+		Value: value,
+		Slots: slots,
+	}, nil
+}
+
+// GetChargingWindow returns the cheapest or greenest window for consuming energy (kWh) at power (W) before the deadline,
+// considering the adjusted solar forecast. Useful for devices not controlled by evcc.
+func (site *Site) GetChargingWindow(energy, power float64, deadline time.Time, objective string, split bool) (res site.ChargingWindow, err error) {
+	if energy <= 0 || power <= 0 {
+		return res, errors.New("invalid energy or power")
+	}
+
+	usage := api.TariffUsageGrid
+	switch objective {
+	case windowPrice:
+	case windowCo2:
+		usage = api.TariffUsageCo2
+	default:
+		return res, fmt.Errorf("invalid objective: %s", objective)
+	}
+
+	t := site.GetTariff(usage)
+	if t == nil {
+		return res, fmt.Errorf("%s tariff not configured", usage)
+	}
+
+	rr, err := t.Rates()
+	if err != nil {
+		return res, err
+	}
+
+	// slots between now and deadline, first slot clipped to now
+	now := time.Now()
+
+	var slots api.Rates
+	for _, r := range tariff.Between(rr, now, deadline) {
+		if r.End.After(deadline) {
+			continue
+		}
+		if r.Start.Before(now) {
+			r.Start = now
+		}
+		slots = append(slots, r)
+	}
+
+	var feedin api.Rates
+	if usage == api.TariffUsageGrid {
+		if t := site.GetTariff(api.TariffUsageFeedIn); t != nil {
+			feedin, _ = t.Rates()
+		}
+	}
+
+	res, err = chargingWindow(effectiveRates(slots, site.GetSolarForecast(), feedin, power), energy, power, split)
+	res.Objective = objective
+
+	return res, err
+}
@@ -0,0 +1,72 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChargingWindow(t *testing.T) {
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local)
+
+	hourly := func(prices ...float64) api.Rates {
+		var res api.Rates
+		for i, p := range prices {
+			start := day.Add(time.Duration(i) * time.Hour)
+			res = append(res, api.Rate{Start: start, End: start.Add(time.Hour), Price: p})
+		}
+		return res
+	}
+
+	rr := hourly(0.3, 0.1, 0.4, 0.15, 0.2, 0.3)
+
+	// contiguous 2h window at 1kW
+	w, err := chargingWindow(rr, 2, 1e3, false)
+	require.NoError(t, err)
+	assert.Equal(t, day.Add(3*time.Hour), w.Start)
+	assert.Equal(t, day.Add(5*time.Hour), w.End)
+	assert.InDelta(t, 0.35, w.Value, 1e-6)
+
+	// split 2h window
+	w, err = chargingWindow(rr, 2, 1e3, true)
+	require.NoError(t, err)
+	assert.Equal(t, day.Add(1*time.Hour), w.Start)
+	assert.Equal(t, day.Add(4*time.Hour), w.End)
+	assert.Len(t, w.Slots, 2)
+	assert.InDelta(t, 0.25, w.Value, 1e-6)
+
+	// partial slot at end of split window
+	w, err = chargingWindow(rr, 1.5, 1e3, true)
+	require.NoError(t, err)
+	assert.Equal(t, day.Add(1*time.Hour), w.Start)
+	assert.Equal(t, day.Add(3*time.Hour+30*time.Minute), w.Slots[1].Start)
+	assert.InDelta(t, 0.175, w.Value, 1e-6)
+
+	// not enough rates
+	_, err = chargingWindow(rr, 10, 1e3, true)
+	assert.Error(t, err)
+}
+
+func TestEffectiveRates(t *testing.T) {
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local)
+
+	rr := api.Rates{
+		{Start: day, End: day.Add(time.Hour), Price: 0.3},
+		{Start: day.Add(time.Hour), End: day.Add(2 * time.Hour), Price: 0.3},
+	}
+	solar := api.Rates{{Start: day.Add(time.Hour), End: day.Add(2 * time.Hour), Price: 1000}}
+	feedin := api.Rates{{Start: day, End: day.Add(2 * time.Hour), Price: 0.1}}
+
+	// half of 2kW covered by solar
+	res := effectiveRates(rr, solar, feedin, 2e3)
+	assert.InDelta(t, 0.3, res[0].Price, 1e-6)
+	assert.InDelta(t, 0.2, res[1].Price, 1e-6)
+
+	// co2 without feed-in
+	res = effectiveRates(rr, solar, nil, 500)
+	assert.InDelta(t, 0.3, res[0].Price, 1e-6)
+	assert.InDelta(t, 0, res[1].Price, 1e-6)
+}
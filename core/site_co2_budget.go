@@ -0,0 +1,96 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/jinzhu/now"
+	"github.com/samber/lo"
+)
+
+const evCo2Budget = "co2budget" // monthly co2 budget for charging exhausted
+
+// Co2BudgetConfig limits co2 emissions of charging
+type Co2BudgetConfig struct {
+	Mass float64 `mapstructure:"mass"` // maximum co2 emissions of charging per month (kg)
+}
+
+// co2Budget tracks co2 emissions of charging per month
+type co2Budget struct {
+	Month time.Time `json:"month"`
+	Mass  float64   `json:"mass"` // co2 emitted by charging this month (kg)
+
+	updated time.Time
+}
+
+// update accounts co2 emissions of charging since last update
+func (cb *co2Budget) update(ts time.Time, chargePower, co2 float64) {
+	if month := now.With(ts).BeginningOfMonth(); !month.Equal(cb.Month) {
+		*cb = co2Budget{Month: month}
+	}
+
+	if !cb.updated.IsZero() {
+		cb.Mass += max(0, chargePower) * max(0, co2) * ts.Sub(cb.updated).Hours() / 1e6
+	}
+
+	cb.updated = ts
+}
+
+// scale returns the share of the smart co2 limit applicable with the remaining budget.
+// The limit is reduced while emissions run ahead of an even spread over the month.
+func (cb *co2Budget) scale(ts time.Time, budget float64) float64 {
+	used := cb.Mass / budget
+	if used >= 1 {
+		return 0
+	}
+
+	month := now.With(ts)
+	elapsed := ts.Sub(month.BeginningOfMonth()).Seconds() / month.EndOfMonth().Sub(month.BeginningOfMonth()).Seconds()
+	if elapsed >= 1 {
+		return 1
+	}
+
+	return min(max((1-used)/(1-elapsed), 0), 1)
+}
+
+// updateCo2Budget accounts co2 emissions of charging and tightens the loadpoints' smart co2 limit as the budget depletes
+func (site *Site) updateCo2Budget(chargePower, greenShare float64) {
+	budget := site.Co2Budget.Mass
+	if budget <= 0 {
+		return
+	}
+
+	co2 := site.effectiveCo2(greenShare)
+	if co2 == nil {
+		return
+	}
+
+	ts := time.Now()
+	site.co2Budget.update(ts, chargePower, *co2)
+
+	if err := settings.SetJson(keys.Co2Budget, site.co2Budget); err != nil {
+		site.log.ERROR.Println("co2 budget:", err)
+	}
+
+	// smart cost limit is a co2 limit for co2 planner tariffs only
+	var scale *float64
+	if t := site.GetTariff(api.TariffUsagePlanner); t != nil && t.Type() == api.TariffTypeCo2 {
+		scale = lo.ToPtr(site.co2Budget.scale(ts, budget))
+		site.publish(keys.Co2BudgetScale, *scale)
+	}
+
+	for _, lp := range site.loadpoints {
+		lp.co2BudgetScale = scale
+	}
+
+	exceeded := site.co2Budget.Mass >= budget
+	if exceeded && !site.co2BudgetExceeded {
+		site.log.WARN.Printf("co2 budget: %.1fkg of %.1fkg used", site.co2Budget.Mass, budget)
+		site.pushEvent(evCo2Budget)
+	}
+	site.co2BudgetExceeded = exceeded
+
+	site.publish(keys.Co2BudgetMass, site.co2Budget.Mass)
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCo2BudgetUpdate(t *testing.T) {
+	var cb co2Budget
+
+	ts := time.Date(2025, 1, 10, 10, 0, 0, 0, time.Local)
+	cb.update(ts, 10000, 400)
+	assert.Equal(t, 0.0, cb.Mass, "first update")
+
+	// 10kWh at 400g/kWh
+	cb.update(ts.Add(time.Hour), 10000, 400)
+	assert.Equal(t, 4.0, cb.Mass)
+
+	// not charging
+	cb.update(ts.Add(2*time.Hour), 0, 400)
+	assert.Equal(t, 4.0, cb.Mass)
+
+	// reset at beginning of month
+	cb.update(time.Date(2025, 2, 1, 1, 0, 0, 0, time.Local), 10000, 400)
+	assert.Equal(t, 0.0, cb.Mass)
+}
+
+func TestCo2BudgetScale(t *testing.T) {
+	mid := time.Date(2025, 4, 16, 0, 0, 0, 0, time.Local) // half of april elapsed
+
+	for _, tc := range []struct {
+		mass, scale float64
+	}{
+		{0, 1},    // unused
+		{25, 1},   // below even spread
+		{50, 1},   // on track
+		{75, 0.5}, // ahead of even spread
+		{100, 0},  // exhausted
+		{120, 0},  // exceeded
+	} {
+		cb := co2Budget{Mass: tc.mass}
+		assert.InDelta(t, tc.scale, cb.scale(mid, 100), 1e-3, "mass %.0fkg", tc.mass)
+	}
+}
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// CrossDischarge selects when the battery is held to prevent it from discharging into vehicle charging
+type CrossDischarge string
+
+const (
+	CrossDischargeAllow CrossDischarge = ""     // battery may discharge into charging
+	CrossDischargeGrid  CrossDischarge = "grid" // hold battery while charging beyond pv surplus
+	CrossDischargeAll   CrossDischarge = "all"  // hold battery while charging, battery buffer is not used for pv charging
+)
+
+// Validate checks for a known cross discharge policy
+func (c CrossDischarge) Validate() error {
+	switch c {
+	case CrossDischargeAllow, CrossDischargeGrid, CrossDischargeAll:
+		return nil
+	default:
+		return fmt.Errorf("invalid cross discharge policy: %s", c)
+	}
+}
+
+// crossDischargeActive determines if the battery must be held for the enabled loadpoints
+func (site *Site) crossDischargeActive(rate api.Rate) bool {
+	if site.CrossDischarge == CrossDischargeAllow {
+		return false
+	}
+
+	for _, lp := range site.loadpoints {
+		if !lp.enabled {
+			continue
+		}
+
+		if site.CrossDischarge == CrossDischargeAll ||
+			lp.GetMode() != api.ModePV || lp.IsFastChargingActive() || site.smartCostActive(lp, rate) {
+			return true
+		}
+	}
+
+	return false
+}
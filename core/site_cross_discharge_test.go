@@ -0,0 +1,67 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCrossDischargeActive(t *testing.T) {
+	tc := []struct {
+		policy  CrossDischarge
+		mode    api.ChargeMode
+		enabled bool
+		res     bool
+	}{
+		{CrossDischargeAllow, api.ModeNow, true, false},
+		{CrossDischargeGrid, api.ModeNow, true, true},
+		{CrossDischargeGrid, api.ModeMinPV, true, true},
+		{CrossDischargeGrid, api.ModePV, true, false},
+		{CrossDischargeGrid, api.ModeNow, false, false},
+		{CrossDischargeAll, api.ModePV, true, true},
+		{CrossDischargeAll, api.ModePV, false, false},
+	}
+
+	for _, tc := range tc {
+		t.Logf("%+v", tc)
+
+		lp := &Loadpoint{
+			log:     util.NewLogger("foo"),
+			mode:    tc.mode,
+			enabled: tc.enabled,
+		}
+
+		s := &Site{
+			CrossDischarge: tc.policy,
+			loadpoints:     []*Loadpoint{lp},
+		}
+
+		assert.Equal(t, tc.res, s.crossDischargeActive(api.Rate{}))
+	}
+
+	assert.Error(t, CrossDischarge("foo").Validate())
+}
+
+func TestCrossDischargeBatteryMode(t *testing.T) {
+	lp := &Loadpoint{
+		log:     util.NewLogger("foo"),
+		mode:    api.ModePV,
+		enabled: true,
+	}
+
+	s := &Site{
+		CrossDischarge: CrossDischargeAll,
+		batteryMeters:  []api.Meter{nil},
+		batteryMode:    api.BatteryNormal,
+		loadpoints:     []*Loadpoint{lp},
+	}
+
+	assert.Equal(t, api.BatteryHold, s.requiredBatteryMode(false, api.Rate{}))
+
+	// release after loadpoint got disabled
+	s.batteryMode = api.BatteryHold
+	lp.enabled = false
+	assert.Equal(t, api.BatteryNormal, s.requiredBatteryMode(false, api.Rate{}))
+}
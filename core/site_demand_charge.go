@@ -0,0 +1,52 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/samber/lo"
+)
+
+// DemandChargeConfig configures the charging limits avoiding new peaks of a demand charge grid tariff
+type DemandChargeConfig struct {
+	MinPeak float64 `mapstructure:"minPeak"` // peak demand (W) charging may always use, e.g. while the peak builds up after the billing period starts
+	Now     bool    `mapstructure:"now"`     // limit charging in now mode too
+}
+
+// updateDemand records grid import for demand charges and limits loadpoints to the power not creating a new peak
+func (site *Site) updateDemand() {
+	dt, ok := tariff.As[api.DemandTariff](site.GetTariff(api.TariffUsageGrid))
+	if !ok || site.gridMeter == nil {
+		return
+	}
+
+	dt.RecordDemand(time.Now(), site.gridPower)
+
+	peak := dt.DemandPeak()
+	site.publish(keys.DemandPeak, peak)
+
+	// no limit until the billing period's first peak is known
+	if peak > 0 {
+		peak = max(peak, site.DemandCharge.MinPeak)
+	}
+
+	limited := lo.Filter(site.loadpoints, func(lp *Loadpoint, _ int) bool {
+		limit := peak > 0 && (site.DemandCharge.Now || lp.GetMode() != api.ModeNow)
+		if !limit {
+			lp.demandLimit = 0
+		}
+		return limit
+	})
+
+	// share remaining headroom equally among charging loadpoints, loadpoints starting to charge may use all of it
+	headroom := peak - site.gridPower
+	if charging := lo.CountBy(limited, func(lp *Loadpoint) bool { return lp.GetChargePower() > 0 }); charging > 0 {
+		headroom /= float64(charging)
+	}
+
+	for _, lp := range limited {
+		lp.demandLimit = max(1, lp.GetChargePower()+headroom)
+	}
+}
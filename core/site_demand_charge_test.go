@@ -0,0 +1,72 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type peakTariff struct {
+	api.Tariff
+	peak float64
+}
+
+func (t *peakTariff) RecordDemand(time.Time, float64) {}
+
+func (t *peakTariff) DemandPeak() float64 {
+	return t.peak
+}
+
+func (t *peakTariff) DemandSurcharge(float64) float64 {
+	return 0
+}
+
+func TestUpdateDemand(t *testing.T) {
+	energy, err := tariff.NewFixedFromConfig(map[string]any{"price": 0.3})
+	require.NoError(t, err)
+
+	trf := &peakTariff{Tariff: energy, peak: 8000}
+
+	charging := &Loadpoint{mode: api.ModePV, chargePower: 4000}
+	idle := &Loadpoint{mode: api.ModePV}
+	now := &Loadpoint{mode: api.ModeNow, chargePower: 11000}
+
+	site := &Site{
+		log:        util.NewLogger("foo"),
+		gridMeter:  &limitedPvMeter{},
+		gridPower:  6000,
+		loadpoints: []*Loadpoint{charging, idle, now},
+		tariffs:    &tariff.Tariffs{Grid: trf},
+	}
+
+	// headroom only shared among charging loadpoints, now mode exempt
+	site.updateDemand()
+	assert.Equal(t, 6000.0, charging.demandLimit)
+	assert.Equal(t, 2000.0, idle.demandLimit)
+	assert.Zero(t, now.demandLimit)
+
+	// now mode limited if configured
+	site.DemandCharge.Now = true
+	site.updateDemand()
+	assert.Equal(t, 5000.0, charging.demandLimit)
+	assert.Equal(t, 12000.0, now.demandLimit)
+
+	// minimum peak after the billing period started
+	trf.peak = 500
+	site.DemandCharge = DemandChargeConfig{MinPeak: 10000}
+	site.updateDemand()
+	assert.Equal(t, 8000.0, charging.demandLimit)
+	assert.Equal(t, 4000.0, idle.demandLimit)
+	assert.Zero(t, now.demandLimit)
+
+	// no limit without peak
+	trf.peak = 0
+	site.updateDemand()
+	assert.Zero(t, charging.demandLimit)
+	assert.Zero(t, idle.demandLimit)
+}
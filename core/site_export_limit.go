@@ -0,0 +1,230 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/eventlog"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/push"
+)
+
+const evExportLimit = "exportlimit" // feed-in limit exceeded
+
+// exportLimitDeadband avoids rewriting inverter limits on small changes (W)
+const exportLimitDeadband = 100
+
+// ExportLimitConfig limits feed-in at the grid connection point, e.g. to 70% or 60% of installed pv power
+type ExportLimitConfig struct {
+	Power     float64       `mapstructure:"power"`     // feed-in limit (W)
+	Ratio     float64       `mapstructure:"ratio"`     // feed-in limit as share of installed pv power, e.g. 0.7
+	Dynamic   bool          `mapstructure:"dynamic"`   // regulate inverters by grid measurement instead of a fixed inverter limit
+	Tolerance time.Duration `mapstructure:"tolerance"` // duration the limit may be exceeded before a violation is logged
+}
+
+// exportLimit is the state of the feed-in limitation
+type exportLimit struct {
+	limit    float64   // total inverter power limit (W)
+	limited  bool      // inverter limit is applied
+	exceeded time.Time // start of current limit excess
+	violated bool      // current excess has been logged as violation
+}
+
+// installedPvPower returns the sum of the pv inverters' max AC power and the individual inverter powers
+func (site *Site) installedPvPower() (float64, []float64) {
+	var total float64
+	res := make([]float64, len(site.pvMeters))
+
+	for i, meter := range site.pvMeters {
+		if m, ok := meter.(api.MaxACPowerGetter); ok {
+			res[i] = m.MaxACPower()
+			total += res[i]
+		}
+	}
+
+	return total, res
+}
+
+// exportLimitPower returns the configured feed-in limit, zero if not configured
+func (site *Site) exportLimitPower(installed float64) float64 {
+	conf := site.ExportLimit
+
+	var res float64
+	if conf.Ratio > 0 && installed > 0 {
+		res = conf.Ratio * installed
+	}
+
+	if conf.Power > 0 && (res == 0 || conf.Power < res) {
+		res = conf.Power
+	}
+
+	return res
+}
+
+// powerLimiters returns the number of pv meters supporting power limitation
+func (site *Site) powerLimiters() int {
+	var res int
+	for _, meter := range site.pvMeters {
+		if _, ok := deviceFeature[api.PowerLimiter](meter, api.PowerLimit); ok {
+			res++
+		}
+	}
+	return res
+}
+
+// applyPowerLimit distributes the total inverter limit by installed power, or equally if unknown
+func (site *Site) applyPowerLimit(limit float64, installed float64, inverters []float64) error {
+	return site.eachPowerLimiter(func(i int, pl api.PowerLimiter) error {
+		power := limit / float64(site.powerLimiters())
+		if installed > 0 {
+			power = limit * inverters[i] / installed
+		}
+
+		return pl.SetPowerLimit(power)
+	})
+}
+
+// removePowerLimit restores unlimited inverter output
+func (site *Site) removePowerLimit() error {
+	return site.eachPowerLimiter(func(_ int, pl api.PowerLimiter) error {
+		return pl.RemovePowerLimit()
+	})
+}
+
+// eachPowerLimiter applies fn to all pv meters supporting power limitation
+func (site *Site) eachPowerLimiter(fn func(int, api.PowerLimiter) error) error {
+	var errs []error
+	for i, meter := range site.pvMeters {
+		pl, ok := deviceFeature[api.PowerLimiter](meter, api.PowerLimit)
+		if !ok {
+			continue
+		}
+
+		if err := fn(i, pl); err != nil && !errors.Is(err, api.ErrNotAvailable) {
+			errs = append(errs, fmt.Errorf("pv %d: %w", i+1, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// inverterLimit returns the total inverter limit required to keep feed-in within limit and if a limit is required at all
+func (site *Site) inverterLimit(limit, installed float64, dynamic bool) (float64, bool) {
+	// static limitation caps inverter output regardless of consumption
+	if !dynamic {
+		return limit, true
+	}
+
+	export := max(0, -site.gridPower)
+	if !site.exportLimit.limited && export <= limit {
+		return 0, false
+	}
+
+	// follow consumption by shifting pv output by the feed-in margin
+	res := max(0, site.pvPower+limit-export)
+	if installed > 0 && res >= installed {
+		return 0, false
+	}
+
+	return res, true
+}
+
+// updateExportLimit limits pv feed-in at the grid connection point and logs limit violations.
+// Feed-in is curtailed completely while blocked at negative feed-in prices.
+func (site *Site) updateExportLimit() {
+	installed, inverters := site.installedPvPower()
+
+	limit := site.exportLimitPower(installed)
+	block := site.negativePrice.blockFeedIn
+
+	if limit <= 0 && !block || site.gridMeter == nil {
+		// remove inverter limit once feed-in is no longer blocked
+		if site.exportLimit.limited {
+			if err := site.removePowerLimit(); err != nil {
+				site.log.ERROR.Println("export limit:", err)
+			} else {
+				site.exportLimit.limit, site.exportLimit.limited = 0, false
+			}
+		}
+
+		return
+	}
+
+	target, dynamic := limit, site.ExportLimit.Dynamic
+	if block {
+		target, dynamic = 0, true
+	}
+
+	// without power limiters feed-in is verified only
+	if res, limited := site.inverterLimit(target, installed, dynamic); site.powerLimiters() > 0 {
+		switch {
+		case !limited && site.exportLimit.limited:
+			if err := site.removePowerLimit(); err != nil {
+				site.log.ERROR.Println("export limit:", err)
+			} else {
+				site.log.DEBUG.Println("export limit: inverter limit removed")
+				site.exportLimit.limit, site.exportLimit.limited = 0, false
+			}
+
+		case limited && (!site.exportLimit.limited || math.Abs(res-site.exportLimit.limit) >= exportLimitDeadband ||
+			res == 0 && site.exportLimit.limit != 0):
+			if err := site.applyPowerLimit(res, installed, inverters); err != nil {
+				site.log.ERROR.Println("export limit:", err)
+			} else {
+				site.log.DEBUG.Printf("export limit: inverter limit %.0fW", res)
+				site.exportLimit.limit, site.exportLimit.limited = res, true
+			}
+		}
+	}
+
+	if limit <= 0 {
+		return
+	}
+
+	site.verifyExportLimit(time.Now(), limit)
+
+	site.publish(keys.ExportLimit, limit)
+	site.publish(keys.ExportLimitActive, site.exportLimitActive())
+}
+
+// verifyExportLimit logs a violation once the measured feed-in exceeds the limit for longer than the tolerance
+func (site *Site) verifyExportLimit(ts time.Time, limit float64) {
+	tolerance := site.ExportLimit.Tolerance
+	if tolerance <= 0 {
+		tolerance = time.Minute
+	}
+
+	export := max(0, -site.gridPower)
+	if export <= limit+exportLimitDeadband {
+		site.exportLimit.exceeded = time.Time{}
+		site.exportLimit.violated = false
+		return
+	}
+
+	if site.exportLimit.exceeded.IsZero() {
+		site.exportLimit.exceeded = ts
+	}
+
+	if site.exportLimit.violated || ts.Sub(site.exportLimit.exceeded) < tolerance {
+		return
+	}
+
+	site.exportLimit.violated = true
+
+	msg := fmt.Sprintf("feed-in %.0fW exceeds limit %.0fW", export, limit)
+	site.log.WARN.Println("export limit:", msg)
+	eventlog.Record(eventlog.CategoryAlert, "site", evExportLimit, msg)
+
+	if site.pushChan != nil {
+		site.pushChan <- push.Event{Event: evExportLimit}
+	}
+}
+
+// exportLimitActive determines if pv output is currently being curtailed or feed-in exceeds the limit
+func (site *Site) exportLimitActive() bool {
+	curtailed := site.exportLimit.limited && site.pvPower >= site.exportLimit.limit-exportLimitDeadband
+	return curtailed || !site.exportLimit.exceeded.IsZero()
+}
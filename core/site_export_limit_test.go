@@ -0,0 +1,130 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+type limitedPvMeter struct {
+	power, maxPower, limit float64
+	limited                bool
+}
+
+func (m *limitedPvMeter) CurrentPower() (float64, error) {
+	return m.power, nil
+}
+
+func (m *limitedPvMeter) MaxACPower() float64 {
+	return m.maxPower
+}
+
+func (m *limitedPvMeter) SetPowerLimit(power float64) error {
+	m.limit, m.limited = power, true
+	return nil
+}
+
+func (m *limitedPvMeter) RemovePowerLimit() error {
+	m.limit, m.limited = 0, false
+	return nil
+}
+
+// describedPvMeter implements api.PowerLimiter regardless of its configuration like configurable meters
+type describedPvMeter struct {
+	limitedPvMeter
+	features []api.Feature
+}
+
+func (m *describedPvMeter) Features() []api.Feature {
+	return m.features
+}
+
+func TestExportLimitStatic(t *testing.T) {
+	a := &limitedPvMeter{maxPower: 6000}
+	b := &limitedPvMeter{maxPower: 4000}
+
+	site := &Site{
+		log:         util.NewLogger("foo"),
+		gridMeter:   &limitedPvMeter{},
+		pvMeters:    []api.Meter{a, b},
+		ExportLimit: ExportLimitConfig{Ratio: 0.7},
+	}
+
+	site.updateExportLimit()
+	assert.Equal(t, 4200.0, a.limit)
+	assert.Equal(t, 2800.0, b.limit)
+}
+
+func TestExportLimitFeature(t *testing.T) {
+	a := &describedPvMeter{limitedPvMeter: limitedPvMeter{maxPower: 6000}, features: []api.Feature{api.PowerLimit}}
+	b := &describedPvMeter{limitedPvMeter: limitedPvMeter{maxPower: 4000}}
+
+	site := &Site{
+		log:         util.NewLogger("foo"),
+		gridMeter:   &limitedPvMeter{},
+		pvMeters:    []api.Meter{a, b},
+		ExportLimit: ExportLimitConfig{Power: 5000},
+	}
+
+	// meter without power limit feature is not curtailed
+	site.updateExportLimit()
+	assert.Equal(t, 3000.0, a.limit)
+	assert.False(t, b.limited)
+}
+
+func TestExportLimitDynamic(t *testing.T) {
+	pv := &limitedPvMeter{maxPower: 10000}
+
+	site := &Site{
+		log:         util.NewLogger("foo"),
+		gridMeter:   &limitedPvMeter{},
+		pvMeters:    []api.Meter{pv},
+		ExportLimit: ExportLimitConfig{Ratio: 0.6, Dynamic: true},
+	}
+
+	// feed-in within limit
+	site.pvPower, site.gridPower = 5000, -4000
+	site.updateExportLimit()
+	assert.False(t, pv.limited)
+	assert.False(t, site.exportLimitActive())
+
+	// curtail excess feed-in
+	site.pvPower, site.gridPower = 8000, -7000
+	site.updateExportLimit()
+	assert.Equal(t, 7000.0, pv.limit)
+	assert.True(t, site.exportLimitActive())
+
+	// follow rising consumption
+	site.pvPower, site.gridPower = 7000, -4000
+	site.updateExportLimit()
+	assert.Equal(t, 9000.0, pv.limit)
+
+	// remove limit once above installed power
+	site.pvPower, site.gridPower = 7000, -2000
+	site.updateExportLimit()
+	assert.False(t, pv.limited)
+}
+
+func TestExportLimitViolation(t *testing.T) {
+	site := &Site{
+		log:         util.NewLogger("foo"),
+		ExportLimit: ExportLimitConfig{Power: 3000},
+	}
+
+	ts := time.Now()
+	site.gridPower = -5000
+
+	site.verifyExportLimit(ts, 3000)
+	assert.False(t, site.exportLimit.violated)
+
+	site.verifyExportLimit(ts.Add(2*time.Minute), 3000)
+	assert.True(t, site.exportLimit.violated)
+
+	site.gridPower = -2000
+	site.verifyExportLimit(ts.Add(3*time.Minute), 3000)
+	assert.False(t, site.exportLimit.violated)
+	assert.True(t, site.exportLimit.exceeded.IsZero())
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/eventlog"
+	"github.com/evcc-io/evcc/core/keys"
+)
+
+const (
+	evFault        = "fault"     // device fault detected
+	faultsInterval = time.Minute // fault register polling interval
+)
+
+// deviceFault is an active fault of a site meter
+type deviceFault struct {
+	Meter       string `json:"meter"`
+	Code        string `json:"code"`
+	Description string `json:"description"`
+}
+
+// siteMeterRefs returns the site's meters with their references
+func (site *Site) siteMeterRefs() ([]string, []api.Meter) {
+	refs := []string{site.Meters.GridMeterRef}
+	meters := []api.Meter{site.gridMeter}
+
+	refs = append(refs, site.Meters.PVMetersRef...)
+	meters = append(meters, site.pvMeters...)
+
+	refs = append(refs, site.Meters.BatteryMetersRef...)
+	meters = append(meters, site.batteryMeters...)
+
+	return refs, meters
+}
+
+// updateFaults collects active device faults from site meters and notifies about new faults
+func (site *Site) updateFaults() {
+	if time.Since(site.faultsUpdated) < faultsInterval {
+		return
+	}
+	site.faultsUpdated = time.Now()
+
+	refs, meters := site.siteMeterRefs()
+
+	var res []deviceFault
+	for i, meter := range meters {
+		fr, ok := meter.(api.FaultReporter)
+		if !ok || i >= len(refs) {
+			continue
+		}
+
+		faults, err := fr.Faults()
+		if err != nil {
+			site.log.DEBUG.Printf("%s faults: %v", refs[i], err)
+			continue
+		}
+
+		for _, f := range faults {
+			res = append(res, deviceFault{Meter: refs[i], Code: f.Code, Description: f.Description})
+		}
+	}
+
+	var added []string
+	for _, f := range res {
+		if !slices.Contains(site.faults, f) {
+			msg := fmt.Sprintf("%s fault %s: %s", f.Meter, f.Code, f.Description)
+			site.log.WARN.Println(msg)
+			added = append(added, msg)
+		}
+	}
+
+	if len(added) > 0 || len(res) != len(site.faults) {
+		site.faults = res
+		site.publish(keys.Faults, res)
+	}
+
+	if len(added) > 0 {
+		msg := strings.Join(added, ", ")
+		site.publish(keys.FaultMessage, msg)
+
+		eventlog.Record(eventlog.CategoryAlert, "site", evFault, msg)
+		site.pushEventWithAttachment(evFault, nil)
+	}
+}
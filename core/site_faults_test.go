@@ -0,0 +1,51 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+type faultyPvMeter struct {
+	limitedPvMeter
+	faults []api.Fault
+}
+
+func (m *faultyPvMeter) Faults() ([]api.Fault, error) {
+	return m.faults, nil
+}
+
+func TestFaultNotification(t *testing.T) {
+	pv := &faultyPvMeter{faults: []api.Fault{{Code: "1392", Description: "Fault"}}}
+
+	uiChan := make(chan util.Param, 10)
+	pushChan := make(chan push.Event, 1)
+
+	site := &Site{
+		log:      util.NewLogger("foo"),
+		pvMeters: []api.Meter{pv},
+		uiChan:   uiChan,
+		pushChan: pushChan,
+	}
+	site.Meters.PVMetersRef = []string{"pv1"}
+
+	site.updateFaults()
+	assert.Equal(t, evFault, (<-pushChan).Event)
+
+	var msg any
+	for len(uiChan) > 0 {
+		if p := <-uiChan; p.Key == keys.FaultMessage {
+			msg = p.Val
+		}
+	}
+	assert.Equal(t, "pv1 fault 1392: Fault", msg)
+
+	// known fault is not notified again
+	site.faultsUpdated = site.faultsUpdated.Add(-faultsInterval)
+	site.updateFaults()
+	assert.Empty(t, pushChan)
+}
@@ -0,0 +1,145 @@
+package core
+
+import (
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/jinzhu/now"
+)
+
+const (
+	forecastAccuracyDays = 30         // tracked days
+	forecastEffective    = "forecast" // effective solar forecast
+)
+
+// forecastDay is the produced and forecasted solar energy of a day
+type forecastDay struct {
+	Day      time.Time          `json:"day"`
+	Pv       float64            `json:"pv"`       // kWh
+	Forecast map[string]float64 `json:"forecast"` // kWh by forecast provider
+}
+
+// forecastAccuracy tracks the daily solar forecast error
+type forecastAccuracy struct {
+	Current forecastDay   `json:"current"`
+	History []forecastDay `json:"history"`
+	updated time.Time
+}
+
+// update accumulates produced and forecasted energy since last update. Completed days are added to the history.
+func (fa *forecastAccuracy) update(ts time.Time, pv float64, forecasts map[string]float64) {
+	if day := now.With(ts).BeginningOfDay(); !day.Equal(fa.Current.Day) {
+		if !fa.Current.Day.IsZero() && fa.Current.Pv > 0 {
+			fa.History = append(fa.History, fa.Current)
+			if len(fa.History) > forecastAccuracyDays {
+				fa.History = fa.History[len(fa.History)-forecastAccuracyDays:]
+			}
+		}
+
+		fa.Current = forecastDay{Day: day, Forecast: make(map[string]float64)}
+	}
+
+	if fa.Current.Forecast == nil {
+		fa.Current.Forecast = make(map[string]float64)
+	}
+
+	if !fa.updated.IsZero() {
+		from := fa.updated
+		if from.Before(fa.Current.Day) {
+			from = fa.Current.Day
+		}
+
+		hours := ts.Sub(from).Hours()
+		fa.Current.Pv += max(0, pv) * hours / 1e3
+
+		for name, forecast := range forecasts {
+			fa.Current.Forecast[name] += max(0, forecast) * hours / 1e3
+		}
+	}
+
+	fa.updated = ts
+}
+
+// metrics returns the accuracy of each forecast provider over the tracked days
+func (fa *forecastAccuracy) metrics() map[string]site.ForecastAccuracy {
+	type sum struct {
+		days           int
+		abs, err, prod float64
+	}
+
+	sums := make(map[string]*sum)
+	for _, d := range fa.History {
+		for name, forecast := range d.Forecast {
+			s, ok := sums[name]
+			if !ok {
+				s = new(sum)
+				sums[name] = s
+			}
+
+			s.days++
+			s.abs += math.Abs(forecast - d.Pv)
+			s.err += forecast - d.Pv
+			s.prod += d.Pv
+		}
+	}
+
+	res := make(map[string]site.ForecastAccuracy, len(sums))
+	for name, s := range sums {
+		res[name] = site.ForecastAccuracy{
+			Days:  s.days,
+			MAE:   s.abs / float64(s.days),
+			Bias:  s.err / float64(s.days),
+			Error: 100 * s.abs / s.prod,
+		}
+	}
+
+	return res
+}
+
+// updateForecastAccuracy accounts produced and forecasted solar energy of the effective forecast and its providers
+func (site *Site) updateForecastAccuracy() {
+	solar := site.GetTariff(api.TariffUsageSolar)
+	if solar == nil || len(site.pvMeters) == 0 || site.GetPanelsCovered() {
+		return
+	}
+
+	forecasts := make(map[string]float64)
+	if v, err := tariff.Now(solar); err == nil {
+		forecasts[forecastEffective] = v
+	}
+
+	if fp, ok := tariff.As[api.ForecastProviders](solar); ok {
+		for name, t := range fp.Providers() {
+			if v, err := tariff.Now(t); err == nil {
+				forecasts[name] = v
+			}
+		}
+	}
+
+	site.Lock()
+	day := site.forecastAccuracy.Current.Day
+	site.forecastAccuracy.update(time.Now(), site.pvPower, forecasts)
+	err := settings.SetJson(keys.ForecastAccuracy, site.forecastAccuracy)
+	site.Unlock()
+
+	if err != nil {
+		site.log.ERROR.Println("forecast accuracy:", err)
+	}
+
+	// metrics only change with completed days
+	if !site.forecastAccuracy.Current.Day.Equal(day) {
+		site.publish(keys.ForecastAccuracy, site.GetForecastAccuracy()[forecastEffective])
+	}
+}
+
+// GetForecastAccuracy implements the site.API interface
+func (site *Site) GetForecastAccuracy() map[string]site.ForecastAccuracy {
+	site.RLock()
+	defer site.RUnlock()
+	return site.forecastAccuracy.metrics()
+}
@@ -0,0 +1,51 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForecastAccuracy(t *testing.T) {
+	var fa forecastAccuracy
+
+	start := time.Date(2025, 6, 1, 0, 0, 0, 0, time.Local)
+
+	// produce given energy during one hour of the day
+	day := func(i int, pv, forecast, provider float64) {
+		ts := start.AddDate(0, 0, i)
+		fa.update(ts, 0, nil)
+		fa.update(ts.Add(10*time.Hour), 0, nil)
+		fa.update(ts.Add(11*time.Hour), pv, map[string]float64{"forecast": forecast, "forecast-1": provider})
+	}
+
+	day(0, 4000, 5000, 4000)
+	assert.Empty(t, fa.metrics(), "no completed day")
+
+	day(1, 2000, 5000, 4000)
+	day(2, 0, 0, 0) // completes day 2
+
+	m := fa.metrics()
+	assert.Equal(t, 2, m["forecast"].Days)
+	assert.InDelta(t, 2.0, m["forecast"].MAE, 1e-6)  // (1 + 3) / 2
+	assert.InDelta(t, 2.0, m["forecast"].Bias, 1e-6) // over-forecast
+	assert.InDelta(t, 100*4.0/6.0, m["forecast"].Error, 1e-6)
+
+	assert.InDelta(t, 1.0, m["forecast-1"].MAE, 1e-6) // (0 + 2) / 2
+	assert.InDelta(t, 1.0, m["forecast-1"].Bias, 1e-6)
+}
+
+func TestForecastAccuracyHistory(t *testing.T) {
+	var fa forecastAccuracy
+
+	ts := time.Date(2025, 6, 1, 10, 0, 0, 0, time.Local)
+	for i := range forecastAccuracyDays + 5 {
+		day := ts.AddDate(0, 0, i)
+		fa.update(day, 1000, map[string]float64{"forecast": 1000})
+		fa.update(day.Add(time.Hour), 1000, map[string]float64{"forecast": 1000})
+	}
+
+	assert.Len(t, fa.History, forecastAccuracyDays)
+	assert.Equal(t, 0.0, fa.metrics()["forecast"].MAE)
+}
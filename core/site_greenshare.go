@@ -0,0 +1,161 @@
+package core
+
+import (
+	"math"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/samber/lo"
+)
+
+// greenShareWindow is the time window used for smoothing the green share inputs
+const greenShareWindow = 2 * time.Minute
+
+// GreenCertificateConfig configures tagging of green charging sessions
+type GreenCertificateConfig struct {
+	Threshold float64 `mapstructure:"threshold"` // minimum green share (%) of a session to be certified as green
+}
+
+// greenPowerSample is a pv and battery power measurement taken at a point in time
+type greenPowerSample struct {
+	ts      time.Time
+	pv      float64
+	battery float64
+}
+
+// addGreenPowerSample records current pv and battery power and drops samples outside the smoothing window
+func (site *Site) addGreenPowerSample(ts time.Time) {
+	site.greenPowerSamples = append(lo.Filter(site.greenPowerSamples, func(s greenPowerSample, _ int) bool {
+		return ts.Sub(s.ts) < greenShareWindow
+	}), greenPowerSample{ts: ts, pv: site.pvPower, battery: site.batteryPower})
+}
+
+// greenPowers returns the available pv and green battery discharge power. If samples are available, pv and
+// battery power are averaged across the smoothing window to avoid green share spikes when the battery
+// briefly changes direction around zero. Battery discharge is green according to how the battery was charged.
+func (site *Site) greenPowers() (float64, float64) {
+	if len(site.greenPowerSamples) == 0 {
+		return math.Max(0, site.pvPower), math.Max(0, site.batteryPower) * site.batteryGreenFraction()
+	}
+
+	n := float64(len(site.greenPowerSamples))
+	pv := lo.SumBy(site.greenPowerSamples, func(s greenPowerSample) float64 { return s.pv }) / n
+	battery := lo.SumBy(site.greenPowerSamples, func(s greenPowerSample) float64 { return s.battery }) / n
+
+	return math.Max(0, pv), math.Max(0, battery) * site.batteryGreenFraction()
+}
+
+// greenPower returns the available green power including vehicle-to-home discharge
+func (site *Site) greenPower() float64 {
+	pv, battery := site.greenPowers()
+	return pv + battery + site.vehicleGreenPower()
+}
+
+// batteryGreenFraction returns the green fraction of the current battery discharge, weighted by discharge power
+func (site *Site) batteryGreenFraction() float64 {
+	var power, green float64
+	for i, p := range site.batteryPowers {
+		if p > 0 && i < len(site.batteryGreen) {
+			power += p
+			green += p * site.batteryGreen[i].fraction()
+		}
+	}
+
+	if power <= 0 {
+		return 1
+	}
+
+	return green / power
+}
+
+// vehicleGreenPower returns the green part of the power discharged from vehicles into the home
+func (site *Site) vehicleGreenPower() float64 {
+	var res float64
+	for i, lp := range site.loadpoints {
+		if p := lp.GetChargePower(); p < 0 {
+			fraction := 1.0
+			if i < len(site.vehicleGreen) {
+				fraction = site.vehicleGreen[i].fraction()
+			}
+			res += -p * fraction
+		}
+	}
+	return res
+}
+
+// updateStorageGreen accounts the green energy charged into or discharged from batteries and vehicles.
+// Battery charging gets green power after home consumption, vehicles according to their loadpoint's green share.
+// Stored energy follows soc and capacity where known, the resulting state is persisted.
+func (site *Site) updateStorageGreen(homePower, nonChargePower float64) {
+	ts := time.Now()
+
+	if len(site.batteryGreen) != len(site.batteryPowers) {
+		site.batteryGreen = make([]storageGreen, len(site.batteryPowers))
+	}
+
+	charge := lo.SumBy(site.batteryPowers, func(p float64) float64 { return max(0, -p) })
+	share := site.greenShare(homePower, homePower+charge)
+
+	for i, p := range site.batteryPowers {
+		site.batteryGreen[i].update(ts, -p, share)
+
+		if i < len(site.batteryStored) && site.batteryStored[i] != nil {
+			site.batteryGreen[i].sync(*site.batteryStored[i])
+		}
+	}
+
+	if len(site.vehicleGreen) != len(site.loadpoints) {
+		site.vehicleGreen = make([]storageGreen, len(site.loadpoints))
+	}
+
+	for i, lp := range site.loadpoints {
+		// stored energy leaves with the vehicle
+		if lp.GetStatus() == api.StatusA {
+			site.vehicleGreen[i] = storageGreen{}
+			continue
+		}
+
+		var share float64
+		power := lp.GetChargePower()
+		if power > 0 {
+			share = site.loadpointGreenShare(lp, nonChargePower)
+		}
+
+		site.vehicleGreen[i].update(ts, power, share)
+
+		if v := lp.GetVehicle(); v != nil && v.Capacity() > 0 && lp.GetVehicleSoc() > 0 {
+			site.vehicleGreen[i].sync(lp.GetVehicleSoc() * v.Capacity() / 100)
+		}
+	}
+
+	if err := settings.SetJson(keys.StorageGreen, storageGreenState{
+		Battery: site.batteryGreen,
+		Vehicle: site.vehicleGreen,
+	}); err != nil {
+		site.log.ERROR.Println("storage green:", err)
+	}
+}
+
+// loadpointGreenShare returns the green share of the loadpoint's charge power. Green power not consumed
+// by the home is allocated to loadpoints by priority, loadpoints of equal priority share it proportionally.
+func (site *Site) loadpointGreenShare(lp loadpoint.API, nonChargePower float64) float64 {
+	prio := lp.EffectivePriority()
+
+	var above, same float64
+	for _, other := range site.loadpoints {
+		power := max(0, other.GetChargePower())
+
+		switch p := other.EffectivePriority(); {
+		case p > prio:
+			above += power
+		case p == prio:
+			same += power
+		}
+	}
+
+	from := nonChargePower + above
+	return site.greenShare(from, from+same)
+}
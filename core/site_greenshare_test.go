@@ -0,0 +1,65 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadpointGreenShare(t *testing.T) {
+	prio := &Loadpoint{priority: 1, chargePower: 4000}
+	a := &Loadpoint{chargePower: 3000}
+	b := &Loadpoint{chargePower: 1000}
+
+	// 5kW pv surplus after 1kW home consumption
+	site := &Site{loadpoints: []*Loadpoint{prio, a, b}, pvPower: 6000}
+
+	assert.Equal(t, 1.0, site.loadpointGreenShare(prio, 1000), "priority loadpoint")
+	assert.Equal(t, 0.25, site.loadpointGreenShare(a, 1000), "equal priority shares remainder")
+	assert.Equal(t, 0.25, site.loadpointGreenShare(b, 1000), "equal priority shares remainder")
+	assert.Equal(t, 5.0/8, site.greenShare(1000, 9000), "site loadpoints share")
+}
+
+func TestStorageGreenShare(t *testing.T) {
+	lp := &Loadpoint{chargePower: -1000}
+
+	// 1kW pv and 2kW battery discharge, battery charged from grid
+	site := &Site{
+		loadpoints:    []*Loadpoint{lp},
+		pvPower:       1000,
+		batteryPower:  2000,
+		batteryPowers: []float64{2000},
+		batteryGreen:  []storageGreen{{Energy: 2, Green: 0}},
+		vehicleGreen:  []storageGreen{{Energy: 10, Green: 5}},
+	}
+
+	// half green vehicle-to-home discharge
+	assert.Equal(t, 0.5, site.greenShare(0, 3000))
+
+	// battery charged from pv
+	site.batteryGreen[0].Green = 2
+	assert.Equal(t, 3500.0/4000, site.greenShare(0, 4000))
+}
+
+func TestStorageGreenSeed(t *testing.T) {
+	site := &Site{
+		log:           util.NewLogger("foo"),
+		batteryPowers: []float64{0},
+		batteryStored: []*float64{lo.ToPtr(4.0)},
+	}
+
+	// stored energy of unknown origin is green
+	site.updateStorageGreen(0, 0)
+	assert.Equal(t, 4.0, site.batteryGreen[0].Energy)
+	assert.Equal(t, 1.0, site.batteryGreen[0].fraction())
+
+	// persisted state
+	var res storageGreenState
+	require.NoError(t, settings.Json(keys.StorageGreen, &res))
+	assert.Equal(t, []storageGreen{{Energy: 4, Green: 4}}, res.Battery)
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/jinzhu/now"
+)
+
+const evGridBudget = "gridbudget" // daily grid energy budget for charging exhausted
+
+// GridBudgetConfig limits grid energy used for charging
+type GridBudgetConfig struct {
+	Energy float64 `mapstructure:"energy"` // maximum grid energy for charging per day (kWh)
+}
+
+// gridBudget tracks grid energy used for charging per day
+type gridBudget struct {
+	Day     time.Time `json:"day"`
+	Energy  float64   `json:"energy"` // grid energy used for charging today (kWh)
+	updated time.Time
+}
+
+// update accounts grid power used for charging since last update
+func (gb *gridBudget) update(ts time.Time, gridPower, chargePower float64) {
+	if day := now.With(ts).BeginningOfDay(); !day.Equal(gb.Day) {
+		*gb = gridBudget{Day: day}
+	}
+
+	if !gb.updated.IsZero() {
+		gb.Energy += min(max(0, gridPower), max(0, chargePower)) * ts.Sub(gb.updated).Hours() / 1e3
+	}
+
+	gb.updated = ts
+}
+
+// updateGridBudget accounts grid energy used for charging and restricts loadpoints to pv charging once the budget is exhausted
+func (site *Site) updateGridBudget(chargePower float64) {
+	budget := site.GridBudget.Energy
+	if budget <= 0 || site.gridMeter == nil {
+		return
+	}
+
+	site.gridBudget.update(time.Now(), site.gridPower, chargePower)
+
+	if err := settings.SetJson(keys.GridBudget, site.gridBudget); err != nil {
+		site.log.ERROR.Println("grid budget:", err)
+	}
+
+	exceeded := site.gridBudget.Energy >= budget
+	for _, lp := range site.loadpoints {
+		if exceeded && !lp.gridBudgetExceeded {
+			lp.log.INFO.Println("grid budget exhausted, falling back to pv charging")
+		}
+		lp.gridBudgetExceeded = exceeded
+	}
+
+	if exceeded && !site.gridBudgetExceeded {
+		site.log.WARN.Printf("grid budget: %.1fkWh of %.1fkWh used", site.gridBudget.Energy, budget)
+		site.pushEvent(evGridBudget)
+	}
+	site.gridBudgetExceeded = exceeded
+
+	site.publish(keys.GridBudgetEnergy, site.gridBudget.Energy)
+	site.publish(keys.GridBudgetExceeded, exceeded)
+}
@@ -0,0 +1,32 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGridBudgetUpdate(t *testing.T) {
+	var gb gridBudget
+
+	ts := time.Date(2025, 1, 1, 10, 0, 0, 0, time.Local)
+	gb.update(ts, 5000, 11000)
+	assert.Equal(t, 0.0, gb.Energy, "first update")
+
+	// grid import limited by charge power
+	gb.update(ts.Add(time.Hour), 5000, 11000)
+	assert.Equal(t, 5.0, gb.Energy)
+
+	// charging from pv
+	gb.update(ts.Add(2*time.Hour), -1000, 11000)
+	assert.Equal(t, 5.0, gb.Energy)
+
+	// household consumption is not counted
+	gb.update(ts.Add(3*time.Hour), 2000, 1000)
+	assert.Equal(t, 6.0, gb.Energy)
+
+	// reset at midnight
+	gb.update(ts.Add(14*time.Hour), 2000, 2000)
+	assert.Equal(t, 0.0, gb.Energy)
+}
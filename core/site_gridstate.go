@@ -0,0 +1,54 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/eventlog"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/push"
+	"github.com/evcc-io/evcc/tariff"
+)
+
+const evGridStress = "gridstress" // grid stress period started
+
+// plannerTariff returns the loadpoint's planner tariff, adjusted to avoid grid stress periods if grid state is available
+func (site *Site) plannerTariff(lp *Loadpoint) api.Tariff {
+	res := site.GetTariff(api.TariffUsagePlanner)
+	if lp.tariff != nil {
+		res = lp.tariff
+	}
+	if gridState := site.GetTariff(api.TariffUsageGridState); res != nil && gridState != nil {
+		res = tariff.NewGridStateAdjusted(res, gridState)
+	}
+	return res
+}
+
+// pushEvent records the site alert and sends push messages to clients
+func (site *Site) pushEvent(event string) {
+	eventlog.Record(eventlog.CategoryAlert, "site", event, "")
+	site.pushEventWithAttachment(event, nil)
+}
+
+// pushEventWithAttachment sends push messages with optional png image to clients
+func (site *Site) pushEventWithAttachment(event string, attachment []byte) {
+	if site.pushChan != nil {
+		site.pushChan <- push.Event{Event: event, Attachment: attachment}
+	}
+}
+
+// updateGridState publishes the regional grid state and notifies when grid stress starts
+func (site *Site) updateGridState() {
+	state, err := tariff.Now(site.GetTariff(api.TariffUsageGridState))
+	if err != nil {
+		return
+	}
+
+	site.publish(keys.GridState, state)
+
+	stressed := state >= tariff.GridStateStressed
+	if stressed && !site.gridStressed {
+		site.log.WARN.Printf("grid stress: state %.0f", state)
+		site.pushEvent(evGridStress)
+	}
+
+	site.gridStressed = stressed
+}
@@ -0,0 +1,17 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff"
+)
+
+// heatingTariff returns the planner tariff for heat pump loadpoints, valued by temperature-dependent efficiency
+func (site *Site) heatingTariff(lp *Loadpoint, res api.Tariff) api.Tariff {
+	temperature := site.GetTariff(api.TariffUsageTemperature)
+	if res == nil || temperature == nil || lp.Cop.Nominal <= 0 || !lp.chargerHasFeature(api.Heating) {
+		return res
+	}
+
+	lp.log.DEBUG.Printf("planner: using cop %.1f at %.0f°C", lp.Cop.Nominal, lp.Cop.Reference)
+	return tariff.NewCopAdjusted(res, temperature, lp.Cop.Cop)
+}
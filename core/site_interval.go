@@ -0,0 +1,42 @@
+package core
+
+import (
+	"slices"
+	"time"
+)
+
+// AdaptiveIntervalConfig adapts the control interval to the control activity
+type AdaptiveIntervalConfig struct {
+	Regulation time.Duration `mapstructure:"regulation"` // additional control steps while charge current is being adjusted
+	Idle       time.Duration `mapstructure:"idle"`       // reduced control interval while no vehicle is connected
+}
+
+// regulating determines if the charge current has been adjusted within given duration while charging
+func (lp *Loadpoint) regulating(d time.Duration) bool {
+	return lp.charging() && lp.clock.Since(lp.currentAdjusted) < d
+}
+
+// regulationTicker returns the ticker for additional control steps in between updates, nil if not configured
+func (site *Site) regulationTicker(interval time.Duration) <-chan time.Time {
+	if d := site.AdaptiveInterval.Regulation; d > 0 && d < interval {
+		return time.Tick(d)
+	}
+	return nil
+}
+
+// regulatingLoadpoint returns the loadpoint whose charge current is being adjusted, nil if none
+func (site *Site) regulatingLoadpoint(interval time.Duration) *Loadpoint {
+	if i := slices.IndexFunc(site.loadpoints, func(lp *Loadpoint) bool {
+		return lp.regulating(interval)
+	}); i >= 0 {
+		return site.loadpoints[i]
+	}
+	return nil
+}
+
+// idle determines if the control step can be skipped while no vehicle is connected.
+// Meters are not polled in between, battery and feed-in control follow the reduced interval.
+func (site *Site) idle(now time.Time) bool {
+	return site.AdaptiveInterval.Idle > 0 && now.Sub(site.updated) < site.AdaptiveInterval.Idle &&
+		!slices.ContainsFunc(site.loadpoints, (*Loadpoint).connected)
+}
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegulationTicker(t *testing.T) {
+	const interval = 30 * time.Second
+
+	site := new(Site)
+	assert.Nil(t, site.regulationTicker(interval), "not configured")
+
+	site.AdaptiveInterval.Regulation = interval
+	assert.Nil(t, site.regulationTicker(interval), "not shorter than interval")
+
+	site.AdaptiveInterval.Regulation = 10 * time.Second
+	assert.NotNil(t, site.regulationTicker(interval))
+}
+
+func TestRegulating(t *testing.T) {
+	const interval = 30 * time.Second
+
+	clock := clock.NewMock()
+	lp := &Loadpoint{
+		clock:  clock,
+		status: api.StatusB,
+	}
+
+	lp.currentAdjusted = clock.Now()
+	assert.False(t, lp.regulating(interval), "not charging")
+
+	lp.status = api.StatusC
+	assert.True(t, lp.regulating(interval), "regulating")
+
+	site := &Site{loadpoints: []*Loadpoint{{clock: clock}, lp}}
+	assert.Equal(t, lp, site.regulatingLoadpoint(interval))
+
+	clock.Add(interval)
+	assert.False(t, lp.regulating(interval), "charging at constant current")
+	assert.Nil(t, site.regulatingLoadpoint(interval))
+}
+
+func TestIdle(t *testing.T) {
+	lp := &Loadpoint{status: api.StatusA}
+	site := &Site{loadpoints: []*Loadpoint{lp}}
+
+	now := time.Now()
+	site.updated = now
+	assert.False(t, site.idle(now), "not configured")
+
+	site.AdaptiveInterval.Idle = 2 * time.Minute
+	assert.True(t, site.idle(now.Add(time.Minute)), "idle")
+	assert.False(t, site.idle(now.Add(2*time.Minute)), "idle interval elapsed")
+
+	lp.status = api.StatusB
+	assert.False(t, site.idle(now.Add(time.Minute)), "vehicle connected")
+}
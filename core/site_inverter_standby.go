@@ -0,0 +1,160 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/jinzhu/now"
+)
+
+// InverterStandbyConfig puts inverters into standby during long periods without production, e.g. overnight or on winter days
+type InverterStandbyConfig struct {
+	Delay       time.Duration `mapstructure:"delay"`       // duration without production before standby
+	Wakeup      time.Duration `mapstructure:"wakeup"`      // wake up this long before forecasted production
+	Threshold   float64       `mapstructure:"threshold"`   // pv power considered as production (W)
+	Consumption float64       `mapstructure:"consumption"` // consumption of each inverter while operating, saved during standby (W)
+}
+
+// inverterStandby tracks standby state and energy saved by standby
+type inverterStandby struct {
+	Month       time.Time `json:"month"`
+	MonthEnergy float64   `json:"monthEnergy"` // energy saved this month (kWh)
+	YearEnergy  float64   `json:"yearEnergy"`  // energy saved this year (kWh)
+	TotalEnergy float64   `json:"totalEnergy"` // energy saved in total (kWh)
+	Active      bool      `json:"active"`      // inverters in standby, persisted to wake them up after restart
+
+	synced  bool      // standby state sent to inverters since start
+	idle    time.Time // start of current period without production
+	updated time.Time
+}
+
+// update accounts energy saved by inverters in standby since last update
+func (is *inverterStandby) update(ts time.Time, inverters int, consumption float64) {
+	if month := now.With(ts).BeginningOfMonth(); !month.Equal(is.Month) {
+		if month.Year() != is.Month.Year() {
+			is.YearEnergy = 0
+		}
+		is.Month = month
+		is.MonthEnergy = 0
+	}
+
+	if is.Active && !is.updated.IsZero() {
+		energy := float64(inverters) * consumption * ts.Sub(is.updated).Hours() / 1e3
+		is.MonthEnergy += energy
+		is.YearEnergy += energy
+		is.TotalEnergy += energy
+	}
+
+	is.updated = ts
+}
+
+// nextProduction returns the start of the next forecasted production or zero time if not within the forecast
+func nextProduction(rates api.Rates, ts time.Time, threshold float64) time.Time {
+	for _, r := range rates {
+		if !r.End.After(ts) || r.Price < threshold {
+			continue
+		}
+
+		if r.Start.Before(ts) {
+			return ts
+		}
+		return r.Start
+	}
+
+	return time.Time{}
+}
+
+// standby determines if inverters should be in standby. Standby requires a forecast to wake up in time.
+func (is *inverterStandby) standby(ts, next time.Time, pvPower float64, conf InverterStandbyConfig) bool {
+	if pvPower > conf.Threshold {
+		is.idle = time.Time{}
+		return false
+	}
+
+	if is.idle.IsZero() {
+		is.idle = ts
+	}
+
+	return ts.Sub(is.idle) >= conf.Delay && !next.IsZero() && next.Sub(ts) > conf.Wakeup
+}
+
+// inverterStandbys returns the pv meters supporting standby
+func (site *Site) inverterStandbys() map[int]api.InverterStandby {
+	res := make(map[int]api.InverterStandby)
+	for i, meter := range site.pvMeters {
+		if is, ok := deviceFeature[api.InverterStandby](meter, api.Standby); ok {
+			res[i] = is
+		}
+	}
+	return res
+}
+
+// setInverterStandby puts all supporting inverters into standby or wakes them up
+func (site *Site) setInverterStandby(inverters map[int]api.InverterStandby, standby bool) error {
+	var errs []error
+	for i, is := range inverters {
+		if err := is.SetStandby(standby); err != nil {
+			errs = append(errs, fmt.Errorf("pv %d: %w", i+1, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// updateInverterStandby puts inverters into standby without production and wakes them up on forecasted production
+func (site *Site) updateInverterStandby() {
+	if site.InverterStandby.Delay <= 0 {
+		return
+	}
+
+	inverters := site.inverterStandbys()
+	if len(inverters) == 0 {
+		return
+	}
+
+	conf := site.InverterStandby
+	if conf.Wakeup <= 0 {
+		conf.Wakeup = 30 * time.Minute
+	}
+	if conf.Threshold <= 0 {
+		conf.Threshold = 50
+	}
+
+	ts := time.Now()
+
+	var next time.Time
+	if t := site.GetTariff(api.TariffUsageSolar); t != nil {
+		if rates, err := t.Rates(); err == nil {
+			next = nextProduction(rates, ts, conf.Threshold)
+		}
+	}
+
+	site.inverterStandby.update(ts, len(inverters), conf.Consumption)
+	persist := site.inverterStandby.Active
+
+	// wakes up if the forecast is not available. The state is sent once after start since
+	// inverters may have been put into standby before the restart.
+	if standby := site.inverterStandby.standby(ts, next, site.pvPower, conf); standby != site.inverterStandby.Active || !site.inverterStandby.synced {
+		if err := site.setInverterStandby(inverters, standby); err != nil {
+			site.log.ERROR.Println("inverter standby:", err)
+		} else {
+			site.log.DEBUG.Printf("inverter standby: %t, next production: %v", standby, next.Round(time.Minute))
+			site.inverterStandby.Active = standby
+			site.inverterStandby.synced = true
+			persist = true
+		}
+	}
+
+	// persist standby state and savings accounted while in standby
+	if persist {
+		if err := settings.SetJson(keys.InverterSavings, site.inverterStandby); err != nil {
+			site.log.ERROR.Println("inverter standby:", err)
+		}
+	}
+
+	site.publish(keys.InverterStandby, site.inverterStandby.Active)
+	site.publish(keys.InverterSavings, site.inverterStandby.TotalEnergy)
+}
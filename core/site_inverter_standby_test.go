@@ -0,0 +1,90 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInverterStandby(t *testing.T) {
+	ts := time.Date(2024, 1, 31, 22, 0, 0, 0, time.Local)
+	morning := time.Date(2024, 2, 1, 8, 0, 0, 0, time.Local)
+
+	rates := api.Rates{
+		{Start: ts, End: morning, Price: 0},
+		{Start: morning, End: morning.Add(time.Hour), Price: 300},
+	}
+
+	next := nextProduction(rates, ts, 50)
+	assert.Equal(t, morning, next)
+	assert.Equal(t, morning.Add(30*time.Minute), nextProduction(rates, morning.Add(30*time.Minute), 50))
+	assert.True(t, nextProduction(rates, ts, 500).IsZero())
+
+	conf := InverterStandbyConfig{Delay: time.Hour, Wakeup: 30 * time.Minute, Threshold: 50}
+
+	var is inverterStandby
+	assert.False(t, is.standby(ts, next, 0, conf), "delay")
+	assert.True(t, is.standby(ts.Add(time.Hour), next, 0, conf))
+	assert.False(t, is.standby(ts.Add(time.Hour), time.Time{}, 0, conf), "no forecast")
+	assert.False(t, is.standby(morning.Add(-20*time.Minute), next, 0, conf), "wakeup")
+	assert.False(t, is.standby(ts.Add(time.Hour), next, 100, conf), "production")
+	assert.False(t, is.standby(ts.Add(90*time.Minute), next, 0, conf), "delay restarted")
+
+	// savings of 2 inverters consuming 10W
+	is.update(ts, 2, 10)
+	is.Active = true
+	is.update(ts.Add(5*time.Hour), 2, 10)
+	assert.InDelta(t, 0.1, is.TotalEnergy, 1e-6)
+	assert.InDelta(t, 0.1, is.YearEnergy, 1e-6)
+	assert.InDelta(t, 0.1, is.MonthEnergy, 1e-6)
+
+	// new year
+	is.update(ts.AddDate(0, 1, 1), 2, 0)
+	assert.Zero(t, is.MonthEnergy)
+	assert.InDelta(t, 0.1, is.YearEnergy, 1e-6)
+	is.update(ts.AddDate(1, 0, 1), 2, 0)
+	assert.Zero(t, is.YearEnergy)
+	assert.InDelta(t, 0.1, is.TotalEnergy, 1e-6)
+}
+
+type standbyPvMeter struct {
+	standby []bool
+}
+
+func (m *standbyPvMeter) CurrentPower() (float64, error) {
+	return 0, nil
+}
+
+func (m *standbyPvMeter) SetStandby(standby bool) error {
+	m.standby = append(m.standby, standby)
+	return nil
+}
+
+func TestInverterStandbyRestart(t *testing.T) {
+	pv := new(standbyPvMeter)
+
+	// restarted while inverters are asleep, no forecast to stay asleep
+	site := &Site{
+		log:             util.NewLogger("foo"),
+		pvMeters:        []api.Meter{pv},
+		tariffs:         new(tariff.Tariffs),
+		InverterStandby: InverterStandbyConfig{Delay: time.Hour},
+		inverterStandby: inverterStandby{Active: true},
+	}
+
+	site.updateInverterStandby()
+	assert.Equal(t, []bool{false}, pv.standby, "wake up after restart")
+	assert.False(t, site.inverterStandby.Active)
+
+	site.updateInverterStandby()
+	assert.Equal(t, []bool{false}, pv.standby, "unchanged state not sent again")
+
+	// unknown state after restart is sent explicitly
+	site.inverterStandby = inverterStandby{}
+	site.updateInverterStandby()
+	assert.Equal(t, []bool{false, false}, pv.standby)
+}
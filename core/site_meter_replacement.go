@@ -0,0 +1,58 @@
+package core
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db/settings"
+)
+
+// meterRefs returns the references of all site meters
+func (site *Site) meterRefs() []string {
+	res := []string{site.Meters.GridMeterRef}
+	res = append(res, site.Meters.PVMetersRef...)
+	res = append(res, site.Meters.BatteryMetersRef...)
+	res = append(res, site.Meters.ExtMetersRef...)
+	return append(res, site.Meters.AuxMetersRef...)
+}
+
+// SetMeterReplacement registers the replacement of a meter's energy counter. The difference between the
+// final reading of the old counter and the initial reading of the new counter is added to all future readings.
+func (site *Site) SetMeterReplacement(ref string, oldEnergy, newEnergy float64) error {
+	if oldEnergy < 0 || newEnergy < 0 {
+		return fmt.Errorf("invalid energy: %.3fkWh, %.3fkWh", oldEnergy, newEnergy)
+	}
+
+	site.Lock()
+	defer site.Unlock()
+
+	if ref == "" || !slices.Contains(site.meterRefs(), ref) {
+		return fmt.Errorf("unknown meter: %s", ref)
+	}
+
+	if site.meterOffsets == nil {
+		site.meterOffsets = make(map[string]float64)
+	}
+
+	// keep corrections of earlier replacements
+	site.meterOffsets[ref] += oldEnergy - newEnergy
+	site.log.INFO.Printf("meter %s replaced: energy offset %.3fkWh", ref, site.meterOffsets[ref])
+
+	if err := settings.SetJson(keys.MeterOffsets, site.meterOffsets); err != nil {
+		return err
+	}
+
+	site.publish(keys.MeterOffsets, maps.Clone(site.meterOffsets))
+
+	return nil
+}
+
+// meterEnergy corrects the energy counter reading of a replaced meter
+func (site *Site) meterEnergy(ref string, energy float64) float64 {
+	site.RLock()
+	defer site.RUnlock()
+
+	return energy + site.meterOffsets[ref]
+}
@@ -0,0 +1,34 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeterReplacement(t *testing.T) {
+	site := &Site{
+		log: util.NewLogger("foo"),
+		Meters: MetersConfig{
+			GridMeterRef: "grid",
+			PVMetersRef:  []string{"pv1", "pv2"},
+		},
+	}
+
+	assert.Equal(t, 100.0, site.meterEnergy("pv1", 100))
+
+	require.NoError(t, site.SetMeterReplacement("pv1", 12000, 10))
+	assert.Equal(t, 12000.0, site.meterEnergy("pv1", 10))
+	assert.Equal(t, 12100.0, site.meterEnergy("pv1", 110))
+	assert.Equal(t, 100.0, site.meterEnergy("pv2", 100), "other meters unchanged")
+
+	// second replacement keeps earlier correction
+	require.NoError(t, site.SetMeterReplacement("pv1", 500, 0))
+	assert.Equal(t, 12490.0, site.meterEnergy("pv1", 0))
+
+	assert.Error(t, site.SetMeterReplacement("foo", 100, 0), "unknown meter")
+	assert.Error(t, site.SetMeterReplacement("", 100, 0), "unknown meter")
+	assert.Error(t, site.SetMeterReplacement("grid", -1, 0), "invalid energy")
+}
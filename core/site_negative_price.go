@@ -0,0 +1,51 @@
+package core
+
+import (
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/tariff"
+)
+
+// NegativePriceConfig configures the handling of negative prices
+type NegativePriceConfig struct {
+	Charge      bool `mapstructure:"charge"`      // charge vehicles and battery from grid while the grid price is negative
+	BlockFeedIn bool `mapstructure:"blockFeedIn"` // curtail pv feed-in while the feed-in price is negative
+}
+
+// negativePrice is the state of negative price handling
+type negativePrice struct {
+	charge      bool // grid price negative, charging from grid
+	blockFeedIn bool // feed-in price negative, feed-in curtailed
+}
+
+// updateNegativePrice determines if negative prices apply and forces loadpoints to charge from grid
+func (site *Site) updateNegativePrice() {
+	conf := site.NegativePrice
+	if !conf.Charge && !conf.BlockFeedIn {
+		return
+	}
+
+	var res negativePrice
+
+	if conf.Charge {
+		price, err := tariff.Now(site.GetTariff(api.TariffUsageGrid))
+		res.charge = err == nil && price < 0
+	}
+
+	if conf.BlockFeedIn {
+		price, err := tariff.Now(site.GetTariff(api.TariffUsageFeedIn))
+		res.blockFeedIn = err == nil && price < 0
+	}
+
+	if res != site.negativePrice {
+		site.log.DEBUG.Printf("negative price: charge %t, block feed-in %t", res.charge, res.blockFeedIn)
+	}
+	site.negativePrice = res
+
+	for _, lp := range site.loadpoints {
+		lp.negativePriceCharge = res.charge
+	}
+
+	site.publish(keys.NegativePriceCharge, res.charge)
+	site.publish(keys.NegativePriceFeedIn, res.blockFeedIn)
+}
@@ -0,0 +1,56 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNegativePrice(t *testing.T) {
+	fixed := func(price float64) api.Tariff {
+		res, err := tariff.NewFixedFromConfig(map[string]any{"price": price})
+		require.NoError(t, err)
+		return res
+	}
+
+	pv := &limitedPvMeter{maxPower: 10000}
+	lp := &Loadpoint{}
+
+	site := &Site{
+		log:           util.NewLogger("foo"),
+		gridMeter:     &limitedPvMeter{},
+		pvMeters:      []api.Meter{pv},
+		loadpoints:    []*Loadpoint{lp},
+		tariffs:       &tariff.Tariffs{Grid: fixed(-0.02), FeedIn: fixed(-0.05)},
+		NegativePrice: NegativePriceConfig{Charge: true, BlockFeedIn: true},
+	}
+
+	site.updateNegativePrice()
+	assert.True(t, lp.negativePriceCharge)
+	assert.True(t, site.batteryGridChargeActive(api.Rate{}))
+
+	// curtail feed-in following consumption
+	site.pvPower, site.gridPower = 5000, -3000
+	site.updateExportLimit()
+	assert.Equal(t, 2000.0, pv.limit)
+
+	// all pv exported, output is curtailed completely
+	site.pvPower, site.gridPower = 2000, -2000
+	site.updateExportLimit()
+	assert.Equal(t, 0.0, pv.limit)
+	assert.True(t, pv.limited)
+
+	// prices positive again
+	site.tariffs = &tariff.Tariffs{Grid: fixed(0.3), FeedIn: fixed(0.08)}
+
+	site.updateNegativePrice()
+	assert.False(t, lp.negativePriceCharge)
+	assert.False(t, site.batteryGridChargeActive(api.Rate{}))
+
+	site.updateExportLimit()
+	assert.False(t, pv.limited)
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/plugin"
+	"github.com/evcc-io/evcc/server/db/settings"
+)
+
+// configurePanelsCovered creates the sensor indicating pv panels covered e.g. by snow
+func (site *Site) configurePanelsCovered(ctx context.Context, source *plugin.Config) error {
+	var err error
+	if site.panelsCoveredG, err = source.BoolGetter(ctx); err != nil {
+		return fmt.Errorf("panels covered source: %w", err)
+	}
+
+	return nil
+}
+
+// GetPanelsCovered returns true if pv panels are covered, set manually or by sensor
+func (site *Site) GetPanelsCovered() bool {
+	site.RLock()
+	defer site.RUnlock()
+	return site.panelsCovered || site.panelsCoveredSensor
+}
+
+// SetPanelsCovered sets the pv panels covered manually, e.g. by snow
+func (site *Site) SetPanelsCovered(val bool) error {
+	site.log.DEBUG.Println("set panels covered:", val)
+
+	site.Lock()
+	defer site.Unlock()
+
+	if site.panelsCovered != val {
+		site.panelsCovered = val
+		settings.SetBool(keys.PanelsCovered, val)
+		site.publish(keys.PanelsCovered, val || site.panelsCoveredSensor)
+	}
+
+	return nil
+}
+
+// updatePanelsCovered reads the panels covered sensor.
+// While covered the solar forecast is not used for planning and forecast learning is suspended.
+func (site *Site) updatePanelsCovered() {
+	if site.panelsCoveredG == nil {
+		return
+	}
+
+	covered, err := site.panelsCoveredG()
+	if err != nil {
+		site.log.ERROR.Printf("panels covered: %v", err)
+		return
+	}
+
+	site.Lock()
+	changed := covered != site.panelsCoveredSensor
+	site.panelsCoveredSensor = covered
+	site.Unlock()
+
+	if changed {
+		site.log.DEBUG.Println("panels covered sensor:", covered)
+		site.publish(keys.PanelsCovered, site.GetPanelsCovered())
+	}
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/evcc-io/evcc/util"
+	"github.com/jinzhu/now"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPanelsCovered(t *testing.T) {
+	ts := now.BeginningOfHour()
+
+	var covered bool
+	site := &Site{
+		log:            util.NewLogger("foo"),
+		pvMeters:       []api.Meter{&limitedPvMeter{}},
+		tariffs:        &tariff.Tariffs{Solar: planeTariff{{Start: ts, End: ts.Add(time.Hour), Price: 1000}}},
+		panelsCoveredG: func() (bool, error) { return covered, nil },
+	}
+
+	solar, _ := site.solarForecastRates()
+	assert.Len(t, solar, 1)
+
+	// sensor
+	covered = true
+	site.updatePanelsCovered()
+	assert.True(t, site.GetPanelsCovered())
+
+	solar, _ = site.solarForecastRates()
+	assert.Empty(t, solar)
+
+	// learning suspended
+	site.pvPower = 0
+	site.updateSolarForecast()
+	assert.Zero(t, site.solarForecast.ForecastEnergy)
+	assert.True(t, site.solarForecast.updated.IsZero())
+
+	// manual flag remains after sensor cleared
+	site.panelsCovered = true
+	covered = false
+	site.updatePanelsCovered()
+	assert.True(t, site.GetPanelsCovered())
+
+	site.panelsCovered = false
+	assert.False(t, site.GetPanelsCovered())
+}
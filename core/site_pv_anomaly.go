@@ -0,0 +1,98 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/tariff"
+)
+
+const (
+	evPvAnomaly          = "pvanomaly" // pv production lags forecast
+	pvAnomalyMinForecast = 200.0       // W, ignore periods of low forecast like dawn and dusk
+)
+
+// PvAnomalyConfig configures monitoring of pv production against forecast
+type PvAnomalyConfig struct {
+	Ratio    float64       `mapstructure:"ratio"`    // alert if production is below this share of forecast
+	Duration time.Duration `mapstructure:"duration"` // evaluation period
+}
+
+// pvAnomaly compares pv and forecast energy during daylight over consecutive periods
+type pvAnomaly struct {
+	start, updated           time.Time
+	pvEnergy, forecastEnergy float64 // Wh
+	active                   bool
+}
+
+// update accumulates energy and returns true if the evaluation period has completed
+func (pa *pvAnomaly) update(ts time.Time, pv, forecast float64, conf PvAnomalyConfig) bool {
+	defer func() { pa.updated = ts }()
+
+	// daylight only
+	if forecast < pvAnomalyMinForecast {
+		pa.start = time.Time{}
+		return false
+	}
+
+	if pa.start.IsZero() || pa.updated.IsZero() {
+		pa.start = ts
+		pa.pvEnergy, pa.forecastEnergy = 0, 0
+		return false
+	}
+
+	hours := ts.Sub(pa.updated).Hours()
+	pa.pvEnergy += max(0, pv) * hours
+	pa.forecastEnergy += forecast * hours
+
+	if ts.Sub(pa.start) < conf.Duration {
+		return false
+	}
+
+	pa.active = pa.pvEnergy < conf.Ratio*pa.forecastEnergy
+	pa.start = ts
+	pa.pvEnergy, pa.forecastEnergy = 0, 0
+
+	return true
+}
+
+// updatePvAnomaly alerts if pv production lags the forecast
+func (site *Site) updatePvAnomaly() {
+	if site.PvAnomaly.Ratio <= 0 || site.GetPanelsCovered() {
+		return
+	}
+
+	forecast, err := tariff.Now(site.GetTariff(api.TariffUsageSolar))
+	if err != nil {
+		return
+	}
+
+	conf := site.PvAnomaly
+	if conf.Duration == 0 {
+		conf.Duration = 3 * time.Hour
+	}
+
+	active := site.pvAnomaly.active
+	if !site.pvAnomaly.update(time.Now(), site.pvPower, forecast, conf) || site.pvAnomaly.active == active {
+		return
+	}
+
+	site.publish(keys.PvAnomaly, site.pvAnomaly.active)
+
+	if site.pvAnomaly.active {
+		site.log.WARN.Printf("pv production below %.0f%% of forecast for %v", conf.Ratio*100, conf.Duration)
+		site.pushEvent(evPvAnomaly)
+	}
+}
+
+// recordProduction feeds measured pv production to self-learning solar forecasts
+func (site *Site) recordProduction() {
+	if site.GetPanelsCovered() {
+		return
+	}
+
+	if pr, ok := tariff.As[api.ProductionRecorder](site.GetTariff(api.TariffUsageSolar)); ok && len(site.pvMeters) > 0 {
+		pr.RecordProduction(time.Now(), site.pvPower)
+	}
+}
@@ -0,0 +1,43 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPvAnomaly(t *testing.T) {
+	conf := PvAnomalyConfig{Ratio: 0.3, Duration: time.Hour}
+	ts := time.Date(2025, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	var pa pvAnomaly
+
+	run := func(pv, forecast float64) (done bool) {
+		for range 6 {
+			ts = ts.Add(10 * time.Minute)
+			done = pa.update(ts, pv, forecast, conf) || done
+		}
+		return done
+	}
+
+	// start period
+	assert.False(t, pa.update(ts, 0, 5000, conf))
+
+	// production as expected
+	assert.True(t, run(4000, 5000))
+	assert.False(t, pa.active)
+
+	// tripped inverter
+	assert.True(t, run(100, 5000))
+	assert.True(t, pa.active)
+
+	// night resets period
+	assert.False(t, run(0, 0))
+	assert.True(t, pa.active)
+
+	// recovered
+	assert.False(t, pa.update(ts, 5000, 5000, conf))
+	assert.True(t, run(5000, 5000))
+	assert.False(t, pa.active)
+}
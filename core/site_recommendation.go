@@ -0,0 +1,92 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/planner"
+)
+
+const (
+	recommendationInterval  = 15 * time.Minute // update interval
+	recommendationHorizon   = 24 * time.Hour   // consider plug-in times within horizon
+	recommendationSessions  = 10               // number of recent sessions for typical energy
+	recommendationMinSaving = 0.1              // minimum saving (currency) worth a recommendation
+)
+
+// recommendation suggests when to plug in for charging the typical session energy at lowest cost
+type recommendation struct {
+	Start   time.Time `json:"start"`
+	Energy  float64   `json:"energy"`  // kWh
+	Savings float64   `json:"savings"` // compared to charging now
+}
+
+// chargeRecommendation returns the cheapest plug-in time for charging energy (kWh) with power (W)
+func chargeRecommendation(rr api.Rates, ts time.Time, energy, power float64) (recommendation, bool) {
+	if energy <= 0 || power <= 0 {
+		return recommendation{}, false
+	}
+
+	d := time.Duration(energy * 1e3 / power * float64(time.Hour))
+
+	costNow, err := planner.Cost(api.Rates{{Start: ts, End: ts.Add(d)}}, rr, power)
+	if err != nil {
+		return recommendation{}, false
+	}
+
+	var future api.Rates
+	for _, r := range rr {
+		if !r.Start.Before(ts) && r.Start.Before(ts.Add(recommendationHorizon)) {
+			future = append(future, r)
+		}
+	}
+
+	window, ok := priceWindow(future, d, false)
+	if !ok {
+		return recommendation{}, false
+	}
+
+	savings := costNow - window.Price*energy
+	if savings < recommendationMinSaving {
+		return recommendation{}, false
+	}
+
+	return recommendation{
+		Start:   window.Start,
+		Energy:  energy,
+		Savings: savings,
+	}, true
+}
+
+// updateRecommendations publishes the recommended plug-in time for loadpoints without connected vehicle
+func (site *Site) updateRecommendations() {
+	if time.Since(site.recommendationUpdated) < recommendationInterval {
+		return
+	}
+	site.recommendationUpdated = time.Now()
+
+	gt := site.GetTariff(api.TariffUsageGrid)
+	if gt == nil || gt.Type() != api.TariffTypePriceForecast {
+		return
+	}
+
+	rr, err := gt.Rates()
+	if err != nil {
+		return
+	}
+
+	for _, lp := range site.loadpoints {
+		var res *recommendation
+
+		if lp.db != nil && !lp.connected() {
+			if energy, err := lp.db.TypicalEnergy(recommendationSessions); err == nil {
+				if r, ok := chargeRecommendation(rr, time.Now(), energy, lp.EffectiveMaxPower()); ok {
+					res = &r
+				}
+			}
+		}
+
+		lp.publish(keys.Recommendation, res)
+	}
+}
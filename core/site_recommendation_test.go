@@ -0,0 +1,38 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/jinzhu/now"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChargeRecommendation(t *testing.T) {
+	ts := now.BeginningOfHour()
+
+	var rr api.Rates
+	for i, price := range []float64{0.4, 0.4, 0.3, 0.1, 0.1, 0.3} {
+		rr = append(rr, api.Rate{
+			Start: ts.Add(time.Duration(i) * time.Hour),
+			End:   ts.Add(time.Duration(i+1) * time.Hour),
+			Price: price,
+		})
+	}
+
+	// 22kWh at 11kW
+	res, ok := chargeRecommendation(rr, ts, 22, 11e3)
+	require.True(t, ok)
+	assert.Equal(t, ts.Add(3*time.Hour), res.Start)
+	assert.InDelta(t, 22*0.4-22*0.1, res.Savings, 1e-9)
+
+	// no savings when charging now is cheapest
+	_, ok = chargeRecommendation(rr[3:], ts.Add(3*time.Hour), 22, 11e3)
+	assert.False(t, ok)
+
+	// no typical energy
+	_, ok = chargeRecommendation(rr, ts, 0, 11e3)
+	assert.False(t, ok)
+}
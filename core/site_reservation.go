@@ -0,0 +1,44 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// reservedPower returns the part of the forecasted solar power reserved for other loadpoints and not used by them.
+// The reserved power is added to the site power of the updated loadpoint. Without solar forecast nothing is reserved.
+func (site *Site) reservedPower(lp updater, sitePower, totalChargePower float64) float64 {
+	// surplus available for charging
+	surplus := totalChargePower - sitePower
+	if surplus <= 0 {
+		return 0
+	}
+
+	now := time.Now()
+
+	forecast, err := site.GetSolarForecast().At(now)
+	if err != nil {
+		return 0
+	}
+
+	var res float64
+	for _, other := range site.loadpoints {
+		if updater(other) == lp {
+			continue
+		}
+
+		share := other.GetSolarReservation(now)
+		if share == 0 || !other.acceptsPower() {
+			continue
+		}
+
+		if mode := other.GetMode(); mode != api.ModePV && mode != api.ModeMinPV {
+			continue
+		}
+
+		res += max(0, share*forecast.Price-other.GetChargePower())
+	}
+
+	return min(res, surplus)
+}
@@ -0,0 +1,71 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSolarReservation(t *testing.T) {
+	lp := &Loadpoint{Reservation: loadpoint.ReservationConfig{Share: 60, Weekdays: []int{1, 2, 3, 4, 5}}}
+
+	monday := time.Date(2025, 1, 6, 12, 0, 0, 0, time.Local)
+	assert.Equal(t, 0.6, lp.GetSolarReservation(monday))
+	assert.Equal(t, 0.0, lp.GetSolarReservation(monday.AddDate(0, 0, -1)), "sunday")
+
+	lp.Reservation.Weekdays = nil
+	assert.Equal(t, 0.6, lp.GetSolarReservation(monday.AddDate(0, 0, -1)), "all days")
+}
+
+func TestReservedPower(t *testing.T) {
+	van := &Loadpoint{
+		status:      api.StatusC,
+		mode:        api.ModePV,
+		chargePower: 1000,
+		Reservation: loadpoint.ReservationConfig{Share: 60},
+	}
+	car := &Loadpoint{
+		status: api.StatusB,
+		mode:   api.ModePV,
+	}
+
+	site := &Site{loadpoints: []*Loadpoint{van, car}}
+
+	// no solar forecast
+	assert.Equal(t, 0.0, site.reservedPower(car, -4000, 1000))
+
+	now := time.Now()
+	site.solarRates = api.Rates{{Start: now.Add(-time.Hour), End: now.Add(time.Hour), Price: 5000}}
+
+	// 5kW forecasted, 3kW reserved for van of which 1kW is used
+	assert.Equal(t, 2000.0, site.reservedPower(car, -4000, 1000))
+
+	// reservation is limited by the actual surplus
+	assert.Equal(t, 1500.0, site.reservedPower(car, 500, 2000))
+
+	// reserving loadpoint may use all surplus
+	assert.Equal(t, 0.0, site.reservedPower(van, -4000, 1000))
+
+	// no surplus
+	assert.Equal(t, 0.0, site.reservedPower(car, 500, 0))
+
+	// reservation only applies to solar charging
+	van.mode = api.ModeNow
+	assert.Equal(t, 0.0, site.reservedPower(car, -4000, 1000))
+	van.mode = api.ModePV
+
+	// reservation only applies to loadpoints able to take power
+	van.vehicleFull = true
+	assert.Equal(t, 0.0, site.reservedPower(car, -4000, 1000), "full")
+	van.vehicleFull = false
+
+	van.remoteDemand = loadpoint.RemoteHardDisable
+	assert.Equal(t, 0.0, site.reservedPower(car, -4000, 1000), "disabled")
+	van.remoteDemand = loadpoint.RemoteEnable
+
+	van.status = api.StatusA
+	assert.Equal(t, 0.0, site.reservedPower(car, -4000, 1000), "disconnected")
+}
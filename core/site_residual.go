@@ -0,0 +1,83 @@
+package core
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/evcc-io/evcc/plugin"
+)
+
+// ResidualPowerPeriod defines the residual power for a time of day
+type ResidualPowerPeriod struct {
+	From  string  `mapstructure:"from"`  // start time of day (HH:MM)
+	To    string  `mapstructure:"to"`    // end time of day (HH:MM), periods may span midnight
+	Power float64 `mapstructure:"power"` // residual power (W)
+}
+
+// minutes returns the period's start and end as minutes of day
+func (p ResidualPowerPeriod) minutes() (int, int, error) {
+	from, err := time.Parse("15:04", p.From)
+	if err != nil {
+		return 0, 0, fmt.Errorf("from: %w", err)
+	}
+
+	to, err := time.Parse("15:04", p.To)
+	if err != nil {
+		return 0, 0, fmt.Errorf("to: %w", err)
+	}
+
+	return from.Hour()*60 + from.Minute(), to.Hour()*60 + to.Minute(), nil
+}
+
+// contains determines if the time of day is within the period
+func (p ResidualPowerPeriod) contains(ts time.Time) bool {
+	from, to, err := p.minutes()
+	if err != nil {
+		return false
+	}
+
+	m := ts.Hour()*60 + ts.Minute()
+	if from <= to {
+		return m >= from && m < to
+	}
+
+	return m >= from || m < to
+}
+
+// configureResidualPower validates the residual power schedule and creates the residual power source
+func (site *Site) configureResidualPower(ctx context.Context, source *plugin.Config) error {
+	for i, p := range site.ResidualPowerSchedule {
+		if _, _, err := p.minutes(); err != nil {
+			return fmt.Errorf("residual power schedule %d: %w", i+1, err)
+		}
+	}
+
+	var err error
+	if site.residualPowerG, err = source.FloatGetter(ctx); err != nil {
+		return fmt.Errorf("residual power source: %w", err)
+	}
+
+	return nil
+}
+
+// effectiveResidualPower returns the residual power from source, schedule or static setting in this order
+func (site *Site) effectiveResidualPower() float64 {
+	if site.residualPowerG != nil {
+		res, err := site.residualPowerG()
+		if err == nil {
+			return res
+		}
+
+		site.log.ERROR.Printf("residual power: %v", err)
+	}
+
+	now := time.Now()
+	for _, p := range site.ResidualPowerSchedule {
+		if p.contains(now) {
+			return p.Power
+		}
+	}
+
+	return site.GetResidualPower()
+}
@@ -0,0 +1,39 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResidualPowerPeriod(t *testing.T) {
+	day := ResidualPowerPeriod{From: "06:00", To: "22:00", Power: 300}
+	night := ResidualPowerPeriod{From: "22:00", To: "06:00", Power: -100}
+
+	ts := time.Date(2025, 1, 1, 12, 0, 0, 0, time.Local)
+	assert.True(t, day.contains(ts))
+	assert.False(t, night.contains(ts))
+
+	ts = time.Date(2025, 1, 1, 23, 0, 0, 0, time.Local)
+	assert.False(t, day.contains(ts))
+	assert.True(t, night.contains(ts))
+
+	ts = time.Date(2025, 1, 1, 6, 0, 0, 0, time.Local)
+	assert.True(t, day.contains(ts))
+	assert.False(t, night.contains(ts))
+
+	invalid := ResidualPowerPeriod{From: "6", To: "22:00"}
+	assert.False(t, invalid.contains(ts))
+}
+
+func TestConfigureResidualPower(t *testing.T) {
+	site := &Site{
+		ResidualPowerSchedule: []ResidualPowerPeriod{{From: "25:00", To: "06:00"}},
+	}
+	assert.Error(t, site.configureResidualPower(t.Context(), nil))
+
+	site.ResidualPowerSchedule[0].From = "22:00"
+	assert.NoError(t, site.configureResidualPower(t.Context(), nil))
+	assert.Nil(t, site.residualPowerG)
+}
@@ -0,0 +1,35 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util/chart"
+)
+
+// snapshotHorizon is the duration of the price curve shown in snapshots
+const snapshotHorizon = 24 * time.Hour
+
+// setFlow remembers the current power flow for snapshots
+func (site *Site) setFlow(flow chart.Flow) {
+	site.Lock()
+	defer site.Unlock()
+	site.flow = flow
+}
+
+// Snapshot renders the current power flow and upcoming grid prices as png image
+func (site *Site) Snapshot(width, height int) ([]byte, error) {
+	site.RLock()
+	flow := site.flow
+	site.RUnlock()
+
+	var rr api.Rates
+	if gt := site.GetTariff(api.TariffUsageGrid); gt != nil {
+		if res, err := gt.Rates(); err == nil {
+			from := time.Now().Truncate(time.Hour)
+			rr = ratesWithin(res, from, from.Add(snapshotHorizon))
+		}
+	}
+
+	return chart.Snapshot(flow, rr, width, height)
+}
@@ -0,0 +1,98 @@
+package core
+
+import (
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/jinzhu/now"
+)
+
+const (
+	solarCorrectionDecay     = 0.95 // per daily sample of an hour, follows changing shading within a season
+	solarCorrectionMinEnergy = 0.5  // forecasted energy of an hour required before correcting (kWh)
+)
+
+// solarCorrection learns the systematic solar forecast error by season and hour of day,
+// e.g. caused by shading from trees or chimneys
+type solarCorrection struct {
+	Pv       [4][24]float64 `json:"pv"`       // decayed produced energy (kWh)
+	Forecast [4][24]float64 `json:"forecast"` // decayed forecasted energy (kWh)
+
+	hour         time.Time // current hour
+	pv, forecast float64   // energy of the current hour (kWh)
+	updated      time.Time
+}
+
+// season returns the meteorological season of the given time, starting with winter
+func season(ts time.Time) int {
+	return int(ts.Month()) % 12 / 3
+}
+
+// update accumulates produced and forecasted energy and trains each completed hour
+func (sc *solarCorrection) update(ts time.Time, pv, forecast float64) {
+	if hour := now.With(ts).BeginningOfHour(); !hour.Equal(sc.hour) {
+		if !sc.hour.IsZero() && sc.forecast > 0 {
+			s, h := season(sc.hour), sc.hour.Hour()
+			sc.Pv[s][h] = solarCorrectionDecay*sc.Pv[s][h] + sc.pv
+			sc.Forecast[s][h] = solarCorrectionDecay*sc.Forecast[s][h] + sc.forecast
+		}
+
+		sc.hour, sc.pv, sc.forecast = hour, 0, 0
+	}
+
+	if !sc.updated.IsZero() {
+		from := sc.updated
+		if from.Before(sc.hour) {
+			from = sc.hour
+		}
+
+		hours := ts.Sub(from).Hours()
+		sc.pv += max(0, pv) * hours / 1e3
+		sc.forecast += max(0, forecast) * hours / 1e3
+	}
+
+	sc.updated = ts
+}
+
+// factor returns the learned correction of the given time within the configured bounds
+func (sc *solarCorrection) factor(ts time.Time, conf SolarForecastConfig) float64 {
+	s, h := season(ts), ts.Hour()
+	if sc.Forecast[s][h] < solarCorrectionMinEnergy {
+		return 1
+	}
+
+	return min(max(sc.Pv[s][h]/sc.Forecast[s][h], conf.MinScale), conf.MaxScale)
+}
+
+// apply corrects each rate by the learned factor of its hour
+func (sc *solarCorrection) apply(rr api.Rates, conf SolarForecastConfig) api.Rates {
+	res := slices.Clone(rr)
+	for i, r := range res {
+		res[i].Price = r.Price * sc.factor(r.Start.Local(), conf)
+	}
+	return res
+}
+
+// updateSolarCorrection trains the hourly solar forecast correction
+func (site *Site) updateSolarCorrection(ts time.Time, forecast float64) {
+	site.solarCorrection.update(ts, site.pvPower, forecast)
+
+	if err := settings.SetJson(keys.SolarCorrection, site.solarCorrection); err != nil {
+		site.log.ERROR.Println("solar correction:", err)
+	}
+}
+
+// correctedSolarForecast returns the solar forecast and its individual planes corrected by the learned hourly factors
+func (site *Site) correctedSolarForecast(solar api.Rates, planes []api.Rates) (api.Rates, []api.Rates) {
+	conf := site.SolarForecast.withDefaults()
+	site.publish(keys.ForecastScale, site.solarCorrection.factor(time.Now(), conf))
+
+	for i, rr := range planes {
+		planes[i] = site.solarCorrection.apply(rr, conf)
+	}
+
+	return site.solarCorrection.apply(solar, conf), planes
+}
@@ -0,0 +1,270 @@
+package core
+
+import (
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/jinzhu/now"
+)
+
+// SolarForecastConfig configures the adjustment of today's remaining solar forecast to the actual production
+type SolarForecastConfig struct {
+	MinScale  float64 `mapstructure:"minScale"`  // lower bound of the forecast scale
+	MaxScale  float64 `mapstructure:"maxScale"`  // upper bound of the forecast scale
+	MinEnergy float64 `mapstructure:"minEnergy"` // forecasted energy required before adjusting (kWh)
+	Planes    bool    `mapstructure:"planes"`    // scale each solar plane by its own pv meter
+	Learn     bool    `mapstructure:"learn"`     // correct by learned hourly errors instead of today's scale
+	Surplus   float64 `mapstructure:"surplus"`   // solar power exceeding home consumption defining the published surplus window (W)
+}
+
+// withDefaults returns the configuration with unset values defaulted
+func (c SolarForecastConfig) withDefaults() SolarForecastConfig {
+	if c.MinScale <= 0 {
+		c.MinScale = 0.5
+	}
+	if c.MaxScale <= 0 {
+		c.MaxScale = 2
+	}
+	if c.MinEnergy <= 0 {
+		c.MinEnergy = 1
+	}
+	return c
+}
+
+// solarForecast compares produced and forecasted solar energy of the current day
+type solarForecast struct {
+	Day            time.Time `json:"day"`
+	PvEnergy       float64   `json:"pvEnergy"`       // kWh
+	ForecastEnergy float64   `json:"forecastEnergy"` // kWh
+	updated        time.Time
+}
+
+// update accumulates produced and forecasted energy since last update, restarting at midnight
+func (sf *solarForecast) update(ts time.Time, pv, forecast float64) {
+	if day := now.With(ts).BeginningOfDay(); !day.Equal(sf.Day) {
+		*sf = solarForecast{Day: day, updated: sf.updated}
+	}
+
+	if !sf.updated.IsZero() {
+		from := sf.updated
+		if from.Before(sf.Day) {
+			from = sf.Day
+		}
+
+		hours := ts.Sub(from).Hours()
+		sf.PvEnergy += max(0, pv) * hours / 1e3
+		sf.ForecastEnergy += max(0, forecast) * hours / 1e3
+	}
+
+	sf.updated = ts
+}
+
+// scale returns the ratio of produced to forecasted energy within the configured bounds.
+// Small forecasts like on cloudy mornings would produce arbitrary ratios and are not adjusted.
+func (sf *solarForecast) scale(conf SolarForecastConfig) float64 {
+	if sf.ForecastEnergy < conf.MinEnergy {
+		return 1
+	}
+
+	return min(max(sf.PvEnergy/sf.ForecastEnergy, conf.MinScale), conf.MaxScale)
+}
+
+// clamped determines if the ratio of produced to forecasted energy exceeds the configured bounds
+func (sf *solarForecast) clamped(conf SolarForecastConfig) bool {
+	if sf.ForecastEnergy < conf.MinEnergy {
+		return false
+	}
+
+	ratio := sf.PvEnergy / sf.ForecastEnergy
+	return ratio < conf.MinScale || ratio > conf.MaxScale
+}
+
+// publishSolarForecast publishes the scale of today's remaining solar forecast and if it has been clamped
+func (site *Site) publishSolarForecast() float64 {
+	conf := site.SolarForecast.withDefaults()
+	scale := site.solarForecast.scale(conf)
+
+	site.publish(keys.ForecastScale, scale)
+	site.publish(keys.ForecastScaleClamped, site.solarForecast.clamped(conf))
+
+	return scale
+}
+
+// solarPlanes returns the individual plane forecasts if the solar tariff sums multiple planes
+func (site *Site) solarPlanes() []api.Tariff {
+	if sp, ok := tariff.As[api.SolarPlanes](site.GetTariff(api.TariffUsageSolar)); ok {
+		if res := sp.Planes(); len(res) > 1 {
+			return res
+		}
+	}
+	return nil
+}
+
+// planeMeters determines if each solar plane is measured by its own pv meter
+func (site *Site) planeMeters(planes []api.Tariff) bool {
+	return site.SolarForecast.Planes && len(planes) > 1 && len(planes) == len(site.pvMeters) && len(site.pvPowers) == len(planes)
+}
+
+// planeScales returns the forecast scale of each solar plane
+func (site *Site) planeScales() []float64 {
+	conf := site.SolarForecast.withDefaults()
+	today := now.BeginningOfDay()
+
+	res := make([]float64, len(site.planeForecasts))
+	for i, sf := range site.planeForecasts {
+		res[i] = 1
+		if sf.Day.Equal(today) {
+			res[i] = sf.scale(conf)
+		}
+	}
+
+	return res
+}
+
+// updateSolarForecast accounts produced and forecasted solar energy, suspended while panels are covered
+func (site *Site) updateSolarForecast() {
+	if len(site.pvMeters) == 0 || site.GetPanelsCovered() {
+		return
+	}
+
+	forecast, err := tariff.Now(site.GetTariff(api.TariffUsageSolar))
+	if err != nil {
+		return
+	}
+
+	if site.SolarForecast.Learn {
+		site.updateSolarCorrection(time.Now(), forecast)
+	}
+
+	site.solarForecast.update(time.Now(), site.pvPower, forecast)
+
+	site.publish(keys.ForecastedToday, site.solarForecast.ForecastEnergy)
+	site.publish(keys.YieldToday, site.solarForecast.PvEnergy)
+
+	// keep the adjustment across restarts
+	if err := settings.SetJson(keys.SolarForecast, site.solarForecast); err != nil {
+		site.log.ERROR.Println("solar forecast:", err)
+	}
+
+	if planes := site.solarPlanes(); site.planeMeters(planes) {
+		site.updatePlaneForecasts(time.Now(), planes)
+	}
+}
+
+// updatePlaneForecasts accounts produced and forecasted solar energy by solar plane
+func (site *Site) updatePlaneForecasts(ts time.Time, planes []api.Tariff) {
+	if len(site.planeForecasts) != len(planes) {
+		site.planeForecasts = make([]solarForecast, len(planes))
+	}
+
+	for i, plane := range planes {
+		forecast, err := tariff.Now(plane)
+		if err != nil {
+			continue
+		}
+
+		site.planeForecasts[i].update(ts, site.pvPowers[i], forecast)
+	}
+
+	if err := settings.SetJson(keys.SolarForecastPlanes, site.planeForecasts); err != nil {
+		site.log.ERROR.Println("solar forecast planes:", err)
+	}
+}
+
+// solarForecastRates returns the adjusted solar forecast and its individual planes.
+// With learning enabled the forecast is corrected by the learned hourly errors.
+// With one pv meter per plane each plane is scaled by its own production and summed up,
+// otherwise all planes are scaled by the site's production.
+// No production is assumed while panels are covered.
+func (site *Site) solarForecastRates() (api.Rates, []api.Rates) {
+	solar := tariff.Forecast(site.GetTariff(api.TariffUsageSolar))
+	if len(solar) == 0 || site.GetPanelsCovered() {
+		return nil, nil
+	}
+
+	planes := site.solarPlanes()
+
+	if site.SolarForecast.Learn {
+		res := make([]api.Rates, len(planes))
+		for i, plane := range planes {
+			res[i] = tariff.Forecast(plane)
+		}
+		return site.correctedSolarForecast(solar, res)
+	}
+
+	ts := time.Now()
+
+	scale := site.publishSolarForecast()
+
+	if len(planes) == 0 {
+		return scaleSolarForecast(solar, scale, ts), nil
+	}
+
+	scales := slices.Repeat([]float64{scale}, len(planes))
+
+	perPlane := site.planeMeters(planes) && len(site.planeForecasts) == len(planes)
+	if perPlane {
+		scales = site.planeScales()
+		site.publish(keys.ForecastPlaneScales, scales)
+	}
+
+	res := make([]api.Rates, len(planes))
+	for i, plane := range planes {
+		res[i] = scaleSolarForecast(tariff.Forecast(plane), scales[i], ts)
+	}
+
+	if perPlane {
+		return sumRates(res), res
+	}
+
+	return scaleSolarForecast(solar, scale, ts), res
+}
+
+// GetSolarForecast returns the solar forecast adjusted to the actual production
+func (site *Site) GetSolarForecast() api.Rates {
+	site.RLock()
+	defer site.RUnlock()
+	return site.solarRates
+}
+
+// scaleSolarForecast scales today's remaining solar forecast from ts until end of day
+func scaleSolarForecast(rr api.Rates, scale float64, ts time.Time) api.Rates {
+	if scale == 1 || len(rr) == 0 {
+		return rr
+	}
+
+	eod := now.With(ts).EndOfDay()
+
+	res := slices.Clone(rr)
+	for i, r := range res {
+		if r.End.After(ts) && r.Start.Before(eod) {
+			res[i].Price = r.Price * scale
+		}
+	}
+
+	return res
+}
+
+// sumRates sums rates of equal periods
+func sumRates(planes []api.Rates) api.Rates {
+	var res api.Rates
+	for _, rr := range planes {
+		for _, r := range rr {
+			if i := slices.IndexFunc(res, func(s api.Rate) bool { return s.Start.Equal(r.Start) }); i >= 0 {
+				res[i].Price += r.Price
+				continue
+			}
+			res = append(res, r)
+		}
+	}
+
+	slices.SortFunc(res, func(a, b api.Rate) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	return res
+}
@@ -0,0 +1,140 @@
+package core
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/evcc-io/evcc/util"
+	"github.com/jinzhu/now"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSolarForecastScale(t *testing.T) {
+	conf := SolarForecastConfig{}.withDefaults()
+
+	var sf solarForecast
+
+	ts := time.Date(2025, 6, 1, 8, 0, 0, 0, time.Local)
+	sf.update(ts, 100, 500)
+	assert.Equal(t, 1.0, sf.scale(conf), "first update")
+
+	// cloudy morning: tiny forecast is not adjusted
+	sf.update(ts.Add(time.Hour), 100, 500)
+	assert.Equal(t, 0.1, sf.PvEnergy)
+	assert.Equal(t, 1.0, sf.scale(conf))
+
+	// production exceeds forecast
+	sf.update(ts.Add(2*time.Hour), 3000, 1500)
+	assert.InDelta(t, 3.1/2.0, sf.scale(conf), 1e-6)
+
+	// clamped
+	sf.update(ts.Add(3*time.Hour), 10000, 1000)
+	assert.Equal(t, conf.MaxScale, sf.scale(conf))
+
+	// restart at midnight
+	sf.update(ts.Add(16*time.Hour), 0, 0)
+	assert.Equal(t, 0.0, sf.ForecastEnergy)
+	assert.Equal(t, 1.0, sf.scale(conf))
+}
+
+func TestSolarForecastRestore(t *testing.T) {
+	ts := time.Date(2025, 6, 1, 12, 0, 0, 0, time.Local)
+
+	var sf solarForecast
+	sf.update(ts, 4000, 2000)
+	sf.update(ts.Add(time.Hour), 4000, 2000)
+
+	b, err := json.Marshal(sf)
+	require.NoError(t, err)
+
+	// downtime is not accounted after restart
+	var restored solarForecast
+	require.NoError(t, json.Unmarshal(b, &restored))
+	restored.update(ts.Add(3*time.Hour), 4000, 2000)
+
+	assert.Equal(t, sf.PvEnergy, restored.PvEnergy)
+	assert.Equal(t, sf.scale(SolarForecastConfig{}.withDefaults()), restored.scale(SolarForecastConfig{}.withDefaults()))
+}
+
+type planeTariff api.Rates
+
+func (t planeTariff) Rates() (api.Rates, error) {
+	return api.Rates(t), nil
+}
+
+func (t planeTariff) Type() api.TariffType {
+	return api.TariffTypeSolar
+}
+
+func TestSolarForecastPlanes(t *testing.T) {
+	ts := now.BeginningOfHour()
+	plane := func(power float64) planeTariff {
+		return planeTariff{{Start: ts, End: ts.Add(time.Hour), Price: power}}
+	}
+
+	site := &Site{
+		log:      util.NewLogger("foo"),
+		pvMeters: []api.Meter{&limitedPvMeter{}, &limitedPvMeter{}},
+		pvPowers: []float64{0, 0},
+		tariffs:  &tariff.Tariffs{Solar: tariff.NewCombined([]api.Tariff{plane(1000), plane(2000)})},
+	}
+
+	// scaled by site production
+	solar, planes := site.solarForecastRates()
+	require.Len(t, planes, 2)
+	assert.Equal(t, 3000.0, solar[0].Price)
+	assert.Equal(t, 1000.0, planes[0][0].Price)
+	assert.Equal(t, 2000.0, planes[1][0].Price)
+
+	// scaled by plane production
+	site.SolarForecast.Planes = true
+	site.planeForecasts = []solarForecast{
+		{Day: now.BeginningOfDay(), PvEnergy: 3, ForecastEnergy: 2},
+		{Day: now.BeginningOfDay(), PvEnergy: 1, ForecastEnergy: 2},
+	}
+
+	solar, planes = site.solarForecastRates()
+	assert.Equal(t, 1500.0, planes[0][0].Price)
+	assert.Equal(t, 1000.0, planes[1][0].Price)
+	assert.Equal(t, 2500.0, solar[0].Price)
+}
+
+func TestSolarCorrection(t *testing.T) {
+	conf := SolarForecastConfig{}.withDefaults()
+
+	var sc solarCorrection
+
+	// shaded afternoon hour produces half of the forecast on consecutive days
+	start := time.Date(2025, 6, 1, 15, 0, 0, 0, time.Local)
+	for i := range 5 {
+		ts := start.AddDate(0, 0, i)
+		sc.update(ts, 1000, 2000)
+		sc.update(ts.Add(30*time.Minute), 1000, 2000)
+		sc.update(ts.Add(time.Hour), 0, 0)
+	}
+
+	assert.InDelta(t, 0.5, sc.factor(start, conf), 1e-6)
+	assert.Equal(t, 1.0, sc.factor(start.Add(-time.Hour), conf), "untrained hour")
+	assert.Equal(t, 1.0, sc.factor(start.AddDate(0, 6, 0), conf), "other season")
+
+	rr := sc.apply(api.Rates{{Start: start, End: start.Add(time.Hour), Price: 3000}}, conf)
+	assert.InDelta(t, 1500, rr[0].Price, 1e-6)
+}
+
+func TestSolarForecastClamped(t *testing.T) {
+	conf := SolarForecastConfig{}.withDefaults()
+
+	sf := solarForecast{PvEnergy: 1, ForecastEnergy: 4}
+	assert.True(t, sf.clamped(conf))
+	assert.Equal(t, conf.MinScale, sf.scale(conf))
+
+	sf = solarForecast{PvEnergy: 3, ForecastEnergy: 4}
+	assert.False(t, sf.clamped(conf))
+
+	sf = solarForecast{PvEnergy: 0.1, ForecastEnergy: 0.5}
+	assert.False(t, sf.clamped(conf), "below min energy")
+}
@@ -0,0 +1,68 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/jinzhu/now"
+)
+
+// solarRemaining returns the forecasted solar energy from ts until end of day (kWh)
+func solarRemaining(solar api.Rates, ts time.Time) float64 {
+	return tariff.AccumulatedEnergy(solar, ts, now.With(ts).EndOfDay())
+}
+
+// surplusWindow returns the next period of forecasted solar power exceeding home consumption by at least surplus (W).
+// Zero times are returned if the forecast has no such period.
+func surplusWindow(solar api.Rates, ts time.Time, homePower, surplus float64) (time.Time, time.Time) {
+	var start, end time.Time
+
+	for _, r := range solar {
+		if !r.End.After(ts) {
+			continue
+		}
+
+		// window ends with insufficient surplus or a gap in the forecast
+		if r.Price-homePower < surplus || !end.IsZero() && r.Start.After(end) {
+			if !start.IsZero() {
+				break
+			}
+			continue
+		}
+
+		if start.IsZero() {
+			start = r.Start
+			if start.Before(ts) {
+				start = ts
+			}
+		}
+		end = r.End
+	}
+
+	return start, end
+}
+
+// publishSolarRemaining publishes the remaining solar energy today and the next surplus window of the adjusted solar forecast
+func (site *Site) publishSolarRemaining(solar api.Rates) {
+	if len(solar) == 0 {
+		return
+	}
+
+	surplus := site.SolarForecast.Surplus
+	if surplus <= 0 {
+		surplus = 1000
+	}
+
+	site.RLock()
+	homePower := site.flow.Home
+	site.RUnlock()
+
+	ts := time.Now()
+	start, end := surplusWindow(solar, ts, homePower, surplus)
+
+	site.publish(keys.SolarRemainingToday, solarRemaining(solar, ts))
+	site.publish(keys.SurplusWindowStart, start)
+	site.publish(keys.SurplusWindowEnd, end)
+}
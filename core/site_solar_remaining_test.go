@@ -0,0 +1,38 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSurplusWindow(t *testing.T) {
+	ts := time.Date(2024, 6, 1, 9, 30, 0, 0, time.Local)
+	day := time.Date(2024, 6, 1, 0, 0, 0, 0, time.Local)
+
+	var solar api.Rates
+	for i, p := range []float64{1000, 2000, 4000, 5000, 4000, 2000, 5000} {
+		start := day.Add(time.Duration(8+i) * time.Hour)
+		solar = append(solar, api.Rate{Start: start, End: start.Add(time.Hour), Price: p})
+	}
+
+	// 9:30-15:00 remaining, 23:59:59 end of day
+	assert.InDelta(t, 1+4+5+4+2+5, solarRemaining(solar, ts), 1e-3)
+
+	// 500W home consumption
+	start, end := surplusWindow(solar, ts, 500, 3000)
+	assert.Equal(t, day.Add(10*time.Hour), start)
+	assert.Equal(t, day.Add(13*time.Hour), end)
+
+	// window started
+	start, end = surplusWindow(solar, ts, 500, 1000)
+	assert.Equal(t, ts, start)
+	assert.Equal(t, day.Add(15*time.Hour), end)
+
+	// no window
+	start, end = surplusWindow(solar, ts, 500, 5000)
+	assert.True(t, start.IsZero())
+	assert.True(t, end.IsZero())
+}
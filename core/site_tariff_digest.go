@@ -0,0 +1,161 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util/chart"
+	"github.com/jinzhu/now"
+)
+
+const (
+	evTariffDigest     = "tariffdigest" // tomorrow's prices available
+	tariffDigestWindow = 3 * time.Hour  // duration of cheapest and most expensive window
+
+	tariffDigestChartWidth  = 480
+	tariffDigestChartHeight = 240
+)
+
+// priceWindow returns the contiguous window of at least the given duration with the lowest or highest
+// average price. The returned rate's price is the time-weighted average.
+func priceWindow(rr api.Rates, d time.Duration, highest bool) (api.Rate, bool) {
+	var (
+		res   api.Rate
+		found bool
+	)
+
+	for i := range rr {
+		var sum float64
+		for j := i; j < len(rr); j++ {
+			if j > i && !rr[j].Start.Equal(rr[j-1].End) {
+				break // gap
+			}
+
+			sum += rr[j].Price * rr[j].End.Sub(rr[j].Start).Hours()
+
+			duration := rr[j].End.Sub(rr[i].Start)
+			if duration < d {
+				continue
+			}
+
+			avg := sum / duration.Hours()
+			if !found || highest && avg > res.Price || !highest && avg < res.Price {
+				res = api.Rate{Start: rr[i].Start, End: rr[j].End, Price: avg}
+				found = true
+			}
+
+			break
+		}
+	}
+
+	return res, found
+}
+
+// planSlots formats the contiguous slots of a charging plan within the given period
+func planSlots(plan api.Rates, from, to time.Time) []string {
+	var (
+		res        []string
+		start, end time.Time
+	)
+
+	flush := func() {
+		if !start.IsZero() {
+			res = append(res, start.Format("15:04")+"-"+end.Format("15:04"))
+		}
+	}
+
+	for _, slot := range plan {
+		if !slot.Start.Before(to) || !slot.End.After(from) {
+			continue
+		}
+
+		if !slot.Start.Equal(end) {
+			flush()
+			start = slot.Start
+		}
+		end = slot.End
+	}
+	flush()
+
+	return res
+}
+
+// ratesWithin returns the rates entirely within the given period
+func ratesWithin(rr api.Rates, from, to time.Time) api.Rates {
+	var res api.Rates
+	for _, r := range rr {
+		if !r.Start.Before(from) && !r.End.After(to) {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// tariffDigest summarizes tomorrow's rates and planned charging
+func (site *Site) tariffDigest(rr api.Rates, from, to time.Time) string {
+	tomorrow := ratesWithin(rr, from, to)
+
+	var sb strings.Builder
+
+	if r, ok := priceWindow(tomorrow, tariffDigestWindow, false); ok {
+		fmt.Fprintf(&sb, "Cheapest: %s-%s (%.3f)\n", r.Start.Format("15:04"), r.End.Format("15:04"), r.Price)
+	}
+	if r, ok := priceWindow(tomorrow, tariffDigestWindow, true); ok {
+		fmt.Fprintf(&sb, "Most expensive: %s-%s (%.3f)\n", r.Start.Format("15:04"), r.End.Format("15:04"), r.Price)
+	}
+
+	for _, lp := range site.loadpoints {
+		planTime := lp.EffectivePlanTime()
+		if planTime.IsZero() {
+			continue
+		}
+
+		goal, _ := lp.GetPlanGoal()
+		requiredDuration := lp.GetPlanRequiredDuration(goal, lp.EffectiveMaxPower())
+
+		if slots := planSlots(lp.GetPlan(planTime, requiredDuration), from, to); len(slots) > 0 {
+			fmt.Fprintf(&sb, "%s: charging %s\n", lp.GetTitle(), strings.Join(slots, ", "))
+		}
+	}
+
+	return strings.TrimSpace(sb.String())
+}
+
+// updateTariffDigest notifies once per day when tomorrow's grid rates become available
+func (site *Site) updateTariffDigest() {
+	gt := site.GetTariff(api.TariffUsageGrid)
+	if gt == nil || gt.Type() != api.TariffTypePriceForecast {
+		return
+	}
+
+	from := now.With(time.Now()).BeginningOfDay().AddDate(0, 0, 1)
+	if site.tariffDigestDay.Equal(from) {
+		return
+	}
+
+	rr, err := gt.Rates()
+	if err != nil || len(rr) == 0 {
+		return
+	}
+
+	// wait until tomorrow's rates are complete
+	to := from.AddDate(0, 0, 1)
+	if rr[len(rr)-1].End.Before(to) {
+		return
+	}
+
+	site.tariffDigestDay = from
+
+	site.publish(keys.TariffDigest, site.tariffDigest(rr, from, to))
+
+	// attach price chart for messengers supporting images
+	img, err := chart.Prices(ratesWithin(rr, from, to), tariffDigestChartWidth, tariffDigestChartHeight)
+	if err != nil {
+		site.log.DEBUG.Printf("tariff digest chart: %v", err)
+	}
+
+	site.pushEventWithAttachment(evTariffDigest, img)
+}
@@ -0,0 +1,44 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceWindow(t *testing.T) {
+	start := time.Date(2025, 1, 2, 0, 0, 0, 0, time.Local)
+
+	var rr api.Rates
+	for i, price := range []float64{0.3, 0.2, 0.1, 0.1, 0.2, 0.5, 0.6, 0.4} {
+		rr = append(rr, api.Rate{
+			Start: start.Add(time.Duration(i) * time.Hour),
+			End:   start.Add(time.Duration(i+1) * time.Hour),
+			Price: price,
+		})
+	}
+
+	r, ok := priceWindow(rr, 3*time.Hour, false)
+	require.True(t, ok)
+	assert.Equal(t, start.Add(time.Hour), r.Start)
+	assert.Equal(t, start.Add(4*time.Hour), r.End)
+	assert.InDelta(t, 0.4/3, r.Price, 1e-6)
+
+	r, ok = priceWindow(rr, 3*time.Hour, true)
+	require.True(t, ok)
+	assert.Equal(t, start.Add(5*time.Hour), r.Start)
+	assert.InDelta(t, 0.5, r.Price, 1e-6)
+
+	_, ok = priceWindow(rr[:2], 3*time.Hour, false)
+	assert.False(t, ok)
+
+	assert.Equal(t, []string{"01:00-02:00", "03:00-05:00"}, planSlots(api.Rates{
+		{Start: start.Add(-time.Hour), End: start},
+		{Start: start.Add(time.Hour), End: start.Add(2 * time.Hour)},
+		{Start: start.Add(3 * time.Hour), End: start.Add(4 * time.Hour)},
+		{Start: start.Add(4 * time.Hour), End: start.Add(5 * time.Hour)},
+	}, start, start.AddDate(0, 0, 1)))
+}
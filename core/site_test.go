@@ -1,10 +1,15 @@
 package core
 
 import (
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff"
+	"github.com/evcc-io/evcc/util"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGreenShare(t *testing.T) {
@@ -120,6 +125,91 @@ func TestGreenShare(t *testing.T) {
 	}
 }
 
+func TestGreenShareSmoothing(t *testing.T) {
+	s := new(Site)
+	now := time.Now()
+
+	// battery flapping around zero
+	for i, battery := range []float64{1000, -1000, 1000, -1000} {
+		s.pvPower = 1000
+		s.batteryPower = battery
+		s.addGreenPowerSample(now.Add(time.Duration(i) * 10 * time.Second))
+	}
+
+	assert.Equal(t, 0.5, s.greenShare(0, 2000))
+
+	// samples outside window are dropped
+	s.batteryPower = 1000
+	s.addGreenPowerSample(now.Add(greenShareWindow + time.Minute))
+
+	assert.Len(t, s.greenPowerSamples, 1)
+	assert.Equal(t, 1.0, s.greenShare(0, 2000))
+}
+
+func TestBatteryExport(t *testing.T) {
+	var be batteryExport
+	ts := time.Date(2025, 1, 1, 18, 0, 0, 0, time.Local)
+
+	be.update(ts, true, 3000, -2000, 0.5)
+	assert.Equal(t, 0.0, be.Energy)
+
+	// export limited to grid feed-in
+	be.update(ts.Add(time.Hour), true, 3000, -2000, 0.5)
+	assert.Equal(t, 2.0, be.Energy)
+	assert.Equal(t, 1.0, be.Revenue)
+
+	// inactive
+	be.update(ts.Add(2*time.Hour), false, 3000, -2000, 0.5)
+	assert.Equal(t, 2.0, be.Energy)
+
+	// new day
+	be.update(ts.Add(8*time.Hour), true, 3000, -2000, 0.5)
+	assert.Equal(t, 0.0, be.Energy)
+	assert.Equal(t, 0.0, be.Revenue)
+}
+
+type controllableBattery struct {
+	features []api.Feature
+	mode     api.BatteryMode
+}
+
+func (m *controllableBattery) CurrentPower() (float64, error) {
+	return 0, nil
+}
+
+func (m *controllableBattery) SetBatteryMode(mode api.BatteryMode) error {
+	if mode == api.BatteryDischarge && !slices.Contains(m.features, api.ForcedDischarge) {
+		return api.ErrNotAvailable
+	}
+	m.mode = mode
+	return nil
+}
+
+func (m *controllableBattery) Features() []api.Feature {
+	return m.features
+}
+
+func TestBatteryExportSupport(t *testing.T) {
+	bat := &controllableBattery{}
+	s := &Site{
+		log:           util.NewLogger("foo"),
+		batteryMeters: []api.Meter{bat},
+	}
+
+	limit := 0.1
+	assert.Error(t, s.SetBatteryExportLimit(&limit))
+	assert.Nil(t, s.GetBatteryExportLimit())
+
+	// discharge must not fail silently
+	assert.ErrorIs(t, s.applyBatteryMode(api.BatteryDischarge), api.ErrNotAvailable)
+	assert.NoError(t, s.applyBatteryMode(api.BatteryHold))
+
+	bat.features = []api.Feature{api.ForcedDischarge}
+	assert.True(t, s.batteryDischargeSupported())
+	assert.NoError(t, s.applyBatteryMode(api.BatteryDischarge))
+	assert.Equal(t, api.BatteryDischarge, bat.mode)
+}
+
 func TestRequiredBatteryMode(t *testing.T) {
 	tc := []struct {
 		gridChargeActive bool
@@ -153,4 +243,70 @@ func TestRequiredBatteryMode(t *testing.T) {
 		res := s.requiredBatteryMode(tc.gridChargeActive, api.Rate{})
 		assert.Equal(t, tc.res, res, "expected %s, got %s", tc.res, res)
 	}
+	{
+		// external mode takes precedence
+		s := &Site{
+			batteryMeters:       []api.Meter{nil},
+			batteryMode:         api.BatteryNormal,
+			batteryModeExternal: api.BatteryDischarge,
+		}
+
+		res := s.requiredBatteryMode(true, api.Rate{})
+		assert.Equal(t, api.BatteryDischarge, res, "expected %s, got %s", api.BatteryDischarge, res)
+	}
+}
+
+type faultMeter struct {
+	faults []api.Fault
+}
+
+func (m *faultMeter) CurrentPower() (float64, error) {
+	return 0, nil
+}
+
+func (m *faultMeter) Faults() ([]api.Fault, error) {
+	return m.faults, nil
+}
+
+func TestUpdateFaults(t *testing.T) {
+	pv := &faultMeter{}
+	site := &Site{
+		log:       util.NewLogger("foo"),
+		Meters:    MetersConfig{PVMetersRef: []string{"pv1"}},
+		pvMeters:  []api.Meter{pv},
+		gridMeter: &faultMeter{},
+	}
+
+	site.updateFaults()
+	assert.Empty(t, site.faults)
+
+	pv.faults = []api.Fault{{Code: "35", Description: "Fault"}}
+	site.faultsUpdated = time.Time{}
+	site.updateFaults()
+	assert.Equal(t, []deviceFault{{Meter: "pv1", Code: "35", Description: "Fault"}}, site.faults)
+
+	pv.faults = nil
+	site.faultsUpdated = time.Time{}
+	site.updateFaults()
+	assert.Empty(t, site.faults)
+}
+
+func TestLoadpointEffectivePrice(t *testing.T) {
+	grid, err := tariff.NewFixedFromConfig(map[string]any{"price": 0.3})
+	require.NoError(t, err)
+
+	override, err := tariff.NewFixedFromConfig(map[string]any{"price": 0.2})
+	require.NoError(t, err)
+
+	s := &Site{
+		log:     util.NewLogger("foo"),
+		tariffs: &tariff.Tariffs{Grid: grid},
+	}
+
+	assert.Equal(t, 0.3, *s.loadpointEffectivePrice(&Loadpoint{}, 0))
+	assert.Equal(t, 0.2, *s.loadpointEffectivePrice(&Loadpoint{tariff: override}, 0))
+
+	rates := s.loadpointRates(&Loadpoint{tariff: override}, nil)
+	require.NotEmpty(t, rates)
+	assert.Equal(t, 0.2, rates[0].Price)
 }
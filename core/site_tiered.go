@@ -0,0 +1,48 @@
+package core
+
+import (
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/tariff"
+)
+
+// tierConsumption tracks grid import within the billing period of a tiered tariff
+type tierConsumption struct {
+	Period  time.Time `json:"period"`
+	Energy  float64   `json:"energy"` // kWh
+	updated time.Time
+}
+
+// update accounts grid import since last update, restarting with a new billing period
+func (tc *tierConsumption) update(period, ts time.Time, gridPower float64) {
+	if !period.Equal(tc.Period) {
+		*tc = tierConsumption{Period: period, updated: tc.updated}
+	}
+
+	if !tc.updated.IsZero() {
+		tc.Energy += max(0, gridPower) * ts.Sub(tc.updated).Hours() / 1e3
+	}
+
+	tc.updated = ts
+}
+
+// updateTierConsumption tracks grid import for selecting the active tier of tiered grid tariffs
+func (site *Site) updateTierConsumption() {
+	tt, ok := tariff.As[api.TieredTariff](site.GetTariff(api.TariffUsageGrid))
+	if !ok || site.gridMeter == nil {
+		return
+	}
+
+	ts := time.Now()
+	site.tierConsumption.update(tt.TierPeriod(ts), ts, site.gridPower)
+	tt.SetTierConsumption(site.tierConsumption.Energy)
+
+	if err := settings.SetJson(keys.TierConsumption, site.tierConsumption); err != nil {
+		site.log.ERROR.Println("tier consumption:", err)
+	}
+
+	site.publish(keys.TierConsumption, site.tierConsumption.Energy)
+}
@@ -0,0 +1,29 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTierConsumptionUpdate(t *testing.T) {
+	var tc tierConsumption
+
+	period := time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)
+	ts := time.Date(2025, 12, 31, 22, 0, 0, 0, time.Local)
+
+	tc.update(period, ts, 2000)
+	assert.Equal(t, 0.0, tc.Energy, "first update")
+
+	tc.update(period, ts.Add(time.Hour), 2000)
+	assert.Equal(t, 2.0, tc.Energy)
+
+	// export is not counted
+	tc.update(period, ts.Add(90*time.Minute), -3000)
+	assert.Equal(t, 2.0, tc.Energy)
+
+	// new billing period
+	tc.update(period.AddDate(1, 0, 0), ts.Add(150*time.Minute), 1000)
+	assert.Equal(t, 1.0, tc.Energy)
+}
@@ -30,6 +30,7 @@ type vehicleStruct struct {
 	Features       []string                  `json:"features,omitempty"`
 	Plan           *planStruct               `json:"plan,omitempty"`
 	RepeatingPlans []api.RepeatingPlanStruct `json:"repeatingPlans"`
+	Profiles       []api.VehicleProfile      `json:"profiles,omitempty"`
 }
 
 // publishVehicles returns a list of vehicle titles
@@ -60,6 +61,7 @@ func (site *Site) publishVehicles() {
 			Features:       lo.Map(instance.Features(), func(f api.Feature, _ int) string { return f.String() }),
 			Plan:           plan,
 			RepeatingPlans: v.GetRepeatingPlans(),
+			Profiles:       v.GetProfiles(),
 		}
 
 		if lp := site.coordinator.Owner(instance); lp != nil {
@@ -77,6 +79,7 @@ func (site *Site) updateVehicles(op config.Operation, dev config.Device[api.Vehi
 	switch op {
 	case config.OpAdd:
 		site.coordinator.Add(vehicle)
+		site.restoreVehicleSoc(dev)
 
 	case config.OpDelete:
 		site.coordinator.Delete(vehicle)
@@ -86,6 +89,11 @@ func (site *Site) updateVehicles(op config.Operation, dev config.Device[api.Vehi
 	site.publishVehicles()
 }
 
+// restoreVehicleSoc restores the soc of vehicles tracking soc from charged energy
+func (site *Site) restoreVehicleSoc(dev config.Device[api.Vehicle]) {
+	vehicle.Adapter(site.log, dev).RestoreSoc()
+}
+
 var _ site.Vehicles = (*vehicles)(nil)
 
 type vehicles struct {
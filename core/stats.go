@@ -19,6 +19,9 @@ type publisher interface {
 type Stats struct {
 	updated time.Time // Time of last charged value update
 	log     *util.Logger
+
+	co2Budget       float64          // monthly co2 budget of charging (kg)
+	inverterStandby *inverterStandby // energy saved by inverter standby
 }
 
 func NewStats() *Stats {
@@ -34,11 +37,27 @@ func (s *Stats) Update(p publisher) {
 	}
 
 	stats := map[string]map[string]float64{
-		"30d":      s.calculate(time.Now().AddDate(0, 0, -30)),
-		"365d":     s.calculate(time.Now().AddDate(0, 0, -365)),
-		"thisYear": s.calculate(now.BeginningOfYear()),
-		"total":    s.calculate(time.Time{}),
+		"30d":       s.calculate(time.Now().AddDate(0, 0, -30)),
+		"365d":      s.calculate(time.Now().AddDate(0, 0, -365)),
+		"thisMonth": s.calculate(now.BeginningOfMonth()),
+		"thisYear":  s.calculate(now.BeginningOfYear()),
+		"total":     s.calculate(time.Time{}),
+	}
+
+	// budget adherence of the current month
+	if s.co2Budget > 0 {
+		month := stats["thisMonth"]
+		month["co2Budget"] = s.co2Budget
+		month["co2BudgetPercentage"] = 100 * month["co2"] / s.co2Budget
 	}
+
+	// energy saved by inverter standby
+	if is := s.inverterStandby; is != nil && !is.Month.IsZero() {
+		stats["thisMonth"]["standbySavedKWh"] = is.MonthEnergy
+		stats["thisYear"]["standbySavedKWh"] = is.YearEnergy
+		stats["total"]["standbySavedKWh"] = is.TotalEnergy
+	}
+
 	p.publish(keys.Statistics, stats)
 
 	s.updated = time.Now()
@@ -61,16 +80,20 @@ func (s *Stats) calculate(fromDate time.Time) map[string]float64 {
 		}
 	}
 
-	var solarPercentage, chargedKWh, avgPrice, avgCo2 float64
+	var solarPercentage, chargedKWh, avgPrice, avgCo2, co2, chargeHours float64
 	executeQuery("SUM(charged_kwh * solar_percentage) / SUM(charged_kwh)", "AND solar_percentage IS NOT NULL", fromDate, &solarPercentage)
 	executeQuery("SUM(charged_kwh)", "AND solar_percentage IS NOT NULL", fromDate, &chargedKWh)
 	executeQuery("SUM(charged_kwh * price_per_kwh) / SUM(charged_kwh)", "AND price_per_kwh IS NOT NULL", fromDate, &avgPrice)
 	executeQuery("SUM(charged_kwh * co2_per_kwh) / SUM(charged_kwh)", "AND co2_per_kwh IS NOT NULL", fromDate, &avgCo2)
+	executeQuery("SUM(charged_kwh * co2_per_kwh) / 1e3", "AND co2_per_kwh IS NOT NULL", fromDate, &co2)
+	executeQuery("SUM(charge_duration) / 3.6e12", "AND charge_duration IS NOT NULL", fromDate, &chargeHours) // stored as nanoseconds
 
 	result["solarPercentage"] = solarPercentage
 	result["chargedKWh"] = chargedKWh
 	result["avgPrice"] = avgPrice
 	result["avgCo2"] = avgCo2
+	result["co2"] = co2 // kg
+	result["chargeHours"] = chargeHours
 
 	return result
 }
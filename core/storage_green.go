@@ -0,0 +1,67 @@
+package core
+
+import (
+	"time"
+)
+
+// storageGreen tracks the energy stored in a battery or vehicle and its green part
+type storageGreen struct {
+	Energy  float64 `json:"energy"` // Stored energy (kWh)
+	Green   float64 `json:"green"`  // Stored green energy (kWh)
+	updated time.Time
+}
+
+// storageGreenState is the persisted green energy of batteries and vehicles
+type storageGreenState struct {
+	Battery []storageGreen `json:"battery"`
+	Vehicle []storageGreen `json:"vehicle"`
+}
+
+// update accounts charging (positive power) or discharging (negative power) since the last update.
+// Charging energy is green according to greenShare, discharging keeps the green fraction.
+func (sg *storageGreen) update(now time.Time, power, greenShare float64) {
+	defer func() { sg.updated = now }()
+
+	if sg.updated.IsZero() {
+		return
+	}
+
+	energy := power * now.Sub(sg.updated).Hours() / 1e3
+
+	switch {
+	case energy > 0:
+		sg.Energy += energy
+		sg.Green += energy * min(1, max(0, greenShare))
+
+	case energy < 0 && sg.Energy > 0:
+		discharged := min(sg.Energy, -energy)
+
+		sg.Green -= sg.Green / sg.Energy * discharged
+		sg.Energy -= discharged
+	}
+}
+
+// fraction returns the green fraction of the stored energy. Energy of unknown origin is considered green.
+func (sg *storageGreen) fraction() float64 {
+	if sg.Energy <= 0 {
+		return 1
+	}
+	return sg.Green / sg.Energy
+}
+
+// sync aligns the tracked energy with the stored energy (kWh) derived from soc and capacity, keeping the green fraction.
+// Stored energy of unknown origin, e.g. on first start, is considered green.
+func (sg *storageGreen) sync(stored float64) {
+	if stored <= 0 {
+		sg.Energy, sg.Green = 0, 0
+		return
+	}
+
+	if sg.Energy <= 0 {
+		sg.Energy, sg.Green = stored, stored
+		return
+	}
+
+	sg.Green *= stored / sg.Energy
+	sg.Energy = stored
+}
@@ -0,0 +1,60 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStorageGreen(t *testing.T) {
+	var sg storageGreen
+	now := time.Now()
+
+	sg.update(now, 0, 0)
+	assert.Equal(t, 1.0, sg.fraction(), "unknown origin")
+
+	// 1kWh from grid
+	now = now.Add(time.Hour)
+	sg.update(now, 1000, 0)
+	assert.InDelta(t, 0, sg.fraction(), 1e-6)
+
+	// 1kWh from pv
+	now = now.Add(time.Hour)
+	sg.update(now, 1000, 1)
+	assert.InDelta(t, 0.5, sg.fraction(), 1e-6)
+
+	// discharge keeps green fraction
+	now = now.Add(time.Hour)
+	sg.update(now, -1000, 0)
+	assert.InDelta(t, 0.5, sg.fraction(), 1e-6)
+	assert.InDelta(t, 1, sg.Energy, 1e-6)
+
+	// full discharge
+	now = now.Add(2 * time.Hour)
+	sg.update(now, -1000, 0)
+	assert.Equal(t, 1.0, sg.fraction())
+}
+
+func TestStorageGreenSync(t *testing.T) {
+	var sg storageGreen
+
+	// unknown origin
+	sg.sync(5)
+	assert.Equal(t, storageGreen{Energy: 5, Green: 5}, sg)
+
+	// 5kWh from grid
+	now := time.Now()
+	sg.update(now, 0, 0)
+	sg.update(now.Add(time.Hour), 5000, 0)
+	assert.InDelta(t, 0.5, sg.fraction(), 1e-6)
+
+	// charging losses keep the green fraction
+	sg.sync(9)
+	assert.InDelta(t, 9, sg.Energy, 1e-6)
+	assert.InDelta(t, 0.5, sg.fraction(), 1e-6)
+
+	// empty
+	sg.sync(0)
+	assert.Equal(t, 1.0, sg.fraction())
+}
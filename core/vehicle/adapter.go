@@ -138,3 +138,50 @@ func (v *adapter) GetRepeatingPlans() []api.RepeatingPlanStruct {
 
 	return []api.RepeatingPlanStruct{}
 }
+
+// SetSoc corrects the soc of a vehicle tracking soc from charged energy
+func (v *adapter) SetSoc(soc float64) error {
+	vt, ok := v.Vehicle.(api.SocTracker)
+	if !ok {
+		return api.ErrNotAvailable
+	}
+
+	if err := vt.SetSoc(soc); err != nil {
+		return err
+	}
+
+	v.log.DEBUG.Printf("set %s soc: %.1f%%", v.name, soc)
+	settings.SetFloat(v.key()+keys.Soc, soc)
+
+	v.publish()
+
+	return nil
+}
+
+// AddEnergy accounts charged energy in kWh to a vehicle tracking soc from charged energy
+func (v *adapter) AddEnergy(energy float64) {
+	vt, ok := v.Vehicle.(api.SocTracker)
+	if !ok {
+		return
+	}
+
+	vt.AddEnergy(energy)
+
+	if soc, err := v.Vehicle.Soc(); err == nil {
+		settings.SetFloat(v.key()+keys.Soc, soc)
+	}
+}
+
+// RestoreSoc restores the soc of a vehicle tracking soc from charged energy
+func (v *adapter) RestoreSoc() {
+	vt, ok := v.Vehicle.(api.SocTracker)
+	if !ok {
+		return
+	}
+
+	if soc, err := settings.Float(v.key() + keys.Soc); err == nil {
+		if err := vt.SetSoc(soc); err != nil {
+			v.log.ERROR.Printf("restore %s soc: %v", v.name, err)
+		}
+	}
+}
@@ -48,6 +48,20 @@ type API interface {
 	// SetRepeatingPlans stores every repeating plan
 	SetRepeatingPlans([]api.RepeatingPlanStruct) error
 
+	// SetSoc corrects the soc of a vehicle tracking soc from charged energy
+	SetSoc(soc float64) error
+	// AddEnergy accounts charged energy in kWh to a vehicle tracking soc from charged energy
+	AddEnergy(energy float64)
+	// RestoreSoc restores the persisted soc of a vehicle tracking soc from charged energy
+	RestoreSoc()
+
+	// GetProfiles returns the settings profiles
+	GetProfiles() []api.VehicleProfile
+	// SetProfiles stores the settings profiles
+	SetProfiles([]api.VehicleProfile) error
+	// ApplyProfile applies the named settings profile
+	ApplyProfile(name string) (api.VehicleProfile, error)
+
 	// // GetMinCurrent returns the min charging current
 	// GetMinCurrent() float64
 	// // SetMinCurrent sets the min charging current
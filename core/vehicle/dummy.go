@@ -1,6 +1,7 @@
 package vehicle
 
 import (
+	"errors"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -56,3 +57,37 @@ func (v *dummy) SetRepeatingPlans(plans []api.RepeatingPlanStruct) error {
 func (v *dummy) GetRepeatingPlans() []api.RepeatingPlanStruct {
 	return []api.RepeatingPlanStruct{}
 }
+
+// GetProfiles returns the settings profiles
+func (v *dummy) GetProfiles() []api.VehicleProfile {
+	return []api.VehicleProfile{}
+}
+
+// SetProfiles stores the settings profiles
+func (v *dummy) SetProfiles(profiles []api.VehicleProfile) error {
+	return nil
+}
+
+// ApplyProfile applies the named settings profile
+func (v *dummy) ApplyProfile(name string) (api.VehicleProfile, error) {
+	return api.VehicleProfile{}, errors.New("profiles not supported")
+}
+
+// SetSoc corrects the soc of a vehicle tracking soc from charged energy
+func (v *dummy) SetSoc(soc float64) error {
+	if vt, ok := v.Vehicle.(api.SocTracker); ok {
+		return vt.SetSoc(soc)
+	}
+	return api.ErrNotAvailable
+}
+
+// AddEnergy accounts charged energy in kWh to a vehicle tracking soc from charged energy
+func (v *dummy) AddEnergy(energy float64) {
+	if vt, ok := v.Vehicle.(api.SocTracker); ok {
+		vt.AddEnergy(energy)
+	}
+}
+
+// RestoreSoc restores the soc of a vehicle tracking soc from charged energy
+func (v *dummy) RestoreSoc() {
+}
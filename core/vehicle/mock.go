@@ -41,6 +41,33 @@ func (m *MockAPI) EXPECT() *MockAPIMockRecorder {
 	return m.recorder
 }
 
+// AddEnergy mocks base method.
+func (m *MockAPI) AddEnergy(energy float64) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "AddEnergy", energy)
+}
+
+// AddEnergy indicates an expected call of AddEnergy.
+func (mr *MockAPIMockRecorder) AddEnergy(energy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddEnergy", reflect.TypeOf((*MockAPI)(nil).AddEnergy), energy)
+}
+
+// ApplyProfile mocks base method.
+func (m *MockAPI) ApplyProfile(name string) (api.VehicleProfile, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApplyProfile", name)
+	ret0, _ := ret[0].(api.VehicleProfile)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ApplyProfile indicates an expected call of ApplyProfile.
+func (mr *MockAPIMockRecorder) ApplyProfile(name any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApplyProfile", reflect.TypeOf((*MockAPI)(nil).ApplyProfile), name)
+}
+
 // GetLimitSoc mocks base method.
 func (m *MockAPI) GetLimitSoc() int {
 	m.ctrl.T.Helper()
@@ -84,6 +111,20 @@ func (mr *MockAPIMockRecorder) GetPlanSoc() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlanSoc", reflect.TypeOf((*MockAPI)(nil).GetPlanSoc))
 }
 
+// GetProfiles mocks base method.
+func (m *MockAPI) GetProfiles() []api.VehicleProfile {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetProfiles")
+	ret0, _ := ret[0].([]api.VehicleProfile)
+	return ret0
+}
+
+// GetProfiles indicates an expected call of GetProfiles.
+func (mr *MockAPIMockRecorder) GetProfiles() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetProfiles", reflect.TypeOf((*MockAPI)(nil).GetProfiles))
+}
+
 // GetRepeatingPlans mocks base method.
 func (m *MockAPI) GetRepeatingPlans() []api.RepeatingPlanStruct {
 	m.ctrl.T.Helper()
@@ -126,6 +167,18 @@ func (mr *MockAPIMockRecorder) Name() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockAPI)(nil).Name))
 }
 
+// RestoreSoc mocks base method.
+func (m *MockAPI) RestoreSoc() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "RestoreSoc")
+}
+
+// RestoreSoc indicates an expected call of RestoreSoc.
+func (mr *MockAPIMockRecorder) RestoreSoc() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreSoc", reflect.TypeOf((*MockAPI)(nil).RestoreSoc))
+}
+
 // SetLimitSoc mocks base method.
 func (m *MockAPI) SetLimitSoc(soc int) {
 	m.ctrl.T.Helper()
@@ -164,6 +217,20 @@ func (mr *MockAPIMockRecorder) SetPlanSoc(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPlanSoc", reflect.TypeOf((*MockAPI)(nil).SetPlanSoc), arg0, arg1)
 }
 
+// SetProfiles mocks base method.
+func (m *MockAPI) SetProfiles(arg0 []api.VehicleProfile) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProfiles", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProfiles indicates an expected call of SetProfiles.
+func (mr *MockAPIMockRecorder) SetProfiles(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProfiles", reflect.TypeOf((*MockAPI)(nil).SetProfiles), arg0)
+}
+
 // SetRepeatingPlans mocks base method.
 func (m *MockAPI) SetRepeatingPlans(arg0 []api.RepeatingPlanStruct) error {
 	m.ctrl.T.Helper()
@@ -177,3 +244,17 @@ func (mr *MockAPIMockRecorder) SetRepeatingPlans(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRepeatingPlans", reflect.TypeOf((*MockAPI)(nil).SetRepeatingPlans), arg0)
 }
+
+// SetSoc mocks base method.
+func (m *MockAPI) SetSoc(soc float64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetSoc", soc)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetSoc indicates an expected call of SetSoc.
+func (mr *MockAPIMockRecorder) SetSoc(soc any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetSoc", reflect.TypeOf((*MockAPI)(nil).SetSoc), soc)
+}
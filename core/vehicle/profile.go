@@ -0,0 +1,95 @@
+package vehicle
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util"
+)
+
+// profileName matches names usable in the profile api route
+var profileName = regexp.MustCompile(`^[a-zA-Z0-9_.:-]+$`)
+
+// GetProfiles returns the settings profiles
+func (v *adapter) GetProfiles() []api.VehicleProfile {
+	var profiles []api.VehicleProfile
+
+	if err := settings.Json(v.key()+keys.Profiles, &profiles); err == nil {
+		return profiles
+	}
+
+	return []api.VehicleProfile{}
+}
+
+// SetProfiles stores the settings profiles
+func (v *adapter) SetProfiles(profiles []api.VehicleProfile) error {
+	names := make(map[string]bool)
+
+	for _, p := range profiles {
+		if p.Name == "" {
+			return errors.New("missing profile name")
+		}
+		if !profileName.MatchString(p.Name) {
+			return fmt.Errorf("invalid profile name: %s", p.Name)
+		}
+		if names[p.Name] {
+			return fmt.Errorf("duplicate profile: %s", p.Name)
+		}
+		names[p.Name] = true
+
+		if p.PlanSoc > 0 {
+			if _, err := time.Parse("15:04", p.PlanTime); err != nil {
+				return fmt.Errorf("invalid plan time: %v", err)
+			}
+		}
+	}
+
+	v.log.DEBUG.Printf("update profiles for %s to: %v", v.name, profiles)
+
+	settings.SetJson(v.key()+keys.Profiles, profiles)
+
+	v.publish()
+
+	return nil
+}
+
+// ApplyProfile applies the named settings profile.
+// Loadpoint settings like the smart cost limit are applied by the caller.
+func (v *adapter) ApplyProfile(name string) (api.VehicleProfile, error) {
+	profiles := v.GetProfiles()
+
+	idx := slices.IndexFunc(profiles, func(p api.VehicleProfile) bool {
+		return p.Name == name
+	})
+	if idx < 0 {
+		return api.VehicleProfile{}, fmt.Errorf("profile not found: %s", name)
+	}
+
+	p := profiles[idx]
+	v.log.DEBUG.Printf("apply %s profile: %s", v.name, p.Name)
+
+	if p.MinSoc != nil {
+		v.SetMinSoc(*p.MinSoc)
+	}
+
+	if p.LimitSoc != nil {
+		v.SetLimitSoc(*p.LimitSoc)
+	}
+
+	if p.PlanSoc > 0 {
+		ts, err := util.NextTimeOfDay(time.Now(), p.PlanTime)
+		if err != nil {
+			return p, err
+		}
+
+		return p, v.SetPlanSoc(ts, p.PlanSoc)
+	}
+
+	return p, nil
+}
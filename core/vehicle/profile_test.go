@@ -0,0 +1,48 @@
+package vehicle
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProfiles(t *testing.T) {
+	v := &adapter{log: util.NewLogger("foo"), name: "profiles"}
+
+	assert.Empty(t, v.GetProfiles())
+
+	assert.Error(t, v.SetProfiles([]api.VehicleProfile{{}}), "missing name")
+	assert.Error(t, v.SetProfiles([]api.VehicleProfile{{Name: "a"}, {Name: "a"}}), "duplicate")
+	assert.Error(t, v.SetProfiles([]api.VehicleProfile{{Name: "road trip"}}), "invalid name")
+	assert.Error(t, v.SetProfiles([]api.VehicleProfile{{Name: "a", PlanSoc: 80}}), "missing plan time")
+
+	require.NoError(t, v.SetProfiles([]api.VehicleProfile{
+		{Name: "commute", MinSoc: lo.ToPtr(30), LimitSoc: lo.ToPtr(80), PlanSoc: 80, PlanTime: "07:00"},
+		{Name: "roadtrip", LimitSoc: lo.ToPtr(100), DisableSmartCost: true},
+	}))
+	assert.Len(t, v.GetProfiles(), 2)
+
+	_, err := v.ApplyProfile("unknown")
+	assert.Error(t, err)
+
+	p, err := v.ApplyProfile("commute")
+	require.NoError(t, err)
+	assert.Equal(t, "commute", p.Name)
+	assert.Equal(t, 30, v.GetMinSoc())
+	assert.Equal(t, 80, v.GetLimitSoc())
+
+	ts, soc := v.GetPlanSoc()
+	assert.Equal(t, 80, soc)
+	assert.True(t, ts.After(time.Now()))
+
+	p, err = v.ApplyProfile("roadtrip")
+	require.NoError(t, err)
+	assert.True(t, p.DisableSmartCost)
+	assert.Equal(t, 30, v.GetMinSoc(), "unchanged")
+	assert.Equal(t, 100, v.GetLimitSoc())
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/hems/eebus"
+	"github.com/evcc-io/evcc/hems/flex"
 	"github.com/evcc-io/evcc/hems/relay"
 	"github.com/evcc-io/evcc/hems/semp"
 	"github.com/evcc-io/evcc/server"
@@ -26,6 +27,8 @@ func NewFromConfig(ctx context.Context, typ string, other map[string]interface{}
 		return eebus.New(ctx, other, site)
 	case "relay":
 		return relay.New(ctx, other, site)
+	case "flex":
+		return flex.New(other, site, httpd)
 	default:
 		return nil, errors.New("unknown hems: " + typ)
 	}
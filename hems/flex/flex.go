@@ -0,0 +1,394 @@
+package flex
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/server"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/util"
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+const basePath = "/api/flex"
+
+// Flex exposes site flexibility to an external aggregator
+type Flex struct {
+	mu    sync.Mutex
+	log   *util.Logger
+	clock clock.Clock
+	db    *gorm.DB
+
+	site        site.API
+	token       string
+	maxDuration time.Duration
+	minSoc      float64
+
+	events []*Event
+	paused bool
+}
+
+// New creates a flexibility HEMS from generic config
+func New(other map[string]interface{}, site site.API, httpd *server.HTTPd) (*Flex, error) {
+	cc := struct {
+		Token       string
+		MaxDuration time.Duration
+		MinSoc      float64
+	}{
+		MaxDuration: 2 * time.Hour,
+		MinSoc:      20,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Token == "" {
+		return nil, errors.New("missing token")
+	}
+
+	c, err := NewFlex(site, cc.Token, cc.MaxDuration, cc.MinSoc)
+	if err != nil {
+		return nil, err
+	}
+
+	c.handlers(httpd.Router())
+
+	return c, nil
+}
+
+// NewFlex creates Flex HEMS
+func NewFlex(site site.API, token string, maxDuration time.Duration, minSoc float64) (*Flex, error) {
+	c := &Flex{
+		log:         util.NewLogger("flex"),
+		clock:       clock.New(),
+		site:        site,
+		token:       token,
+		maxDuration: maxDuration,
+		minSoc:      minSoc,
+	}
+
+	if db.Instance != nil {
+		if err := db.Instance.AutoMigrate(new(Event)); err != nil {
+			return nil, err
+		}
+		c.db = db.Instance
+
+		// resume reserved and active events after restart, finished events are expired by update
+		if err := c.db.Where("status IN ?", []Status{StatusReserved, StatusActive}).Order("id").Find(&c.events).Error; err != nil {
+			return nil, err
+		}
+	}
+
+	return c, nil
+}
+
+func (c *Flex) Run() {
+	for range time.Tick(10 * time.Second) {
+		c.update()
+	}
+}
+
+// persist writes the event audit record
+func (c *Flex) persist(ev *Event) {
+	ev.Updated = c.clock.Now()
+	c.log.INFO.Printf("event %d (%s): %s %s", ev.ID, ev.Reference, ev.Action, ev.Status)
+
+	if c.db == nil {
+		return
+	}
+
+	if err := c.db.Save(ev).Error; err != nil {
+		c.log.ERROR.Printf("persist: %v", err)
+	}
+}
+
+// validate checks the request against the site's constraints
+func (c *Flex) validate(req Request) error {
+	duration := time.Duration(req.Duration) * time.Second
+
+	switch {
+	case req.Action != ActionPause && req.Action != ActionDischarge:
+		return fmt.Errorf("invalid action: %s", req.Action)
+	case req.Action == ActionPause && len(c.site.Loadpoints()) == 0:
+		return errors.New("pause requires loadpoints")
+	case req.Action == ActionDischarge && !c.site.GetBatteryDischargeSupported():
+		return errors.New("discharge requires battery supporting forced discharge")
+	case req.Action == ActionDischarge && c.site.GetBatterySoc() <= c.minSoc:
+		return fmt.Errorf("battery soc below %.0f%%", c.minSoc)
+	case duration <= 0:
+		return errors.New("invalid duration")
+	case duration > c.maxDuration:
+		return fmt.Errorf("duration exceeds %v", c.maxDuration)
+	case req.Start.Add(duration).Before(c.clock.Now()):
+		return errors.New("reservation in the past")
+	}
+
+	end := req.Start.Add(duration)
+	for _, ev := range c.events {
+		if ev.Action == req.Action && (ev.Status == StatusReserved || ev.Status == StatusActive) &&
+			ev.Start.Before(end) && req.Start.Before(ev.End) {
+			return fmt.Errorf("overlaps event %d", ev.ID)
+		}
+	}
+
+	return nil
+}
+
+// Reserve registers a reservation and returns the acknowledged or rejected event
+func (c *Flex) Reserve(req Request) *Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	if req.Start.IsZero() {
+		req.Start = now
+	}
+
+	ev := &Event{
+		Created:      now,
+		Acknowledged: now,
+		Reference:    req.Reference,
+		Action:       req.Action,
+		Start:        req.Start,
+		End:          req.Start.Add(time.Duration(req.Duration) * time.Second),
+		Status:       StatusReserved,
+	}
+
+	if err := c.validate(req); err != nil {
+		ev.Status = StatusRejected
+		ev.Reason = err.Error()
+	}
+
+	c.events = append(c.events, ev)
+	if c.db == nil {
+		ev.ID = uint(len(c.events))
+	}
+
+	c.persist(ev)
+
+	return ev
+}
+
+func (c *Flex) event(id uint) (*Event, error) {
+	for _, ev := range c.events {
+		if ev.ID == id {
+			return ev, nil
+		}
+	}
+	return nil, fmt.Errorf("event not found: %d", id)
+}
+
+// Dispatch activates a reserved event
+func (c *Flex) Dispatch(id uint) (*Event, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ev, err := c.event(id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := c.clock.Now()
+	if ev.Status != StatusReserved || now.Before(ev.Start) || !now.Before(ev.End) {
+		return ev, fmt.Errorf("event %d cannot be dispatched", id)
+	}
+
+	if ev.Action == ActionDischarge && c.site.GetBatterySoc() <= c.minSoc {
+		return ev, fmt.Errorf("event %d cannot be dispatched: battery soc below %.0f%%", id, c.minSoc)
+	}
+
+	ev.Status = StatusActive
+	ev.Dispatched = now
+	c.persist(ev)
+
+	c.apply()
+
+	return ev, nil
+}
+
+// Cancel cancels a reserved or active event
+func (c *Flex) Cancel(id uint) (*Event, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	ev, err := c.event(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if ev.Status != StatusReserved && ev.Status != StatusActive {
+		return ev, fmt.Errorf("event %d cannot be cancelled", id)
+	}
+
+	ev.Status = StatusCancelled
+	c.persist(ev)
+
+	c.apply()
+
+	return ev, nil
+}
+
+// Events returns the audit records
+func (c *Flex) Events() []Event {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	res := make([]Event, 0, len(c.events))
+	for _, ev := range c.events {
+		res = append(res, *ev)
+	}
+
+	return res
+}
+
+// update completes or expires events and applies active actions
+func (c *Flex) update() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.clock.Now()
+	for _, ev := range c.events {
+		// stop discharging at minimum soc
+		if ev.Action == ActionDischarge && ev.Status == StatusActive && c.site.GetBatterySoc() <= c.minSoc {
+			ev.Status = StatusCompleted
+			ev.Reason = fmt.Sprintf("battery soc below %.0f%%", c.minSoc)
+			c.persist(ev)
+			continue
+		}
+
+		if now.Before(ev.End) {
+			continue
+		}
+
+		switch ev.Status {
+		case StatusActive:
+			ev.Status = StatusCompleted
+			c.persist(ev)
+		case StatusReserved:
+			ev.Status = StatusExpired
+			c.persist(ev)
+		}
+	}
+
+	// drop finished events from memory
+	c.events = slices.DeleteFunc(c.events, func(ev *Event) bool {
+		return ev.End.Before(now.Add(-24 * time.Hour))
+	})
+
+	c.apply()
+}
+
+// apply applies the actions of all active events
+func (c *Flex) apply() {
+	active := func(action Action) bool {
+		return slices.ContainsFunc(c.events, func(ev *Event) bool {
+			return ev.Action == action && ev.Status == StatusActive
+		})
+	}
+
+	// only release remote demand if set by flex
+	if pause := active(ActionPause); pause != c.paused {
+		demand := loadpoint.RemoteEnable
+		if pause {
+			demand = loadpoint.RemoteHardDisable
+		}
+		for _, lp := range c.site.Loadpoints() {
+			lp.RemoteControlSource("flex", demand)
+		}
+		c.paused = pause
+	}
+
+	mode := api.BatteryUnknown
+	if active(ActionDischarge) {
+		mode = api.BatteryDischarge
+	}
+	c.site.SetBatteryModeExternal(mode)
+}
+
+func (c *Flex) handlers(router *mux.Router) {
+	r := router.PathPrefix(basePath).Subrouter()
+	r.Use(c.authHandler)
+
+	r.HandleFunc("/events", c.eventsHandler).Methods(http.MethodGet)
+	r.HandleFunc("/events", c.reserveHandler).Methods(http.MethodPost)
+	r.HandleFunc("/events/{id:[0-9]+}/dispatch", c.idHandler(c.Dispatch)).Methods(http.MethodPost)
+	r.HandleFunc("/events/{id:[0-9]+}", c.idHandler(c.Cancel)).Methods(http.MethodDelete)
+}
+
+func (c *Flex) authHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if subtle.ConstantTimeCompare([]byte(r.Header.Get("Authorization")), []byte("Bearer "+c.token)) != 1 {
+			c.writeError(w, http.StatusUnauthorized, errors.New("unauthorized"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (c *Flex) writeJSON(w http.ResponseWriter, status int, res any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(res); err != nil {
+		c.log.ERROR.Printf("encode: %v", err)
+	}
+}
+
+func (c *Flex) writeError(w http.ResponseWriter, status int, err error) {
+	c.writeJSON(w, status, struct {
+		Error string `json:"error"`
+	}{Error: err.Error()})
+}
+
+func (c *Flex) eventsHandler(w http.ResponseWriter, r *http.Request) {
+	c.writeJSON(w, http.StatusOK, c.Events())
+}
+
+func (c *Flex) reserveHandler(w http.ResponseWriter, r *http.Request) {
+	var req Request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		c.writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	ev := c.Reserve(req)
+
+	status := http.StatusCreated
+	if ev.Status == StatusRejected {
+		status = http.StatusUnprocessableEntity
+	}
+
+	c.writeJSON(w, status, ev)
+}
+
+func (c *Flex) idHandler(fun func(uint) (*Event, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 32)
+		if err != nil {
+			c.writeError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		ev, err := fun(uint(id))
+		switch {
+		case ev == nil:
+			c.writeError(w, http.StatusNotFound, err)
+		case err != nil:
+			c.writeError(w, http.StatusConflict, err)
+		default:
+			c.writeJSON(w, http.StatusOK, ev)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package flex
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+type testSite struct {
+	site.API
+	loadpoints []loadpoint.API
+	mode       api.BatteryMode
+	soc        float64
+	discharge  bool
+}
+
+func (s *testSite) Loadpoints() []loadpoint.API {
+	return s.loadpoints
+}
+
+func (s *testSite) SetBatteryModeExternal(mode api.BatteryMode) {
+	s.mode = mode
+}
+
+func (s *testSite) GetBatterySoc() float64 {
+	return s.soc
+}
+
+func (s *testSite) GetBatteryDischargeSupported() bool {
+	return s.discharge
+}
+
+func newTestFlex(t *testing.T, loadpoints ...loadpoint.API) (*Flex, *testSite, *clock.Mock) {
+	t.Helper()
+
+	site := &testSite{loadpoints: loadpoints, soc: 80, discharge: true}
+	c, err := NewFlex(site, "secret", time.Hour, 20)
+	require.NoError(t, err)
+
+	clock := clock.NewMock()
+	clock.Set(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	c.clock = clock
+
+	return c, site, clock
+}
+
+func TestReserve(t *testing.T) {
+	c, _, clock := newTestFlex(t)
+	now := clock.Now()
+
+	tc := []struct {
+		req    Request
+		status Status
+	}{
+		{Request{Action: ActionDischarge, Start: now, Duration: 1800}, StatusReserved},
+		{Request{Action: ActionDischarge, Start: now.Add(15 * time.Minute), Duration: 1800}, StatusRejected}, // overlap
+		{Request{Action: ActionPause, Start: now, Duration: 1800}, StatusRejected},                           // no loadpoints
+		{Request{Action: "boost", Start: now, Duration: 1800}, StatusRejected},
+		{Request{Action: ActionDischarge, Start: now.Add(time.Hour), Duration: 0}, StatusRejected},
+		{Request{Action: ActionDischarge, Start: now.Add(time.Hour), Duration: 7200}, StatusRejected}, // max duration
+		{Request{Action: ActionDischarge, Start: now.Add(-2 * time.Hour), Duration: 1800}, StatusRejected},
+		{Request{Action: ActionDischarge, Start: now.Add(30 * time.Minute), Duration: 1800}, StatusReserved}, // adjacent
+	}
+
+	for i, tc := range tc {
+		ev := c.Reserve(tc.req)
+		assert.Equal(t, tc.status, ev.Status, "%d: %s", i, ev.Reason)
+		assert.Equal(t, uint(i+1), ev.ID)
+	}
+
+	// default start is now
+	ev := c.Reserve(Request{Action: ActionDischarge, Start: time.Time{}, Duration: 60})
+	assert.Equal(t, now, ev.Start)
+}
+
+func TestDispatch(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	lp := loadpoint.NewMockAPI(ctrl)
+
+	c, site, clock := newTestFlex(t, lp)
+
+	pause := c.Reserve(Request{Action: ActionPause, Start: clock.Now().Add(time.Minute), Duration: 1800})
+	discharge := c.Reserve(Request{Action: ActionDischarge, Start: clock.Now(), Duration: 600})
+	require.Equal(t, StatusReserved, pause.Status)
+	require.Equal(t, StatusReserved, discharge.Status)
+
+	// not yet started
+	_, err := c.Dispatch(pause.ID)
+	assert.Error(t, err)
+
+	_, err = c.Dispatch(99)
+	assert.Error(t, err)
+
+	_, err = c.Dispatch(discharge.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusActive, discharge.Status)
+	assert.Equal(t, api.BatteryDischarge, site.mode)
+
+	// pause disables loadpoints
+	clock.Add(time.Minute)
+	lp.EXPECT().RemoteControlSource("flex", loadpoint.RemoteHardDisable)
+	_, err = c.Dispatch(pause.ID)
+	require.NoError(t, err)
+
+	// discharge completes, pause cancelled releases loadpoints
+	clock.Add(10 * time.Minute)
+	c.update()
+	assert.Equal(t, StatusCompleted, discharge.Status)
+	assert.Equal(t, api.BatteryUnknown, site.mode)
+
+	lp.EXPECT().RemoteControlSource("flex", loadpoint.RemoteEnable)
+	_, err = c.Cancel(pause.ID)
+	require.NoError(t, err)
+	assert.Equal(t, StatusCancelled, pause.Status)
+
+	_, err = c.Cancel(pause.ID)
+	assert.Error(t, err, "already cancelled")
+}
+
+func TestDischargeBattery(t *testing.T) {
+	c, site, clock := newTestFlex(t)
+
+	site.discharge = false
+	ev := c.Reserve(Request{Action: ActionDischarge, Start: clock.Now(), Duration: 600})
+	assert.Equal(t, StatusRejected, ev.Status, "forced discharge not supported")
+
+	site.discharge, site.soc = true, 20
+	ev = c.Reserve(Request{Action: ActionDischarge, Start: clock.Now(), Duration: 600})
+	assert.Equal(t, StatusRejected, ev.Status, "min soc")
+
+	site.soc = 30
+	ev = c.Reserve(Request{Action: ActionDischarge, Start: clock.Now(), Duration: 600})
+	require.Equal(t, StatusReserved, ev.Status)
+
+	_, err := c.Dispatch(ev.ID)
+	require.NoError(t, err)
+	assert.Equal(t, api.BatteryDischarge, site.mode)
+
+	// discharge stops at min soc
+	site.soc = 19
+	c.update()
+	assert.Equal(t, StatusCompleted, ev.Status)
+	assert.NotEmpty(t, ev.Reason)
+	assert.Equal(t, api.BatteryUnknown, site.mode)
+}
+
+func TestPersistEvents(t *testing.T) {
+	instance, err := db.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	prev := db.Instance
+	db.Instance = instance
+	t.Cleanup(func() { db.Instance = prev })
+
+	c, site, clock := newTestFlex(t)
+
+	ev := c.Reserve(Request{Action: ActionDischarge, Start: clock.Now(), Duration: 600})
+	_, err = c.Dispatch(ev.ID)
+	require.NoError(t, err)
+	c.Reserve(Request{Action: ActionDischarge, Start: clock.Now().Add(time.Hour), Duration: 600})
+	c.Reserve(Request{Action: "boost", Start: clock.Now(), Duration: 600})
+
+	// restart resumes active and reserved events
+	c, err = NewFlex(site, "secret", time.Hour, 20)
+	require.NoError(t, err)
+	c.clock = clock
+
+	events := c.Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, StatusActive, events[0].Status)
+	assert.Equal(t, StatusReserved, events[1].Status)
+
+	site.mode = api.BatteryUnknown
+	c.update()
+	assert.Equal(t, api.BatteryDischarge, site.mode)
+}
+
+func TestExpire(t *testing.T) {
+	c, _, clock := newTestFlex(t)
+
+	ev := c.Reserve(Request{Action: ActionDischarge, Start: clock.Now(), Duration: 600})
+
+	clock.Add(10 * time.Minute)
+	c.update()
+	assert.Equal(t, StatusExpired, ev.Status)
+
+	// finished events are dropped after a day
+	clock.Add(24*time.Hour + time.Minute)
+	c.update()
+	assert.Empty(t, c.Events())
+}
+
+func TestHandlers(t *testing.T) {
+	c, _, clock := newTestFlex(t)
+
+	router := mux.NewRouter()
+	c.handlers(router)
+
+	request := func(method, path, token string, body any) *httptest.ResponseRecorder {
+		var b bytes.Buffer
+		if body != nil {
+			require.NoError(t, json.NewEncoder(&b).Encode(body))
+		}
+
+		req := httptest.NewRequest(method, basePath+path, &b)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, request(http.MethodGet, "/events", "", nil).Code)
+	assert.Equal(t, http.StatusUnauthorized, request(http.MethodGet, "/events", "wrong", nil).Code)
+	assert.Equal(t, http.StatusOK, request(http.MethodGet, "/events", "secret", nil).Code)
+
+	req := Request{Reference: "ref", Action: ActionDischarge, Start: clock.Now(), Duration: 600}
+	w := request(http.MethodPost, "/events", "secret", req)
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var ev Event
+	require.NoError(t, json.NewDecoder(w.Body).Decode(&ev))
+	assert.Equal(t, "ref", ev.Reference)
+
+	assert.Equal(t, http.StatusUnprocessableEntity, request(http.MethodPost, "/events", "secret", req).Code, "overlap")
+	assert.Equal(t, http.StatusBadRequest, request(http.MethodPost, "/events", "secret", "invalid").Code)
+
+	assert.Equal(t, http.StatusOK, request(http.MethodPost, "/events/1/dispatch", "secret", nil).Code)
+	assert.Equal(t, http.StatusConflict, request(http.MethodPost, "/events/1/dispatch", "secret", nil).Code)
+	assert.Equal(t, http.StatusNotFound, request(http.MethodDelete, "/events/9", "secret", nil).Code)
+	assert.Equal(t, http.StatusOK, request(http.MethodDelete, "/events/1", "secret", nil).Code)
+}
@@ -0,0 +1,53 @@
+package flex
+
+import (
+	"time"
+)
+
+// Action is the flexibility action requested by the aggregator
+type Action string
+
+const (
+	ActionPause     Action = "pause"     // pause charging
+	ActionDischarge Action = "discharge" // discharge home battery
+)
+
+// Status is the lifecycle state of a flexibility event
+type Status string
+
+const (
+	StatusReserved  Status = "reserved"
+	StatusRejected  Status = "rejected"
+	StatusActive    Status = "active"
+	StatusCompleted Status = "completed"
+	StatusCancelled Status = "cancelled"
+	StatusExpired   Status = "expired"
+)
+
+// Event is a flexibility reservation and its audit record
+type Event struct {
+	ID           uint      `json:"id" gorm:"primarykey"`
+	Created      time.Time `json:"created"`
+	Updated      time.Time `json:"updated"`
+	Reference    string    `json:"reference"` // aggregator reference
+	Action       Action    `json:"action"`
+	Start        time.Time `json:"start"`
+	End          time.Time `json:"end"`
+	Status       Status    `json:"status"`
+	Reason       string    `json:"reason,omitempty"`
+	Dispatched   time.Time `json:"dispatched,omitzero"`
+	Acknowledged time.Time `json:"acknowledged,omitzero"`
+}
+
+// TableName returns the database table name
+func (Event) TableName() string {
+	return "flex_events"
+}
+
+// Request is the aggregator reservation request
+type Request struct {
+	Reference string    `json:"reference"`
+	Action    Action    `json:"action"`
+	Start     time.Time `json:"start"`
+	Duration  int64     `json:"duration"` // seconds
+}
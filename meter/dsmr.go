@@ -18,6 +18,7 @@ import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
+	"github.com/grid-x/serial"
 )
 
 // github.com/basvdlei/gotsmart package is subject to the following license:
@@ -54,48 +55,68 @@ import (
 
 // Dsmr meter implementation
 type Dsmr struct {
-	mu      sync.Mutex
-	addr    string
-	energy  string
-	timeout time.Duration
-	frame   dsmr.Frame
-	updated time.Time
+	mu       sync.Mutex
+	addr     string
+	device   string
+	baudrate int
+	energy   string
+	timeout  time.Duration
+	frame    dsmr.Frame
+	updated  time.Time
 }
 
 var (
 	currentObis     = []string{"1-0:31.7.0", "1-0:51.7.0", "1-0:71.7.0"}
+	voltageObis     = []string{"1-0:32.7.0", "1-0:52.7.0", "1-0:72.7.0"}
+	powerImportObis = []string{"1-0:21.7.0", "1-0:41.7.0", "1-0:61.7.0"}
 	powerExportObis = []string{"1-0:22.7.0", "1-0:42.7.0", "1-0:62.7.0"}
 )
 
+// M-Bus device type and reading of the M-Bus channels
+const (
+	mbusTypeObis    = "0-%d:24.1.0"
+	mbusReadingObis = "0-%d:24.2.1"
+	mbusTypeGas     = 3
+)
+
 func init() {
 	registry.Add("dsmr", NewDsmrFromConfig)
 }
 
-//go:generate go tool decorate -f decorateDsmr -b api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)"
+//go:generate go tool decorate -f decorateDsmr -b api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)" -t "api.PhaseVoltages,Voltages,func() (float64, float64, float64, error)" -t "api.PhasePowers,Powers,func() (float64, float64, float64, error)" -t "api.MeterGas,TotalGas,func() (float64, error)"
 
 // NewDsmrFromConfig creates a DSMR meter from generic config
 func NewDsmrFromConfig(other map[string]interface{}) (api.Meter, error) {
 	cc := struct {
-		URI     string
-		Energy  string
-		Timeout time.Duration
+		URI      string
+		Device   string // P1 port connected by serial adapter
+		Baudrate int
+		Energy   string
+		Timeout  time.Duration
 	}{
-		Timeout: 15 * time.Second,
+		Baudrate: 115200,
+		Timeout:  15 * time.Second,
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
 		return nil, err
 	}
 
-	return NewDsmr(cc.URI, cc.Energy, cc.Timeout)
+	if (cc.URI == "") == (cc.Device == "") {
+		return nil, errors.New("need either uri or device")
+	}
+
+	return NewDsmr(cc.URI, cc.Device, cc.Baudrate, cc.Energy, cc.Timeout)
 }
 
-// NewDsmr creates DSMR meter
-func NewDsmr(uri, energy string, timeout time.Duration) (api.Meter, error) {
+// NewDsmr creates DSMR meter connected via TCP or serial device
+func NewDsmr(uri, device string, baudrate int, energy string, timeout time.Duration) (api.Meter, error) {
 	m := &Dsmr{
-		addr:    uri,
-		energy:  energy,
-		timeout: timeout,
+		addr:     uri,
+		device:   device,
+		baudrate: baudrate,
+		energy:   energy,
+		timeout:  timeout,
 	}
 
 	done := make(chan struct{}, 1)
@@ -119,25 +140,42 @@ func NewDsmr(uri, energy string, timeout time.Duration) (api.Meter, error) {
 		totalEnergy = m.totalEnergy
 	}
 
-	// decorate currents
-	var currents func() (float64, float64, float64, error)
+	// decorate phase values
+	var currents, voltages, powers func() (float64, float64, float64, error)
 
-	for _, obis := range currentObis {
-		_, err = m.get(obis)
-		if err != nil {
-			break
-		}
+	if m.available(currentObis...) {
+		currents = m.currents
 	}
 
-	if err == nil {
-		currents = m.currents
+	if m.available(voltageObis...) {
+		voltages = m.voltages
 	}
 
-	return decorateDsmr(m, totalEnergy, currents), nil
+	if m.available(powerImportObis...) {
+		powers = m.powers
+	}
+
+	// decorate gas
+	var totalGas func() (float64, error)
+	if _, err := m.totalGas(); err == nil {
+		totalGas = m.totalGas
+	}
+
+	return decorateDsmr(m, totalEnergy, currents, voltages, powers, totalGas), nil
+}
+
+// available checks if the latest frame contains all given objects
+func (m *Dsmr) available(ids ...string) bool {
+	for _, id := range ids {
+		if _, err := m.get(id); err != nil {
+			return false
+		}
+	}
+	return true
 }
 
 // based on https://github.com/basvdlei/gotsmart/blob/master/gotsmart.go
-func (m *Dsmr) run(conn net.Conn, done chan struct{}) {
+func (m *Dsmr) run(conn io.ReadCloser, done chan struct{}) {
 	log := util.NewLogger("dsmr")
 	bo := backoff.NewExponentialBackOff(backoff.WithMaxInterval(5 * time.Minute))
 
@@ -145,8 +183,9 @@ func (m *Dsmr) run(conn net.Conn, done chan struct{}) {
 		log.ERROR.Printf("%s: %v", op, err)
 		if err == io.EOF ||
 			errors.Is(err, io.ErrUnexpectedEOF) ||
-			errors.Is(err, net.ErrClosed) {
-			conn.Close() // closing on nil socket is safe
+			errors.Is(err, net.ErrClosed) ||
+			errors.Is(err, os.ErrClosed) {
+			conn.Close()
 			conn = nil
 		}
 	}
@@ -220,7 +259,25 @@ func (m *Dsmr) run(conn net.Conn, done chan struct{}) {
 	}
 }
 
-func (m *Dsmr) connect() (net.Conn, error) {
+func (m *Dsmr) connect() (io.ReadCloser, error) {
+	if m.device != "" {
+		conf := &serial.Config{
+			Address:  m.device,
+			BaudRate: m.baudrate,
+			DataBits: 8,
+			StopBits: 1,
+			Parity:   "N",
+		}
+
+		// DSMR 2.2 and 3.0 use 9600 7E1
+		if m.baudrate == 9600 {
+			conf.DataBits = 7
+			conf.Parity = "E"
+		}
+
+		return serial.Open(conf)
+	}
+
 	dialer := net.Dialer{Timeout: request.Timeout}
 
 	conn, err := dialer.Dial("tcp", m.addr)
@@ -244,7 +301,13 @@ func (m *Dsmr) get(id string) (float64, error) {
 		return 0, fmt.Errorf("%w: %s", api.ErrNotAvailable, id)
 	}
 
-	return strconv.ParseFloat(res.Value, 64)
+	// values with capture time like gas readings: (101209112500W)(12785.123
+	val := res.Value
+	if i := strings.LastIndex(val, "("); i >= 0 {
+		val = val[i+1:]
+	}
+
+	return strconv.ParseFloat(val, 64)
 }
 
 // CurrentPower implements the api.Meter interface
@@ -286,3 +349,57 @@ func (m *Dsmr) currents() (float64, float64, float64, error) {
 
 	return res[0], res[1], res[2], nil
 }
+
+// voltages implements the api.PhaseVoltages interface
+func (m *Dsmr) voltages() (float64, float64, float64, error) {
+	var res [3]float64
+
+	for i := range res {
+		var err error
+		if res[i], err = m.get(voltageObis[i]); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+
+	return res[0], res[1], res[2], nil
+}
+
+// powers implements the api.PhasePowers interface
+func (m *Dsmr) powers() (float64, float64, float64, error) {
+	var res [3]float64
+
+	for i := range res {
+		imp, err := m.get(powerImportObis[i])
+		if err != nil {
+			return 0, 0, 0, err
+		}
+
+		exp, err := m.get(powerExportObis[i])
+		if err != nil {
+			return 0, 0, 0, err
+		}
+
+		res[i] = (imp - exp) * 1e3
+	}
+
+	return res[0], res[1], res[2], nil
+}
+
+// totalGas implements the api.MeterGas interface
+func (m *Dsmr) totalGas() (float64, error) {
+	// gas meter may be connected to any of the M-Bus channels
+	for ch := 1; ch <= 4; ch++ {
+		// skip water or heat meters
+		if typ, err := m.get(fmt.Sprintf(mbusTypeObis, ch)); err == nil && typ != mbusTypeGas {
+			continue
+		}
+
+		if res, err := m.get(fmt.Sprintf(mbusReadingObis, ch)); err == nil {
+			return res, nil
+		} else if !errors.Is(err, api.ErrNotAvailable) {
+			return 0, err
+		}
+	}
+
+	return 0, api.ErrNotAvailable
+}
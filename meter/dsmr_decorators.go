@@ -6,12 +6,12 @@ import (
 	"github.com/evcc-io/evcc/api"
 )
 
-func decorateDsmr(base api.Meter, meterEnergy func() (float64, error), phaseCurrents func() (float64, float64, float64, error)) api.Meter {
+func decorateDsmr(base api.Meter, meterEnergy func() (float64, error), phaseCurrents func() (float64, float64, float64, error), phaseVoltages func() (float64, float64, float64, error), phasePowers func() (float64, float64, float64, error), meterGas func() (float64, error)) api.Meter {
 	switch {
-	case meterEnergy == nil && phaseCurrents == nil:
+	case meterEnergy == nil && meterGas == nil && phaseCurrents == nil && phaseVoltages == nil:
 		return base
 
-	case meterEnergy != nil && phaseCurrents == nil:
+	case meterEnergy != nil && meterGas == nil && phaseCurrents == nil && phaseVoltages == nil:
 		return &struct {
 			api.Meter
 			api.MeterEnergy
@@ -22,7 +22,7 @@ func decorateDsmr(base api.Meter, meterEnergy func() (float64, error), phaseCurr
 			},
 		}
 
-	case meterEnergy == nil && phaseCurrents != nil:
+	case meterEnergy == nil && meterGas == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
 			api.Meter
 			api.PhaseCurrents
@@ -33,7 +33,7 @@ func decorateDsmr(base api.Meter, meterEnergy func() (float64, error), phaseCurr
 			},
 		}
 
-	case meterEnergy != nil && phaseCurrents != nil:
+	case meterEnergy != nil && meterGas == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
 		return &struct {
 			api.Meter
 			api.MeterEnergy
@@ -47,6 +47,370 @@ func decorateDsmr(base api.Meter, meterEnergy func() (float64, error), phaseCurr
 				phaseCurrents: phaseCurrents,
 			},
 		}
+
+	case meterEnergy == nil && meterGas == nil && phaseCurrents == nil && phaseVoltages != nil:
+		return &struct {
+			api.Meter
+			api.PhaseVoltages
+		}{
+			Meter: base,
+			PhaseVoltages: &decorateDsmrPhaseVoltagesImpl{
+				phaseVoltages: phaseVoltages,
+			},
+		}
+
+	case meterEnergy != nil && meterGas == nil && phaseCurrents == nil && phaseVoltages != nil:
+		return &struct {
+			api.Meter
+			api.MeterEnergy
+			api.PhaseVoltages
+		}{
+			Meter: base,
+			MeterEnergy: &decorateDsmrMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			PhaseVoltages: &decorateDsmrPhaseVoltagesImpl{
+				phaseVoltages: phaseVoltages,
+			},
+		}
+
+	case meterEnergy == nil && meterGas == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
+		return &struct {
+			api.Meter
+			api.PhaseCurrents
+			api.PhaseVoltages
+		}{
+			Meter: base,
+			PhaseCurrents: &decorateDsmrPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhaseVoltages: &decorateDsmrPhaseVoltagesImpl{
+				phaseVoltages: phaseVoltages,
+			},
+		}
+
+	case meterEnergy != nil && meterGas == nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
+		return &struct {
+			api.Meter
+			api.MeterEnergy
+			api.PhaseCurrents
+			api.PhaseVoltages
+		}{
+			Meter: base,
+			MeterEnergy: &decorateDsmrMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			PhaseCurrents: &decorateDsmrPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhaseVoltages: &decorateDsmrPhaseVoltagesImpl{
+				phaseVoltages: phaseVoltages,
+			},
+		}
+
+	case meterEnergy == nil && meterGas == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
+		return &struct {
+			api.Meter
+			api.PhaseCurrents
+			api.PhasePowers
+		}{
+			Meter: base,
+			PhaseCurrents: &decorateDsmrPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhasePowers: &decorateDsmrPhasePowersImpl{
+				phasePowers: phasePowers,
+			},
+		}
+
+	case meterEnergy != nil && meterGas == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
+		return &struct {
+			api.Meter
+			api.MeterEnergy
+			api.PhaseCurrents
+			api.PhasePowers
+		}{
+			Meter: base,
+			MeterEnergy: &decorateDsmrMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			PhaseCurrents: &decorateDsmrPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhasePowers: &decorateDsmrPhasePowersImpl{
+				phasePowers: phasePowers,
+			},
+		}
+
+	case meterEnergy == nil && meterGas == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
+		return &struct {
+			api.Meter
+			api.PhaseCurrents
+			api.PhasePowers
+			api.PhaseVoltages
+		}{
+			Meter: base,
+			PhaseCurrents: &decorateDsmrPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhasePowers: &decorateDsmrPhasePowersImpl{
+				phasePowers: phasePowers,
+			},
+			PhaseVoltages: &decorateDsmrPhaseVoltagesImpl{
+				phaseVoltages: phaseVoltages,
+			},
+		}
+
+	case meterEnergy != nil && meterGas == nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
+		return &struct {
+			api.Meter
+			api.MeterEnergy
+			api.PhaseCurrents
+			api.PhasePowers
+			api.PhaseVoltages
+		}{
+			Meter: base,
+			MeterEnergy: &decorateDsmrMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			PhaseCurrents: &decorateDsmrPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhasePowers: &decorateDsmrPhasePowersImpl{
+				phasePowers: phasePowers,
+			},
+			PhaseVoltages: &decorateDsmrPhaseVoltagesImpl{
+				phaseVoltages: phaseVoltages,
+			},
+		}
+
+	case meterEnergy == nil && meterGas != nil && phaseCurrents == nil && phaseVoltages == nil:
+		return &struct {
+			api.Meter
+			api.MeterGas
+		}{
+			Meter: base,
+			MeterGas: &decorateDsmrMeterGasImpl{
+				meterGas: meterGas,
+			},
+		}
+
+	case meterEnergy != nil && meterGas != nil && phaseCurrents == nil && phaseVoltages == nil:
+		return &struct {
+			api.Meter
+			api.MeterEnergy
+			api.MeterGas
+		}{
+			Meter: base,
+			MeterEnergy: &decorateDsmrMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			MeterGas: &decorateDsmrMeterGasImpl{
+				meterGas: meterGas,
+			},
+		}
+
+	case meterEnergy == nil && meterGas != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
+		return &struct {
+			api.Meter
+			api.MeterGas
+			api.PhaseCurrents
+		}{
+			Meter: base,
+			MeterGas: &decorateDsmrMeterGasImpl{
+				meterGas: meterGas,
+			},
+			PhaseCurrents: &decorateDsmrPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+		}
+
+	case meterEnergy != nil && meterGas != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages == nil:
+		return &struct {
+			api.Meter
+			api.MeterEnergy
+			api.MeterGas
+			api.PhaseCurrents
+		}{
+			Meter: base,
+			MeterEnergy: &decorateDsmrMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			MeterGas: &decorateDsmrMeterGasImpl{
+				meterGas: meterGas,
+			},
+			PhaseCurrents: &decorateDsmrPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+		}
+
+	case meterEnergy == nil && meterGas != nil && phaseCurrents == nil && phaseVoltages != nil:
+		return &struct {
+			api.Meter
+			api.MeterGas
+			api.PhaseVoltages
+		}{
+			Meter: base,
+			MeterGas: &decorateDsmrMeterGasImpl{
+				meterGas: meterGas,
+			},
+			PhaseVoltages: &decorateDsmrPhaseVoltagesImpl{
+				phaseVoltages: phaseVoltages,
+			},
+		}
+
+	case meterEnergy != nil && meterGas != nil && phaseCurrents == nil && phaseVoltages != nil:
+		return &struct {
+			api.Meter
+			api.MeterEnergy
+			api.MeterGas
+			api.PhaseVoltages
+		}{
+			Meter: base,
+			MeterEnergy: &decorateDsmrMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			MeterGas: &decorateDsmrMeterGasImpl{
+				meterGas: meterGas,
+			},
+			PhaseVoltages: &decorateDsmrPhaseVoltagesImpl{
+				phaseVoltages: phaseVoltages,
+			},
+		}
+
+	case meterEnergy == nil && meterGas != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
+		return &struct {
+			api.Meter
+			api.MeterGas
+			api.PhaseCurrents
+			api.PhaseVoltages
+		}{
+			Meter: base,
+			MeterGas: &decorateDsmrMeterGasImpl{
+				meterGas: meterGas,
+			},
+			PhaseCurrents: &decorateDsmrPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhaseVoltages: &decorateDsmrPhaseVoltagesImpl{
+				phaseVoltages: phaseVoltages,
+			},
+		}
+
+	case meterEnergy != nil && meterGas != nil && phaseCurrents != nil && phasePowers == nil && phaseVoltages != nil:
+		return &struct {
+			api.Meter
+			api.MeterEnergy
+			api.MeterGas
+			api.PhaseCurrents
+			api.PhaseVoltages
+		}{
+			Meter: base,
+			MeterEnergy: &decorateDsmrMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			MeterGas: &decorateDsmrMeterGasImpl{
+				meterGas: meterGas,
+			},
+			PhaseCurrents: &decorateDsmrPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhaseVoltages: &decorateDsmrPhaseVoltagesImpl{
+				phaseVoltages: phaseVoltages,
+			},
+		}
+
+	case meterEnergy == nil && meterGas != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
+		return &struct {
+			api.Meter
+			api.MeterGas
+			api.PhaseCurrents
+			api.PhasePowers
+		}{
+			Meter: base,
+			MeterGas: &decorateDsmrMeterGasImpl{
+				meterGas: meterGas,
+			},
+			PhaseCurrents: &decorateDsmrPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhasePowers: &decorateDsmrPhasePowersImpl{
+				phasePowers: phasePowers,
+			},
+		}
+
+	case meterEnergy != nil && meterGas != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages == nil:
+		return &struct {
+			api.Meter
+			api.MeterEnergy
+			api.MeterGas
+			api.PhaseCurrents
+			api.PhasePowers
+		}{
+			Meter: base,
+			MeterEnergy: &decorateDsmrMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			MeterGas: &decorateDsmrMeterGasImpl{
+				meterGas: meterGas,
+			},
+			PhaseCurrents: &decorateDsmrPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhasePowers: &decorateDsmrPhasePowersImpl{
+				phasePowers: phasePowers,
+			},
+		}
+
+	case meterEnergy == nil && meterGas != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
+		return &struct {
+			api.Meter
+			api.MeterGas
+			api.PhaseCurrents
+			api.PhasePowers
+			api.PhaseVoltages
+		}{
+			Meter: base,
+			MeterGas: &decorateDsmrMeterGasImpl{
+				meterGas: meterGas,
+			},
+			PhaseCurrents: &decorateDsmrPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhasePowers: &decorateDsmrPhasePowersImpl{
+				phasePowers: phasePowers,
+			},
+			PhaseVoltages: &decorateDsmrPhaseVoltagesImpl{
+				phaseVoltages: phaseVoltages,
+			},
+		}
+
+	case meterEnergy != nil && meterGas != nil && phaseCurrents != nil && phasePowers != nil && phaseVoltages != nil:
+		return &struct {
+			api.Meter
+			api.MeterEnergy
+			api.MeterGas
+			api.PhaseCurrents
+			api.PhasePowers
+			api.PhaseVoltages
+		}{
+			Meter: base,
+			MeterEnergy: &decorateDsmrMeterEnergyImpl{
+				meterEnergy: meterEnergy,
+			},
+			MeterGas: &decorateDsmrMeterGasImpl{
+				meterGas: meterGas,
+			},
+			PhaseCurrents: &decorateDsmrPhaseCurrentsImpl{
+				phaseCurrents: phaseCurrents,
+			},
+			PhasePowers: &decorateDsmrPhasePowersImpl{
+				phasePowers: phasePowers,
+			},
+			PhaseVoltages: &decorateDsmrPhaseVoltagesImpl{
+				phaseVoltages: phaseVoltages,
+			},
+		}
 	}
 
 	return nil
@@ -60,6 +424,14 @@ func (impl *decorateDsmrMeterEnergyImpl) TotalEnergy() (float64, error) {
 	return impl.meterEnergy()
 }
 
+type decorateDsmrMeterGasImpl struct {
+	meterGas func() (float64, error)
+}
+
+func (impl *decorateDsmrMeterGasImpl) TotalGas() (float64, error) {
+	return impl.meterGas()
+}
+
 type decorateDsmrPhaseCurrentsImpl struct {
 	phaseCurrents func() (float64, float64, float64, error)
 }
@@ -67,3 +439,19 @@ type decorateDsmrPhaseCurrentsImpl struct {
 func (impl *decorateDsmrPhaseCurrentsImpl) Currents() (float64, float64, float64, error) {
 	return impl.phaseCurrents()
 }
+
+type decorateDsmrPhasePowersImpl struct {
+	phasePowers func() (float64, float64, float64, error)
+}
+
+func (impl *decorateDsmrPhasePowersImpl) Powers() (float64, float64, float64, error) {
+	return impl.phasePowers()
+}
+
+type decorateDsmrPhaseVoltagesImpl struct {
+	phaseVoltages func() (float64, float64, float64, error)
+}
+
+func (impl *decorateDsmrPhaseVoltagesImpl) Voltages() (float64, float64, float64, error) {
+	return impl.phaseVoltages()
+}
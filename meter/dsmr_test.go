@@ -0,0 +1,60 @@
+package meter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/basvdlei/gotsmart/dsmr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDsmrFrame(t *testing.T) {
+	frame, err := dsmr.ParseFrame(`/ISk5\2MT382-1000
+
+1-3:0.2.8(50)
+0-0:1.0.0(101209113020W)
+1-0:1.7.0(01.193*kW)
+1-0:2.7.0(00.000*kW)
+1-0:32.7.0(220.1*V)
+1-0:52.7.0(220.2*V)
+1-0:72.7.0(220.3*V)
+1-0:21.7.0(01.111*kW)
+1-0:41.7.0(00.082*kW)
+1-0:61.7.0(00.000*kW)
+1-0:22.7.0(00.000*kW)
+1-0:42.7.0(00.000*kW)
+1-0:62.7.0(00.100*kW)
+0-1:24.1.0(007)
+0-1:24.2.1(101209112500W)(00312.145*m3)
+0-2:24.1.0(003)
+0-2:24.2.1(101209112500W)(12785.123*m3)
+!`)
+	require.NoError(t, err)
+
+	m := &Dsmr{
+		frame:   frame,
+		updated: time.Now(),
+		timeout: time.Minute,
+	}
+
+	assert.True(t, m.available(voltageObis...))
+	assert.False(t, m.available(currentObis...))
+
+	p, err := m.CurrentPower()
+	require.NoError(t, err)
+	assert.InDelta(t, 1193, p, 1e-6)
+
+	u1, u2, u3, err := m.voltages()
+	require.NoError(t, err)
+	assert.Equal(t, []float64{220.1, 220.2, 220.3}, []float64{u1, u2, u3})
+
+	p1, p2, p3, err := m.powers()
+	require.NoError(t, err)
+	assert.InDeltaSlice(t, []float64{1111, 82, -100}, []float64{p1, p2, p3}, 1e-6)
+
+	// water meter on channel 1 is skipped
+	gas, err := m.totalGas()
+	require.NoError(t, err)
+	assert.Equal(t, 12785.123, gas)
+}
@@ -2,7 +2,9 @@ package meter
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/meter/measurement"
@@ -14,7 +16,7 @@ func init() {
 	registry.AddCtx(api.Custom, NewConfigurableFromConfig)
 }
 
-//go:generate go tool decorate -f decorateMeter -b api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)" -t "api.PhaseVoltages,Voltages,func() (float64, float64, float64, error)" -t "api.PhasePowers,Powers,func() (float64, float64, float64, error)" -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryCapacity,Capacity,func() float64" -t "api.MaxACPowerGetter,MaxACPower,func() float64" -t "api.BatteryController,SetBatteryMode,func(api.BatteryMode) error"
+//go:generate go tool decorate -f decorateMeter -b *Meter -r api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)" -t "api.PhaseVoltages,Voltages,func() (float64, float64, float64, error)" -t "api.PhasePowers,Powers,func() (float64, float64, float64, error)" -t "api.Battery,Soc,func() (float64, error)" -t "api.BatteryCapacity,Capacity,func() float64" -t "api.MaxACPowerGetter,MaxACPower,func() float64" -t "api.BatteryController,SetBatteryMode,func(api.BatteryMode) error"
 
 // NewConfigurableFromConfig creates api.Meter from config
 func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}) (api.Meter, error) {
@@ -29,6 +31,13 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 		Soc         *plugin.Config // optional
 		LimitSoc    *plugin.Config // optional
 		BatteryMode *plugin.Config // optional
+
+		// pv
+		PowerLimit       *plugin.Config // optional
+		PowerLimitRemove *plugin.Config // optional
+		Standby          *plugin.Config // optional
+
+		Features []api.Feature
 	}{
 		battery: battery{
 			MinSoc: 20,
@@ -80,6 +89,31 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 		}
 	}
 
+	powerLimitS, err := cc.PowerLimit.FloatSetter(ctx, "powerLimit")
+	if err != nil {
+		return nil, fmt.Errorf("power limit: %w", err)
+	}
+
+	if cc.PowerLimit != nil && cc.PowerLimitRemove == nil && cc.MaxACPower == 0 {
+		return nil, errors.New("power limit: requires powerLimitRemove or maxacpower")
+	}
+
+	powerLimitRemoveS, err := cc.PowerLimitRemove.BoolSetter(ctx, "powerLimitRemove")
+	if err != nil {
+		return nil, fmt.Errorf("power limit remove: %w", err)
+	}
+
+	standbyS, err := cc.Standby.BoolSetter(ctx, "standby")
+	if err != nil {
+		return nil, fmt.Errorf("standby: %w", err)
+	}
+
+	m.features = cc.Features
+	m.powerLimitS = powerLimitS
+	m.powerLimitRemoveS = powerLimitRemoveS
+	m.maxACPower = cc.MaxACPower
+	m.standbyS = standbyS
+
 	res := m.Decorate(energyG, currentsG, voltagesG, powersG, socG, cc.capacity.Decorator(), cc.maxpower.Decorator(), batModeS)
 
 	return res, nil
@@ -95,7 +129,12 @@ func NewConfigurable(currentPowerG func() (float64, error)) (*Meter, error) {
 
 // Meter is an api.Meter implementation with configurable getters and setters.
 type Meter struct {
-	currentPowerG func() (float64, error)
+	features          []api.Feature
+	currentPowerG     func() (float64, error)
+	powerLimitS       func(float64) error
+	powerLimitRemoveS func(bool) error
+	maxACPower        float64
+	standbyS          func(bool) error
 }
 
 // Decorate attaches additional capabilities to the base meter
@@ -116,3 +155,50 @@ func (m *Meter) Decorate(
 func (m *Meter) CurrentPower() (float64, error) {
 	return m.currentPowerG()
 }
+
+var _ api.FeatureDescriber = (*Meter)(nil)
+
+// Features implements the api.FeatureDescriber interface
+func (m *Meter) Features() []api.Feature {
+	res := slices.Clone(m.features)
+	if m.powerLimitS != nil {
+		res = append(res, api.PowerLimit)
+	}
+	if m.standbyS != nil {
+		res = append(res, api.Standby)
+	}
+	return res
+}
+
+var _ api.PowerLimiter = (*Meter)(nil)
+
+// SetPowerLimit implements the api.PowerLimiter interface
+func (m *Meter) SetPowerLimit(power float64) error {
+	if m.powerLimitS == nil {
+		return api.ErrNotAvailable
+	}
+	return m.powerLimitS(power)
+}
+
+// RemovePowerLimit implements the api.PowerLimiter interface
+func (m *Meter) RemovePowerLimit() error {
+	switch {
+	case m.powerLimitS == nil:
+		return api.ErrNotAvailable
+	case m.powerLimitRemoveS != nil:
+		return m.powerLimitRemoveS(true)
+	default:
+		// without explicit removal the limit is raised to the inverter's rated power
+		return m.powerLimitS(m.maxACPower)
+	}
+}
+
+var _ api.InverterStandby = (*Meter)(nil)
+
+// SetStandby implements the api.InverterStandby interface
+func (m *Meter) SetStandby(standby bool) error {
+	if m.standbyS == nil {
+		return api.ErrNotAvailable
+	}
+	return m.standbyS(standby)
+}
@@ -28,3 +28,85 @@ func TestACPower(t *testing.T) {
 	_, ok = m.(api.MaxACPowerGetter)
 	assert.True(t, ok, "api.MaxACPowerGetter")
 }
+
+func TestPowerLimitFeature(t *testing.T) {
+	power := map[string]any{
+		"source": "const",
+		"value":  1000,
+	}
+
+	m, err := NewConfigurableFromConfig(context.TODO(), map[string]any{
+		"power": power,
+	})
+	require.NoError(t, err)
+
+	// interface is always implemented, availability is described by feature
+	pl, ok := m.(api.PowerLimiter)
+	require.True(t, ok, "api.PowerLimiter")
+	assert.ErrorIs(t, pl.SetPowerLimit(1000), api.ErrNotAvailable)
+	assert.ErrorIs(t, pl.RemovePowerLimit(), api.ErrNotAvailable)
+	assert.NotContains(t, m.(api.FeatureDescriber).Features(), api.PowerLimit)
+
+	// limit removal must be configurable
+	_, err = NewConfigurableFromConfig(context.TODO(), map[string]any{
+		"power":      power,
+		"powerLimit": map[string]any{"source": "js", "script": "0"},
+	})
+	require.Error(t, err)
+
+	m, err = NewConfigurableFromConfig(context.TODO(), map[string]any{
+		"power":      power,
+		"maxacpower": 10000,
+		"powerLimit": map[string]any{"source": "js", "script": "0"},
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, m.(api.PowerLimiter).SetPowerLimit(1000))
+	assert.NoError(t, m.(api.PowerLimiter).RemovePowerLimit())
+	assert.Contains(t, m.(api.FeatureDescriber).Features(), api.PowerLimit)
+
+	m, err = NewConfigurableFromConfig(context.TODO(), map[string]any{
+		"power":            power,
+		"powerLimit":       map[string]any{"source": "js", "script": "0"},
+		"powerLimitRemove": map[string]any{"source": "js", "script": "0"},
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, m.(api.PowerLimiter).RemovePowerLimit())
+}
+
+func TestStandbyFeature(t *testing.T) {
+	power := map[string]any{
+		"source": "const",
+		"value":  1000,
+	}
+
+	m, err := NewConfigurableFromConfig(context.TODO(), map[string]any{
+		"power": power,
+	})
+	require.NoError(t, err)
+
+	assert.ErrorIs(t, m.(api.InverterStandby).SetStandby(true), api.ErrNotAvailable)
+	assert.NotContains(t, m.(api.FeatureDescriber).Features(), api.Standby)
+
+	m, err = NewConfigurableFromConfig(context.TODO(), map[string]any{
+		"power":   power,
+		"standby": map[string]any{"source": "js", "script": "0"},
+	})
+	require.NoError(t, err)
+
+	assert.NoError(t, m.(api.InverterStandby).SetStandby(true))
+	assert.Contains(t, m.(api.FeatureDescriber).Features(), api.Standby)
+}
+
+func TestDeclaredFeatures(t *testing.T) {
+	m, err := NewConfigurableFromConfig(context.TODO(), map[string]any{
+		"power":       map[string]any{"source": "const", "value": 1000},
+		"soc":         map[string]any{"source": "const", "value": 50},
+		"batteryMode": map[string]any{"source": "js", "script": "0"},
+		"features":    []string{"forcedDischarge"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, []api.Feature{api.ForcedDischarge}, m.(api.FeatureDescriber).Features())
+}
@@ -212,6 +212,39 @@ func (m *RCT) batterySoc() (float64, error) {
 	return res * 100, err
 }
 
+// rctFaults are the inverter fault bit masks
+var rctFaults = []rct.Identifier{0x37F9D5CA, 0x234B4736, 0x3B7FCD47, 0x7F813D73}
+
+var _ api.FaultReporter = (*RCT)(nil)
+
+// Faults implements the api.FaultReporter interface
+func (m *RCT) Faults() ([]api.Fault, error) {
+	// faults are device-wide, report once
+	if m.usage != "pv" {
+		return nil, nil
+	}
+
+	var res []api.Fault
+
+	for i, id := range rctFaults {
+		val, err := m.queryInt32(id)
+		if err != nil {
+			return nil, err
+		}
+
+		for bit := range 32 {
+			if uint32(val)&(1<<bit) != 0 {
+				res = append(res, api.Fault{
+					Code:        fmt.Sprintf("%d.%d", i, bit),
+					Description: rctFault(i, bit),
+				})
+			}
+		}
+	}
+
+	return res, nil
+}
+
 // queryFloat adds retry logic of recoverable errors to QueryFloat32
 func (m *RCT) queryFloat(id rct.Identifier) (float64, error) {
 	res, err := m.conn.QueryFloat32(id)
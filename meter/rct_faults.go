@@ -0,0 +1,21 @@
+package meter
+
+import "fmt"
+
+// rctFaultText are the RCT Power fault descriptions indexed by fault word and bit as shown by the RCT Power app
+var rctFaultText = map[[2]int]string{
+	{0, 0}: "TRAP occurred",
+	{0, 1}: "RTC can't be configured",
+	{0, 2}: "Correction data (adjustment) invalid",
+	{0, 3}: "Inverter and power switch processors not synchronized",
+	{0, 4}: "Power switch processor not responding",
+}
+
+// rctFault decodes an RCT fault bit to its vendor description.
+// Bits without known description are identified by their fault[word].flt register bit as shown in the RCT Power app.
+func rctFault(word, bit int) string {
+	if s, ok := rctFaultText[[2]int{word, bit}]; ok {
+		return s
+	}
+	return fmt.Sprintf("fault[%d].flt bit %d", word, bit)
+}
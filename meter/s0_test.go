@@ -0,0 +1,47 @@
+package meter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestS0(t *testing.T) {
+	var count int64
+
+	m := NewS0(func() (int64, error) { return count, nil }, nil, 1000)
+	clk := clock.NewMock()
+	m.clock = clk
+
+	power := func() float64 {
+		t.Helper()
+		p, err := m.CurrentPower()
+		require.NoError(t, err)
+		return p
+	}
+
+	count = 5000
+	assert.Equal(t, 0.0, power(), "first reading")
+
+	// 100 impulses in 1 minute: 0.1kWh/min
+	clk.Add(time.Minute)
+	count += 100
+	assert.InDelta(t, 6000, power(), 1e-6)
+
+	// no impulse, power bounded by a single impulse since last change
+	clk.Add(10 * time.Minute)
+	assert.InDelta(t, 6, power(), 1e-6)
+
+	// counter reset
+	clk.Add(time.Minute)
+	count = 0
+	assert.Equal(t, 0.0, power())
+
+	count = 2500
+	e, err := m.TotalEnergy()
+	require.NoError(t, err)
+	assert.Equal(t, 2.5, e)
+}
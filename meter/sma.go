@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"sort"
+	"strconv"
 	"text/tabwriter"
 
 	"github.com/evcc-io/evcc/api"
@@ -170,6 +171,42 @@ func (sm *SMA) soc() (float64, error) {
 	return sma.AsFloat(values[sunny.BatteryCharge]), err
 }
 
+// smaFaults are the SMA device status and operating state codes indicating faults,
+// other states like standby, waiting for pv voltage or derating are regular operation
+var smaFaults = map[int]string{
+	35:   "Fault",
+	455:  "Warning",
+	1392: "Fault",
+}
+
+// smaNaN is the status code for unavailable values
+const smaNaN = 0xFFFFFD
+
+var _ api.FaultReporter = (*SMA)(nil)
+
+// Faults implements the api.FaultReporter interface
+func (sm *SMA) Faults() ([]api.Fault, error) {
+	if sm.device.IsEnergyMeter() {
+		return nil, nil
+	}
+
+	values, err := sm.device.Values()
+	if err != nil {
+		return nil, err
+	}
+
+	var res []api.Fault
+
+	// status tag without attribute flags
+	if status := int(sma.AsFloat(values[sunny.DeviceStatus])) & 0xFFFFFF; status != smaNaN {
+		if desc, ok := smaFaults[status]; ok {
+			res = append(res, api.Fault{Code: strconv.Itoa(status), Description: desc})
+		}
+	}
+
+	return res, nil
+}
+
 var _ api.Diagnosis = (*SMA)(nil)
 
 // Diagnose implements the api.Diagnosis interface
@@ -0,0 +1,298 @@
+package meter
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/meter/sml"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/grid-x/serial"
+)
+
+// Sml meter reads the SML protocol of an optical reading head connected by serial
+// device or TCP, e.g. using a Tasmota serial bridge
+type Sml struct {
+	mu       sync.Mutex
+	addr     string
+	device   string
+	baudrate int
+	timeout  time.Duration
+	values   map[string]sml.Value
+	updated  time.Time
+}
+
+const (
+	smlPowerObis  = "1-0:16.7.0"
+	smlEnergyObis = "1-0:1.8.0"
+)
+
+var (
+	smlCurrentObis    = []string{"1-0:31.7.0", "1-0:51.7.0", "1-0:71.7.0"}
+	smlVoltageObis    = []string{"1-0:32.7.0", "1-0:52.7.0", "1-0:72.7.0"}
+	smlPhasePowerObis = []string{"1-0:36.7.0", "1-0:56.7.0", "1-0:76.7.0"}
+)
+
+func init() {
+	registry.Add("sml", NewSmlFromConfig)
+}
+
+//go:generate go tool decorate -f decorateSml -b api.Meter -t "api.MeterEnergy,TotalEnergy,func() (float64, error)" -t "api.PhaseCurrents,Currents,func() (float64, float64, float64, error)" -t "api.PhaseVoltages,Voltages,func() (float64, float64, float64, error)" -t "api.PhasePowers,Powers,func() (float64, float64, float64, error)"
+
+// NewSmlFromConfig creates an SML meter from generic config
+func NewSmlFromConfig(other map[string]interface{}) (api.Meter, error) {
+	cc := struct {
+		URI      string
+		Device   string // optical reading head connected by serial adapter
+		Baudrate int
+		Timeout  time.Duration
+	}{
+		Baudrate: 9600,
+		Timeout:  15 * time.Second,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if (cc.URI == "") == (cc.Device == "") {
+		return nil, errors.New("need either uri or device")
+	}
+
+	return NewSml(cc.URI, cc.Device, cc.Baudrate, cc.Timeout)
+}
+
+// NewSml creates SML meter connected via TCP or serial device
+func NewSml(uri, device string, baudrate int, timeout time.Duration) (api.Meter, error) {
+	m := &Sml{
+		addr:     uri,
+		device:   device,
+		baudrate: baudrate,
+		timeout:  timeout,
+	}
+
+	done := make(chan struct{}, 1)
+	conn, err := m.connect()
+	if err != nil {
+		return nil, err
+	}
+
+	go m.run(conn, done)
+
+	// wait for initial value
+	select {
+	case <-done:
+	case <-time.NewTimer(timeout).C:
+		return nil, os.ErrDeadlineExceeded
+	}
+
+	return m.decorate(), nil
+}
+
+// decorate adds the readings available from the meter. Meters without PIN entry
+// may only provide energy, per-phase values are often missing altogether.
+func (m *Sml) decorate() api.Meter {
+	var totalEnergy func() (float64, error)
+	if m.available(smlEnergyObis) {
+		totalEnergy = m.totalEnergy
+	}
+
+	var currents, voltages, powers func() (float64, float64, float64, error)
+
+	if m.available(smlCurrentObis...) {
+		currents = m.currents
+	}
+
+	if m.available(smlVoltageObis...) {
+		voltages = m.voltages
+	}
+
+	if m.available(smlPhasePowerObis...) {
+		powers = m.powers
+	}
+
+	return decorateSml(m, totalEnergy, currents, voltages, powers)
+}
+
+// available checks if the latest reading contains all given objects
+func (m *Sml) available(ids ...string) bool {
+	for _, id := range ids {
+		if _, err := m.get(id); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+func (m *Sml) run(conn io.ReadCloser, done chan struct{}) {
+	log := util.NewLogger("sml")
+	bo := backoff.NewExponentialBackOff(backoff.WithMaxInterval(5 * time.Minute))
+
+	handle := func(op string, err error) {
+		log.ERROR.Printf("%s: %v", op, err)
+		if err == io.EOF ||
+			errors.Is(err, io.ErrUnexpectedEOF) ||
+			errors.Is(err, net.ErrClosed) ||
+			errors.Is(err, os.ErrClosed) {
+			conn.Close()
+			conn = nil
+		}
+	}
+
+	reader := bufio.NewReader(conn)
+
+	for {
+		if conn == nil {
+			var err error
+			conn, err = m.connect()
+			if err != nil {
+				handle("connect", err)
+				time.Sleep(bo.NextBackOff().Truncate(time.Second))
+				continue
+			}
+
+			reader.Reset(conn)
+		}
+
+		frame, err := sml.ReadFrame(reader)
+		if err != nil {
+			handle("read", err)
+			continue
+		}
+
+		bo.Reset()
+		log.TRACE.Printf("read: % x", frame)
+
+		values, err := sml.Parse(frame)
+		if err != nil {
+			log.ERROR.Printf("could not parse frame: %v", err)
+			continue
+		}
+
+		// skip frames without readings, e.g. open/close responses only
+		if len(values) == 0 {
+			continue
+		}
+
+		res := make(map[string]sml.Value, len(values))
+		for _, v := range values {
+			res[v.Obis] = v
+		}
+
+		m.mu.Lock()
+		m.values = res
+		m.updated = time.Now()
+		m.mu.Unlock()
+
+		select {
+		case done <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (m *Sml) connect() (io.ReadCloser, error) {
+	if m.device != "" {
+		return serial.Open(&serial.Config{
+			Address:  m.device,
+			BaudRate: m.baudrate,
+			DataBits: 8,
+			StopBits: 1,
+			Parity:   "N",
+		})
+	}
+
+	dialer := net.Dialer{Timeout: request.Timeout}
+
+	return dialer.Dial("tcp", m.addr)
+}
+
+func (m *Sml) value(id string) (sml.Value, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if time.Since(m.updated) > m.timeout {
+		return sml.Value{}, os.ErrDeadlineExceeded
+	}
+
+	res, ok := m.values[id]
+	if !ok {
+		return sml.Value{}, fmt.Errorf("%w: %s", api.ErrNotAvailable, id)
+	}
+
+	return res, nil
+}
+
+func (m *Sml) get(id string) (float64, error) {
+	res, err := m.value(id)
+	return res.Value, err
+}
+
+// CurrentPower implements the api.Meter interface
+func (m *Sml) CurrentPower() (float64, error) {
+	power, err := m.value(smlPowerObis)
+
+	// sum of phase powers if total power is missing
+	if errors.Is(err, api.ErrNotAvailable) {
+		l1, l2, l3, err := m.powers()
+		return l1 + l2 + l3, err
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	res := power.Value
+
+	// meters reporting the power's magnitude only indicate feed-in by the energy direction status bit
+	if res > 0 && !power.Signed {
+		if energy, err := m.value(smlEnergyObis); err == nil && energy.Status&sml.StatusExport != 0 {
+			res = -res
+		}
+	}
+
+	return res, nil
+}
+
+// totalEnergy implements the api.MeterEnergy interface
+func (m *Sml) totalEnergy() (float64, error) {
+	res, err := m.get(smlEnergyObis)
+	return res / 1e3, err
+}
+
+// phases returns the values of the given per-phase objects
+func (m *Sml) phases(ids []string) (float64, float64, float64, error) {
+	var res [3]float64
+
+	for i := range res {
+		var err error
+		if res[i], err = m.get(ids[i]); err != nil {
+			return 0, 0, 0, err
+		}
+	}
+
+	return res[0], res[1], res[2], nil
+}
+
+// currents implements the api.PhaseCurrents interface
+func (m *Sml) currents() (float64, float64, float64, error) {
+	return m.phases(smlCurrentObis)
+}
+
+// voltages implements the api.PhaseVoltages interface
+func (m *Sml) voltages() (float64, float64, float64, error) {
+	return m.phases(smlVoltageObis)
+}
+
+// powers implements the api.PhasePowers interface
+func (m *Sml) powers() (float64, float64, float64, error) {
+	return m.phases(smlPhasePowerObis)
+}
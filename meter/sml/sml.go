@@ -0,0 +1,290 @@
+// Package sml decodes the Smart Message Language (SML) of german smart meters' optical interface
+package sml
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Units of list entries (DLMS)
+const (
+	UnitW  = 27
+	UnitWh = 30
+	UnitA  = 33
+	UnitV  = 35
+)
+
+// StatusExport is the energy direction bit of the FNN status word, set while feeding in (-A)
+const StatusExport = 0x20
+
+var (
+	escape = []byte{0x1b, 0x1b, 0x1b, 0x1b}
+	start  = []byte{0x01, 0x01, 0x01, 0x01}
+)
+
+// Value is a list entry of an SML get list response
+type Value struct {
+	Obis   string
+	Status uint64
+	Unit   uint8
+	Value  float64 // scaled value
+	Signed bool    // value is transmitted as signed integer
+}
+
+// ReadFrame reads the next complete and checksum verified transport frame
+func ReadFrame(r *bufio.Reader) ([]byte, error) {
+	header := append(append([]byte{}, escape...), start...)
+
+	// synchronize to start sequence
+	window := make([]byte, 0, len(header))
+	for !bytes.Equal(window, header) {
+		c, err := r.ReadByte()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(window) == cap(window) {
+			window = append(window[:0], window[1:]...)
+		}
+		window = append(window, c)
+	}
+
+	frame := append([]byte{}, header...)
+	seq := make([]byte, 4)
+
+	// frame content is padded to multiples of four bytes
+	for {
+		if _, err := io.ReadFull(r, seq); err != nil {
+			return nil, err
+		}
+
+		if !bytes.Equal(seq, escape) {
+			frame = append(frame, seq...)
+			continue
+		}
+
+		if _, err := io.ReadFull(r, seq); err != nil {
+			return nil, err
+		}
+
+		switch {
+		case bytes.Equal(seq, escape):
+			// escaped escape sequence
+			frame = append(frame, escape...)
+			frame = append(frame, escape...)
+
+		case bytes.Equal(seq, start):
+			// restart
+			frame = append(frame[:0], header...)
+
+		case seq[0] == 0x1a:
+			frame = append(frame, escape...)
+			frame = append(frame, seq...)
+
+			crc := Checksum(frame[:len(frame)-2])
+			if frame[len(frame)-2] != byte(crc) || frame[len(frame)-1] != byte(crc>>8) {
+				return nil, errors.New("crc mismatch")
+			}
+
+			return frame, nil
+
+		default:
+			return nil, fmt.Errorf("invalid escape sequence: % x", seq)
+		}
+	}
+}
+
+// Checksum returns the CRC-16/X-25 checksum of data
+func Checksum(data []byte) uint16 {
+	crc := uint16(0xffff)
+	for _, b := range data {
+		crc ^= uint16(b)
+		for range 8 {
+			if crc&1 != 0 {
+				crc = crc>>1 ^ 0x8408
+			} else {
+				crc >>= 1
+			}
+		}
+	}
+	return ^crc
+}
+
+// node is a decoded SML element
+type node struct {
+	typ   byte
+	bytes []byte
+	int   int64
+	uint  uint64
+	list  []node
+	empty bool // optional element not set
+}
+
+const (
+	typeOctet  = 0x00
+	typeBool   = 0x40
+	typeInt    = 0x50
+	typeUint   = 0x60
+	typeList   = 0x70
+	endMessage = 0x00
+)
+
+// decode decodes a single element
+func decode(b []byte) (node, []byte, error) {
+	if len(b) == 0 {
+		return node{}, nil, io.ErrUnexpectedEOF
+	}
+
+	// optional element not set
+	if b[0] == 0x01 {
+		return node{empty: true}, b[1:], nil
+	}
+
+	tl := b[0]
+	typ := tl & 0x70
+	length := int(tl & 0x0f)
+	n := 1
+
+	for tl&0x80 != 0 {
+		if n >= len(b) {
+			return node{}, nil, io.ErrUnexpectedEOF
+		}
+		tl = b[n]
+		length = length<<4 | int(tl&0x0f)
+		n++
+	}
+
+	res := node{typ: typ}
+
+	if typ == typeList {
+		b = b[n:]
+		for range length {
+			var (
+				el  node
+				err error
+			)
+			if el, b, err = decode(b); err != nil {
+				return node{}, nil, err
+			}
+			res.list = append(res.list, el)
+		}
+		return res, b, nil
+	}
+
+	// length includes type-length field
+	if length < n || length > len(b) {
+		return node{}, nil, io.ErrUnexpectedEOF
+	}
+
+	data := b[n:length]
+
+	switch typ {
+	case typeOctet:
+		res.bytes = data
+
+	case typeBool, typeUint:
+		for _, v := range data {
+			res.uint = res.uint<<8 | uint64(v)
+		}
+
+	case typeInt:
+		for _, v := range data {
+			res.int = res.int<<8 | int64(v)
+		}
+		// sign extension
+		if l := len(data); l > 0 && l < 8 && data[0]&0x80 != 0 {
+			res.int -= 1 << (8 * l)
+		}
+
+	default:
+		return node{}, nil, fmt.Errorf("invalid type: %02x", typ)
+	}
+
+	return res, b[length:], nil
+}
+
+// number returns the numeric value of an integer element
+func (n node) number() (float64, bool) {
+	switch {
+	case n.empty:
+		return 0, false
+	case n.typ == typeInt:
+		return float64(n.int), true
+	case n.typ == typeUint:
+		return float64(n.uint), true
+	default:
+		return 0, false
+	}
+}
+
+// Parse returns the list entries of all get list responses of a transport frame
+func Parse(frame []byte) ([]Value, error) {
+	if len(frame) < 16 {
+		return nil, io.ErrUnexpectedEOF
+	}
+
+	// strip start and end sequences and unescape content
+	var b []byte
+	for i := 8; i+4 <= len(frame)-8; i += 4 {
+		if bytes.Equal(frame[i:i+4], escape) {
+			i += 4
+		}
+		b = append(b, frame[i:i+4]...)
+	}
+
+	var res []Value
+	for len(b) > 0 {
+		// end of message or padding
+		if b[0] == endMessage {
+			b = b[1:]
+			continue
+		}
+
+		msg, rest, err := decode(b)
+		if err != nil {
+			return nil, err
+		}
+		b = rest
+
+		res = append(res, values(msg)...)
+	}
+
+	return res, nil
+}
+
+// values collects list entries of object name, status, time, unit, scaler, value and signature
+func values(n node) []Value {
+	if n.typ != typeList {
+		return nil
+	}
+
+	if len(n.list) == 7 && len(n.list[0].bytes) == 6 {
+		if v, ok := n.list[5].number(); ok {
+			obis := n.list[0].bytes
+			res := Value{
+				Obis:   fmt.Sprintf("%d-%d:%d.%d.%d", obis[0], obis[1], obis[2], obis[3], obis[4]),
+				Status: n.list[1].uint,
+				Unit:   uint8(n.list[3].uint),
+				Value:  v,
+				Signed: n.list[5].typ == typeInt,
+			}
+
+			if scaler, ok := n.list[4].number(); ok {
+				res.Value *= math.Pow10(int(int8(scaler)))
+			}
+
+			return []Value{res}
+		}
+	}
+
+	var res []Value
+	for _, el := range n.list {
+		res = append(res, values(el)...)
+	}
+
+	return res
+}
@@ -0,0 +1,107 @@
+package sml
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// entry encodes a list entry of object name, status, time, unit, scaler, value and signature
+func entry(obis []byte, status, unit byte, scaler int8, value []byte) []byte {
+	res := []byte{0x77, 0x07}
+	res = append(res, obis...)
+	if status != 0 {
+		res = append(res, 0x62, status)
+	} else {
+		res = append(res, 0x01)
+	}
+	res = append(res, 0x01, 0x62, unit, 0x52, byte(scaler))
+	res = append(res, value...)
+	return append(res, 0x01)
+}
+
+// frame wraps a message containing the entries into a transport frame
+func frame(entries ...[]byte) []byte {
+	// message: transaction id, group, abort, body, crc, end of message
+	msg := []byte{0x76, 0x03, 0x01, 0x02, 0x62, 0x00, 0x62, 0x00}
+	// body: get list response with list of entries
+	msg = append(msg, 0x72, 0x63, 0x07, 0x01, 0x77, 0x01, 0x01, 0x01, 0x01, 0x01)
+	msg = append(msg, 0x70|byte(len(entries)))
+	for _, e := range entries {
+		msg = append(msg, e...)
+	}
+	msg = append(msg, 0x01, 0x01, 0x63, 0x00, 0x00, 0x00)
+
+	res := append([]byte{}, escape...)
+	res = append(res, start...)
+	res = append(res, msg...)
+
+	padding := (4 - len(res)%4) % 4
+	res = append(res, make([]byte, padding)...)
+	res = append(res, escape...)
+	res = append(res, 0x1a, byte(padding))
+
+	return binary.LittleEndian.AppendUint16(res, Checksum(res))
+}
+
+var (
+	obisEnergy = []byte{1, 0, 1, 8, 0, 255}
+	obisPower  = []byte{1, 0, 16, 7, 0, 255}
+)
+
+func TestChecksum(t *testing.T) {
+	assert.Equal(t, uint16(0x906e), Checksum([]byte("123456789")))
+}
+
+func TestParse(t *testing.T) {
+	b := frame(
+		entry(obisEnergy, 0x82, UnitWh, -1, []byte{0x65, 0x00, 0x01, 0x86, 0xa0}),
+		entry(obisPower, 0, UnitW, 0, []byte{0x53, 0xff, 0x4c}),
+	)
+
+	// leading garbage
+	r := bufio.NewReader(bytes.NewReader(append([]byte{0x00, 0x1b, 0x01}, b...)))
+
+	fr, err := ReadFrame(r)
+	require.NoError(t, err)
+
+	res, err := Parse(fr)
+	require.NoError(t, err)
+	require.Len(t, res, 2)
+
+	assert.Equal(t, Value{Obis: "1-0:1.8.0", Status: 0x82, Unit: UnitWh, Value: 10000}, res[0])
+	assert.Equal(t, Value{Obis: "1-0:16.7.0", Unit: UnitW, Value: -180, Signed: true}, res[1])
+}
+
+func TestParseEscaped(t *testing.T) {
+	// value containing escape sequence
+	b := frame(entry(obisEnergy, 0, UnitWh, 0, []byte{0x68, 0x00, 0x00, 0x1b, 0x1b, 0x1b, 0x1b, 0x00}))
+
+	// escape the escape sequence, found at aligned position in this frame
+	i := bytes.Index(b[8:], escape) + 8
+	require.Zero(t, i%4, "escape sequence not aligned")
+	b = append(b[:i:i], append(escape, b[i:]...)...)
+
+	// recalculate checksum
+	b = binary.LittleEndian.AppendUint16(b[:len(b)-2], Checksum(b[:len(b)-2]))
+
+	fr, err := ReadFrame(bufio.NewReader(bytes.NewReader(b)))
+	require.NoError(t, err)
+
+	res, err := Parse(fr)
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	assert.Equal(t, float64(0x1b1b1b1b00), res[0].Value)
+}
+
+func TestReadFrameChecksum(t *testing.T) {
+	b := frame(entry(obisPower, 0, UnitW, 0, []byte{0x62, 0x10}))
+	b[len(b)-1] ^= 0xff
+
+	_, err := ReadFrame(bufio.NewReader(bytes.NewReader(b)))
+	assert.Error(t, err)
+}
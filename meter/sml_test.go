@@ -0,0 +1,68 @@
+package meter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/meter/sml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSmlPower(t *testing.T) {
+	m := &Sml{
+		timeout: time.Minute,
+		updated: time.Now(),
+	}
+
+	for _, tc := range []struct {
+		values []sml.Value
+		power  float64
+	}{
+		// signed power
+		{[]sml.Value{{Obis: smlPowerObis, Value: -180, Signed: true}}, -180},
+		// unsigned power with export status
+		{[]sml.Value{{Obis: smlPowerObis, Value: 180}, {Obis: smlEnergyObis, Status: 0x1a2}}, -180},
+		// unsigned power with import status
+		{[]sml.Value{{Obis: smlPowerObis, Value: 180}, {Obis: smlEnergyObis, Status: 0x182}}, 180},
+		// phase powers only
+		{[]sml.Value{{Obis: "1-0:36.7.0", Value: 100}, {Obis: "1-0:56.7.0", Value: -300, Signed: true}, {Obis: "1-0:76.7.0", Value: 20}}, -180},
+	} {
+		m.values = make(map[string]sml.Value)
+		for _, v := range tc.values {
+			m.values[v.Obis] = v
+		}
+
+		res, err := m.CurrentPower()
+		require.NoError(t, err)
+		assert.Equal(t, tc.power, res, tc.values)
+	}
+}
+
+func TestSmlDecorate(t *testing.T) {
+	m := &Sml{
+		timeout: time.Minute,
+		updated: time.Now(),
+		values: map[string]sml.Value{
+			smlPowerObis:  {Obis: smlPowerObis, Value: 180},
+			smlEnergyObis: {Obis: smlEnergyObis, Value: 12345},
+			"1-0:32.7.0":  {Obis: "1-0:32.7.0", Value: 230},
+		},
+	}
+
+	meter := m.decorate()
+
+	energy, ok := meter.(api.MeterEnergy)
+	require.True(t, ok)
+
+	res, err := energy.TotalEnergy()
+	require.NoError(t, err)
+	assert.Equal(t, 12.345, res)
+
+	// incomplete phase values
+	_, ok = meter.(api.PhaseVoltages)
+	assert.False(t, ok)
+	_, ok = meter.(api.PhaseCurrents)
+	assert.False(t, ok)
+}
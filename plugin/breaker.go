@@ -0,0 +1,208 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+)
+
+// breakerPlugin is a circuit breaker for failing providers. After consecutive failures the device
+// is considered degraded and the wrapped provider is only probed with exponentially increasing delay.
+type breakerPlugin struct {
+	mu       sync.Mutex
+	ctx      context.Context
+	log      *util.Logger
+	clock    clock.Clock
+	get, set Config
+	failures int
+	delay    time.Duration
+	maxDelay time.Duration
+
+	count   int           // consecutive failures
+	backoff time.Duration // current probe delay, zero if not degraded
+	retry   time.Time     // next probe
+	err     error         // last error
+}
+
+func init() {
+	registry.AddCtx("breaker", NewBreakerFromConfig)
+}
+
+// NewBreakerFromConfig creates circuit breaker provider
+func NewBreakerFromConfig(ctx context.Context, other map[string]interface{}) (Plugin, error) {
+	cc := struct {
+		Failures        int
+		Delay, MaxDelay time.Duration
+		Get, Set        Config
+	}{
+		Failures: 3,
+		Delay:    10 * time.Second,
+		MaxDelay: 10 * time.Minute,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Failures < 1 {
+		return nil, fmt.Errorf("invalid failures: %d", cc.Failures)
+	}
+
+	o := &breakerPlugin{
+		ctx:      ctx,
+		log:      contextLogger(ctx, util.NewLogger("breaker")),
+		clock:    clock.New(),
+		get:      cc.Get,
+		set:      cc.Set,
+		failures: cc.Failures,
+		delay:    cc.Delay,
+		maxDelay: max(cc.MaxDelay, cc.Delay),
+	}
+
+	return o, nil
+}
+
+// exec executes fn unless the breaker is open and tracks its result
+func (o *breakerPlugin) exec(fn func() error) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if o.backoff > 0 && o.clock.Now().Before(o.retry) {
+		return fmt.Errorf("degraded: %w", o.err)
+	}
+
+	err := fn()
+	if err == nil {
+		if o.backoff > 0 {
+			o.log.INFO.Printf("recovered after %d failures", o.count)
+		}
+
+		o.count = 0
+		o.backoff = 0
+		o.err = nil
+
+		return nil
+	}
+
+	o.count++
+	o.err = err
+
+	switch {
+	case o.backoff > 0:
+		o.backoff = min(2*o.backoff, o.maxDelay)
+		o.log.DEBUG.Printf("probe failed, retry in %v: %v", o.backoff, err)
+	case o.count >= o.failures:
+		o.backoff = o.delay
+		o.log.WARN.Printf("degraded after %d failures, retry in %v: %v", o.count, o.backoff, err)
+	default:
+		return err
+	}
+
+	o.retry = o.clock.Now().Add(o.backoff)
+
+	return err
+}
+
+// breakerGetter is the generic getter function for breakerPlugin
+// it is currently not possible to write this as a method
+func breakerGetter[T any](o *breakerPlugin, get func() (T, error)) func() (T, error) {
+	return func() (T, error) {
+		var res T
+		err := o.exec(func() error {
+			var err error
+			res, err = get()
+			return err
+		})
+		return res, err
+	}
+}
+
+// breakerSetter is the generic setter function for breakerPlugin
+func breakerSetter[T any](o *breakerPlugin, set func(T) error) func(T) error {
+	return func(val T) error {
+		return o.exec(func() error {
+			return set(val)
+		})
+	}
+}
+
+var _ FloatGetter = (*breakerPlugin)(nil)
+
+func (o *breakerPlugin) FloatGetter() (func() (float64, error), error) {
+	get, err := o.get.FloatGetter(o.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return breakerGetter(o, get), nil
+}
+
+var _ IntGetter = (*breakerPlugin)(nil)
+
+func (o *breakerPlugin) IntGetter() (func() (int64, error), error) {
+	get, err := o.get.IntGetter(o.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return breakerGetter(o, get), nil
+}
+
+var _ StringGetter = (*breakerPlugin)(nil)
+
+func (o *breakerPlugin) StringGetter() (func() (string, error), error) {
+	get, err := o.get.StringGetter(o.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return breakerGetter(o, get), nil
+}
+
+var _ BoolGetter = (*breakerPlugin)(nil)
+
+func (o *breakerPlugin) BoolGetter() (func() (bool, error), error) {
+	get, err := o.get.BoolGetter(o.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return breakerGetter(o, get), nil
+}
+
+var _ IntSetter = (*breakerPlugin)(nil)
+
+func (o *breakerPlugin) IntSetter(param string) (func(int64) error, error) {
+	set, err := o.set.IntSetter(o.ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	return breakerSetter(o, set), nil
+}
+
+var _ FloatSetter = (*breakerPlugin)(nil)
+
+func (o *breakerPlugin) FloatSetter(param string) (func(float64) error, error) {
+	set, err := o.set.FloatSetter(o.ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	return breakerSetter(o, set), nil
+}
+
+var _ BoolSetter = (*breakerPlugin)(nil)
+
+func (o *breakerPlugin) BoolSetter(param string) (func(bool) error, error) {
+	set, err := o.set.BoolSetter(o.ctx, param)
+	if err != nil {
+		return nil, err
+	}
+
+	return breakerSetter(o, set), nil
+}
@@ -0,0 +1,76 @@
+package plugin
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker(t *testing.T) {
+	clock := clock.NewMock()
+
+	o := &breakerPlugin{
+		log:      util.NewLogger("foo"),
+		clock:    clock,
+		failures: 2,
+		delay:    time.Second,
+		maxDelay: 3 * time.Second,
+	}
+
+	var (
+		calls int
+		fail  = errors.New("fail")
+		err   error
+	)
+
+	get := breakerGetter(o, func() (float64, error) {
+		calls++
+		return 1, err
+	})
+
+	// closed
+	err = fail
+	for range 2 {
+		_, e := get()
+		assert.ErrorIs(t, e, fail)
+	}
+	assert.Equal(t, 2, calls)
+
+	// open, provider not called
+	_, e := get()
+	assert.ErrorIs(t, e, fail)
+	assert.Equal(t, 2, calls)
+
+	// probe fails, backoff doubles
+	clock.Add(time.Second)
+	_, e = get()
+	assert.ErrorIs(t, e, fail)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, 2*time.Second, o.backoff)
+
+	clock.Add(time.Second)
+	_, _ = get()
+	assert.Equal(t, 3, calls)
+
+	// backoff limited to max delay
+	clock.Add(time.Second)
+	_, _ = get()
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, 3*time.Second, o.backoff)
+
+	// probe succeeds, breaker closed
+	err = nil
+	clock.Add(3 * time.Second)
+	v, e := get()
+	assert.NoError(t, e)
+	assert.Equal(t, 1.0, v)
+	assert.Equal(t, 5, calls)
+
+	_, e = get()
+	assert.NoError(t, e)
+	assert.Equal(t, 6, calls)
+}
@@ -0,0 +1,209 @@
+package push
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"maps"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+const (
+	actionLifetime       = 24 * time.Hour // validity of notification actions
+	actionMaxPending     = 100            // number of pending notification actions kept
+	requestTokenLifetime = time.Minute    // validity of the api token used for server-side api calls
+)
+
+// ErrActionExpired is returned for unknown, used or expired notification actions
+var ErrActionExpired = errors.New("action expired")
+
+// ActionTemplateConfig is the configuration of a notification action calling the evcc api
+type ActionTemplateConfig struct {
+	Label  string // button label
+	Method string // http method, defaults to POST
+	Path   string // api path, e.g. /loadpoints/${loadpoint}/mode/now
+}
+
+// Action is a notification action. The api call is kept server-side and executed once by its token.
+type Action struct {
+	Label string
+	Token string // single-use token of the pending api call
+	URI   string // callback uri executing the action
+}
+
+// pendingAction is the api call of a notification action
+type pendingAction struct {
+	label, method, path string
+	expires             time.Time
+}
+
+// apiUser is implemented by messengers executing notification actions on their own
+type apiUser interface {
+	setApi(*apiClient)
+}
+
+// apiClient calls the evcc api, authenticated if an admin password is configured
+type apiClient struct {
+	uri       string // api uri
+	actionUri string // notification action callback uri
+	token     func(time.Duration) (string, error)
+
+	mu      sync.Mutex
+	pending map[string]pendingAction
+}
+
+// headers returns the authorization headers for api calls
+func (c *apiClient) headers() (map[string]string, error) {
+	res := make(map[string]string)
+	if c.token == nil {
+		return res, nil
+	}
+
+	token, err := c.token(requestTokenLifetime)
+	if err == nil && token != "" {
+		res["Authorization"] = "Bearer " + token
+	}
+
+	return res, err
+}
+
+// body calls the api and returns the raw response
+func (c *apiClient) body(log *util.Logger, method, path string) ([]byte, error) {
+	headers, err := c.headers()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := request.New(method, c.uri+path, nil, headers)
+	if err != nil {
+		return nil, err
+	}
+
+	return request.NewHelper(log).DoBody(req)
+}
+
+// action stores the api call and returns the action identified by a random single-use token
+func (c *apiClient) action(label, method, path string) (Action, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return Action{}, err
+	}
+	token := hex.EncodeToString(b)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.pending == nil {
+		c.pending = make(map[string]pendingAction)
+	}
+
+	now := time.Now()
+	maps.DeleteFunc(c.pending, func(_ string, a pendingAction) bool {
+		return now.After(a.expires)
+	})
+
+	// drop oldest actions
+	for len(c.pending) >= actionMaxPending {
+		oldest := slices.MinFunc(slices.Collect(maps.Keys(c.pending)), func(a, b string) int {
+			return c.pending[a].expires.Compare(c.pending[b].expires)
+		})
+		delete(c.pending, oldest)
+	}
+
+	c.pending[token] = pendingAction{
+		label:   label,
+		method:  method,
+		path:    path,
+		expires: now.Add(actionLifetime),
+	}
+
+	return Action{
+		Label: label,
+		Token: token,
+		URI:   c.actionUri + token,
+	}, nil
+}
+
+// pendingAction returns the pending action of the token
+func (c *apiClient) pendingAction(token string) (pendingAction, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	a, ok := c.pending[token]
+	return a, ok && time.Now().Before(a.expires)
+}
+
+// execute performs the api call of the action and invalidates its token
+func (c *apiClient) execute(log *util.Logger, token string) (string, error) {
+	a, ok := c.pendingAction(token)
+
+	c.mu.Lock()
+	delete(c.pending, token)
+	c.mu.Unlock()
+
+	if !ok {
+		return "", ErrActionExpired
+	}
+
+	_, err := c.body(log, a.method, a.path)
+	return a.label, err
+}
+
+// actions renders the action templates for the event
+func (h *Hub) actions(ev Event, cc []ActionTemplateConfig) ([]Action, error) {
+	if h.api == nil || len(cc) == 0 {
+		return nil, nil
+	}
+
+	res := make([]Action, 0, len(cc))
+	for _, c := range cc {
+		label, err := h.apply(ev, c.Label)
+		if err != nil {
+			return nil, err
+		}
+
+		path, err := h.apply(ev, c.Path)
+		if err != nil {
+			return nil, err
+		}
+
+		method := c.Method
+		if method == "" {
+			method = http.MethodPost
+		}
+
+		action, err := h.api.action(label, method, path)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, action)
+	}
+
+	return res, nil
+}
+
+// ActionLabel returns the label of a pending notification action
+func (h *Hub) ActionLabel(token string) (string, bool) {
+	if h.api == nil {
+		return "", false
+	}
+
+	a, ok := h.api.pendingAction(token)
+	return a.label, ok
+}
+
+// ExecuteAction executes a pending notification action once and returns its label
+func (h *Hub) ExecuteAction(token string) (string, error) {
+	if h.api == nil {
+		return "", ErrActionExpired
+	}
+
+	return h.api.execute(util.NewLogger("push"), token)
+}
@@ -0,0 +1,90 @@
+package push
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionSingleUse(t *testing.T) {
+	var calls []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+r.Header.Get("Authorization"))
+	}))
+	defer srv.Close()
+
+	api := &apiClient{
+		uri:       srv.URL + "/api",
+		actionUri: "http://evcc.local/push/action/",
+		token: func(lifetime time.Duration) (string, error) {
+			assert.Equal(t, requestTokenLifetime, lifetime)
+			return "secret", nil
+		},
+	}
+
+	a, err := api.action("Charge now", http.MethodPost, "/loadpoints/1/mode/now")
+	require.NoError(t, err)
+
+	assert.Equal(t, "Charge now", a.Label)
+	assert.Len(t, a.Token, 32)
+	assert.Equal(t, "http://evcc.local/push/action/"+a.Token, a.URI)
+	assert.NotContains(t, a.URI, "secret")
+
+	label, err := api.execute(util.NewLogger("foo"), a.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "Charge now", label)
+	assert.Equal(t, []string{"POST /api/loadpoints/1/mode/now Bearer secret"}, calls)
+
+	// token is invalidated
+	_, err = api.execute(util.NewLogger("foo"), a.Token)
+	assert.ErrorIs(t, err, ErrActionExpired)
+	assert.Len(t, calls, 1)
+}
+
+func TestActionPending(t *testing.T) {
+	api := new(apiClient)
+
+	first, err := api.action("first", http.MethodPost, "/first")
+	require.NoError(t, err)
+
+	for range actionMaxPending {
+		_, err := api.action("foo", http.MethodPost, "/foo")
+		require.NoError(t, err)
+	}
+
+	assert.Len(t, api.pending, actionMaxPending)
+
+	_, ok := api.pendingAction(first.Token)
+	assert.False(t, ok, "oldest action dropped")
+
+	// expired
+	a, err := api.action("expired", http.MethodPost, "/expired")
+	require.NoError(t, err)
+
+	p := api.pending[a.Token]
+	p.expires = time.Now().Add(-time.Second)
+	api.pending[a.Token] = p
+
+	_, ok = api.pendingAction(a.Token)
+	assert.False(t, ok)
+
+	_, err = api.execute(util.NewLogger("foo"), a.Token)
+	assert.ErrorIs(t, err, ErrActionExpired)
+}
+
+func TestActionExternalUri(t *testing.T) {
+	h, err := NewHub(nil, nil, nil)
+	require.NoError(t, err)
+
+	h.SetApi("http://evcc.local:7070", "https://evcc.example.com/", nil)
+	assert.Equal(t, "http://evcc.local:7070/api", h.api.uri)
+	assert.Equal(t, "https://evcc.example.com/push/action/", h.api.actionUri)
+
+	h.SetApi("http://evcc.local:7070", "", nil)
+	assert.Equal(t, "http://evcc.local:7070/push/action/", h.api.actionUri)
+}
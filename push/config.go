@@ -13,6 +13,20 @@ type Messenger interface {
 	Send(title, msg string)
 }
 
+// Message is a rendered event notification
+type Message struct {
+	Event      string
+	Severity   Severity
+	Title, Msg string
+	Actions    []Action
+	Attachment []byte // png image
+}
+
+// MessageSender is implemented by messengers supporting event routing, actions or attachments
+type MessageSender interface {
+	SendMessage(Message)
+}
+
 var registry = reg.New[Messenger]("messenger")
 
 // NewFromConfig creates messenger from configuration
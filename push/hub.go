@@ -2,23 +2,32 @@ package push
 
 import (
 	"fmt"
+	"net/url"
+	"slices"
 	"strings"
 	"text/template"
+	"time"
 
 	"github.com/Masterminds/sprig/v3"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/core/vehicle"
 	"github.com/evcc-io/evcc/util"
 )
 
 // Event is a notification event
 type Event struct {
-	Loadpoint *int // optional loadpoint id
-	Event     string
+	Loadpoint  *int // optional loadpoint id
+	Event      string
+	Attachment []byte // optional png image
 }
 
 // EventTemplateConfig is the push message configuration for an event
 type EventTemplateConfig struct {
 	Title, Msg string
+	Actions    []ActionTemplateConfig
+	Snapshot   bool // attach power flow and price image
+	Profiles   bool // add action buttons applying the connected vehicle's settings profiles
 }
 
 type Vehicles interface {
@@ -26,12 +35,29 @@ type Vehicles interface {
 	ByName(string) (vehicle.API, error)
 }
 
+// Site is the subset of the site api used for snapshots and messenger commands
+type Site interface {
+	Loadpoints() []loadpoint.API
+	Vehicles() site.Vehicles
+	Snapshot(width, height int) ([]byte, error)
+}
+
+// siteUser is implemented by messengers accessing the site on their own
+type siteUser interface {
+	setSite(Site, *util.ParamCache)
+}
+
+// snapshot image size
+const snapshotWidth, snapshotHeight = 480, 320
+
 // Hub subscribes to event notifications and sends them to client devices
 type Hub struct {
 	definitions map[string]EventTemplateConfig
 	sender      []Messenger
 	cache       *util.ParamCache
 	vehicles    Vehicles
+	api         *apiClient // api for notification actions
+	site        Site       // site for snapshots and messenger commands
 }
 
 // NewHub creates push hub with definitions and receiver
@@ -44,6 +70,14 @@ func NewHub(cc map[string]EventTemplateConfig, vv Vehicles, cache *util.ParamCac
 		if _, err := template.New("out").Funcs(sprig.FuncMap()).Parse(v.Msg); err != nil {
 			return nil, fmt.Errorf("invalid event message: %s (%w)", k, err)
 		}
+		for _, a := range v.Actions {
+			if a.Label == "" || a.Path == "" {
+				return nil, fmt.Errorf("invalid event action: %s (missing label or path)", k)
+			}
+			if _, err := template.New("out").Funcs(sprig.FuncMap()).Parse(a.Path); err != nil {
+				return nil, fmt.Errorf("invalid event action: %s (%w)", k, err)
+			}
+		}
 	}
 
 	h := &Hub{
@@ -57,9 +91,50 @@ func NewHub(cc map[string]EventTemplateConfig, vv Vehicles, cache *util.ParamCac
 
 // Add adds a sender to the list of senders
 func (h *Hub) Add(sender Messenger) {
+	if u, ok := sender.(apiUser); ok && h.api != nil {
+		u.setApi(h.api)
+	}
+	if u, ok := sender.(siteUser); ok && h.site != nil {
+		u.setSite(h.site, h.cache)
+	}
 	h.sender = append(h.sender, sender)
 }
 
+// SetApi enables notification actions and messenger commands for the evcc instance at given uri.
+// Action links opened by the user point to externalUri if reachable from outside the local network.
+// Token creates the api token if authentication is enabled, it is only used for server-side api calls.
+func (h *Hub) SetApi(uri, externalUri string, token func(time.Duration) (string, error)) {
+	uri = strings.TrimSuffix(uri, "/")
+
+	externalUri = strings.TrimSuffix(externalUri, "/")
+	if externalUri == "" {
+		externalUri = uri
+	}
+
+	h.api = &apiClient{
+		uri:       uri + "/api",
+		actionUri: externalUri + "/push/action/",
+		token:     token,
+	}
+
+	for _, sender := range h.sender {
+		if u, ok := sender.(apiUser); ok {
+			u.setApi(h.api)
+		}
+	}
+}
+
+// SetSite enables snapshots and messenger commands
+func (h *Hub) SetSite(site Site) {
+	h.site = site
+
+	for _, sender := range h.sender {
+		if u, ok := sender.(siteUser); ok {
+			u.setSite(site, h.cache)
+		}
+	}
+}
+
 // apply applies the event template to the content to produce the actual message
 func (h *Hub) apply(ev Event, tmpl string) (string, error) {
 	attr := make(map[string]interface{})
@@ -93,6 +168,31 @@ func (h *Hub) apply(ev Event, tmpl string) (string, error) {
 	return util.ReplaceFormatted(tmpl, attr)
 }
 
+// profileActions returns action templates applying the settings profiles of the event's vehicle
+func (h *Hub) profileActions(ev Event) []ActionTemplateConfig {
+	var name string
+	for _, p := range h.cache.All() {
+		if p.Key == "vehicleName" && p.Loadpoint != nil && ev.Loadpoint != nil && *p.Loadpoint == *ev.Loadpoint {
+			name, _ = p.Val.(string)
+		}
+	}
+
+	v, err := h.vehicles.ByName(name)
+	if name == "" || err != nil {
+		return nil
+	}
+
+	var res []ActionTemplateConfig
+	for _, p := range v.GetProfiles() {
+		res = append(res, ActionTemplateConfig{
+			Label: p.Name,
+			Path:  "/vehicles/" + url.PathEscape(name) + "/profile/" + url.PathEscape(p.Name),
+		})
+	}
+
+	return res
+}
+
 // Run is the Hub's main publishing loop
 func (h *Hub) Run(events <-chan Event, valueChan chan<- util.Param) {
 	log := util.NewLogger("push")
@@ -128,7 +228,37 @@ func (h *Hub) Run(events <-chan Event, valueChan chan<- util.Param) {
 			continue
 		}
 
+		cc := definition.Actions
+		if definition.Profiles {
+			cc = append(slices.Clone(cc), h.profileActions(ev)...)
+		}
+
+		actions, err := h.actions(ev, cc)
+		if err != nil {
+			log.ERROR.Printf("invalid actions for %s: %v", ev.Event, err)
+		}
+
+		if definition.Snapshot && ev.Attachment == nil && h.site != nil {
+			if ev.Attachment, err = h.site.Snapshot(snapshotWidth, snapshotHeight); err != nil {
+				log.ERROR.Printf("snapshot for %s: %v", ev.Event, err)
+			}
+		}
+
+		message := Message{
+			Event:      ev.Event,
+			Severity:   EventSeverity(ev.Event),
+			Title:      title,
+			Msg:        msg,
+			Actions:    actions,
+			Attachment: ev.Attachment,
+		}
+
 		for _, sender := range h.sender {
+			if ms, ok := sender.(MessageSender); ok {
+				go ms.SendMessage(message)
+				continue
+			}
+
 			go sender.Send(title, msg)
 		}
 	}
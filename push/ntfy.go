@@ -1,8 +1,14 @@
 package push
 
 import (
+	"bytes"
+	"cmp"
 	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"path"
 	"strings"
 
 	"github.com/evcc-io/evcc/util"
@@ -15,18 +21,30 @@ func init() {
 
 // Ntfy implements the ntfy messaging aggregator
 type Ntfy struct {
-	log      *util.Logger
-	uri      string
-	priority string
-	tags     string
+	log        *util.Logger
+	uri        string
+	priority   string
+	priorities map[string]string // event specific priorities
+	topics     map[string]string // event specific topics
+	tags       string
+}
+
+// ntfyPriority maps event severities to ntfy priorities
+var ntfyPriority = map[Severity]string{
+	SeverityLow:      "low",
+	SeverityInfo:     "default",
+	SeverityWarning:  "high",
+	SeverityCritical: "urgent",
 }
 
 // NewNtfyFromConfig creates new Ntfy messenger
 func NewNtfyFromConfig(other map[string]interface{}) (Messenger, error) {
 	var cc struct {
-		URI      string
-		Priority string
-		Tags     string
+		URI        string
+		Priority   string
+		Priorities map[string]string
+		Topics     map[string]string
+		Tags       string
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
@@ -43,24 +61,103 @@ func NewNtfyFromConfig(other map[string]interface{}) (Messenger, error) {
 	}
 
 	m := &Ntfy{
-		log:      log,
-		uri:      cc.URI,
-		priority: cc.Priority,
-		tags:     cc.Tags,
+		log:        log,
+		uri:        cc.URI,
+		priority:   cc.Priority,
+		priorities: make(map[string]string),
+		topics:     make(map[string]string),
+		tags:       cc.Tags,
+	}
+
+	// config keys are case-insensitive
+	for k, v := range cc.Priorities {
+		m.priorities[strings.ToLower(k)] = v
+	}
+
+	for k, v := range cc.Topics {
+		uri, err := m.topicUri(v)
+		if err != nil {
+			return nil, fmt.Errorf("topic %s: %w", k, err)
+		}
+		log.Redact(v)
+		m.topics[strings.ToLower(k)] = uri
 	}
 
 	return m, nil
 }
 
+// topicUri replaces the topic of the configured uri, keeping the server's base path
+func (m *Ntfy) topicUri(topic string) (string, error) {
+	u, err := url.Parse(m.uri)
+	if err != nil {
+		return "", err
+	}
+	u.Path = path.Join(path.Dir(u.Path), strings.Trim(topic, "/"))
+	u.RawPath = ""
+	return u.String(), nil
+}
+
+// ntfyQuote quotes action values containing the action separators
+func ntfyQuote(s string) string {
+	if !strings.ContainsAny(s, `,;"'`) {
+		return s
+	}
+	if !strings.Contains(s, `"`) {
+		return `"` + s + `"`
+	}
+	return `'` + strings.ReplaceAll(s, `'`, "’") + `'`
+}
+
 // Send sends to all receivers
 func (m *Ntfy) Send(title, msg string) {
-	req, err := request.New("POST", m.uri, strings.NewReader(msg), map[string]string{
-		"Priority": m.priority,
-		"Title":    title,
+	m.SendMessage(Message{Severity: SeverityInfo, Title: title, Msg: msg})
+}
+
+// SendMessage sends to the event's topic, adding priority, http action buttons and attachment
+func (m *Ntfy) SendMessage(message Message) {
+	uri := m.uri
+	if topic, ok := m.topics[message.Event]; ok {
+		uri = topic
+	}
+
+	// event priority, configured default or severity
+	priority, ok := m.priorities[message.Event]
+	if !ok {
+		priority = m.priority
+	}
+	if priority == "" {
+		priority = ntfyPriority[message.Severity]
+	}
+
+	headers := map[string]string{
+		"Priority": priority,
+		"Title":    message.Title,
 		"Tags":     m.tags,
-	})
+	}
+
+	if len(message.Actions) > 0 {
+		res := make([]string, 0, len(message.Actions))
+		for _, a := range message.Actions {
+			res = append(res, fmt.Sprintf("http, %s, %s, method=POST, clear=true", ntfyQuote(a.Label), ntfyQuote(a.URI)))
+		}
+		headers["Actions"] = strings.Join(res, "; ")
+	}
+
+	method := http.MethodPost
+	var body io.Reader = strings.NewReader(message.Msg)
+
+	// attachments are uploaded as body, message moves to header
+	if len(message.Attachment) > 0 {
+		method = http.MethodPut
+		body = bytes.NewReader(message.Attachment)
+		headers["Filename"] = cmp.Or(message.Event, "evcc") + ".png"
+		headers["Message"] = strings.ReplaceAll(message.Msg, "\n", `\n`)
+	}
+
+	req, err := request.New(method, uri, body, headers)
 	if err != nil {
 		m.log.ERROR.Printf("ntfy: %v", err)
+		return
 	}
 
 	if _, err := http.DefaultClient.Do(req); err != nil {
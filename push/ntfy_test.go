@@ -0,0 +1,80 @@
+package push
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNtfyQuote(t *testing.T) {
+	for _, tc := range []struct {
+		in, out string
+	}{
+		{"Charge now", "Charge now"},
+		{"Charge, now", `"Charge, now"`},
+		{"Charge; now", `"Charge; now"`},
+		{`Charge "now", please`, `'Charge "now", please'`},
+		{`Don't "stop"`, `'Don’t "stop"'`},
+	} {
+		assert.Equal(t, tc.out, ntfyQuote(tc.in), tc.in)
+	}
+}
+
+func TestNtfyActions(t *testing.T) {
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+	}))
+	defer srv.Close()
+
+	m, err := NewNtfyFromConfig(map[string]any{"uri": srv.URL + "/evcc"})
+	require.NoError(t, err)
+
+	m.(*Ntfy).SendMessage(Message{
+		Severity: SeverityInfo,
+		Title:    "Car connected",
+		Msg:      "msg",
+		Actions: []Action{
+			{Label: "Charge now", Token: "1234", URI: "http://evcc.local/push/action/1234"},
+			{Label: "Skip; plan", Token: "5678", URI: "http://evcc.local/push/action/5678"},
+		},
+	})
+
+	require.NotNil(t, header)
+	assert.Equal(t, `http, Charge now, http://evcc.local/push/action/1234, method=POST, clear=true; http, "Skip; plan", http://evcc.local/push/action/5678, method=POST, clear=true`, header.Get("Actions"))
+	assert.NotContains(t, header.Get("Actions"), "Authorization")
+}
+
+func TestNtfyTopicUri(t *testing.T) {
+	for _, tc := range []struct {
+		uri, topic, out string
+	}{
+		{"https://ntfy.sh/evcc", "alerts", "https://ntfy.sh/alerts"},
+		{"https://example.com/ntfy/evcc", "alerts", "https://example.com/ntfy/alerts"},
+		{"https://example.com/ntfy/evcc", "/alerts", "https://example.com/ntfy/alerts"},
+	} {
+		m := &Ntfy{uri: tc.uri}
+		uri, err := m.topicUri(tc.topic)
+		require.NoError(t, err)
+		assert.Equal(t, tc.out, uri, tc.uri)
+	}
+}
+
+func TestNtfyAttachment(t *testing.T) {
+	var header http.Header
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header
+	}))
+	defer srv.Close()
+
+	m, err := NewNtfyFromConfig(map[string]any{"uri": srv.URL + "/evcc"})
+	require.NoError(t, err)
+
+	m.(*Ntfy).SendMessage(Message{Severity: SeverityInfo, Msg: "msg", Attachment: []byte{0}})
+
+	require.NotNil(t, header)
+	assert.Equal(t, "evcc.png", header.Get("Filename"))
+}
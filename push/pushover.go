@@ -2,6 +2,8 @@ package push
 
 import (
 	"errors"
+	"fmt"
+	"html"
 	"strings"
 
 	"github.com/evcc-io/evcc/util"
@@ -50,7 +52,26 @@ func NewPushOverFromConfig(other map[string]interface{}) (Messenger, error) {
 
 // Send sends to all receivers
 func (m *PushOver) Send(title, msg string) {
-	message := pushover.NewMessageWithTitle(msg, title)
+	m.send(pushover.NewMessageWithTitle(msg, title))
+}
+
+// SendMessage sends to all receivers, adding actions as links to their confirmation page
+func (m *PushOver) SendMessage(msg Message) {
+	message := pushover.NewMessageWithTitle(msg.Msg, msg.Title)
+
+	if len(msg.Actions) > 0 {
+		message.HTML = true
+		message.Message = html.EscapeString(msg.Msg)
+
+		for _, a := range msg.Actions {
+			message.Message += fmt.Sprintf("\n<a href=\"%s\">%s</a>", html.EscapeString(a.URI), html.EscapeString(a.Label))
+		}
+	}
+
+	m.send(message)
+}
+
+func (m *PushOver) send(message *pushover.Message) {
 	message.DeviceName = m.device
 
 	for _, id := range m.recipients {
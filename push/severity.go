@@ -0,0 +1,33 @@
+package push
+
+// Severity classifies the urgency of events
+type Severity int
+
+// Severities
+const (
+	SeverityLow Severity = iota
+	SeverityInfo
+	SeverityWarning
+	SeverityCritical
+)
+
+// eventSeverity maps events to severities, unknown events are informational
+var eventSeverity = map[string]Severity{
+	"soc":          SeverityLow,
+	"tariffdigest": SeverityLow,
+	"idle":         SeverityWarning,
+	"gridbudget":   SeverityWarning,
+	"co2budget":    SeverityWarning,
+	"exportlimit":  SeverityWarning,
+	"gridstress":   SeverityWarning,
+	"pvanomaly":    SeverityWarning,
+	"fault":        SeverityCritical,
+}
+
+// EventSeverity returns the severity of the event
+func EventSeverity(event string) Severity {
+	if s, ok := eventSeverity[event]; ok {
+		return s
+	}
+	return SeverityInfo
+}
@@ -1,9 +1,12 @@
 package push
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/evcc-io/evcc/util"
@@ -19,15 +22,20 @@ func init() {
 type Telegram struct {
 	log *util.Logger
 	sync.Mutex
-	bot   *bot.Bot
-	chats map[int64]struct{}
+	bot     *bot.Bot
+	chats   map[int64]struct{}
+	control map[int64]struct{} // chats allowed to use control commands
+	api     *apiClient
+	site    Site
+	cache   *util.ParamCache
 }
 
 // NewTelegramFromConfig creates new pushover messenger
 func NewTelegramFromConfig(ctx context.Context, other map[string]interface{}) (Messenger, error) {
 	var cc struct {
-		Token string
-		Chats []int64
+		Token   string
+		Chats   []int64
+		Control []int64
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
@@ -37,8 +45,9 @@ func NewTelegramFromConfig(ctx context.Context, other map[string]interface{}) (M
 	log := util.NewLogger("telegram").Redact(cc.Token)
 
 	m := &Telegram{
-		log:   log,
-		chats: make(map[int64]struct{}),
+		log:     log,
+		chats:   make(map[int64]struct{}),
+		control: make(map[int64]struct{}),
 	}
 
 	bot, err := bot.New(cc.Token, bot.WithDefaultHandler(m.handler), bot.WithErrorsHandler(func(err error) {
@@ -59,36 +68,120 @@ func NewTelegramFromConfig(ctx context.Context, other map[string]interface{}) (M
 		m.chats[chat] = struct{}{}
 	}
 
+	for _, chat := range cc.Control {
+		if _, ok := m.chats[chat]; !ok {
+			return nil, fmt.Errorf("control chat %d not in chats", chat)
+		}
+		m.control[chat] = struct{}{}
+	}
+
 	return m, nil
 }
 
-// handler captures ids of all chats that bot participates in
+// handler captures ids of all chats that bot participates in and executes commands
 func (m *Telegram) handler(ctx context.Context, b *bot.Bot, update *models.Update) {
+	if update.CallbackQuery != nil {
+		m.callback(ctx, update.CallbackQuery)
+		return
+	}
+
 	if update.Message == nil {
 		return
 	}
 
 	m.Lock()
-	defer m.Unlock()
-
 	if _, ok := m.chats[update.Message.Chat.ID]; !ok {
 		m.log.INFO.Printf("new chat id: %d", update.Message.Chat.ID)
 	}
+	m.Unlock()
+
+	if strings.HasPrefix(update.Message.Text, "/") {
+		m.command(ctx, update.Message.Chat.ID, update.Message.Text)
+	}
+}
+
+// callback executes notification actions selected in configured chats
+func (m *Telegram) callback(ctx context.Context, query *models.CallbackQuery) {
+	m.Lock()
+	api := m.api
+	var allowed bool
+	if msg := query.Message.Message; msg != nil {
+		_, allowed = m.chats[msg.Chat.ID]
+	}
+	m.Unlock()
+
+	text := ErrActionExpired.Error()
+	if allowed && api != nil {
+		label, err := api.execute(m.log, query.Data)
+		text = label
+		if err != nil {
+			m.log.ERROR.Printf("action %s: %v", label, err)
+			text = err.Error()
+		}
+	}
+
+	if _, err := m.bot.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+		CallbackQueryID: query.ID,
+		Text:            text,
+	}); err != nil {
+		m.log.ERROR.Println("callback:", err)
+	}
 }
 
 // Send sends to all receivers
 func (m *Telegram) Send(title, msg string) {
+	m.send(msg, nil, nil)
+}
+
+// SendMessage sends to all receivers, adding inline keyboard buttons for the actions and the attached image
+func (m *Telegram) SendMessage(message Message) {
+	if len(message.Actions) == 0 {
+		m.send(message.Msg, nil, message.Attachment)
+		return
+	}
+
+	row := make([]models.InlineKeyboardButton, 0, len(message.Actions))
+	for _, a := range message.Actions {
+		row = append(row, models.InlineKeyboardButton{
+			Text:         a.Label,
+			CallbackData: a.Token,
+		})
+	}
+
+	m.send(message.Msg, &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{row},
+	}, message.Attachment)
+}
+
+func (m *Telegram) send(msg string, markup models.ReplyMarkup, image []byte) {
 	m.Lock()
 	defer m.Unlock()
 
 	for chat := range m.chats {
 		m.log.DEBUG.Printf("sending to %d", chat)
 
-		if _, err := m.bot.SendMessage(context.Background(), &bot.SendMessageParams{
-			ChatID: chat,
-			Text:   msg,
-		}); err != nil {
+		if err := m.sendTo(context.Background(), chat, msg, markup, image); err != nil {
 			m.log.ERROR.Println("send:", err)
 		}
 	}
 }
+
+// sendTo sends a text message or, if an image is given, a photo captioned with the message
+func (m *Telegram) sendTo(ctx context.Context, chat int64, msg string, markup models.ReplyMarkup, image []byte) error {
+	if len(image) == 0 {
+		_, err := m.bot.SendMessage(ctx, &bot.SendMessageParams{
+			ChatID:      chat,
+			Text:        msg,
+			ReplyMarkup: markup,
+		})
+		return err
+	}
+
+	_, err := m.bot.SendPhoto(ctx, &bot.SendPhotoParams{
+		ChatID:      chat,
+		Photo:       &models.InputFileUpload{Filename: "snapshot.png", Data: bytes.NewReader(image)},
+		Caption:     msg,
+		ReplyMarkup: markup,
+	})
+	return err
+}
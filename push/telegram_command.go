@@ -0,0 +1,210 @@
+package push
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/vehicle"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/encode"
+)
+
+const telegramHelp = `Commands:
+/status - current power flow and loadpoints
+/snapshot - power flow and price image
+/mode <off|now|minpv|pv> [loadpoint] - set charge mode
+/plan <soc> <hh:mm> [loadpoint] - plan charging the loadpoint's vehicle`
+
+// telegramState is the subset of the cached site state used by commands
+type telegramState struct {
+	PvPower   float64 `json:"pvPower"`
+	HomePower float64 `json:"homePower"`
+	Grid      struct {
+		Power float64 `json:"power"`
+	} `json:"grid"`
+}
+
+// setSite implements the siteUser interface
+func (m *Telegram) setSite(site Site, cache *util.ParamCache) {
+	m.Lock()
+	defer m.Unlock()
+	m.site = site
+	m.cache = cache
+}
+
+// command executes a bot command received from given chat
+func (m *Telegram) command(ctx context.Context, chat int64, text string) {
+	res, image, ok := m.reply(chat, text)
+	if !ok {
+		return
+	}
+
+	if err := m.sendTo(ctx, chat, res, nil, image); err != nil {
+		m.log.ERROR.Println("command:", err)
+	}
+}
+
+// reply executes the command and returns the reply text or image. Commands from unknown chats are ignored.
+func (m *Telegram) reply(chat int64, text string) (string, []byte, bool) {
+	m.Lock()
+	site, cache := m.site, m.cache
+	_, allowed := m.chats[chat]
+	_, control := m.control[chat]
+	m.Unlock()
+
+	var res string
+	var image []byte
+	var err error
+
+	// strip bot name from group commands like /status@evccbot
+	args := strings.Fields(text)
+	cmd, _, _ := strings.Cut(args[0], "@")
+
+	switch {
+	case !allowed:
+		m.log.WARN.Printf("command from unknown chat %d: %s", chat, cmd)
+		return "", nil, false
+
+	case site == nil:
+		err = errors.New("site not available")
+
+	case cmd == "/status":
+		res, err = m.status(site, cache)
+
+	case cmd == "/snapshot":
+		image, err = site.Snapshot(snapshotWidth, snapshotHeight)
+
+	case cmd == "/mode" || cmd == "/plan":
+		if !control {
+			err = errors.New("not authorized")
+			break
+		}
+
+		if cmd == "/mode" {
+			res, err = m.mode(site, args[1:])
+		} else {
+			res, err = m.plan(site, args[1:], time.Now())
+		}
+
+	default:
+		res = telegramHelp
+	}
+
+	if err != nil {
+		res = "error: " + err.Error()
+	}
+
+	return res, image, true
+}
+
+// loadpoint returns the loadpoint and its 1-based id from the optional argument
+func (m *Telegram) loadpoint(site Site, args []string, idx int) (loadpoint.API, int, error) {
+	id := 1
+	if len(args) > idx {
+		var err error
+		if id, err = strconv.Atoi(args[idx]); err != nil {
+			return nil, 0, fmt.Errorf("invalid loadpoint: %s", args[idx])
+		}
+	}
+
+	lps := site.Loadpoints()
+	if id < 1 || id > len(lps) {
+		return nil, 0, fmt.Errorf("invalid loadpoint: %d", id)
+	}
+
+	return lps[id-1], id, nil
+}
+
+func (m *Telegram) status(site Site, cache *util.ParamCache) (string, error) {
+	var state telegramState
+	if cache != nil {
+		b, err := json.Marshal(cache.State(encode.NewEncoder()))
+		if err == nil {
+			err = json.Unmarshal(b, &state)
+		}
+		if err != nil {
+			return "", err
+		}
+	}
+
+	res := []string{fmt.Sprintf("PV %.1fkW, grid %.1fkW, home %.1fkW", state.PvPower/1e3, state.Grid.Power/1e3, state.HomePower/1e3)}
+
+	for i, lp := range site.Loadpoints() {
+		line := fmt.Sprintf("%d %s: %s", i+1, lp.GetTitle(), lp.GetMode())
+		if lp.GetStatus() == api.StatusC {
+			line += fmt.Sprintf(", charging %.1fkW", lp.GetChargePower()/1e3)
+		}
+		if v := lp.GetVehicle(); v != nil {
+			line += fmt.Sprintf(", %s %.0f%%", v.Title(), lp.GetVehicleSoc())
+		}
+		res = append(res, line)
+	}
+
+	return strings.Join(res, "\n"), nil
+}
+
+func (m *Telegram) mode(site Site, args []string) (string, error) {
+	if len(args) < 1 {
+		return "", errors.New("missing mode")
+	}
+
+	mode, err := api.ChargeModeString(args[0])
+	if err != nil || mode == api.ModeEmpty {
+		return "", fmt.Errorf("invalid mode: %s", args[0])
+	}
+
+	lp, id, err := m.loadpoint(site, args, 1)
+	if err != nil {
+		return "", err
+	}
+
+	lp.SetMode(mode)
+
+	return fmt.Sprintf("loadpoint %d: mode %s", id, mode), nil
+}
+
+func (m *Telegram) plan(site Site, args []string, now time.Time) (string, error) {
+	if len(args) < 2 {
+		return "", errors.New("missing soc or time")
+	}
+
+	soc, err := strconv.Atoi(strings.TrimSuffix(args[0], "%"))
+	if err != nil || soc <= 0 || soc > 100 {
+		return "", fmt.Errorf("invalid soc: %s", args[0])
+	}
+
+	ts, err := util.NextTimeOfDay(now, args[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid time: %s", args[1])
+	}
+
+	lp, id, err := m.loadpoint(site, args, 2)
+	if err != nil {
+		return "", err
+	}
+
+	instance := lp.GetVehicle()
+	vehicles := site.Vehicles().Settings()
+
+	idx := slices.IndexFunc(vehicles, func(v vehicle.API) bool {
+		return instance != nil && v.Instance() == instance
+	})
+	if idx < 0 {
+		return "", fmt.Errorf("loadpoint %d: no vehicle", id)
+	}
+
+	v := vehicles[idx]
+	if err := v.SetPlanSoc(ts, soc); err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%s: %d%% until %s", instance.Title(), soc, ts.Format("Mon 15:04")), nil
+}
@@ -0,0 +1,132 @@
+package push
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/site"
+	"github.com/evcc-io/evcc/core/vehicle"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+type telegramSite struct {
+	loadpoints []loadpoint.API
+	vehicles   []vehicle.API
+}
+
+func (s *telegramSite) Loadpoints() []loadpoint.API { return s.loadpoints }
+
+func (s *telegramSite) Vehicles() site.Vehicles { return s }
+
+func (s *telegramSite) Snapshot(width, height int) ([]byte, error) { return []byte("png"), nil }
+
+func (s *telegramSite) Settings() []vehicle.API { return s.vehicles }
+
+func (s *telegramSite) ByName(string) (vehicle.API, error) { return nil, api.ErrNotAvailable }
+
+func (s *telegramSite) Instances() []api.Vehicle { return nil }
+
+func TestTelegramCommandAuthorization(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	m := &Telegram{
+		log:     util.NewLogger("foo"),
+		chats:   map[int64]struct{}{1: {}, 2: {}},
+		control: map[int64]struct{}{1: {}},
+	}
+	m.setSite(&telegramSite{loadpoints: []loadpoint.API{lp}}, nil)
+
+	_, _, ok := m.reply(3, "/mode pv")
+	assert.False(t, ok, "unknown chat")
+
+	res, _, ok := m.reply(2, "/mode pv")
+	assert.True(t, ok)
+	assert.Equal(t, "error: not authorized", res)
+
+	lp.EXPECT().SetMode(api.ModePV)
+	res, _, _ = m.reply(1, "/mode@evccbot PV")
+	assert.Equal(t, "loadpoint 1: mode pv", res)
+
+	res, _, _ = m.reply(1, "/mode foo")
+	assert.Equal(t, "error: invalid mode: foo", res)
+
+	res, _, _ = m.reply(1, "/mode pv 2")
+	assert.Equal(t, "error: invalid loadpoint: 2", res)
+
+	_, image, _ := m.reply(2, "/snapshot")
+	assert.Equal(t, []byte("png"), image)
+
+	res, _, _ = m.reply(2, "/help")
+	assert.Equal(t, telegramHelp, res)
+}
+
+func TestTelegramStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	v := api.NewMockVehicle(ctrl)
+	v.EXPECT().Title().Return("Model 3").AnyTimes()
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().GetTitle().Return("Garage")
+	lp.EXPECT().GetMode().Return(api.ModePV)
+	lp.EXPECT().GetStatus().Return(api.StatusC)
+	lp.EXPECT().GetChargePower().Return(3700.0)
+	lp.EXPECT().GetVehicle().Return(v)
+	lp.EXPECT().GetVehicleSoc().Return(55.0)
+
+	cache := util.NewParamCache()
+	cache.Add(keys.PvPower, util.Param{Key: keys.PvPower, Val: 5000.0})
+	cache.Add(keys.HomePower, util.Param{Key: keys.HomePower, Val: 800.0})
+	cache.Add(keys.Grid, util.Param{Key: keys.Grid, Val: struct {
+		Power float64 `json:"power"`
+	}{Power: -500}})
+
+	m := &Telegram{log: util.NewLogger("foo")}
+
+	res, err := m.status(&telegramSite{loadpoints: []loadpoint.API{lp}}, cache)
+	assert.NoError(t, err)
+	assert.Equal(t, "PV 5.0kW, grid -0.5kW, home 0.8kW\n1 Garage: pv, charging 3.7kW, Model 3 55%", res)
+}
+
+func TestTelegramPlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+
+	instance := api.NewMockVehicle(ctrl)
+	instance.EXPECT().Title().Return("Model 3").AnyTimes()
+
+	other := vehicle.NewMockAPI(ctrl)
+	other.EXPECT().Instance().Return(api.NewMockVehicle(ctrl)).AnyTimes()
+
+	v := vehicle.NewMockAPI(ctrl)
+	v.EXPECT().Instance().Return(instance).AnyTimes()
+
+	lp := loadpoint.NewMockAPI(ctrl)
+	lp.EXPECT().GetVehicle().Return(instance)
+
+	m := &Telegram{log: util.NewLogger("foo")}
+	s := &telegramSite{loadpoints: []loadpoint.API{lp}, vehicles: []vehicle.API{other, v}}
+
+	now := time.Date(2024, 1, 1, 8, 0, 0, 0, time.Local)
+	v.EXPECT().SetPlanSoc(time.Date(2024, 1, 2, 7, 0, 0, 0, time.Local), 80)
+
+	res, err := m.plan(s, []string{"80%", "7:00"}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, "Model 3: 80% until Tue 07:00", res)
+
+	_, err = m.plan(s, []string{"80", "7"}, now)
+	assert.EqualError(t, err, "invalid time: 7")
+
+	_, err = m.plan(s, []string{"101", "7:00"}, now)
+	assert.EqualError(t, err, "invalid soc: 101")
+
+	// no vehicle
+	lp.EXPECT().GetVehicle().Return(nil)
+	_, err = m.plan(s, []string{"80", "7:00"}, now)
+	assert.EqualError(t, err, "loadpoint 1: no vehicle")
+}
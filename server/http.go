@@ -88,7 +88,7 @@ func (s *HTTPd) Router() *mux.Router {
 }
 
 // RegisterSiteHandlers connects the http handlers to the site
-func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param) {
+func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param, auth auth.Auth) {
 	router := s.Server.Handler.(*mux.Router)
 
 	// api
@@ -105,14 +105,26 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param)
 		"buffersoc":               {"POST", "/buffersoc/{value:[0-9.]+}", floatHandler(site.SetBufferSoc, site.GetBufferSoc)},
 		"bufferstartsoc":          {"POST", "/bufferstartsoc/{value:[0-9.]+}", floatHandler(site.SetBufferStartSoc, site.GetBufferStartSoc)},
 		"batterydischargecontrol": {"POST", "/batterydischargecontrol/{value:[01truefalse]+}", boolHandler(site.SetBatteryDischargeControl, site.GetBatteryDischargeControl)},
+		"panelscovered":           {"POST", "/panelscovered/{value:[01truefalse]+}", boolHandler(site.SetPanelsCovered, site.GetPanelsCovered)},
 		"batterygridcharge":       {"POST", "/batterygridchargelimit/{value:-?[0-9.]+}", floatPtrHandler(pass(site.SetBatteryGridChargeLimit), site.GetBatteryGridChargeLimit)},
 		"batterygridchargedelete": {"DELETE", "/batterygridchargelimit", floatPtrHandler(pass(site.SetBatteryGridChargeLimit), site.GetBatteryGridChargeLimit)},
+		"batteryexport":           {"POST", "/batteryexportlimit/{value:-?[0-9.]+}", floatPtrHandler(site.SetBatteryExportLimit, site.GetBatteryExportLimit)},
+		"batteryexportdelete":     {"DELETE", "/batteryexportlimit", floatPtrHandler(site.SetBatteryExportLimit, site.GetBatteryExportLimit)},
+		"batterywarranty":         {"GET", "/batterywarranty", getHandler(site.GetBatteryWarranty)},
 		"prioritysoc":             {"POST", "/prioritysoc/{value:[0-9.]+}", floatHandler(site.SetPrioritySoc, site.GetPrioritySoc)},
 		"residualpower":           {"POST", "/residualpower/{value:-?[0-9.]+}", floatHandler(site.SetResidualPower, site.GetResidualPower)},
 		"smartcost":               {"POST", "/smartcostlimit/{value:-?[0-9.]+}", updateSmartCostLimit(site)},
 		"smartcostdelete":         {"DELETE", "/smartcostlimit", updateSmartCostLimit(site)},
 		"tariff":                  {"GET", "/tariff/{tariff:[a-z]+}", tariffHandler(site)},
+		"tariff2":                 {"POST", "/tariff/{tariff:[a-z]+}", setTariffHandler(site)},
+		"forecastaccuracy":        {"GET", "/forecast/accuracy", forecastAccuracyHandler(site)},
+		"simulate":                {"GET", "/simulate", simulateHandler(site)},
+		"chargingwindow":          {"GET", "/chargingwindow", chargingWindowHandler(site)},
+		"snapshot":                {"GET", "/snapshot", snapshotHandler(site)},
+		"meterreplacement":        {"POST", "/meters/{name:[a-zA-Z0-9_.:-]+}/replacement/{old:[0-9.]+}/{new:[0-9.]+}", meterReplacementHandler(site)},
 		"sessions":                {"GET", "/sessions", sessionHandler},
+		"greensessions":           {"GET", "/sessions/green", greenSessionHandler},
+		"awaysessions":            {"POST", "/sessions/away/{name:[a-zA-Z0-9_.:-]+}", awaySessionsHandler(site)},
 		"updatesession":           {"PUT", "/session/{id:[0-9]+}", updateSessionHandler},
 		"deletesession":           {"DELETE", "/session/{id:[0-9]+}", deleteSessionHandler},
 		"telemetry":               {"GET", "/settings/telemetry", getHandler(telemetry.Enabled)},
@@ -140,9 +152,12 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param)
 	vehicles := map[string]route{
 		"minsoc":         {"POST", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/minsoc/{value:[0-9]+}", minSocHandler(site)},
 		"limitsoc":       {"POST", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/limitsoc/{value:[0-9]+}", limitSocHandler(site)},
+		"soc":            {"POST", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/soc/{value:[0-9.]+}", vehicleSocHandler(site)},
 		"plan":           {"POST", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/plan/soc/{value:[0-9]+}/{time:[0-9TZ:.+-]+}", planSocHandler(site)},
 		"plan2":          {"DELETE", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/plan/soc", planSocRemoveHandler(site)},
 		"repeatingPlans": {"POST", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/plan/repeating", addRepeatingPlansHandler(site)},
+		"profiles":       {"POST", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/profiles", profilesHandler(site)},
+		"profile":        {"POST", "/vehicles/{name:[a-zA-Z0-9_.:-]+}/profile/{profile:[a-zA-Z0-9_.:-]+}", applyProfileHandler(site)},
 
 		// config ui
 		// "mode":       {"POST", "/mode/{value:[a-z]+}", chargeModeHandler(v)},
@@ -168,6 +183,7 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param)
 			"maxcurrent":           {"POST", "/maxcurrent/{value:[0-9.]+}", floatHandler(lp.SetMaxCurrent, lp.GetMaxCurrent)},
 			"phases":               {"POST", "/phases/{value:[0-9]+}", intHandler(lp.SetPhasesConfigured, lp.GetPhasesConfigured)},
 			"plan":                 {"GET", "/plan", planHandler(lp)},
+			"planHistory":          {"GET", "/plan/history", planHistoryHandler(lp)},
 			"staticPlanPreview":    {"GET", "/plan/static/preview/{type:(?:soc|energy)}/{value:[0-9.]+}/{time:[0-9TZ:.+-]+}", staticPlanPreviewHandler(lp)},
 			"repeatingPlanPreview": {"GET", "/plan/repeating/preview/{soc:[0-9]+}/{weekdays:[0-6,]+}/{time:[0-2][0-9]:[0-5][0-9]}/{tz:[a-zA-Z0-9_./:-]+}", repeatingPlanPreviewHandler(lp)},
 			"planenergy":           {"POST", "/plan/energy/{value:[0-9.]+}/{time:[0-9TZ:.+-]+}", planEnergyHandler(lp)},
@@ -182,13 +198,28 @@ func (s *HTTPd) RegisterSiteHandlers(site site.API, valueChan chan<- util.Param)
 			"disableDelay":         {"POST", "/disable/delay/{value:[0-9]+}", durationHandler(pass(lp.SetDisableDelay), lp.GetDisableDelay)},
 			"smartCost":            {"POST", "/smartcostlimit/{value:-?[0-9.]+}", floatPtrHandler(pass(lp.SetSmartCostLimit), lp.GetSmartCostLimit)},
 			"smartCostDelete":      {"DELETE", "/smartcostlimit", floatPtrHandler(pass(lp.SetSmartCostLimit), lp.GetSmartCostLimit)},
+			"smartCostHysteresis":  {"POST", "/smartcost/hysteresis/{value:[0-9.]+}", floatHandler(pass(lp.SetSmartCostHysteresis), lp.GetSmartCostHysteresis)},
+			"smartCostMinDuration": {"POST", "/smartcost/minduration/{value:[0-9]+}", durationHandler(pass(lp.SetSmartCostMinDuration), lp.GetSmartCostMinDuration)},
 			"priority":             {"POST", "/priority/{value:[0-9]+}", intHandler(pass(lp.SetPriority), lp.GetPriority)},
 			"batteryBoost":         {"POST", "/batteryboost/{value:[01truefalse]+}", boolHandler(lp.SetBatteryBoost, func() bool { return lp.GetBatteryBoost() > 0 })},
+			"guest":                {"POST", "/guest/{energy:[0-9.]+}/{cost:[0-9.]+}", guestSessionHandler(lp)},
+			"guest2":               {"DELETE", "/guest", guestSessionRemoveHandler(lp)},
 		}
 
 		for _, r := range routes {
 			api.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
 		}
+
+		// connector control (secured)
+		connector := api.PathPrefix("/connector").Subrouter()
+		connector.Use(ensureAuthHandler(auth))
+
+		for _, r := range map[string]route{
+			"unlock": {"POST", "/unlock", connectorUnlockHandler(lp)},
+			"lock":   {"POST", "/lock/{value:[01truefalse]+}", connectorLockHandler(lp)},
+		} {
+			connector.Methods(r.Methods()...).Path(r.Pattern).Handler(r.HandlerFunc)
+		}
 	}
 }
 
@@ -249,6 +280,16 @@ func (s *HTTPd) RegisterSystemHandler(valueChan chan<- util.Param, cache *util.P
 			"interval":           {"POST", "/interval/{value:[0-9.]+}", settingsSetDurationHandler(keys.Interval)},
 			"updatesponsortoken": {"POST", "/sponsortoken", updateSponsortokenHandler},
 			"deletesponsortoken": {"DELETE", "/sponsortoken", deleteSponsorTokenHandler},
+			"rfidtags":           {"GET", "/rfid", rfidTagsHandler},
+			"newrfidtag":         {"POST", "/rfid", newRfidTagHandler},
+			"updaterfidtag":      {"PUT", "/rfid/{id:[0-9]+}", updateRfidTagHandler},
+			"deleterfidtag":      {"DELETE", "/rfid/{id:[0-9]+}", deleteRfidTagHandler},
+			"rfidusage":          {"GET", "/rfid/usage", rfidUsageHandler},
+			"ocpptransactions":   {"GET", "/ocpp/transactions", ocppTransactionsHandler},
+			"eventlog":           {"GET", "/events", eventLogHandler},
+			"oauthproviders":     {"GET", "/oauth", oauthProvidersHandler},
+			"oauthtoken":         {"POST", "/oauth/{key:[a-zA-Z0-9_.:-]+}", oauthTokenHandler},
+			"oauthlogin":         {"POST", "/oauth/{key:[a-zA-Z0-9_.:-]+}/login", oauthLoginHandler},
 		}
 
 		// yaml handlers
@@ -37,6 +37,7 @@ func getLoadpointDynamicConfig(lp loadpoint.API) loadpoint.DynamicConfig {
 		SmartCostLimit:   lp.GetSmartCostLimit(),
 		Thresholds:       lp.GetThresholds(),
 		Soc:              lp.GetSocConfig(),
+		SmartCost:        lp.GetSmartCostConfig(),
 		PlanEnergy:       planEnergy,
 		PlanTime:         planTime,
 		LimitEnergy:      lp.GetLimitEnergy(),
@@ -0,0 +1,48 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/evcc-io/evcc/core/eventlog"
+)
+
+// eventLogHandler returns the event log, optionally filtered by category, device and time range (RFC3339)
+func eventLogHandler(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	filter := eventlog.Filter{
+		Category: q.Get("category"),
+		Device:   q.Get("device"),
+		Limit:    100,
+	}
+
+	if s := q.Get("limit"); s != "" {
+		val, err := strconv.Atoi(s)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+		filter.Limit = val
+	}
+
+	for key, ts := range map[string]*time.Time{"from": &filter.From, "to": &filter.To} {
+		if s := q.Get(key); s != "" {
+			val, err := time.Parse(time.RFC3339, s)
+			if err != nil {
+				jsonError(w, http.StatusBadRequest, err)
+				return
+			}
+			*ts = val
+		}
+	}
+
+	res, err := eventlog.Entries(filter)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonResult(w, res)
+}
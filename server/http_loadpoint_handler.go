@@ -41,6 +41,36 @@ func remoteDemandHandler(lp loadpoint.API) http.HandlerFunc {
 	}
 }
 
+// connectorUnlockHandler unlocks the connector to release the cable
+func connectorUnlockHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if err := lp.UnlockConnector(); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, true)
+	}
+}
+
+// connectorLockHandler permanently locks or unlocks the connector
+func connectorLockHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lock, err := strconv.ParseBool(mux.Vars(r)["value"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := lp.LockConnector(lock); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, lock)
+	}
+}
+
 // planHandler returns the current plan
 func planHandler(lp loadpoint.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -74,6 +104,13 @@ func planHandler(lp loadpoint.API) http.HandlerFunc {
 	}
 }
 
+// planHistoryHandler returns the outcomes of past charge plans
+func planHistoryHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jsonResult(w, lp.GetPlanHistory())
+	}
+}
+
 // staticPlanPreviewHandler returns a plan preview for given parameters
 func staticPlanPreviewHandler(lp loadpoint.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -116,15 +153,19 @@ func staticPlanPreviewHandler(lp loadpoint.API) http.HandlerFunc {
 		}
 
 		res := struct {
-			PlanTime time.Time `json:"planTime"`
-			Duration int64     `json:"duration"`
-			Plan     api.Rates `json:"plan"`
-			Power    float64   `json:"power"`
+			PlanTime    time.Time          `json:"planTime"`
+			Duration    int64              `json:"duration"`
+			Plan        api.Rates          `json:"plan"`
+			Power       float64            `json:"power"`
+			Reservation float64            `json:"reservation,omitempty"` // reserved share of solar surplus at plan time (%)
+			Comparison  map[string]float64 `json:"comparison,omitempty"`  // plan cost by comparison tariff
 		}{
-			PlanTime: planTime,
-			Duration: int64(requiredDuration.Seconds()),
-			Plan:     plan,
-			Power:    maxPower,
+			PlanTime:    planTime,
+			Duration:    int64(requiredDuration.Seconds()),
+			Plan:        plan,
+			Power:       maxPower,
+			Reservation: 100 * lp.GetSolarReservation(planTime),
+			Comparison:  lp.GetPlanComparison(planTime, requiredDuration, maxPower),
 		}
 
 		jsonResult(w, res)
@@ -169,15 +210,19 @@ func repeatingPlanPreviewHandler(lp loadpoint.API) http.HandlerFunc {
 		}
 
 		res := struct {
-			PlanTime time.Time `json:"planTime"`
-			Duration int64     `json:"duration"`
-			Plan     api.Rates `json:"plan"`
-			Power    float64   `json:"power"`
+			PlanTime    time.Time          `json:"planTime"`
+			Duration    int64              `json:"duration"`
+			Plan        api.Rates          `json:"plan"`
+			Power       float64            `json:"power"`
+			Reservation float64            `json:"reservation,omitempty"` // reserved share of solar surplus at plan time (%)
+			Comparison  map[string]float64 `json:"comparison,omitempty"`  // plan cost by comparison tariff
 		}{
-			PlanTime: planTime,
-			Duration: int64(requiredDuration.Seconds()),
-			Plan:     plan,
-			Power:    maxPower,
+			PlanTime:    planTime,
+			Duration:    int64(requiredDuration.Seconds()),
+			Plan:        plan,
+			Power:       maxPower,
+			Reservation: 100 * lp.GetSolarReservation(planTime),
+			Comparison:  lp.GetPlanComparison(planTime, requiredDuration, maxPower),
 		}
 
 		jsonResult(w, res)
@@ -233,6 +278,47 @@ func planRemoveHandler(lp loadpoint.API) http.HandlerFunc {
 	}
 }
 
+// guestSessionHandler starts a guest session with energy and cost caps
+func guestSessionHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		energy, err := strconv.ParseFloat(vars["energy"], 64)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		cost, err := strconv.ParseFloat(vars["cost"], 64)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		lp.StartGuestSession(energy, cost)
+
+		res := struct {
+			Energy float64 `json:"energy"`
+			Cost   float64 `json:"cost"`
+		}{
+			Energy: energy,
+			Cost:   cost,
+		}
+
+		jsonResult(w, res)
+	}
+}
+
+// guestSessionRemoveHandler cancels the guest session
+func guestSessionRemoveHandler(lp loadpoint.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lp.StopGuestSession()
+
+		res := struct{}{}
+		jsonResult(w, res)
+	}
+}
+
 // vehicleSelectHandler sets active vehicle
 func vehicleSelectHandler(site site.API, lp loadpoint.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
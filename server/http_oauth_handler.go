@@ -0,0 +1,81 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/evcc-io/evcc/server/oauth2redirect"
+	"github.com/evcc-io/evcc/util/oauth"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/gorilla/mux"
+	"golang.org/x/oauth2"
+)
+
+// oauthProvidersHandler returns the token refresh status of all oauth providers
+func oauthProvidersHandler(w http.ResponseWriter, r *http.Request) {
+	jsonResult(w, oauth.Providers())
+}
+
+// oauthTokenHandler re-authenticates a single oauth provider using the given token
+func oauthTokenHandler(w http.ResponseWriter, r *http.Request) {
+	var token oauth2.Token
+	if err := json.NewDecoder(r.Body).Decode(&token); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if token.AccessToken == "" && token.RefreshToken == "" {
+		jsonError(w, http.StatusBadRequest, errors.New("missing token"))
+		return
+	}
+
+	key := mux.Vars(r)["key"]
+	if err := oauth.SetToken(key, &token); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonResult(w, oauth.Providers()[key])
+}
+
+// oauthLoginHandler starts re-authentication of a single oauth provider by browser login.
+// The provider redirects to the oauth callback which exchanges the authorization code for a token.
+func oauthLoginHandler(w http.ResponseWriter, r *http.Request) {
+	key := mux.Vars(r)["key"]
+
+	oc, err := oauth.LoginConfig(key)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	verifier := oauth2.GenerateVerifier()
+
+	state := oauth2redirect.Register(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), oauth2.HTTPClient, request.NewClient(log))
+
+		token, err := oc.Exchange(ctx, r.URL.Query().Get("code"), oauth2.VerifierOption(verifier))
+		if err == nil {
+			err = oauth.SetToken(key, token)
+		}
+
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprintf(w, "error: %v\n", err)
+			return
+		}
+
+		http.Redirect(w, r, "/", http.StatusFound)
+	})
+
+	res := struct {
+		LoginUri string `json:"loginUri"`
+	}{
+		LoginUri: oc.AuthCodeURL(state, oauth2.AccessTypeOffline, oauth2.S256ChallengeOption(verifier)),
+	}
+
+	jsonResult(w, res)
+}
@@ -0,0 +1,86 @@
+package server
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/evcc-io/evcc/server/oauth2redirect"
+	"github.com/evcc-io/evcc/util/oauth"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+type expiredRefresher struct{}
+
+func (expiredRefresher) RefreshToken(*oauth2.Token) (*oauth2.Token, error) {
+	return nil, errors.New("invalid_grant")
+}
+
+func TestOAuthLoginHandler(t *testing.T) {
+	t.Setenv("EVCC_OAUTH_KEY", base64.StdEncoding.EncodeToString([]byte("0123456789abcdef0123456789abcdef")))
+
+	var form url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		form = r.PostForm
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"access_token":"access","refresh_token":"refresh","expires_in":3600}`))
+	}))
+	defer srv.Close()
+
+	oc := &oauth2.Config{
+		ClientID: "evcc",
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  srv.URL + "/authorize",
+			TokenURL: srv.URL + "/token",
+		},
+	}
+
+	oauth.RegisterLogin("test.login", "Test", oc, oauth.RefreshTokenSource(nil, expiredRefresher{}))
+	oauth.SetRedirectURL("http://evcc.local/oauth/callback")
+
+	router := mux.NewRouter()
+	router.Methods(http.MethodPost).Path("/api/oauth/{key}/login").HandlerFunc(oauthLoginHandler)
+	oauth2redirect.SetupRouter(router.PathPrefix("/oauth").Subrouter())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/oauth/test.login/login", nil))
+	require.Equal(t, http.StatusOK, w.Code)
+
+	var res struct {
+		Result struct {
+			LoginUri string `json:"loginUri"`
+		} `json:"result"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &res))
+
+	u, err := url.Parse(res.Result.LoginUri)
+	require.NoError(t, err)
+	assert.Equal(t, "http://evcc.local/oauth/callback", u.Query().Get("redirect_uri"))
+	assert.NotEmpty(t, u.Query().Get("code_challenge"))
+
+	// provider redirects back after login
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/oauth/callback?code=1234&state="+url.QueryEscape(u.Query().Get("state")), nil))
+	assert.Equal(t, http.StatusFound, w.Code, w.Body.String())
+
+	assert.Equal(t, "1234", form.Get("code"))
+	assert.NotEmpty(t, form.Get("code_verifier"))
+
+	status := oauth.Providers()["test.login"]
+	assert.True(t, status.Login)
+	assert.Empty(t, status.Error)
+	assert.False(t, status.Expiry.IsZero())
+
+	// providers without login config
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/api/oauth/unknown/login", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
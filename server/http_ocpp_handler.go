@@ -0,0 +1,29 @@
+package server
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/evcc-io/evcc/charger/ocpp"
+)
+
+// ocppTransactionsHandler returns the OCPP transaction log, optionally filtered by station
+func ocppTransactionsHandler(w http.ResponseWriter, r *http.Request) {
+	limit := 100
+	if s := r.URL.Query().Get("limit"); s != "" {
+		val, err := strconv.Atoi(s)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+		limit = val
+	}
+
+	res, err := ocpp.Transactions(r.URL.Query().Get("station"), limit)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonResult(w, res)
+}
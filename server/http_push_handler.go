@@ -0,0 +1,67 @@
+package server
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/gorilla/mux"
+)
+
+// PushActions executes pending notification actions by their single-use token
+type PushActions interface {
+	ActionLabel(token string) (string, bool)
+	ExecuteAction(token string) (string, error)
+}
+
+var pushActionTemplate = template.Must(template.New("action").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{ .Label }}</title>
+</head>
+<body>
+{{ if .Done }}<p>{{ .Label }}: {{ .Result }}</p>
+{{ else }}<form method="post"><button type="submit">{{ .Label }}</button></form>
+{{ end }}</body>
+</html>
+`))
+
+// pushActionHandler confirms (GET) and executes (POST) a notification action.
+// Links opened from messengers without http actions use GET and require confirmation.
+func pushActionHandler(actions PushActions) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := mux.Vars(r)["token"]
+
+		label, ok := actions.ActionLabel(token)
+		if !ok {
+			http.Error(w, "action expired", http.StatusNotFound)
+			return
+		}
+
+		data := map[string]any{"Label": label}
+
+		if r.Method == http.MethodPost {
+			data["Done"] = true
+			data["Result"] = "ok"
+
+			if _, err := actions.ExecuteAction(token); err != nil {
+				log.ERROR.Printf("push action %s: %v", label, err)
+				w.WriteHeader(http.StatusBadGateway)
+				data["Result"] = err.Error()
+			}
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+		if err := pushActionTemplate.Execute(w, data); err != nil {
+			log.ERROR.Printf("httpd: failed to render action: %v", err)
+		}
+	}
+}
+
+// RegisterPushActionHandler provides the notification action callbacks as /push/action/<token>
+func (s *HTTPd) RegisterPushActionHandler(actions PushActions) {
+	router := s.Server.Handler.(*mux.Router)
+
+	router.Methods(http.MethodGet, http.MethodPost).Path("/push/action/{token:[0-9a-f]+}").Handler(pushActionHandler(actions))
+}
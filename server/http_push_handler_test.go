@@ -0,0 +1,55 @@
+package server
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+)
+
+type pushActions map[string]string
+
+func (a pushActions) ActionLabel(token string) (string, bool) {
+	label, ok := a[token]
+	return label, ok
+}
+
+func (a pushActions) ExecuteAction(token string) (string, error) {
+	label, ok := a[token]
+	if !ok {
+		return "", errors.New("action expired")
+	}
+	delete(a, token)
+	return label, nil
+}
+
+func TestPushActionHandler(t *testing.T) {
+	actions := pushActions{"abcd": "Charge now"}
+
+	router := mux.NewRouter()
+	router.Methods(http.MethodGet, http.MethodPost).Path("/push/action/{token:[0-9a-f]+}").Handler(pushActionHandler(actions))
+
+	serve := func(method string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(method, "/push/action/abcd", nil))
+		return w
+	}
+
+	// confirmation page does not execute the action
+	w := serve(http.MethodGet)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `<form method="post">`)
+	assert.Contains(t, actions, "abcd")
+
+	w = serve(http.MethodPost)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Charge now: ok")
+	assert.NotContains(t, actions, "abcd")
+
+	// single use
+	w = serve(http.MethodPost)
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
@@ -0,0 +1,123 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/evcc-io/evcc/core/rfid"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/gorilla/mux"
+	"gorm.io/gorm"
+)
+
+// rfidTagsHandler returns the list of managed RFID tags
+func rfidTagsHandler(w http.ResponseWriter, r *http.Request) {
+	res, err := rfid.Tags()
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonResult(w, res)
+}
+
+// newRfidTagHandler adds a new RFID tag
+func newRfidTagHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	var tag rfid.Tag
+	if err := json.NewDecoder(r.Body).Decode(&tag); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := rfid.AddTag(tag)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	jsonResult(w, res)
+}
+
+// updateRfidTagHandler updates an RFID tag
+func updateRfidTagHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	var tag rfid.Tag
+	if err := json.NewDecoder(r.Body).Decode(&tag); err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	res, err := rfid.UpdateTag(uint(id), tag)
+	if err != nil {
+		jsonError(w, rfidErrorStatus(err), err)
+		return
+	}
+
+	jsonResult(w, res)
+}
+
+// deleteRfidTagHandler deletes an RFID tag
+func deleteRfidTagHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	id, err := strconv.ParseUint(mux.Vars(r)["id"], 10, 32)
+	if err != nil {
+		jsonError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	if err := rfid.DeleteTag(uint(id)); err != nil {
+		jsonError(w, rfidErrorStatus(err), err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// rfidUsageHandler returns the RFID usage log, optionally filtered by tag
+func rfidUsageHandler(w http.ResponseWriter, r *http.Request) {
+	limit := 100
+	if s := r.URL.Query().Get("limit"); s != "" {
+		val, err := strconv.Atoi(s)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+		limit = val
+	}
+
+	res, err := rfid.Usages(r.URL.Query().Get("idTag"), limit)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonResult(w, res)
+}
+
+func rfidErrorStatus(err error) int {
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return http.StatusNotFound
+	}
+	return http.StatusBadRequest
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/server/db"
 	"github.com/evcc-io/evcc/util/locale"
 	"github.com/gorilla/mux"
@@ -28,13 +29,8 @@ func csvResult(ctx context.Context, w http.ResponseWriter, res any, filename str
 	}
 }
 
-// sessionHandler returns the list of charging sessions
-func sessionHandler(w http.ResponseWriter, r *http.Request) {
-	if db.Instance == nil {
-		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
-		return
-	}
-
+// querySessions returns the charging sessions filtered by year and month request parameters
+func querySessions(r *http.Request) (session.Sessions, string, error) {
 	var (
 		res  session.Sessions
 		cond []string
@@ -57,10 +53,29 @@ func sessionHandler(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	if tag := strings.ToLower(strings.TrimSpace(r.URL.Query().Get("tag"))); tag != "" {
+		filename += "-" + tag
+		escaped := strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(tag)
+		push(`(',' || tags || ',') LIKE ? ESCAPE '\'`, "%,"+escaped+",%")
+	}
+
 	// TODO support other databases than Sqlite
 	query := strings.Join(append([]string{"charged_kwh>=0.05"}, cond...), " AND ")
-	if txn := db.Instance.Where(query, args...).Order("created DESC").Find(&res); txn.Error != nil {
-		jsonError(w, http.StatusInternalServerError, txn.Error)
+	txn := db.Instance.Where(query, args...).Order("created DESC").Find(&res)
+
+	return res, filename, txn.Error
+}
+
+// sessionHandler returns the list of charging sessions
+func sessionHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	res, filename, err := querySessions(r)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -90,6 +105,22 @@ func sessionHandler(w http.ResponseWriter, r *http.Request) {
 	jsonResult(w, res)
 }
 
+// greenSessionHandler returns the number and energy of green certified charging sessions
+func greenSessionHandler(w http.ResponseWriter, r *http.Request) {
+	if db.Instance == nil {
+		jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+		return
+	}
+
+	res, _, err := querySessions(r)
+	if err != nil {
+		jsonError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	jsonResult(w, res.GreenSummary())
+}
+
 // deleteSessionHandler removes session in sessions table with given id
 func deleteSessionHandler(w http.ResponseWriter, r *http.Request) {
 	if db.Instance == nil {
@@ -126,12 +157,21 @@ func updateSessionHandler(w http.ResponseWriter, r *http.Request) {
 	}
 
 	updates := map[string]interface{}{}
-	for _, field := range []string{"vehicle", "loadpoint"} {
+	for _, field := range []string{"vehicle", "loadpoint", "notes"} {
 		if val, ok := data[field]; ok {
 			updates[field] = val
 		}
 	}
 
+	if val, ok := data["tags"]; ok {
+		tags, err := session.ParseTags(val)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+		updates["tags"] = tags
+	}
+
 	if len(updates) == 0 {
 		jsonError(w, http.StatusBadRequest, errors.New("no valid fields to update"))
 		return
@@ -143,3 +183,33 @@ func updateSessionHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// awaySessionsHandler imports charging records of the vehicle charged away from home
+func awaySessionsHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if db.Instance == nil {
+			jsonError(w, http.StatusBadRequest, errors.New("database offline"))
+			return
+		}
+
+		v, err := site.Vehicles().ByName(mux.Vars(r)["name"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		var records []api.ChargingRecord
+		if err := json.NewDecoder(r.Body).Decode(&records); err != nil {
+			jsonError(w, http.StatusBadRequest, errors.New("invalid JSON"))
+			return
+		}
+
+		n, err := session.ImportAway(db.Instance, v.Instance().Title(), records)
+		if err != nil {
+			jsonError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		jsonResult(w, n)
+	}
+}
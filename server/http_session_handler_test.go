@@ -0,0 +1,38 @@
+package server
+
+import (
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQuerySessionsTag(t *testing.T) {
+	instance, err := db.New("sqlite", ":memory:")
+	require.NoError(t, err)
+	require.NoError(t, instance.AutoMigrate(new(session.Session)))
+
+	prev := db.Instance
+	db.Instance = instance
+	t.Cleanup(func() { db.Instance = prev })
+
+	for _, tags := range []string{"business", "a_b", "axb,business", "100%"} {
+		require.NoError(t, instance.Create(&session.Session{ChargedEnergy: 1, Tags: tags}).Error)
+	}
+
+	for tag, expected := range map[string]int{
+		"business": 2,
+		"a_b":      1,
+		"100%":     1,
+		"%":        0,
+		"_":        0,
+	} {
+		res, _, err := querySessions(httptest.NewRequest("GET", "/?tag="+url.QueryEscape(tag), nil))
+		require.NoError(t, err)
+		assert.Len(t, res, expected, tag)
+	}
+}
@@ -12,14 +12,17 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/planner"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/server/assets"
+	"github.com/evcc-io/evcc/tariff"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/encode"
 	"github.com/evcc-io/evcc/util/jq"
 	"github.com/evcc-io/evcc/util/logstash"
 	"github.com/gorilla/mux"
 	"github.com/itchyny/gojq"
+	"github.com/samber/lo"
 	"golang.org/x/text/language"
 	"gopkg.in/yaml.v3"
 )
@@ -247,24 +250,44 @@ func healthHandler(site site.API) http.HandlerFunc {
 	}
 }
 
-// tariffHandler returns the configured tariff
+// forecastAccuracyHandler returns the solar forecast accuracy by forecast provider
+func forecastAccuracyHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jsonResult(w, site.GetForecastAccuracy())
+	}
+}
+
+// tariffHandler returns the configured tariff's rates, optionally limited to the period between from and to (RFC3339).
+// The solar tariff additionally returns the adjusted solar forecast and its accumulated energy.
 func tariffHandler(site site.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 		val := vars["tariff"]
 
-		tariff, err := api.TariffUsageString(val)
+		usage, err := api.TariffUsageString(val)
 		if err != nil {
 			jsonError(w, http.StatusNotFound, err)
 			return
 		}
 
-		t := site.GetTariff(tariff)
+		t := site.GetTariff(usage)
 		if t == nil {
 			jsonError(w, http.StatusNotFound, errors.New("tariff not available"))
 			return
 		}
 
+		var from, to time.Time
+		for key, ts := range map[string]*time.Time{"from": &from, "to": &to} {
+			if s := r.URL.Query().Get(key); s != "" {
+				val, err := time.Parse(time.RFC3339, s)
+				if err != nil {
+					jsonError(w, http.StatusBadRequest, err)
+					return
+				}
+				*ts = val
+			}
+		}
+
 		rates, err := t.Rates()
 		if err != nil {
 			jsonError(w, http.StatusNotFound, err)
@@ -272,9 +295,238 @@ func tariffHandler(site site.API) http.HandlerFunc {
 		}
 
 		res := struct {
+			Rates  api.Rates `json:"rates"`
+			Solar  api.Rates `json:"solar,omitempty"`  // solar forecast adjusted to actual production
+			Energy *float64  `json:"energy,omitempty"` // accumulated energy of the adjusted solar forecast (kWh)
+		}{
+			Rates: tariff.Merge(tariff.Between(rates, from, to)),
+		}
+
+		if usage == api.TariffUsageSolar {
+			solar := site.GetSolarForecast()
+			res.Solar = tariff.Between(solar, from, to)
+			res.Energy = lo.ToPtr(tariff.AccumulatedEnergy(solar, from, to))
+		}
+
+		jsonResult(w, res)
+	}
+}
+
+// setTariffRates passes externally provided rates to a tariff supporting it
+func setTariffRates(site site.API, usage string, rates api.Rates) error {
+	tu, err := api.TariffUsageString(usage)
+	if err != nil {
+		return err
+	}
+
+	t := site.GetTariff(tu)
+	if t == nil {
+		return errors.New("tariff not available")
+	}
+
+	rs, ok := tariff.As[api.RatesSetter](t)
+	if !ok {
+		return errors.New("tariff does not accept external rates")
+	}
+
+	return rs.SetRates(rates)
+}
+
+// setTariffHandler updates the rates of an external tariff
+func setTariffHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		var res struct {
 			Rates api.Rates `json:"rates"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := setTariffRates(site, vars["tariff"], res.Rates); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, res)
+	}
+}
+
+// simulateHandler returns the expected cost and co2 of a hypothetical charging session
+func simulateHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		energy, err := strconv.ParseFloat(q.Get("energy"), 64)
+		if err != nil || energy <= 0 {
+			jsonError(w, http.StatusBadRequest, errors.New("invalid energy"))
+			return
+		}
+
+		power := 11e3
+		if s := q.Get("power"); s != "" {
+			if power, err = strconv.ParseFloat(s, 64); err != nil || power <= 0 {
+				jsonError(w, http.StatusBadRequest, errors.New("invalid power"))
+				return
+			}
+		}
+
+		now := time.Now()
+		end := now.Add(24 * time.Hour)
+		if s := q.Get("end"); s != "" {
+			if end, err = time.Parse(time.RFC3339, s); err != nil {
+				jsonError(w, http.StatusBadRequest, err)
+				return
+			}
+		}
+
+		duration := time.Duration(energy * 1e3 / power * float64(time.Hour))
+		immediate := api.Rates{{Start: now, End: now.Add(duration)}}
+
+		mode := q.Get("mode")
+		if mode == "" {
+			mode = "cheap"
+		}
+
+		var plan api.Rates
+		switch mode {
+		case "now":
+			plan = immediate
+		case "cheap":
+			plan = planner.New(log, site.GetTariff(api.TariffUsagePlanner)).Plan(duration, end)
+		default:
+			jsonError(w, http.StatusBadRequest, fmt.Errorf("invalid mode: %s", mode))
+			return
+		}
+
+		if len(plan) == 0 {
+			jsonError(w, http.StatusBadRequest, errors.New("no plan available"))
+			return
+		}
+
+		cost := func(u api.TariffUsage, plan api.Rates) *float64 {
+			t := site.GetTariff(u)
+			if t == nil {
+				return nil
+			}
+
+			rr, err := t.Rates()
+			if err != nil {
+				return nil
+			}
+
+			if res, err := planner.Cost(plan, rr, power); err == nil {
+				return &res
+			}
+
+			return nil
+		}
+
+		res := struct {
+			Mode    string    `json:"mode"`
+			Energy  float64   `json:"energy"`
+			Start   time.Time `json:"start"`
+			End     time.Time `json:"end"`
+			Cost    *float64  `json:"cost,omitempty"`
+			Co2     *float64  `json:"co2,omitempty"`
+			Savings *float64  `json:"savings,omitempty"`
+			Plan    api.Rates `json:"plan"`
 		}{
-			Rates: rates,
+			Mode:   mode,
+			Energy: energy,
+			Start:  planner.Start(plan),
+			End:    planner.End(plan),
+			Cost:   cost(api.TariffUsageGrid, plan),
+			Co2:    cost(api.TariffUsageCo2, plan),
+			Plan:   plan,
+		}
+
+		// savings compared to charging immediately
+		if costNow := cost(api.TariffUsageGrid, immediate); costNow != nil && res.Cost != nil {
+			savings := *costNow - *res.Cost
+			res.Savings = &savings
+		}
+
+		jsonResult(w, res)
+	}
+}
+
+// chargingWindowHandler returns the cheapest or greenest window for consuming energy before a deadline,
+// e.g. for devices not controlled by evcc
+func chargingWindowHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+
+		energy, err := strconv.ParseFloat(q.Get("energy"), 64)
+		if err != nil || energy <= 0 {
+			jsonError(w, http.StatusBadRequest, errors.New("invalid energy"))
+			return
+		}
+
+		power := 11e3
+		if s := q.Get("power"); s != "" {
+			if power, err = strconv.ParseFloat(s, 64); err != nil || power <= 0 {
+				jsonError(w, http.StatusBadRequest, errors.New("invalid power"))
+				return
+			}
+		}
+
+		end := time.Now().Add(24 * time.Hour)
+		if s := q.Get("end"); s != "" {
+			if end, err = time.Parse(time.RFC3339, s); err != nil {
+				jsonError(w, http.StatusBadRequest, err)
+				return
+			}
+		}
+
+		objective := q.Get("objective")
+		if objective == "" {
+			objective = "price"
+		}
+
+		split := q.Get("split") == "true"
+
+		res, err := site.GetChargingWindow(energy, power, end, objective, split)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, res)
+	}
+}
+
+// meterReplacementHandler registers the final and initial energy counter readings of a replaced meter
+func meterReplacementHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		oldEnergy, err := parseFloat(vars["old"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		newEnergy, err := parseFloat(vars["new"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := site.SetMeterReplacement(vars["name"], oldEnergy, newEnergy); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res := struct {
+			Old float64 `json:"old"`
+			New float64 `json:"new"`
+		}{
+			Old: oldEnergy,
+			New: newEnergy,
 		}
 
 		jsonResult(w, res)
@@ -324,3 +576,24 @@ func logHandler(w http.ResponseWriter, r *http.Request) {
 
 	jsonResult(w, log)
 }
+
+// snapshotHandler renders the current power flow and upcoming prices as png image
+func snapshotHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		size := func(key string, def int) int {
+			if v, err := strconv.Atoi(r.URL.Query().Get(key)); err == nil {
+				return min(max(v, 100), 1920)
+			}
+			return def
+		}
+
+		b, err := site.Snapshot(size("width", 480), size("height", 320))
+		if err != nil {
+			jsonError(w, http.StatusInternalServerError, err)
+			return
+		}
+
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(b)
+	}
+}
@@ -0,0 +1,128 @@
+package server
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/api/globalconfig"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/encode"
+	"github.com/gorilla/handlers"
+	"github.com/gorilla/mux"
+)
+
+var statusTemplate = template.Must(template.New("status").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta name="viewport" content="width=device-width, initial-scale=1">
+<title>{{ .Title }}</title>
+</head>
+<body>
+<h1>{{ .Title }}</h1>
+<table>
+{{ range .Values }}<tr><th>{{ .Key }}</th><td>{{ .Value }}</td></tr>
+{{ end }}</table>
+<p>{{ .Updated.Format "2006-01-02 15:04:05" }}</p>
+</body>
+</html>
+`))
+
+// statusValue is a single value of the public status
+type statusValue struct {
+	Key   string
+	Value any
+}
+
+// statusLookup resolves a dotted key like pvPower or loadpoints.0.chargePower from the state
+func statusLookup(state map[string]any, key string) (any, bool) {
+	var val any = state
+
+	for _, segment := range strings.Split(key, ".") {
+		switch v := val.(type) {
+		case map[string]any:
+			var ok bool
+			if val, ok = v[segment]; !ok {
+				return nil, false
+			}
+
+		case []map[string]any:
+			i, err := strconv.Atoi(segment)
+			if err != nil || i < 0 || i >= len(v) {
+				return nil, false
+			}
+			val = v[i]
+
+		default:
+			return nil, false
+		}
+	}
+
+	return val, true
+}
+
+// statusValues returns the configured values available in the state
+func statusValues(state map[string]any, conf globalconfig.Status) []statusValue {
+	res := make([]statusValue, 0, len(conf.Keys))
+	for _, key := range conf.Keys {
+		if val, ok := statusLookup(state, key); ok {
+			res = append(res, statusValue{Key: key, Value: val})
+		}
+	}
+	return res
+}
+
+// publicStatusHandler returns the configured state values without authentication as json or html
+func publicStatusHandler(cache *util.ParamCache, conf globalconfig.Status, html bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		state := cache.State(encode.NewEncoder(encode.WithDuration()))
+		values := statusValues(state, conf)
+
+		w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(conf.MaxAge.Seconds())))
+
+		if !html {
+			res := make(map[string]any, len(values))
+			for _, v := range values {
+				res[v.Key] = v.Value
+			}
+
+			w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+			jsonResult(w, res)
+			return
+		}
+
+		title := conf.Title
+		if title == "" {
+			title, _ = state[keys.SiteTitle].(string)
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=UTF-8")
+		if err := statusTemplate.Execute(w, map[string]any{
+			"Title":   title,
+			"Values":  values,
+			"Updated": time.Now(),
+		}); err != nil {
+			log.ERROR.Printf("httpd: failed to render status: %v", err)
+		}
+	}
+}
+
+// RegisterPublicStatusHandler provides the read-only public status as /status.json and /status.html
+func (s *HTTPd) RegisterPublicStatusHandler(cache *util.ParamCache, conf globalconfig.Status) {
+	router := s.Server.Handler.(*mux.Router)
+
+	if conf.MaxAge <= 0 {
+		conf.MaxAge = 30 * time.Second
+	}
+
+	for path, html := range map[string]bool{"/status.json": false, "/status.html": true} {
+		router.Methods(http.MethodGet).Path(path).Handler(
+			handlers.CompressHandler(handlers.CORS()(publicStatusHandler(cache, conf, html))),
+		)
+	}
+}
@@ -0,0 +1,26 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api/globalconfig"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatusValues(t *testing.T) {
+	state := map[string]any{
+		"pvPower": 5000.0,
+		"loadpoints": []map[string]any{
+			{"chargePower": 11000.0},
+		},
+	}
+
+	res := statusValues(state, globalconfig.Status{
+		Keys: []string{"pvPower", "loadpoints.0.chargePower", "loadpoints.1.chargePower", "loadpoints.x", "pvPower.foo", "gridPower"},
+	})
+
+	assert.Equal(t, []statusValue{
+		{Key: "pvPower", Value: 5000.0},
+		{Key: "loadpoints.0.chargePower", Value: 11000.0},
+	}, res)
+}
@@ -69,6 +69,38 @@ func limitSocHandler(site site.API) http.HandlerFunc {
 	}
 }
 
+// vehicleSocHandler corrects the soc of vehicles tracking soc from charged energy
+func vehicleSocHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		v, err := site.Vehicles().ByName(vars["name"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		soc, err := strconv.ParseFloat(vars["value"], 64)
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := v.SetSoc(soc); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		res := struct {
+			Soc float64 `json:"soc"`
+		}{
+			Soc: soc,
+		}
+
+		jsonResult(w, res)
+	}
+}
+
 // planSocHandler updates plan soc and time
 func planSocHandler(site site.API) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -161,3 +193,62 @@ func planSocRemoveHandler(site site.API) http.HandlerFunc {
 		jsonResult(w, res)
 	}
 }
+
+// profilesHandler stores the vehicle settings profiles
+func profilesHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		v, err := site.Vehicles().ByName(vars["name"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		var res struct {
+			Profiles []api.VehicleProfile `json:"profiles"`
+		}
+
+		if err := json.NewDecoder(r.Body).Decode(&res); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		if err := v.SetProfiles(res.Profiles); err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		jsonResult(w, res)
+	}
+}
+
+// applyProfileHandler applies a vehicle settings profile
+func applyProfileHandler(site site.API) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		v, err := site.Vehicles().ByName(vars["name"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		p, err := v.ApplyProfile(vars["profile"])
+		if err != nil {
+			jsonError(w, http.StatusBadRequest, err)
+			return
+		}
+
+		// apply smart cost limit to the loadpoint the vehicle is connected to
+		if p.SmartCostLimit != nil || p.DisableSmartCost {
+			for _, lp := range site.Loadpoints() {
+				if lp.GetVehicle() == v.Instance() {
+					lp.SetSmartCostLimit(p.SmartCostLimit)
+				}
+			}
+		}
+
+		jsonResult(w, p)
+	}
+}
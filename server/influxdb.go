@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/util"
 	influxdb2 "github.com/influxdata/influxdb-client-go/v2"
@@ -25,6 +26,8 @@ type Influx struct {
 	client   influxdb2.Client
 	org      string
 	database string
+
+	annotations map[string]string // last values of annotated parameters
 }
 
 // NewInfluxClient creates new publisher for influx
@@ -62,8 +65,13 @@ type pointWriter interface {
 
 // writePoint asynchronously writes a point to influx
 func (m *Influx) writePoint(writer pointWriter, key string, fields map[string]any, tags map[string]string) {
+	m.writePointAt(writer, key, fields, tags, m.clock.Now())
+}
+
+// writePointAt asynchronously writes a point with given timestamp to influx
+func (m *Influx) writePointAt(writer pointWriter, key string, fields map[string]any, tags map[string]string, ts time.Time) {
 	m.log.TRACE.Printf("write %s=%v (%v)", key, fields, tags)
-	writer.WritePoint(influxdb2.NewPoint(key, tags, fields, m.clock.Now()))
+	writer.WritePoint(influxdb2.NewPoint(key, tags, fields, ts))
 }
 
 // writeComplexPoint asynchronously writes a point to influx
@@ -169,6 +177,16 @@ func (m *Influx) Run(site site.API, in <-chan util.Param) {
 			if v := lp.GetVehicle(); v != nil {
 				tags["vehicle"] = v.Title()
 			}
+
+			switch param.Key {
+			case keys.Session, keys.PlanSlot:
+				tags["mode"] = string(lp.GetMode())
+			}
+		}
+
+		m.writeAnnotation(writer, param, tags)
+		if m.writeEvent(writer, param, tags) {
+			continue
 		}
 
 		m.writeComplexPoint(writer, param.Key, param.Val, tags)
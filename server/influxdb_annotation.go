@@ -0,0 +1,80 @@
+package server
+
+import (
+	"fmt"
+	"maps"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/util"
+)
+
+// annotationMeasurement is the measurement holding Grafana annotations.
+// Query title, text and event tag using e.g. `SELECT title, text, event FROM annotation WHERE $timeFilter`.
+const annotationMeasurement = "annotation"
+
+// annotation returns event and title for parameters representing state transitions
+func (m *Influx) annotation(param util.Param) (string, string, bool) {
+	switch param.Key {
+	case keys.Connected, keys.Charging, keys.PlanActive, keys.Mode, keys.Fatal:
+	default:
+		return "", "", false
+	}
+
+	if m.annotations == nil {
+		m.annotations = make(map[string]string)
+	}
+
+	// only transitions are annotated, initial values are not
+	id := param.UniqueID()
+	val := fmt.Sprintf("%v", param.Val)
+	prev, ok := m.annotations[id]
+	m.annotations[id] = val
+
+	if !ok || prev == val {
+		return "", "", false
+	}
+
+	switch val := param.Val.(type) {
+	case bool:
+		switch param.Key {
+		case keys.Connected:
+			return "session", map[bool]string{true: "Session started", false: "Session finished"}[val], true
+		case keys.Charging:
+			return "charging", map[bool]string{true: "Charging started", false: "Charging stopped"}[val], true
+		case keys.PlanActive:
+			return "plan", map[bool]string{true: "Plan activated", false: "Plan deactivated"}[val], true
+		}
+
+	case error:
+		if param.Key == keys.Fatal {
+			return "alert", val.Error(), true
+		}
+	}
+
+	if param.Key == keys.Mode && param.Val != nil {
+		return "mode", fmt.Sprintf("Mode changed to %v", param.Val), true
+	}
+
+	return "", "", false
+}
+
+// writeAnnotation writes a Grafana annotation for state transitions
+func (m *Influx) writeAnnotation(writer pointWriter, param util.Param, tags map[string]string) {
+	event, title, ok := m.annotation(param)
+	if !ok {
+		return
+	}
+
+	atags := map[string]string{"event": event}
+	maps.Copy(atags, tags)
+
+	text := title
+	if lp, ok := tags["loadpoint"]; ok {
+		text = fmt.Sprintf("%s: %s", lp, title)
+	}
+
+	m.writePoint(writer, annotationMeasurement, map[string]any{
+		"title": title,
+		"text":  text,
+	}, atags)
+}
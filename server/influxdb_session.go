@@ -0,0 +1,74 @@
+package server
+
+import (
+	"maps"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/session"
+	"github.com/evcc-io/evcc/util"
+)
+
+// Measurements holding completed sessions and executed plan slots, tagged by loadpoint, vehicle and mode.
+// Query e.g. `SELECT sum(price) FROM session WHERE $timeFilter GROUP BY vehicle`.
+const (
+	sessionMeasurement  = "session"
+	planSlotMeasurement = "planSlot"
+)
+
+// writeEvent writes sessions and plan slots as measurements of their own
+func (m *Influx) writeEvent(writer pointWriter, param util.Param, tags map[string]string) bool {
+	switch val := param.Val.(type) {
+	case session.Session:
+		if param.Key != keys.Session {
+			return false
+		}
+
+		stags := maps.Clone(tags)
+		if val.Loadpoint != "" {
+			stags["loadpoint"] = val.Loadpoint
+		}
+		if val.Vehicle != "" {
+			stags["vehicle"] = val.Vehicle
+		}
+
+		fields := map[string]any{
+			"chargedEnergy": val.ChargedEnergy,
+			"started":       val.Created.Unix(),
+		}
+
+		if val.ChargeDuration != nil {
+			fields["chargeDuration"] = val.ChargeDuration.Seconds()
+		}
+
+		for key, f := range map[string]*float64{
+			"solarPercentage": val.SolarPercentage,
+			"price":           val.Price,
+			"pricePerKWh":     val.PricePerKWh,
+			"co2PerKWh":       val.Co2PerKWh,
+		} {
+			if f != nil {
+				fields[key] = *f
+			}
+		}
+
+		m.writePointAt(writer, sessionMeasurement, fields, stags, val.Finished)
+
+	case loadpoint.PlanSlot:
+		if param.Key != keys.PlanSlot {
+			return false
+		}
+
+		m.writePointAt(writer, planSlotMeasurement, map[string]any{
+			"duration": val.End.Sub(val.Start).Seconds(),
+			"price":    val.Price,
+			"energy":   val.Energy,
+			"cost":     val.Price * val.Energy,
+		}, tags, val.Start)
+
+	default:
+		return false
+	}
+
+	return true
+}
@@ -2,8 +2,12 @@ package server
 
 import (
 	"testing"
+	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/evcc-io/evcc/core/session"
 	"github.com/evcc-io/evcc/util"
 	inf2 "github.com/influxdata/influxdb-client-go/v2"
 	"github.com/influxdata/influxdb-client-go/v2/api/write"
@@ -107,3 +111,68 @@ func (w *influxSuite) TestSliceOfStruct() {
 		inf2.NewPoint("gridSoc", map[string]string{"id": "2"}, map[string]any{"value": 20.0}, w.clock.Now()),
 	}, w.p)
 }
+
+func (w *influxSuite) TestAnnotation() {
+	lp := 0
+	tags := map[string]string{"loadpoint": "Garage"}
+
+	// initial value is not annotated
+	w.Influx.writeAnnotation(w, util.Param{Loadpoint: &lp, Key: keys.Connected, Val: false}, tags)
+	w.Len(w.p, 0)
+
+	w.Influx.writeAnnotation(w, util.Param{Loadpoint: &lp, Key: keys.Connected, Val: true}, tags)
+	w.Influx.writeAnnotation(w, util.Param{Loadpoint: &lp, Key: keys.Connected, Val: true}, tags)
+	w.Equal([]*write.Point{
+		inf2.NewPoint("annotation", map[string]string{"event": "session", "loadpoint": "Garage"}, map[string]any{
+			"title": "Session started",
+			"text":  "Garage: Session started",
+		}, w.clock.Now()),
+	}, w.p)
+
+	// other parameters are not annotated
+	w.Influx.writeAnnotation(w, util.Param{Key: "foo", Val: 1}, nil)
+	w.Influx.writeAnnotation(w, util.Param{Key: "foo", Val: 2}, nil)
+	w.Len(w.p, 1)
+}
+
+func (w *influxSuite) TestEvents() {
+	lp := 0
+	tags := map[string]string{"loadpoint": "Garage", "vehicle": "Guest", "mode": "pv"}
+	start := w.clock.Now()
+	end := start.Add(time.Hour)
+
+	w.True(w.Influx.writeEvent(w, util.Param{Loadpoint: &lp, Key: keys.Session, Val: session.Session{
+		Created:        start,
+		Finished:       end,
+		Vehicle:        "Model 3",
+		ChargedEnergy:  10,
+		ChargeDuration: lo.ToPtr(30 * time.Minute),
+		Price:          lo.ToPtr(2.5),
+	}}, tags))
+
+	w.True(w.Influx.writeEvent(w, util.Param{Loadpoint: &lp, Key: keys.PlanSlot, Val: loadpoint.PlanSlot{
+		Start:  start,
+		End:    end,
+		Price:  0.2,
+		Energy: 5,
+	}}, tags))
+
+	w.Equal([]*write.Point{
+		inf2.NewPoint("session", map[string]string{"loadpoint": "Garage", "vehicle": "Model 3", "mode": "pv"}, map[string]any{
+			"chargedEnergy":  10.0,
+			"started":        start.Unix(),
+			"chargeDuration": 1800.0,
+			"price":          2.5,
+		}, end),
+		inf2.NewPoint("planSlot", tags, map[string]any{
+			"duration": 3600.0,
+			"price":    0.2,
+			"energy":   5.0,
+			"cost":     1.0,
+		}, start),
+	}, w.p)
+
+	// other parameters are not written as events
+	w.False(w.Influx.writeEvent(w, util.Param{Key: "foo", Val: 1}, nil))
+	w.Len(w.p, 2)
+}
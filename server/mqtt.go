@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/keys"
 	"github.com/evcc-io/evcc/core/loadpoint"
 	"github.com/evcc-io/evcc/core/site"
 	"github.com/evcc-io/evcc/core/vehicle"
@@ -28,6 +29,10 @@ type MQTT struct {
 	Handler   *mqtt.Client
 	root      string
 	publisher func(topic string, retained bool, payload string)
+
+	Compact bool // publish rates using compact encoding
+
+	forecastSlots map[string]int // number of published slots by forecast topic
 }
 
 // NewMQTT creates MQTT server
@@ -82,6 +87,15 @@ func (m *MQTT) publishComplex(topic string, retained bool, payload interface{})
 		return
 	}
 
+	if rr, ok := payload.(api.Rates); ok && m.Compact {
+		if b, err := json.Marshal(rr.Compact()); err == nil {
+			m.publishSingleValue(topic, retained, string(b))
+		} else {
+			m.log.ERROR.Printf("marshal mqtt: %v", err)
+		}
+		return
+	}
+
 	if mm, ok := payload.(MQTTMarshaler); ok {
 		if b, err := mm.MarshalMQTT(); err == nil {
 			m.publishSingleValue(topic, retained, string(b))
@@ -204,12 +218,26 @@ func (m *MQTT) listenSiteSetters(topic string, site site.API) error {
 			}
 		}))},
 		{"batteryGridChargeLimit", floatPtrSetter(pass(site.SetBatteryGridChargeLimit))},
+		{"batteryExportLimit", floatPtrSetter(site.SetBatteryExportLimit)},
 	} {
 		if err := m.Handler.ListenSetter(topic+"/"+s.topic, s.fun); err != nil {
 			return err
 		}
 	}
 
+	// external tariff rates
+	for _, usage := range api.TariffUsageStrings() {
+		if err := m.Handler.ListenSetter(topic+"/tariff/"+usage, func(payload string) error {
+			var rates api.Rates
+			if err := json.Unmarshal([]byte(payload), &rates); err != nil {
+				return err
+			}
+			return setTariffRates(site, usage, rates)
+		}); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -227,6 +255,8 @@ func (m *MQTT) listenLoadpointSetters(topic string, site site.API, lp loadpoint.
 		{"enableDelay", durationSetter(pass(lp.SetEnableDelay))},
 		{"disableDelay", durationSetter(pass(lp.SetDisableDelay))},
 		{"smartCostLimit", floatPtrSetter(pass(lp.SetSmartCostLimit))},
+		{"smartCostHysteresis", floatSetter(pass(lp.SetSmartCostHysteresis))},
+		{"smartCostMinDuration", durationSetter(pass(lp.SetSmartCostMinDuration))},
 		{"batteryBoost", boolSetter(lp.SetBatteryBoost)},
 		{"planEnergy", func(payload string) error {
 			var plan struct {
@@ -264,6 +294,7 @@ func (m *MQTT) listenVehicleSetters(topic string, v vehicle.API) error {
 	for _, s := range []setter{
 		{"limitSoc", intSetter(pass(v.SetLimitSoc))},
 		{"minSoc", intSetter(pass(v.SetMinSoc))},
+		{"soc", floatSetter(v.SetSoc)},
 		{"planSoc", func(payload string) error {
 			var plan struct {
 				Time  time.Time `json:"time"`
@@ -323,5 +354,10 @@ func (m *MQTT) Run(site site.API, in <-chan util.Param) {
 
 		// value
 		m.publish(topic, true, p.Val)
+
+		// forecasts on dedicated topics
+		if p.Key == keys.Forecast {
+			m.publishForecast(p.Val)
+		}
 	}
 }
@@ -0,0 +1,73 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// publishForecast publishes each forecast series on dedicated topics:
+//
+//	<root>/forecast/<series>                  rates as json
+//	<root>/forecast/<series>/slots            number of slots
+//	<root>/forecast/<series>/slots/<n>/start  slot start, end and value
+func (m *MQTT) publishForecast(fc any) {
+	val := reflect.Indirect(reflect.ValueOf(fc))
+	if val.Kind() != reflect.Struct {
+		return
+	}
+
+	if m.forecastSlots == nil {
+		m.forecastSlots = make(map[string]int)
+	}
+
+	for i := range val.NumField() {
+		rr, ok := val.Field(i).Interface().(api.Rates)
+		if !ok {
+			continue
+		}
+
+		f := val.Type().Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "" {
+			name = strings.ToLower(f.Name[:1]) + f.Name[1:]
+		}
+
+		m.publishForecastRates(fmt.Sprintf("%s/forecast/%s", m.root, name), rr)
+	}
+}
+
+// publishForecastRates publishes a forecast series and removes slots of a previous longer series
+func (m *MQTT) publishForecastRates(topic string, rr api.Rates) {
+	var payload any = rr
+	if m.Compact {
+		payload = rr.Compact()
+	}
+
+	if b, err := json.Marshal(payload); err == nil {
+		m.publishSingleValue(topic, true, string(b))
+	} else {
+		m.log.ERROR.Printf("marshal mqtt: %v", err)
+	}
+
+	m.publishSingleValue(topic+"/slots", true, len(rr))
+
+	for i, r := range rr {
+		slot := fmt.Sprintf("%s/slots/%d", topic, i+1)
+		m.publishSingleValue(slot+"/start", true, r.Start)
+		m.publishSingleValue(slot+"/end", true, r.End)
+		m.publishSingleValue(slot+"/value", true, r.Price)
+	}
+
+	for i := len(rr); i < m.forecastSlots[topic]; i++ {
+		slot := fmt.Sprintf("%s/slots/%d", topic, i+1)
+		for _, s := range []string{"start", "end", "value"} {
+			m.publishSingleValue(slot+"/"+s, true, nil)
+		}
+	}
+
+	m.forecastSlots[topic] = len(rr)
+}
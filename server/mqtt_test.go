@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/evcc-io/evcc/api"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -123,3 +124,55 @@ func (suite *mqttSuite) TestGrid() {
 	suite.Equal(append(topics, "test/currents/1", "test/currents/2", "test/currents/3"), suite.topics, "topics")
 	suite.Equal([]string{"0", "", "3", "", "", "1", "2", "3"}, suite.payloads, "payloads")
 }
+
+func (suite *mqttSuite) TestCompactRates() {
+	ts := time.Unix(1735689600, 0)
+	rr := api.Rates{{Start: ts, End: ts.Add(time.Hour), Price: 0.25}}
+
+	suite.MQTT.Compact = true
+	defer func() { suite.MQTT.Compact = false }()
+
+	suite.publish("test", false, rr)
+	suite.Require().Len(suite.topics, 1)
+	suite.Equal(`{"base":1735689600,"end":3600,"values":[[0,0.25]]}`, suite.payloads[0])
+}
+
+func (suite *mqttSuite) TestForecast() {
+	suite.MQTT.root = "evcc"
+	defer func() { suite.MQTT.root = "" }()
+
+	ts := time.Now().Truncate(time.Hour)
+	rr := api.Rates{
+		{Start: ts, End: ts.Add(time.Hour), Price: 0.25},
+		{Start: ts.Add(time.Hour), End: ts.Add(2 * time.Hour), Price: 0.3},
+	}
+
+	suite.MQTT.publishForecast(struct {
+		Grid   api.Rates   `json:"grid,omitempty"`
+		Planes []api.Rates `json:"planes,omitempty"`
+	}{
+		Grid: rr,
+	})
+
+	suite.Equal([]string{
+		"evcc/forecast/grid",
+		"evcc/forecast/grid/slots",
+		"evcc/forecast/grid/slots/1/start",
+		"evcc/forecast/grid/slots/1/end",
+		"evcc/forecast/grid/slots/1/value",
+		"evcc/forecast/grid/slots/2/start",
+		"evcc/forecast/grid/slots/2/end",
+		"evcc/forecast/grid/slots/2/value",
+	}, suite.topics)
+	suite.Equal([]string{"2", "0.25"}, []string{suite.payloads[1], suite.payloads[4]})
+
+	// shorter series removes stale slots
+	suite.MQTT.publishForecast(struct {
+		Grid api.Rates `json:"grid"`
+	}{
+		Grid: rr[:1],
+	})
+
+	suite.Len(suite.topics, 8)
+	suite.Equal([]string{"1", "", "", ""}, []string{suite.payloads[1], suite.payloads[5], suite.payloads[6], suite.payloads[7]})
+}
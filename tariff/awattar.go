@@ -10,6 +10,7 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/tariff/awattar"
+	"github.com/evcc-io/evcc/tariff/zone"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
 )
@@ -28,24 +29,28 @@ func init() {
 }
 
 func NewAwattarFromConfig(other map[string]interface{}) (api.Tariff, error) {
-	cc := struct {
+	var cc struct {
 		embed  `mapstructure:",squash"`
 		Region string
-	}{
-		Region: "DE",
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
 		return nil, err
 	}
 
+	log := util.NewLogger("awattar")
+
+	if cc.Region = zone.Select(log, "awattar", cc.Region); cc.Region == "" {
+		cc.Region = "DE"
+	}
+
 	if err := cc.init(); err != nil {
 		return nil, err
 	}
 
 	t := &Awattar{
 		embed: &cc.embed,
-		log:   util.NewLogger("awattar"),
+		log:   log,
 		uri:   fmt.Sprintf(awattar.RegionURI, strings.ToLower(cc.Region)),
 		data:  util.NewMonitor[api.Rates](2 * time.Hour),
 	}
@@ -0,0 +1,287 @@
+package tariff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+// Blend merges the forecasts of multiple solar forecast providers for the same pv system,
+// either as weighted average or by using the provider with the lowest recent forecast error
+type Blend struct {
+	log  *util.Logger
+	best bool
+
+	mu      sync.Mutex
+	sources []*blendSource
+}
+
+type blendSource struct {
+	api.Tariff
+	name    string
+	weight  float64
+	error   float64 // decaying mean absolute forecast error (W)
+	samples int
+}
+
+var (
+	_ api.Tariff             = (*Blend)(nil)
+	_ api.ProductionRecorder = (*Blend)(nil)
+	_ api.ForecastProviders  = (*Blend)(nil)
+)
+
+// blendDecay is applied per daytime production sample. Its time constant of 1000 samples
+// covers about 8 hours of production at the default 30s interval.
+const blendDecay = 0.999
+
+func init() {
+	registry.AddCtx("blend", NewBlendFromConfig)
+}
+
+// NewBlendFromConfig creates a blended solar forecast
+func NewBlendFromConfig(ctx context.Context, other map[string]interface{}) (api.Tariff, error) {
+	cc := struct {
+		Strategy  string // weighted or best
+		Forecasts []struct {
+			Name   string // optional, defaults to forecast-<n>
+			Weight float64
+			Tariff config.Typed
+		}
+	}{
+		Strategy: "weighted",
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	var best bool
+	switch strings.ToLower(cc.Strategy) {
+	case "weighted":
+	case "best":
+		best = true
+	default:
+		return nil, fmt.Errorf("invalid strategy: %s", cc.Strategy)
+	}
+
+	if len(cc.Forecasts) < 2 {
+		return nil, errors.New("at least two forecasts required")
+	}
+
+	var (
+		tt      []api.Tariff
+		weights []float64
+	)
+
+	for i, f := range cc.Forecasts {
+		if f.Tariff.Type == "" {
+			return nil, fmt.Errorf("forecast %d: missing tariff", i+1)
+		}
+
+		if f.Weight < 0 {
+			return nil, fmt.Errorf("forecast %d: invalid weight", i+1)
+		}
+
+		t, err := NewFromConfig(ctx, f.Tariff.Type, f.Tariff.Other)
+		if err != nil {
+			return nil, fmt.Errorf("forecast %d: %w", i+1, err)
+		}
+
+		tt = append(tt, t)
+		weights = append(weights, f.Weight)
+	}
+
+	res := NewBlend(tt, weights, best)
+	for i, f := range cc.Forecasts {
+		if f.Name != "" {
+			res.sources[i].name = f.Name
+		}
+	}
+
+	return res, nil
+}
+
+// NewBlend creates a blended solar forecast. Zero weights default to 1.
+func NewBlend(tariffs []api.Tariff, weights []float64, best bool) *Blend {
+	t := &Blend{
+		log:  util.NewLogger("blend"),
+		best: best,
+	}
+
+	for i, tt := range tariffs {
+		weight := 1.0
+		if i < len(weights) && weights[i] > 0 {
+			weight = weights[i]
+		}
+
+		t.sources = append(t.sources, &blendSource{
+			Tariff: tt,
+			name:   fmt.Sprintf("forecast-%d", i+1),
+			weight: weight,
+		})
+	}
+
+	return t
+}
+
+// RecordProduction implements the api.ProductionRecorder interface. It tracks each provider's forecast error
+// and passes the measured production on to self-learning providers.
+func (t *Blend) RecordProduction(ts time.Time, power float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, s := range t.sources {
+		if pr, ok := s.Tariff.(api.ProductionRecorder); ok {
+			pr.RecordProduction(ts, power)
+		}
+
+		r, err := At(s.Tariff, ts)
+		if err != nil {
+			continue
+		}
+
+		// night time is trivially forecasted
+		if r.Price <= 0 && power <= 0 {
+			continue
+		}
+
+		s.error = blendDecay*s.error + (1-blendDecay)*math.Abs(r.Price-max(0, power))
+		s.samples++
+	}
+}
+
+// Providers implements the api.ForecastProviders interface
+func (t *Blend) Providers() map[string]api.Tariff {
+	res := make(map[string]api.Tariff, len(t.sources))
+	for _, s := range t.sources {
+		res[s.name] = s.Tariff
+	}
+	return res
+}
+
+// weights returns the effective source weights. The best strategy selects the source with the lowest
+// forecast error, falling back to the configured weights until all sources have been evaluated.
+func (t *Blend) weights() []float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	res := make([]float64, len(t.sources))
+	for i, s := range t.sources {
+		res[i] = s.weight
+	}
+
+	if !t.best || slices.ContainsFunc(t.sources, func(s *blendSource) bool { return s.samples == 0 }) {
+		return res
+	}
+
+	idx := 0
+	for i, s := range t.sources {
+		if s.error < t.sources[idx].error {
+			idx = i
+		}
+	}
+
+	for i := range res {
+		if i != idx {
+			res[i] = 0
+		}
+	}
+
+	return res
+}
+
+// Rates implements the api.Tariff interface. Providers with differing period lengths are merged
+// on the finest period, unavailable providers are skipped.
+func (t *Blend) Rates() (api.Rates, error) {
+	weights := t.weights()
+
+	var (
+		rates []api.Rates
+		keys  []time.Time
+		errs  []error
+	)
+
+	for i, s := range t.sources {
+		rr, err := s.Rates()
+		if err != nil {
+			errs = append(errs, err)
+		}
+
+		if weights[i] == 0 {
+			rr = nil
+		}
+
+		rates = append(rates, rr)
+
+		for _, r := range rr {
+			if !slices.ContainsFunc(keys, r.Start.Equal) {
+				keys = append(keys, r.Start)
+			}
+		}
+	}
+
+	if len(keys) == 0 {
+		if err := errors.Join(errs...); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	for _, err := range errs {
+		t.log.DEBUG.Println(err)
+	}
+
+	slices.SortFunc(keys, func(a, b time.Time) int {
+		return a.Compare(b)
+	})
+
+	res := make(api.Rates, 0, len(keys))
+	for k, ts := range keys {
+		var sum, weight float64
+		var end time.Time
+
+		for i, rr := range rates {
+			r, err := rr.At(ts)
+			if err != nil {
+				continue
+			}
+
+			sum += weights[i] * r.Price
+			weight += weights[i]
+
+			if end.IsZero() || r.End.Before(end) {
+				end = r.End
+			}
+		}
+
+		if weight == 0 {
+			continue
+		}
+
+		if k+1 < len(keys) && keys[k+1].Before(end) {
+			end = keys[k+1]
+		}
+
+		res = append(res, api.Rate{
+			Start: ts,
+			End:   end,
+			Price: sum / weight,
+		})
+	}
+
+	return res, nil
+}
+
+// Type implements the api.Tariff interface
+func (t *Blend) Type() api.TariffType {
+	return api.TariffTypeSolar
+}
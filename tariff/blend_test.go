@@ -0,0 +1,51 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlendWeighted(t *testing.T) {
+	start := time.Now().Truncate(time.Hour)
+	rate := func(from, to time.Duration, val float64) api.Rate {
+		return api.Rate{Start: start.Add(from), End: start.Add(to), Price: val}
+	}
+
+	// hourly and half-hourly providers
+	a := &tariff{api.Rates{rate(0, time.Hour, 1000), rate(time.Hour, 2*time.Hour, 2000)}}
+	b := &tariff{api.Rates{rate(0, 30*time.Minute, 4000), rate(30*time.Minute, time.Hour, 1000)}}
+
+	rr, err := NewBlend([]api.Tariff{a, b}, []float64{2, 1}, false).Rates()
+	require.NoError(t, err)
+	assert.Equal(t, api.Rates{
+		rate(0, 30*time.Minute, 2000),
+		rate(30*time.Minute, time.Hour, 1000),
+		rate(time.Hour, 2*time.Hour, 2000),
+	}, rr)
+}
+
+func TestBlendBest(t *testing.T) {
+	start := time.Now().Truncate(time.Hour)
+	rate := func(val float64) api.Rate {
+		return api.Rate{Start: start, End: start.Add(time.Hour), Price: val}
+	}
+
+	a := &tariff{api.Rates{rate(1000)}}
+	b := &tariff{api.Rates{rate(3000)}}
+	blend := NewBlend([]api.Tariff{a, b}, nil, true)
+
+	// equal weights until evaluated
+	rr, err := blend.Rates()
+	require.NoError(t, err)
+	assert.Equal(t, api.Rates{rate(2000)}, rr)
+
+	blend.RecordProduction(start.Add(time.Minute), 2800)
+
+	rr, err = blend.Rates()
+	require.NoError(t, err)
+	assert.Equal(t, api.Rates{rate(3000)}, rr)
+}
@@ -0,0 +1,128 @@
+package tariff
+
+import (
+	"errors"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util"
+)
+
+// Cached normalizes the rates of a tariff, persists the latest rates and restores them while the tariff is unavailable
+type Cached struct {
+	api.Tariff
+	log *util.Logger
+	key string
+
+	mu      sync.Mutex
+	saved   api.Rates
+	invalid string
+}
+
+type cachedRates struct {
+	Type  api.TariffType `json:"type"`
+	Rates api.Rates      `json:"rates"`
+}
+
+// NewCached creates a tariff cache persisted under given name.
+// Optional interfaces of the cached tariff are accessible using As.
+func NewCached(name string, t api.Tariff) api.Tariff {
+	return &Cached{
+		Tariff: t,
+		log:    util.NewLogger(name),
+		key:    "tariff.cache." + name,
+	}
+}
+
+// Unwrap returns the cached tariff
+func (t *Cached) Unwrap() api.Tariff {
+	return t.Tariff
+}
+
+func (t *Cached) load() (cachedRates, error) {
+	var res cachedRates
+	if err := settings.Json(t.key, &res); err != nil {
+		return res, err
+	}
+
+	res.Rates = slices.DeleteFunc(res.Rates, func(r api.Rate) bool {
+		return !r.End.After(time.Now())
+	})
+
+	if len(res.Rates) == 0 {
+		return res, errors.New("cache expired")
+	}
+
+	return res, nil
+}
+
+func (t *Cached) save(rr api.Rates) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if slices.EqualFunc(rr, t.saved, func(a, b api.Rate) bool {
+		return a.Start.Equal(b.Start) && a.End.Equal(b.End) && a.Price == b.Price
+	}) {
+		return
+	}
+
+	if err := settings.SetJson(t.key, cachedRates{Type: t.Tariff.Type(), Rates: rr}); err == nil {
+		t.saved = slices.Clone(rr)
+	}
+}
+
+// validated logs validation errors once per change
+func (t *Cached) validated(err error) {
+	var s string
+	if err != nil {
+		s = err.Error()
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if s != t.invalid && s != "" {
+		t.log.WARN.Println("rates:", s)
+	}
+	t.invalid = s
+}
+
+// Rates implements the api.Tariff interface
+func (t *Cached) Rates() (api.Rates, error) {
+	rr, err := t.Tariff.Rates()
+	if err == nil {
+		var nerr error
+		rr, nerr = Normalize(rr)
+		t.validated(nerr)
+	}
+
+	if err == nil && len(rr) > 0 {
+		if t.Tariff.Type() != api.TariffTypePriceStatic {
+			t.save(rr)
+		}
+		return rr, nil
+	}
+
+	if cached, cerr := t.load(); cerr == nil {
+		return cached.Rates, nil
+	}
+
+	return rr, err
+}
+
+// Type implements the api.Tariff interface
+func (t *Cached) Type() api.TariffType {
+	if typ := t.Tariff.Type(); typ != 0 {
+		return typ
+	}
+
+	// tariff failed to initialize
+	if cached, err := t.load(); err == nil {
+		return cached.Type
+	}
+
+	return 0
+}
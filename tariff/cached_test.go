@@ -0,0 +1,64 @@
+package tariff
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/jinzhu/now"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cachedTestTariff struct {
+	rates api.Rates
+	typ   api.TariffType
+	err   error
+}
+
+func (t *cachedTestTariff) Rates() (api.Rates, error) {
+	return t.rates, t.err
+}
+
+func (t *cachedTestTariff) Type() api.TariffType {
+	return t.typ
+}
+
+func TestCached(t *testing.T) {
+	start := now.BeginningOfHour()
+	rr := api.Rates{
+		{Start: start.Add(-time.Hour), End: start, Price: 1},
+		{Start: start, End: start.Add(time.Hour), Price: 2},
+	}
+
+	trf := &cachedTestTariff{rates: rr, typ: api.TariffTypePriceForecast}
+	res, err := NewCached("test", trf).Rates()
+	require.NoError(t, err)
+	assert.Len(t, res, 2)
+
+	// restore after restart with failed tariff
+	c := NewCached("test", NewWrapper("foo", nil, errors.New("offline")))
+	assert.Equal(t, api.TariffTypePriceForecast, c.Type())
+
+	res, err = c.Rates()
+	require.NoError(t, err)
+	require.Len(t, res, 1, "expired rates removed")
+	assert.Equal(t, 2.0, res[0].Price)
+}
+
+func TestCachedPreservesInterfaces(t *testing.T) {
+	_, ok := As[api.RatesSetter](NewCached("external", new(External)))
+	assert.True(t, ok)
+
+	_, ok = As[api.ProductionRecorder](NewCached("learning", new(Learning)))
+	assert.True(t, ok)
+
+	// converted demand tariffs keep the demand surcharge conversion
+	d := NewConverted(NewDemand("demand", &cachedTestTariff{typ: api.TariffTypePriceForecast}, 10, time.Hour), func() (float64, error) { return 0.5, nil })
+	_, ok = As[api.DemandTariff](NewCached("converted", d))
+	assert.True(t, ok)
+
+	_, ok = As[api.TieredTariff](NewCached("converted", d))
+	assert.False(t, ok)
+}
@@ -12,6 +12,8 @@ type combined struct {
 	tariffs []api.Tariff
 }
 
+var _ api.ProductionRecorder = (*combined)(nil)
+
 func NewCombined(tariffs []api.Tariff) api.Tariff {
 	return &combined{
 		tariffs: tariffs,
@@ -65,6 +67,58 @@ func (t *combined) Rates() (api.Rates, error) {
 	return res, nil
 }
 
+// Planes implements the api.SolarPlanes interface
+func (t *combined) Planes() []api.Tariff {
+	return t.tariffs
+}
+
 func (t *combined) Type() api.TariffType {
 	return api.TariffTypeSolar
 }
+
+// RecordProduction implements the api.ProductionRecorder interface.
+// Measured production is split across the planes by their forecast share.
+// Planes without forecast like untrained learning planes without kwp receive the mean share to start training.
+func (t *combined) RecordProduction(ts time.Time, power float64) {
+	forecast := make([]float64, len(t.tariffs))
+
+	var total float64
+	var known int
+	var unknown []int
+
+	for i, t := range t.tariffs {
+		r, err := At(t, ts)
+		if err != nil {
+			if _, ok := t.(api.ProductionRecorder); ok {
+				unknown = append(unknown, i)
+			}
+			continue
+		}
+
+		forecast[i] = max(0, r.Price)
+		total += forecast[i]
+		known++
+	}
+
+	if len(unknown) > 0 {
+		share := 1.0
+		if total > 0 {
+			share = total / float64(known)
+		}
+
+		for _, i := range unknown {
+			forecast[i] = share
+			total += share
+		}
+	}
+
+	if total == 0 {
+		return
+	}
+
+	for i, t := range t.tariffs {
+		if pr, ok := t.(api.ProductionRecorder); ok && forecast[i] > 0 {
+			pr.RecordProduction(ts, power*forecast[i]/total)
+		}
+	}
+}
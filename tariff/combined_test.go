@@ -40,3 +40,55 @@ func TestCombined(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, api.Rates{rate(1, 1), rate(2, 4), rate(3, 3)}, rr)
 }
+
+type recordingTariff struct {
+	tariff
+	power float64
+}
+
+func (t *recordingTariff) RecordProduction(_ time.Time, power float64) {
+	t.power += power
+}
+
+func TestCombinedRecordProduction(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	rates := func(val float64) api.Rates {
+		return api.Rates{{Start: now, End: now.Add(time.Hour), Price: val}}
+	}
+
+	a := &recordingTariff{tariff: tariff{rates(3000)}}
+	b := &recordingTariff{tariff: tariff{rates(1000)}}
+	c := NewCombined([]api.Tariff{a, b})
+
+	pr, ok := c.(api.ProductionRecorder)
+	require.True(t, ok)
+
+	pr.RecordProduction(now.Add(time.Minute), 2000)
+	assert.Equal(t, 1500.0, a.power)
+	assert.Equal(t, 500.0, b.power)
+}
+
+func TestCombinedRecordProductionUntrained(t *testing.T) {
+	now := time.Now().Truncate(time.Hour)
+	rates := func(val float64) api.Rates {
+		return api.Rates{{Start: now, End: now.Add(time.Hour), Price: val}}
+	}
+
+	// untrained planes without forecast receive the mean share
+	a := &recordingTariff{tariff: tariff{rates(2000)}}
+	b := &recordingTariff{}
+	c := NewCombined([]api.Tariff{a, b})
+
+	c.(api.ProductionRecorder).RecordProduction(now.Add(time.Minute), 2000)
+	assert.Equal(t, 1000.0, a.power)
+	assert.Equal(t, 1000.0, b.power)
+
+	// split evenly if no plane has a forecast
+	a = &recordingTariff{}
+	b = &recordingTariff{}
+	c = NewCombined([]api.Tariff{a, b})
+
+	c.(api.ProductionRecorder).RecordProduction(now.Add(time.Minute), 2000)
+	assert.Equal(t, 1000.0, a.power)
+	assert.Equal(t, 1000.0, b.power)
+}
@@ -0,0 +1,173 @@
+package tariff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+// Contracts switches between electricity contracts by their validity period, e.g. on a supplier change
+type Contracts struct {
+	clock     clock.Clock
+	contracts []contract
+}
+
+// contract is a tariff valid from start until end (exclusive), zero end if open-ended
+type contract struct {
+	api.Tariff
+	from, until time.Time
+}
+
+var _ api.Tariff = (*Contracts)(nil)
+
+func init() {
+	registry.AddCtx("contracts", NewContractsFromConfig)
+}
+
+// NewContractsFromConfig creates a tariff switching between contracts
+func NewContractsFromConfig(ctx context.Context, other map[string]interface{}) (api.Tariff, error) {
+	var cc struct {
+		Contracts []struct {
+			From   string // first day of validity
+			Until  string // optional last day of validity, defaults to start of next contract
+			Tariff config.Typed
+		}
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if len(cc.Contracts) == 0 {
+		return nil, errors.New("missing contracts")
+	}
+
+	var contracts []contract
+
+	for i, c := range cc.Contracts {
+		if c.Tariff.Type == "" {
+			return nil, fmt.Errorf("contract %d: missing tariff", i+1)
+		}
+
+		var (
+			res contract
+			err error
+		)
+
+		if res.from, err = time.ParseInLocation(time.DateOnly, c.From, time.Local); err != nil {
+			return nil, fmt.Errorf("contract %d: from: %w", i+1, err)
+		}
+
+		if c.Until != "" {
+			until, err := time.ParseInLocation(time.DateOnly, c.Until, time.Local)
+			if err != nil {
+				return nil, fmt.Errorf("contract %d: until: %w", i+1, err)
+			}
+			res.until = until.AddDate(0, 0, 1)
+		}
+
+		if res.Tariff, err = NewFromConfig(ctx, c.Tariff.Type, c.Tariff.Other); err != nil {
+			return nil, fmt.Errorf("contract %d: %w", i+1, err)
+		}
+
+		contracts = append(contracts, res)
+	}
+
+	return newContracts(contracts)
+}
+
+// newContracts creates a tariff from contracts of non-overlapping validity
+func newContracts(contracts []contract) (*Contracts, error) {
+	contracts = slices.Clone(contracts)
+	slices.SortFunc(contracts, func(a, b contract) int {
+		return a.from.Compare(b.from)
+	})
+
+	for i := range contracts {
+		c := &contracts[i]
+
+		if !c.until.IsZero() && !c.until.After(c.from) {
+			return nil, fmt.Errorf("contract from %s: invalid validity", c.from.Format(time.DateOnly))
+		}
+
+		if i+1 < len(contracts) {
+			next := contracts[i+1].from
+			if next.Equal(c.from) || !c.until.IsZero() && c.until.After(next) {
+				return nil, fmt.Errorf("contract from %s: overlaps next contract", c.from.Format(time.DateOnly))
+			}
+
+			// open-ended contracts are superseded by the next contract
+			if c.until.IsZero() {
+				c.until = next
+			}
+		}
+	}
+
+	return &Contracts{
+		clock:     clock.New(),
+		contracts: contracts,
+	}, nil
+}
+
+// Rates implements the api.Tariff interface. Rates are clipped to the validity of their contract.
+func (t *Contracts) Rates() (api.Rates, error) {
+	ts := t.clock.Now()
+
+	var res api.Rates
+	for _, c := range t.contracts {
+		// expired
+		if !c.until.IsZero() && !c.until.After(ts) {
+			continue
+		}
+
+		rr, err := c.Rates()
+		if err != nil {
+			// future contracts may not provide prices yet
+			if c.from.After(ts) {
+				continue
+			}
+			return nil, err
+		}
+
+		for _, r := range rr {
+			if !r.End.After(c.from) || !c.until.IsZero() && !r.Start.Before(c.until) {
+				continue
+			}
+
+			if r.Start.Before(c.from) {
+				r.Start = c.from
+			}
+			if !c.until.IsZero() && r.End.After(c.until) {
+				r.End = c.until
+			}
+
+			res = append(res, r)
+		}
+	}
+
+	res.Sort()
+
+	return res, nil
+}
+
+// Type implements the api.Tariff interface
+func (t *Contracts) Type() api.TariffType {
+	res := api.TariffTypePriceStatic
+	for _, c := range t.contracts {
+		res = max(res, c.Type())
+	}
+
+	// prices change with the contract
+	if res == api.TariffTypePriceStatic && len(t.contracts) > 1 {
+		res = api.TariffTypePriceDynamic
+	}
+
+	return res
+}
@@ -0,0 +1,65 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestContracts(t *testing.T) {
+	day := time.Date(2025, 6, 30, 0, 0, 0, 0, time.Local)
+
+	prices := func(price float64) ratesTariff {
+		var res ratesTariff
+		for i := range 48 {
+			ts := day.Add(time.Duration(i) * time.Hour)
+			res = append(res, api.Rate{Start: ts, End: ts.Add(time.Hour), Price: price})
+		}
+		return res
+	}
+
+	c, err := newContracts([]contract{
+		{Tariff: prices(0.2), from: day.AddDate(0, 0, 1)},
+		{Tariff: prices(0.3), from: day.AddDate(0, -6, 0)},
+	})
+	require.NoError(t, err)
+
+	clock := clock.NewMock()
+	clock.Set(day.Add(12 * time.Hour))
+	c.clock = clock
+
+	rr, err := c.Rates()
+	require.NoError(t, err)
+	require.Len(t, rr, 48)
+
+	// contract switch at midnight
+	r, err := rr.At(day.Add(23 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0.3, r.Price)
+
+	r, err = rr.At(day.Add(24 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0.2, r.Price)
+
+	// expired contract
+	clock.Set(day.Add(36 * time.Hour))
+	rr, err = c.Rates()
+	require.NoError(t, err)
+	assert.Len(t, rr, 24)
+
+	assert.Equal(t, api.TariffTypePriceForecast, c.Type())
+}
+
+func TestContractsOverlap(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local)
+
+	_, err := newContracts([]contract{
+		{Tariff: ratesTariff{}, from: from, until: from.AddDate(0, 2, 0)},
+		{Tariff: ratesTariff{}, from: from.AddDate(0, 1, 0)},
+	})
+	assert.Error(t, err)
+}
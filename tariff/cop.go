@@ -0,0 +1,55 @@
+package tariff
+
+import (
+	"slices"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// CopAdjusted converts electricity rates of the underlying tariff into heat rates
+// by dividing through the temperature-dependent heat pump efficiency
+type CopAdjusted struct {
+	tariff, temperature api.Tariff
+	cop                 func(float64) float64
+}
+
+var _ api.Tariff = (*CopAdjusted)(nil)
+
+// NewCopAdjusted creates a cop adjusted tariff
+func NewCopAdjusted(tariff, temperature api.Tariff, cop func(float64) float64) api.Tariff {
+	return &CopAdjusted{
+		tariff:      tariff,
+		temperature: temperature,
+		cop:         cop,
+	}
+}
+
+// Rates implements the api.Tariff interface
+func (t *CopAdjusted) Rates() (api.Rates, error) {
+	rr, err := t.tariff.Rates()
+	if err != nil || len(rr) == 0 {
+		return rr, err
+	}
+
+	temps, err := t.temperature.Rates()
+	if err != nil || len(temps) == 0 {
+		return rr, nil
+	}
+
+	res := slices.Clone(rr)
+	for i, r := range res {
+		temp, err := temps.At(r.Start)
+		if err != nil {
+			continue
+		}
+
+		res[i].Price /= t.cop(temp.Price)
+	}
+
+	return res, nil
+}
+
+// Type implements the api.Tariff interface
+func (t *CopAdjusted) Type() api.TariffType {
+	return t.tariff.Type()
+}
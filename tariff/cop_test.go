@@ -0,0 +1,40 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/core/loadpoint"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ratesTariff api.Rates
+
+func (t ratesTariff) Rates() (api.Rates, error) { return api.Rates(t), nil }
+
+func (t ratesTariff) Type() api.TariffType { return api.TariffTypePriceForecast }
+
+func TestCopAdjusted(t *testing.T) {
+	start := time.Now().Truncate(time.Hour)
+	rate := func(i int, price float64) api.Rate {
+		return api.Rate{
+			Start: start.Add(time.Duration(i) * time.Hour),
+			End:   start.Add(time.Duration(i+1) * time.Hour),
+			Price: price,
+		}
+	}
+
+	prices := ratesTariff{rate(0, 0.3), rate(1, 0.3), rate(2, 0.3)}
+	temps := ratesTariff{rate(0, -3), rate(1, 7)}
+
+	cop := loadpoint.CopConfig{Nominal: 3, Reference: 7, Slope: 0.1}
+	rr, err := NewCopAdjusted(prices, temps, cop.Cop).Rates()
+	require.NoError(t, err)
+
+	assert.InDelta(t, 0.15, rr[0].Price, 1e-6, "cold slot")
+	assert.InDelta(t, 0.1, rr[1].Price, 1e-6, "nominal slot")
+	assert.Equal(t, 0.3, rr[2].Price, "no temperature")
+	assert.Equal(t, 0.3, prices[0].Price, "source unchanged")
+}
@@ -0,0 +1,228 @@
+package tariff
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"golang.org/x/text/currency"
+)
+
+// Converted converts the prices of a tariff quoted in a foreign currency
+type Converted struct {
+	api.Tariff
+	rate func() (float64, error)
+}
+
+// NewConverted creates a currency converting tariff.
+// Optional interfaces not affected by conversion are accessible using As.
+func NewConverted(t api.Tariff, rate func() (float64, error)) api.Tariff {
+	c := &Converted{
+		Tariff: t,
+		rate:   rate,
+	}
+
+	dt, isDemand := t.(api.DemandTariff)
+	pc, isComposer := t.(api.PriceComposer)
+
+	switch {
+	case isDemand && isComposer:
+		return &struct {
+			*Converted
+			*convertedDemand
+			*convertedComposer
+		}{c, &convertedDemand{dt, rate}, &convertedComposer{pc, rate}}
+	case isDemand:
+		return &struct {
+			*Converted
+			*convertedDemand
+		}{c, &convertedDemand{dt, rate}}
+	case isComposer:
+		return &struct {
+			*Converted
+			*convertedComposer
+		}{c, &convertedComposer{pc, rate}}
+	}
+
+	return c
+}
+
+// Unwrap returns the converted tariff
+func (t *Converted) Unwrap() api.Tariff {
+	return t.Tariff
+}
+
+// convertedDemand converts the demand surcharge of a demand tariff
+type convertedDemand struct {
+	api.DemandTariff
+	rate func() (float64, error)
+}
+
+// DemandSurcharge implements the api.DemandTariff interface
+func (t *convertedDemand) DemandSurcharge(power float64) float64 {
+	rate, err := t.rate()
+	if err != nil {
+		return 0
+	}
+	return t.DemandTariff.DemandSurcharge(power) * rate
+}
+
+// convertedComposer converts the raw price of a composed tariff
+type convertedComposer struct {
+	api.PriceComposer
+	rate func() (float64, error)
+}
+
+// RawPrice implements the api.PriceComposer interface
+func (t *convertedComposer) RawPrice(ts time.Time) (float64, error) {
+	price, err := t.PriceComposer.RawPrice(ts)
+	if err != nil {
+		return 0, err
+	}
+
+	rate, err := t.rate()
+	if err != nil {
+		return 0, fmt.Errorf("exchange rate: %w", err)
+	}
+
+	return price * rate, nil
+}
+
+// Rates implements the api.Tariff interface
+func (t *Converted) Rates() (api.Rates, error) {
+	rr, err := t.Tariff.Rates()
+	if err != nil {
+		return nil, err
+	}
+
+	rate, err := t.rate()
+	if err != nil {
+		return nil, fmt.Errorf("exchange rate: %w", err)
+	}
+
+	res := make(api.Rates, 0, len(rr))
+	for _, r := range rr {
+		r.Price *= rate
+		res = append(res, r)
+	}
+
+	return res, nil
+}
+
+// ExchangeRate returns the conversion rate between currencies. If rate is not configured,
+// the daily ECB reference rates are used.
+func ExchangeRate(from, to currency.Unit, rate float64) func() (float64, error) {
+	return exchangeRate(from, to, rate, ecb.rates)
+}
+
+// exchangeRate returns the conversion rate between currencies using the given EUR reference rates
+func exchangeRate(from, to currency.Unit, rate float64, reference func() (map[string]float64, error)) func() (float64, error) {
+	if from == to {
+		rate = 1
+	}
+
+	if rate > 0 {
+		return func() (float64, error) {
+			return rate, nil
+		}
+	}
+
+	return func() (float64, error) {
+		rates, err := reference()
+		if err != nil {
+			return 0, err
+		}
+
+		// ECB rates are quoted against EUR
+		quote := func(cur currency.Unit) (float64, error) {
+			if cur == currency.EUR {
+				return 1, nil
+			}
+			if r, ok := rates[cur.String()]; ok && r > 0 {
+				return r, nil
+			}
+			return 0, fmt.Errorf("unknown currency: %s", cur)
+		}
+
+		f, err := quote(from)
+		if err != nil {
+			return 0, err
+		}
+
+		t, err := quote(to)
+		if err != nil {
+			return 0, err
+		}
+
+		return t / f, nil
+	}
+}
+
+const ecbUri = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+
+type ecbCache struct {
+	mu      sync.Mutex
+	updated time.Time
+	data    map[string]float64
+}
+
+var ecb ecbCache
+
+func (c *ecbCache) rates() (map[string]float64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if time.Since(c.updated) < 12*time.Hour {
+		return c.data, nil
+	}
+
+	b, err := request.NewHelper(util.NewLogger("ecb")).GetBody(ecbUri)
+	if err == nil {
+		var res map[string]float64
+		if res, err = parseEcbRates(b); err == nil {
+			c.data = res
+			c.updated = time.Now()
+		}
+	}
+
+	// keep using outdated rates during outages
+	if err != nil && c.data != nil {
+		return c.data, nil
+	}
+
+	return c.data, err
+}
+
+func parseEcbRates(b []byte) (map[string]float64, error) {
+	var res struct {
+		Cube struct {
+			Cube struct {
+				Cube []struct {
+					Currency string  `xml:"currency,attr"`
+					Rate     float64 `xml:"rate,attr"`
+				} `xml:"Cube"`
+			} `xml:"Cube"`
+		} `xml:"Cube"`
+	}
+
+	if err := xml.Unmarshal(b, &res); err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]float64)
+	for _, r := range res.Cube.Cube.Cube {
+		data[strings.ToUpper(r.Currency)] = r.Rate
+	}
+
+	if len(data) == 0 {
+		return nil, errors.New("no exchange rates")
+	}
+
+	return data, nil
+}
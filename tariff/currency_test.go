@@ -0,0 +1,83 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/currency"
+)
+
+func TestParseEcbRates(t *testing.T) {
+	b := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01" xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+	<gesmes:subject>Reference rates</gesmes:subject>
+	<Cube>
+		<Cube time="2025-01-02">
+			<Cube currency="USD" rate="1.0321"/>
+			<Cube currency="DKK" rate="7.4575"/>
+			<Cube currency="CHF" rate="0.9386"/>
+		</Cube>
+	</Cube>
+</gesmes:Envelope>`)
+
+	res, err := parseEcbRates(b)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]float64{"USD": 1.0321, "DKK": 7.4575, "CHF": 0.9386}, res)
+}
+
+func TestExchangeRate(t *testing.T) {
+	rate, err := ExchangeRate(currency.EUR, currency.EUR, 0)()
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, rate)
+
+	rate, err = ExchangeRate(currency.DKK, currency.EUR, 0.134)()
+	require.NoError(t, err)
+	assert.Equal(t, 0.134, rate)
+
+	reference := func() (map[string]float64, error) {
+		return map[string]float64{"DKK": 7.5, "CHF": 0.9}, nil
+	}
+
+	rate, err = exchangeRate(currency.DKK, currency.CHF, 0, reference)()
+	require.NoError(t, err)
+	assert.InDelta(t, 0.12, rate, 1e-9)
+
+	_, err = exchangeRate(currency.JPY, currency.EUR, 0, reference)()
+	assert.Error(t, err)
+}
+
+func TestConverted(t *testing.T) {
+	ts := time.Now()
+	trf := &cachedTestTariff{
+		rates: api.Rates{{Start: ts, End: ts.Add(time.Hour), Price: 2}},
+		typ:   api.TariffTypePriceForecast,
+	}
+
+	rr, err := NewConverted(trf, func() (float64, error) { return 0.5, nil }).Rates()
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, rr[0].Price)
+	assert.Equal(t, 2.0, trf.rates[0].Price, "source rates unchanged")
+}
+
+func TestConvertedRawPrice(t *testing.T) {
+	e := &embed{Charges: 1}
+	require.NoError(t, e.init())
+
+	ts := time.Now()
+	e.totalPrice(2, ts)
+
+	trf := &struct {
+		*cachedTestTariff
+		*embed
+	}{&cachedTestTariff{typ: api.TariffTypePriceForecast}, e}
+
+	pc, ok := NewConverted(trf, func() (float64, error) { return 0.5, nil }).(api.PriceComposer)
+	require.True(t, ok)
+
+	raw, err := pc.RawPrice(ts)
+	require.NoError(t, err)
+	assert.Equal(t, 1.0, raw)
+}
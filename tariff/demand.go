@@ -0,0 +1,191 @@
+package tariff
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+	"github.com/jinzhu/now"
+)
+
+// Demand adds demand charges to an energy tariff. Demand charges are billed per kW of the highest
+// average grid import power over a measurement interval within the monthly billing period.
+type Demand struct {
+	api.Tariff
+	log      *util.Logger
+	clock    clock.Clock
+	price    float64       // price per kW of peak demand
+	interval time.Duration // demand measurement interval
+	key      string
+
+	mu      sync.Mutex
+	state   demandState
+	slot    time.Time // current interval start
+	partial bool      // current interval started before first measurement
+	energy  float64   // energy imported during current interval (Wh)
+	updated time.Time
+}
+
+type demandState struct {
+	Period time.Time `json:"period"` // billing period start
+	Peak   float64   `json:"peak"`   // peak average demand (W)
+}
+
+var (
+	_ api.Tariff       = (*Demand)(nil)
+	_ api.DemandTariff = (*Demand)(nil)
+)
+
+func init() {
+	registry.AddCtx("demand", NewDemandFromConfig)
+}
+
+// NewDemandFromConfig creates a demand charge tariff wrapping an energy tariff
+func NewDemandFromConfig(ctx context.Context, other map[string]interface{}) (api.Tariff, error) {
+	cc := struct {
+		Tariff   config.Typed
+		Price    float64       // price per kW of peak demand
+		Interval time.Duration // measurement interval
+	}{
+		Interval: 15 * time.Minute,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Tariff.Type == "" {
+		return nil, errors.New("missing tariff")
+	}
+
+	if cc.Interval <= 0 {
+		return nil, errors.New("invalid interval")
+	}
+
+	energy, err := NewFromConfig(ctx, cc.Tariff.Type, cc.Tariff.Other)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewDemand(contextName(ctx, "demand"), energy, cc.Price, cc.Interval), nil
+}
+
+// NewDemand creates a demand charge tariff restoring the current billing period's peak persisted under given name
+func NewDemand(name string, energy api.Tariff, price float64, interval time.Duration) *Demand {
+	t := &Demand{
+		Tariff:   energy,
+		log:      util.NewLogger("demand"),
+		clock:    clock.New(),
+		price:    price,
+		interval: interval,
+		key:      "tariff.demand." + name,
+	}
+
+	if err := settings.Json(t.key, &t.state); err != nil && !errors.Is(err, settings.ErrNotFound) {
+		t.log.WARN.Println("peak:", err)
+	}
+
+	return t
+}
+
+// period resets the peak once a new billing period starts
+func (t *Demand) period(ts time.Time) {
+	if period := now.With(ts).BeginningOfMonth(); !period.Equal(t.state.Period) {
+		t.state = demandState{Period: period}
+	}
+}
+
+// RecordDemand implements the api.DemandTariff interface
+func (t *Demand) RecordDemand(ts time.Time, power float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.period(ts)
+
+	// partial interval at startup is not accounted
+	if t.updated.IsZero() {
+		t.slot = ts.Truncate(t.interval)
+		t.partial = !t.slot.Equal(ts)
+		t.updated = ts
+		return
+	}
+
+	// split elapsed time at interval boundaries
+	for from := t.updated; from.Before(ts); {
+		end := t.slot.Add(t.interval)
+		if ts.Before(end) {
+			t.energy += max(0, power) * ts.Sub(from).Hours()
+			break
+		}
+
+		t.energy += max(0, power) * end.Sub(from).Hours()
+		if !t.partial {
+			t.peak(t.energy / t.interval.Hours())
+		}
+
+		t.slot = end
+		t.partial = false
+		t.energy = 0
+		from = end
+	}
+
+	t.updated = ts
+}
+
+func (t *Demand) peak(demand float64) {
+	if demand <= t.state.Peak {
+		return
+	}
+
+	t.log.DEBUG.Printf("new peak: %.0fW", demand)
+	t.state.Peak = demand
+
+	if err := settings.SetJson(t.key, t.state); err != nil {
+		t.log.ERROR.Println("peak:", err)
+	}
+}
+
+// DemandPeak implements the api.DemandTariff interface
+func (t *Demand) DemandPeak() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.period(t.clock.Now())
+
+	return t.state.Peak
+}
+
+// DemandSurcharge implements the api.DemandTariff interface. The cost of raising the peak by
+// continuing to import the given power until the end of the interval is distributed across
+// the energy imported during the interval.
+func (t *Demand) DemandSurcharge(power float64) float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ts := t.clock.Now()
+	t.period(ts)
+
+	if power <= 0 || t.price <= 0 {
+		return 0
+	}
+
+	energy := max(0, power) * t.interval.Hours()
+	if slot := ts.Truncate(t.interval); slot.Equal(t.slot) {
+		remaining := slot.Add(t.interval).Sub(ts)
+		energy = t.energy + power*remaining.Hours()
+	}
+
+	demand := energy / t.interval.Hours()
+	if demand <= t.state.Peak {
+		return 0
+	}
+
+	// cost of new peak per kWh imported
+	return t.price * (demand - t.state.Peak) / energy
+}
@@ -0,0 +1,61 @@
+package tariff
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDemand(t *testing.T) {
+	clock := clock.NewMock()
+	clock.Set(time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local))
+
+	d := &Demand{
+		log:      util.NewLogger("foo"),
+		clock:    clock,
+		price:    10,
+		interval: 15 * time.Minute,
+		key:      "tariff.demand.test",
+	}
+
+	// partial interval at startup is ignored
+	d.RecordDemand(clock.Now().Add(-5*time.Minute), 5000)
+	d.RecordDemand(clock.Now(), 5000)
+	assert.Equal(t, 0.0, d.DemandPeak())
+
+	// full interval at 4kW
+	for range 15 {
+		clock.Add(time.Minute)
+		d.RecordDemand(clock.Now(), 4000)
+	}
+	clock.Add(time.Minute)
+	d.RecordDemand(clock.Now(), 1000)
+	assert.InDelta(t, 4000, d.DemandPeak(), 1e-6)
+
+	// below peak
+	assert.Equal(t, 0.0, d.DemandSurcharge(3000))
+
+	// remaining 14 minutes at 8kW raise the interval average above peak
+	surcharge := d.DemandSurcharge(8000)
+	energy := 1000.0/60 + 8000*14.0/60
+	assert.InDelta(t, 10*(energy/0.25-4000)/energy, surcharge, 1e-6)
+
+	// new billing period
+	clock.Set(time.Date(2026, 4, 1, 0, 0, 0, 0, time.Local))
+	assert.Equal(t, 0.0, d.DemandPeak())
+}
+
+func TestDemandKey(t *testing.T) {
+	ctx := WithName(context.TODO(), "grid")
+
+	d, err := NewDemandFromConfig(ctx, map[string]any{
+		"tariff": map[string]any{"type": "fixed", "price": 0.3},
+		"price":  10,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "tariff.demand.grid", d.(*Demand).key)
+}
@@ -79,7 +79,7 @@ func NewEdfTempoFromConfig(other map[string]interface{}) (api.Tariff, error) {
 
 	t.Client.Transport = &oauth2.Transport{
 		Base:   t.Client.Transport,
-		Source: oauth.RefreshTokenSource(new(oauth2.Token), t),
+		Source: oauth.Monitor("tariff.edf-tempo", "EDF Tempo", oauth.RefreshTokenSource(new(oauth2.Token), t)),
 	}
 
 	done := make(chan error)
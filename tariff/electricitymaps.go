@@ -102,13 +102,12 @@ func (t *ElectricityMaps) run(done chan error) {
 		for _, r := range res.Forecast {
 			ar := api.Rate{
 				Start: r.Datetime.Local(),
-				End:   r.Datetime.Add(time.Hour).Local(),
 				Price: r.CarbonIntensity,
 			}
 			data = append(data, ar)
 		}
 
-		mergeRates(t.data, data)
+		mergeRates(t.data, slotEnds(data))
 		once.Do(func() { close(done) })
 	}
 }
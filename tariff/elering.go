@@ -12,6 +12,7 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/tariff/elering"
+	"github.com/evcc-io/evcc/tariff/zone"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
 )
@@ -39,7 +40,9 @@ func NewEleringFromConfig(other map[string]interface{}) (api.Tariff, error) {
 		return nil, err
 	}
 
-	if cc.Region == "" {
+	log := util.NewLogger("elering")
+
+	if cc.Region = zone.Select(log, "elering", cc.Region); cc.Region == "" {
 		return nil, errors.New("missing region")
 	}
 
@@ -49,7 +52,7 @@ func NewEleringFromConfig(other map[string]interface{}) (api.Tariff, error) {
 
 	t := &Elering{
 		embed:  &cc.embed,
-		log:    util.NewLogger("elering"),
+		log:    log,
 		region: strings.ToLower(cc.Region),
 		data:   util.NewMonitor[api.Rates](2 * time.Hour),
 	}
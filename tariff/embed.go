@@ -3,20 +3,42 @@ package tariff
 import (
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
+	"sync"
 	"time"
 
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/plugin/golang/stdlib"
 	"github.com/traefik/yaegi/interp"
 )
 
 type embed struct {
-	Charges float64 `mapstructure:"charges"`
-	Tax     float64 `mapstructure:"tax"`
-	Formula string  `mapstructure:"formula"`
+	Charges    float64     `mapstructure:"charges"`
+	Tax        float64     `mapstructure:"tax"`
+	Surcharges []surcharge `mapstructure:"surcharges"`
+	Formula    string      `mapstructure:"formula"`
 
 	calc func(float64, time.Time) (float64, error)
+
+	mu    sync.Mutex
+	clock clock.Clock       // defaults to wall clock
+	raw   map[int64]float64 // raw price by rate start
+}
+
+// surcharge is a named price component like levies or supplier margin
+type surcharge struct {
+	Name    string  `mapstructure:"name"`
+	Charges float64 `mapstructure:"charges"` // absolute price per kWh
+	Percent float64 `mapstructure:"percent"` // relative to raw price (%)
 }
 
+const (
+	rawPriceRetention = 24 * time.Hour // raw prices of past rates kept for lookup
+	rawPriceSlot      = time.Hour      // duration of the last rate if not known from its predecessor
+)
+
 func (t *embed) init() (err error) {
 	defer func() {
 		if r := recover(); r != nil && err == nil {
@@ -39,9 +61,10 @@ func (t *embed) init() (err error) {
 		var (
 			price float64 = %f
 			charges float64 = %f
+			surcharges float64 = %f
 			tax float64 = %f
 			ts = time.Unix(%d, 0).Local()
-		)`, price, t.Charges, t.Tax, ts.Unix())); err != nil {
+		)`, price, t.Charges, t.surcharges(price), t.Tax, ts.Unix())); err != nil {
 			return 0, err
 		}
 
@@ -63,10 +86,79 @@ func (t *embed) init() (err error) {
 	return err
 }
 
+// surcharges returns the sum of all surcharges at given raw price
+func (t *embed) surcharges(price float64) float64 {
+	var res float64
+	for _, s := range t.Surcharges {
+		res += s.Charges + price*s.Percent/100
+	}
+	return res
+}
+
+// totalPrice composes the price from the raw price of the rate starting at ts
 func (t *embed) totalPrice(price float64, ts time.Time) float64 {
+	t.recordRawPrice(price, ts)
+
 	if t.calc != nil {
 		res, _ := t.calc(price, ts)
 		return res
 	}
-	return (price + t.Charges) * (1 + t.Tax)
+	return (price + t.Charges + t.surcharges(price)) * (1 + t.Tax)
+}
+
+func (t *embed) recordRawPrice(price float64, ts time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.raw == nil {
+		t.raw = make(map[int64]float64)
+	}
+	if t.clock == nil {
+		t.clock = clock.New()
+	}
+
+	t.raw[ts.Unix()] = price
+
+	// retention is measured from now since future rates are recorded ahead of time
+	expired := t.clock.Now().Add(-rawPriceRetention).Unix()
+	maps.DeleteFunc(t.raw, func(k int64, _ float64) bool {
+		return k < expired
+	})
+}
+
+// RawPrice implements the api.PriceComposer interface
+func (t *embed) RawPrice(ts time.Time) (float64, error) {
+	// tariffs without price composition
+	if t == nil {
+		return 0, api.ErrNotAvailable
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	starts := slices.Sorted(maps.Keys(t.raw))
+
+	// latest rate started before ts
+	idx, found := slices.BinarySearch(starts, ts.Unix())
+	if !found {
+		idx--
+	}
+
+	if idx < 0 {
+		return 0, api.ErrNotAvailable
+	}
+
+	// the last rate is assumed to last as long as its predecessor
+	if idx == len(starts)-1 {
+		slot := int64(rawPriceSlot.Seconds())
+		if idx > 0 {
+			slot = starts[idx] - starts[idx-1]
+		}
+
+		if ts.Unix() >= starts[idx]+slot {
+			return 0, api.ErrNotAvailable
+		}
+	}
+
+	return t.raw[starts[idx]], nil
 }
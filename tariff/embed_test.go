@@ -0,0 +1,92 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEmbedSurcharges(t *testing.T) {
+	e := &embed{
+		Charges: 0.1,
+		Tax:     0.2,
+		Surcharges: []surcharge{
+			{Name: "levies", Charges: 0.05},
+			{Name: "margin", Charges: 0.01, Percent: 10},
+		},
+	}
+	require.NoError(t, e.init())
+
+	ts := time.Date(2025, 1, 1, 12, 0, 0, 0, time.Local)
+
+	// (0.2 + 0.1 + 0.05 + 0.01 + 0.02) * 1.2
+	assert.InDelta(t, 0.456, e.totalPrice(0.2, ts), 1e-9)
+	assert.InDelta(t, 0.456, e.totalPrice(0.2, ts), 1e-9, "repeatable")
+}
+
+func TestEmbedFormulaSurcharges(t *testing.T) {
+	e := &embed{
+		Surcharges: []surcharge{{Name: "margin", Percent: 50}},
+		Formula:    "price + surcharges",
+	}
+	require.NoError(t, e.init())
+
+	assert.InDelta(t, 0.3, e.totalPrice(0.2, time.Now()), 1e-9)
+}
+
+func TestEmbedRawPrice(t *testing.T) {
+	ts := time.Date(2025, 1, 1, 12, 0, 0, 0, time.Local)
+
+	clk := clock.NewMock()
+	clk.Set(ts)
+
+	e := &embed{Charges: 0.1, clock: clk}
+	require.NoError(t, e.init())
+
+	_, err := e.RawPrice(ts)
+	assert.ErrorIs(t, err, api.ErrNotAvailable)
+
+	e.totalPrice(0.2, ts)
+	e.totalPrice(0.3, ts.Add(time.Hour))
+
+	for _, tc := range []struct {
+		ts  time.Time
+		raw float64
+	}{
+		{ts, 0.2},
+		{ts.Add(30 * time.Minute), 0.2},
+		{ts.Add(time.Hour), 0.3},
+		{ts.Add(2*time.Hour - time.Minute), 0.3},
+	} {
+		raw, err := e.RawPrice(tc.ts)
+		require.NoError(t, err)
+		assert.Equal(t, tc.raw, raw, tc.ts)
+	}
+
+	_, err = e.RawPrice(ts.Add(-time.Minute))
+	assert.ErrorIs(t, err, api.ErrNotAvailable)
+
+	// beyond the last rate
+	_, err = e.RawPrice(ts.Add(2 * time.Hour))
+	assert.ErrorIs(t, err, api.ErrNotAvailable)
+
+	// recording day-ahead rates keeps today's prices
+	e.totalPrice(0.4, ts.Add(30*time.Hour))
+	raw, err := e.RawPrice(ts.Add(30 * time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, 0.2, raw)
+
+	// past prices expire
+	clk.Add(25 * time.Hour)
+	e.totalPrice(0.4, ts.Add(30*time.Hour))
+	_, err = e.RawPrice(ts.Add(30 * time.Minute))
+	assert.ErrorIs(t, err, api.ErrNotAvailable)
+
+	// tariffs without price composition
+	_, err = (*embed)(nil).RawPrice(ts)
+	assert.ErrorIs(t, err, api.ErrNotAvailable)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/tariff/energinet"
+	"github.com/evcc-io/evcc/tariff/zone"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
 )
@@ -38,7 +39,9 @@ func NewEnerginetFromConfig(other map[string]interface{}) (api.Tariff, error) {
 		return nil, err
 	}
 
-	if cc.Region == "" {
+	log := util.NewLogger("energinet")
+
+	if cc.Region = zone.Select(log, "energinet", cc.Region); cc.Region == "" {
 		return nil, errors.New("missing region")
 	}
 
@@ -48,7 +51,7 @@ func NewEnerginetFromConfig(other map[string]interface{}) (api.Tariff, error) {
 
 	t := &Energinet{
 		embed:  &cc.embed,
-		log:    util.NewLogger("energinet"),
+		log:    log,
 		region: strings.ToLower(cc.Region),
 		data:   util.NewMonitor[api.Rates](2 * time.Hour),
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/cenkalti/backoff/v4"
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/tariff/entsoe"
+	"github.com/evcc-io/evcc/tariff/zone"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/util/transport"
@@ -47,7 +48,9 @@ func NewEntsoeFromConfig(other map[string]interface{}) (api.Tariff, error) {
 		return nil, errors.New("missing securitytoken")
 	}
 
-	if cc.Domain == "" {
+	log := util.NewLogger("entsoe").Redact(cc.Securitytoken)
+
+	if cc.Domain = zone.Select(log, "entsoe", cc.Domain); cc.Domain == "" {
 		return nil, errors.New("missing domain")
 	}
 
@@ -60,8 +63,6 @@ func NewEntsoeFromConfig(other map[string]interface{}) (api.Tariff, error) {
 		return nil, err
 	}
 
-	log := util.NewLogger("entsoe").Redact(cc.Securitytoken)
-
 	t := &Entsoe{
 		log:    log,
 		Helper: request.NewHelper(log),
@@ -142,7 +143,7 @@ func (t *Entsoe) run(done chan error) {
 		}
 
 		// extract desired series
-		res, err := entsoe.GetTsPriceData(tr.TimeSeries, entsoe.ResolutionHour)
+		res, err := entsoe.GetTsPriceDataFinest(tr.TimeSeries)
 		if err != nil {
 			once.Do(func() { done <- err })
 			t.log.ERROR.Println(err)
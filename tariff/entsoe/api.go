@@ -49,6 +49,19 @@ type Rate struct {
 	Value float64
 }
 
+// GetTsPriceDataFinest returns the price data of the finest resolution available,
+// e.g. quarter-hourly data where the market has moved to 15-minute products.
+func GetTsPriceDataFinest(ts []TimeSeries) ([]Rate, error) {
+	var err error
+	for _, resolution := range []ResolutionType{ResolutionQuarterHour, ResolutionHalfHour, ResolutionHour} {
+		var res []Rate
+		if res, err = GetTsPriceData(ts, resolution); err == nil {
+			return res, nil
+		}
+	}
+	return nil, err
+}
+
 // GetTsPriceData accepts a set of TimeSeries data entries, and
 // returns a sorted array of Rate based on the timestamp of each data entry.
 func GetTsPriceData(ts []TimeSeries, resolution ResolutionType) ([]Rate, error) {
@@ -102,6 +115,11 @@ func ExtractPeriodPriceData(period *TimeSeriesPeriod) ([]Rate, error) {
 	}
 
 	ts := period.TimeInterval.Start.Time
+
+	// periods may deviate from a full day, e.g. on daylight saving changes
+	if end := period.TimeInterval.End.Time; end.After(ts) {
+		count = int(end.Sub(ts) / duration)
+	}
 	points := lo.SliceToMap(period.Point, func(p Point) (int, Point) {
 		return p.Position, p
 	})
@@ -0,0 +1,102 @@
+package tariff
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+)
+
+// External is a tariff receiving its rates via api or mqtt, e.g. from home-grown forecasting models
+type External struct {
+	log  *util.Logger
+	typ  api.TariffType
+	data *util.Monitor[api.Rates]
+}
+
+var (
+	_ api.Tariff      = (*External)(nil)
+	_ api.RatesSetter = (*External)(nil)
+)
+
+func init() {
+	registry.Add("external", NewExternalFromConfig)
+}
+
+// NewExternalFromConfig creates an external tariff from generic config
+func NewExternalFromConfig(other map[string]interface{}) (api.Tariff, error) {
+	cc := struct {
+		Type    api.TariffType `mapstructure:"tariff"`
+		Timeout time.Duration
+	}{
+		Type:    api.TariffTypePriceForecast,
+		Timeout: 24 * time.Hour,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	t := &External{
+		log:  util.NewLogger("external"),
+		typ:  cc.Type,
+		data: util.NewMonitor[api.Rates](cc.Timeout),
+	}
+
+	// don't block waiting for the first update
+	t.data.Set(nil)
+
+	return t, nil
+}
+
+// validateRates checks that rates are non-empty, well-formed and non-overlapping
+func validateRates(rr api.Rates) error {
+	if len(rr) == 0 {
+		return errors.New("empty rates")
+	}
+
+	for i, r := range rr {
+		if !r.End.After(r.Start) {
+			return fmt.Errorf("invalid rate: end %v not after start %v", r.End, r.Start)
+		}
+
+		if i > 0 && r.Start.Before(rr[i-1].End) {
+			return fmt.Errorf("overlapping rates at %v", r.Start)
+		}
+	}
+
+	return nil
+}
+
+// SetRates implements the api.RatesSetter interface
+func (t *External) SetRates(rr api.Rates) error {
+	rr = slices.Clone(rr)
+	rr.Sort()
+
+	if err := validateRates(rr); err != nil {
+		return err
+	}
+
+	t.log.DEBUG.Printf("received %d rates from %v to %v", len(rr), rr[0].Start.Local(), rr[len(rr)-1].End.Local())
+
+	mergeRates(t.data, rr)
+
+	return nil
+}
+
+// Rates implements the api.Tariff interface
+func (t *External) Rates() (api.Rates, error) {
+	var res api.Rates
+	err := t.data.GetFunc(func(val api.Rates) {
+		res = slices.Clone(val)
+	})
+	return res, err
+}
+
+// Type implements the api.Tariff interface
+func (t *External) Type() api.TariffType {
+	return t.typ
+}
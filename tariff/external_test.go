@@ -0,0 +1,39 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExternal(t *testing.T) {
+	at, err := NewExternalFromConfig(map[string]interface{}{"tariff": "co2"})
+	require.NoError(t, err)
+	assert.Equal(t, api.TariffTypeCo2, at.Type())
+
+	rr, err := at.Rates()
+	require.NoError(t, err)
+	assert.Empty(t, rr, "no rates yet")
+
+	start := time.Now().Truncate(time.Hour)
+	rate := func(i int, price float64) api.Rate {
+		return api.Rate{
+			Start: start.Add(time.Duration(i) * time.Hour),
+			End:   start.Add(time.Duration(i+1) * time.Hour),
+			Price: price,
+		}
+	}
+
+	rs := at.(api.RatesSetter)
+	assert.Error(t, rs.SetRates(nil), "empty")
+	assert.Error(t, rs.SetRates(api.Rates{rate(0, 1), {Start: start, End: start.Add(2 * time.Hour)}}), "overlap")
+
+	require.NoError(t, rs.SetRates(api.Rates{rate(1, 200), rate(0, 100)}))
+
+	rr, err = at.Rates()
+	require.NoError(t, err)
+	assert.Equal(t, api.Rates{rate(0, 100), rate(1, 200)}, rr)
+}
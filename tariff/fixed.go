@@ -13,9 +13,10 @@ import (
 )
 
 type Fixed struct {
-	clock   clock.Clock
-	zones   fixed.Zones
-	dynamic bool
+	clock    clock.Clock
+	zones    fixed.Zones
+	calendar *fixed.Calendar
+	dynamic  bool
 }
 
 var _ api.Tariff = (*Fixed)(nil)
@@ -26,10 +27,12 @@ func init() {
 
 func NewFixedFromConfig(other map[string]interface{}) (api.Tariff, error) {
 	var cc struct {
-		Price float64
-		Zones []struct {
-			Price       float64
-			Days, Hours string
+		Price      float64
+		Holidays   string   // country code, holidays are treated as sundays
+		Exceptions []string // additional dates treated as sundays
+		Zones      []struct {
+			Price               float64
+			Days, Hours, Months string
 		}
 	}
 
@@ -37,9 +40,15 @@ func NewFixedFromConfig(other map[string]interface{}) (api.Tariff, error) {
 		return nil, err
 	}
 
+	calendar, err := fixed.NewCalendar(cc.Holidays, cc.Exceptions)
+	if err != nil {
+		return nil, err
+	}
+
 	t := &Fixed{
-		clock:   clock.New(),
-		dynamic: len(cc.Zones) >= 1,
+		clock:    clock.New(),
+		calendar: calendar,
+		dynamic:  len(cc.Zones) >= 1,
 	}
 
 	for _, z := range cc.Zones {
@@ -53,19 +62,26 @@ func NewFixedFromConfig(other map[string]interface{}) (api.Tariff, error) {
 			return nil, err
 		}
 
+		months, err := fixed.ParseMonths(z.Months)
+		if err != nil {
+			return nil, err
+		}
+
 		if len(hours) == 0 {
 			t.zones = append(t.zones, fixed.Zone{
-				Price: z.Price,
-				Days:  days,
+				Price:  z.Price,
+				Days:   days,
+				Months: months,
 			})
 			continue
 		}
 
 		for _, h := range hours {
 			t.zones = append(t.zones, fixed.Zone{
-				Price: z.Price,
-				Days:  days,
-				Hours: h,
+				Price:  z.Price,
+				Days:   days,
+				Hours:  h,
+				Months: months,
 			})
 		}
 	}
@@ -82,18 +98,25 @@ func NewFixedFromConfig(other map[string]interface{}) (api.Tariff, error) {
 
 // Rates implements the api.Tariff interface
 func (t *Fixed) Rates() (api.Rates, error) {
+	start := now.With(t.clock.Now().Local()).BeginningOfDay()
+	return t.RatesBetween(start, start.AddDate(0, 0, 7))
+}
+
+// RatesBetween returns the rates of all days between from and to, applying month and holiday zones
+func (t *Fixed) RatesBetween(from, to time.Time) (api.Rates, error) {
 	var res api.Rates
 
-	start := now.With(t.clock.Now().Local()).BeginningOfDay()
-	for i := range 7 {
-		dow := fixed.Day((int(start.Weekday()) + i) % 7)
+	for dayStart := now.With(from.Local()).BeginningOfDay(); dayStart.Before(to); dayStart = dayStart.AddDate(0, 0, 1) {
+		dow := fixed.Day(dayStart.Weekday())
+		if t.calendar.IsHoliday(dayStart) {
+			dow = fixed.Sunday
+		}
 
-		zones := t.zones.ForDay(dow)
+		zones := t.zones.ForMonth(dayStart.Month()).ForDay(dow)
 		if len(zones) == 0 {
 			return nil, fmt.Errorf("no zones for weekday %d", dow)
 		}
 
-		dayStart := start.AddDate(0, 0, i)
 		markers := zones.TimeTableMarkers()
 
 		for i, m := range markers {
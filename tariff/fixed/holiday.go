@@ -0,0 +1,129 @@
+package fixed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// holiday is a public holiday defined by fixed date or offset to easter sunday
+type holiday struct {
+	year   int // optional, for single occurrence
+	month  time.Month
+	day    int
+	easter *int
+}
+
+func date(month time.Month, day int) holiday {
+	return holiday{month: month, day: day}
+}
+
+func easter(offset int) holiday {
+	return holiday{easter: &offset}
+}
+
+// nationwide public holidays by ISO 3166 country code
+var holidays = map[string][]holiday{
+	"AT": {
+		date(time.January, 1), date(time.January, 6), easter(1), date(time.May, 1), easter(39), easter(50), easter(60),
+		date(time.August, 15), date(time.October, 26), date(time.November, 1), date(time.December, 8), date(time.December, 25), date(time.December, 26),
+	},
+	"CH": {
+		date(time.January, 1), easter(-2), easter(1), easter(39), easter(50), date(time.August, 1), date(time.December, 25), date(time.December, 26),
+	},
+	"DE": {
+		date(time.January, 1), easter(-2), easter(1), date(time.May, 1), easter(39), easter(50),
+		date(time.October, 3), date(time.December, 25), date(time.December, 26),
+	},
+	"DK": {
+		date(time.January, 1), easter(-3), easter(-2), easter(0), easter(1), easter(39), easter(49), easter(50),
+		date(time.June, 5), date(time.December, 25), date(time.December, 26),
+	},
+	"FR": {
+		date(time.January, 1), easter(1), date(time.May, 1), date(time.May, 8), easter(39), easter(50), date(time.July, 14),
+		date(time.August, 15), date(time.November, 1), date(time.November, 11), date(time.December, 25),
+	},
+	"NL": {
+		date(time.January, 1), easter(-2), easter(0), easter(1), date(time.April, 27), date(time.May, 5), easter(39), easter(49), easter(50),
+		date(time.December, 25), date(time.December, 26),
+	},
+}
+
+// easterSunday returns easter sunday for given year (anonymous gregorian algorithm)
+func easterSunday(year int) time.Time {
+	a := year % 19
+	b, c := year/100, year%100
+	d, e := b/4, b%4
+	f := (b + 8) / 25
+	g := (b - f + 1) / 3
+	h := (19*a + b - d - g + 15) % 30
+	i, k := c/4, c%4
+	l := (32 + 2*e + 2*i - h - k) % 7
+	m := (a + 11*h + 22*l) / 451
+	month := (h + l - 7*m + 114) / 31
+	day := (h+l-7*m+114)%31 + 1
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC)
+}
+
+// Calendar identifies public holidays and additional exception dates
+type Calendar struct {
+	holidays   []holiday
+	exceptions []holiday
+}
+
+// NewCalendar creates a calendar for given country and exception dates in MM-DD or YYYY-MM-DD format
+func NewCalendar(country string, exceptions []string) (*Calendar, error) {
+	res := new(Calendar)
+
+	if country != "" {
+		hh, ok := holidays[strings.ToUpper(country)]
+		if !ok {
+			return nil, fmt.Errorf("unsupported holiday country: %s", country)
+		}
+		res.holidays = hh
+	}
+
+	for _, s := range exceptions {
+		s = strings.TrimSpace(s)
+
+		if ts, err := time.Parse(time.DateOnly, s); err == nil {
+			res.exceptions = append(res.exceptions, holiday{year: ts.Year(), month: ts.Month(), day: ts.Day()})
+			continue
+		}
+
+		ts, err := time.Parse("01-02", s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exception date: %s", s)
+		}
+		res.exceptions = append(res.exceptions, date(ts.Month(), ts.Day()))
+	}
+
+	return res, nil
+}
+
+// IsHoliday returns true if given day is a public holiday or exception date
+func (c *Calendar) IsHoliday(ts time.Time) bool {
+	if c == nil {
+		return false
+	}
+
+	year, month, day := ts.Date()
+
+	for _, h := range append(c.holidays, c.exceptions...) {
+		if h.year != 0 && h.year != year {
+			continue
+		}
+
+		if h.easter != nil {
+			d := easterSunday(year).AddDate(0, 0, *h.easter)
+			if d.Month() == month && d.Day() == day {
+				return true
+			}
+		} else if h.month == month && h.day == day {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,30 @@
+package fixed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEasterSunday(t *testing.T) {
+	assert.Equal(t, time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC), easterSunday(2024))
+	assert.Equal(t, time.Date(2025, 4, 20, 0, 0, 0, 0, time.UTC), easterSunday(2025))
+	assert.Equal(t, time.Date(2026, 4, 5, 0, 0, 0, 0, time.UTC), easterSunday(2026))
+}
+
+func TestCalendar(t *testing.T) {
+	c, err := NewCalendar("de", []string{"12-24", "2025-12-31"})
+	require.NoError(t, err)
+
+	assert.True(t, c.IsHoliday(time.Date(2025, 4, 18, 12, 0, 0, 0, time.Local)), "good friday")
+	assert.True(t, c.IsHoliday(time.Date(2025, 10, 3, 0, 0, 0, 0, time.Local)), "unity day")
+	assert.True(t, c.IsHoliday(time.Date(2026, 12, 24, 0, 0, 0, 0, time.Local)), "recurring exception")
+	assert.True(t, c.IsHoliday(time.Date(2025, 12, 31, 0, 0, 0, 0, time.Local)), "single exception")
+	assert.False(t, c.IsHoliday(time.Date(2026, 12, 31, 0, 0, 0, 0, time.Local)), "single exception other year")
+	assert.False(t, c.IsHoliday(time.Date(2025, 4, 22, 0, 0, 0, 0, time.Local)))
+
+	_, err = NewCalendar("xx", nil)
+	assert.Error(t, err)
+}
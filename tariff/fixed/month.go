@@ -0,0 +1,95 @@
+package fixed
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var shortMonths = map[string]time.Month{
+	// english
+	"jan": time.January,
+	"feb": time.February,
+	"mar": time.March,
+	"apr": time.April,
+	"may": time.May,
+	"jun": time.June,
+	"jul": time.July,
+	"aug": time.August,
+	"sep": time.September,
+	"oct": time.October,
+	"nov": time.November,
+	"dec": time.December,
+	// german
+	"mär": time.March,
+	"mai": time.May,
+	"okt": time.October,
+	"dez": time.December,
+}
+
+// ParseMonth parses a single month
+func ParseMonth(s string) (time.Month, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	if len(s) > 3 {
+		for m := time.January; m <= time.December; m++ {
+			if strings.ToLower(m.String()) == s {
+				return m, nil
+			}
+		}
+	}
+
+	if m, ok := shortMonths[s]; ok {
+		return m, nil
+	}
+
+	m, err := strconv.Atoi(s)
+	if m < 1 || m > 12 || err != nil {
+		return 0, fmt.Errorf("invalid month: %s", s)
+	}
+
+	return time.Month(m), nil
+}
+
+// ParseMonths converts a months string into a slice of individual months
+// Months format:
+//
+//	month[-month][, ...]
+func ParseMonths(s string) ([]time.Month, error) {
+	var res []time.Month
+
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+
+	for _, segment := range strings.Split(s, ",") {
+		fromto := strings.SplitN(segment, "-", 2)
+
+		from, err := ParseMonth(fromto[0])
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, from)
+
+		if len(fromto) == 2 {
+			to, err := ParseMonth(fromto[1])
+			if err != nil {
+				return nil, err
+			}
+
+			// wrap around end of year
+			for m := from; m != to; {
+				m = m%12 + 1
+				res = append(res, m)
+			}
+		}
+	}
+
+	if len(slices.Compact(slices.Sorted(slices.Values(res)))) < len(res) {
+		return nil, fmt.Errorf("duplicate months: %s", s)
+	}
+
+	return res, nil
+}
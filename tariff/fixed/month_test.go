@@ -0,0 +1,25 @@
+package fixed
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseMonths(t *testing.T) {
+	m, err := ParseMonths("Nov-Feb")
+	require.NoError(t, err)
+	assert.Equal(t, []time.Month{time.November, time.December, time.January, time.February}, m)
+
+	_, err = ParseMonths("apr, 6, Juli")
+	require.Error(t, err)
+
+	m, err = ParseMonths("apr, 6, july")
+	require.NoError(t, err)
+	assert.Equal(t, []time.Month{time.April, time.June, time.July}, m)
+
+	_, err = ParseMonths("jan-mar,feb")
+	assert.Error(t, err)
+}
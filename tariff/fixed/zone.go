@@ -2,12 +2,14 @@ package fixed
 
 import (
 	"slices"
+	"time"
 )
 
 type Zone struct {
-	Price float64
-	Days  []Day
-	Hours TimeRange
+	Price  float64
+	Days   []Day
+	Hours  TimeRange
+	Months []time.Month // season, empty for full year
 }
 
 type Zones []Zone
@@ -40,6 +42,18 @@ func (r Zones) ForDay(day Day) Zones {
 	return zones
 }
 
+// ForMonth returns the zones applicable in given month
+func (r Zones) ForMonth(month time.Month) Zones {
+	var zones Zones
+	for _, z := range r {
+		if slices.Contains(z.Months, month) || len(z.Months) == 0 {
+			zones = append(zones, z)
+		}
+	}
+
+	return zones
+}
+
 // TimeTableMarkers returns list of zone start/end markers
 func (r Zones) TimeTableMarkers() []HourMin {
 	res := []HourMin{{Hour: 0, Min: 0}}
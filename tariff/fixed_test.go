@@ -103,3 +103,73 @@ func TestFixedSplitZones(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, expect, rates)
 }
+
+func TestFixedHolidaysAndSeasons(t *testing.T) {
+	at, err := NewFixedFromConfig(map[string]interface{}{
+		"price":      0.3,
+		"holidays":   "DE",
+		"exceptions": []string{"12-24"},
+		"zones": []map[string]interface{}{
+			{"price": 0.2, "days": "Sun"},
+			{"price": 0.25, "days": "Mon-Sat", "months": "Nov-Feb"},
+		},
+	})
+	require.NoError(t, err)
+
+	tf := at.(*Fixed)
+	clk := clock.NewMock()
+	tf.clock = clk
+
+	priceAt := func(ts time.Time) float64 {
+		rates, err := tf.Rates()
+		require.NoError(t, err)
+
+		r, err := rates.At(ts)
+		require.NoError(t, err)
+		return r.Price
+	}
+
+	// Thursday 2025-12-25 christmas holiday
+	clk.Set(time.Date(2025, 12, 22, 0, 0, 0, 0, time.Local))
+	assert.Equal(t, 0.25, priceAt(time.Date(2025, 12, 23, 12, 0, 0, 0, time.Local)), "winter weekday")
+	assert.Equal(t, 0.2, priceAt(time.Date(2025, 12, 24, 12, 0, 0, 0, time.Local)), "exception")
+	assert.Equal(t, 0.2, priceAt(time.Date(2025, 12, 25, 12, 0, 0, 0, time.Local)), "holiday")
+
+	// Tuesday 2025-06-10, summer weekday after whit monday
+	clk.Set(time.Date(2025, 6, 9, 0, 0, 0, 0, time.Local))
+	assert.Equal(t, 0.2, priceAt(time.Date(2025, 6, 9, 12, 0, 0, 0, time.Local)), "whit monday")
+	assert.Equal(t, 0.3, priceAt(time.Date(2025, 6, 10, 12, 0, 0, 0, time.Local)), "summer weekday")
+}
+
+func TestFixedRatesBetween(t *testing.T) {
+	at, err := NewFixedFromConfig(map[string]interface{}{
+		"price":    0.3,
+		"holidays": "DE",
+		"zones": []map[string]interface{}{
+			{"price": 0.2, "days": "Sun"},
+			{"price": 0.25, "days": "Mon-Sat", "months": "Nov-Feb"},
+		},
+	})
+	require.NoError(t, err)
+
+	tf := at.(*Fixed)
+	tf.clock = clock.NewMock()
+
+	// christmas period months before the current week
+	from := time.Date(2025, 12, 23, 12, 0, 0, 0, time.Local)
+	rates, err := tf.RatesBetween(from, from.AddDate(0, 0, 2))
+	require.NoError(t, err)
+
+	assert.Equal(t, time.Date(2025, 12, 23, 0, 0, 0, 0, time.Local), rates[0].Start)
+	assert.Equal(t, time.Date(2025, 12, 26, 0, 0, 0, 0, time.Local), rates[len(rates)-1].End)
+
+	for ts, price := range map[time.Time]float64{
+		from:                   0.25, // winter weekday
+		from.AddDate(0, 0, 2):  0.2,  // holiday
+		from.Add(-time.Minute): 0.25,
+	} {
+		r, err := rates.At(ts)
+		require.NoError(t, err)
+		assert.Equal(t, price, r.Price, ts)
+	}
+}
@@ -0,0 +1,107 @@
+package tariff
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// GridFee adds time-variable network charges to an energy tariff, e.g. the reduced
+// fee windows of §14a EnWG module 3
+type GridFee struct {
+	api.Tariff
+	fee api.Tariff
+}
+
+// NewGridFee creates a tariff of energy price plus network charges
+func NewGridFee(energy, fee api.Tariff) api.Tariff {
+	t := &GridFee{
+		Tariff: energy,
+		fee:    fee,
+	}
+
+	switch energy := energy.(type) {
+	case api.RatesSetter:
+		return &struct {
+			*GridFee
+			api.RatesSetter
+		}{t, energy}
+	case api.DemandTariff:
+		return &struct {
+			*GridFee
+			api.DemandTariff
+		}{t, energy}
+	case api.TieredTariff:
+		return &struct {
+			*GridFee
+			api.TieredTariff
+		}{t, energy}
+	case api.PriceComposer:
+		return &struct {
+			*GridFee
+			api.PriceComposer
+		}{t, energy}
+	}
+
+	return t
+}
+
+// Rates implements the api.Tariff interface. Rates are split at fee changes, periods without fee are omitted.
+func (t *GridFee) Rates() (api.Rates, error) {
+	energy, err := t.Tariff.Rates()
+	if err != nil {
+		return nil, err
+	}
+
+	fee, err := t.fee.Rates()
+	if err != nil {
+		return nil, fmt.Errorf("grid fee: %w", err)
+	}
+
+	return addRates(energy, fee), nil
+}
+
+// Type implements the api.Tariff interface
+func (t *GridFee) Type() api.TariffType {
+	return max(t.Tariff.Type(), t.fee.Type())
+}
+
+// addRates adds the overlapping fee rates to the energy rates
+func addRates(energy, fee api.Rates) api.Rates {
+	energy, fee = slices.Clone(energy), slices.Clone(fee)
+	energy.Sort()
+	fee.Sort()
+
+	var (
+		res api.Rates
+		j   int
+	)
+
+	for _, e := range energy {
+		// skip fees ending before the energy rate
+		for j < len(fee) && !fee[j].End.After(e.Start) {
+			j++
+		}
+
+		for k := j; k < len(fee) && fee[k].Start.Before(e.End); k++ {
+			f := fee[k]
+
+			r := api.Rate{
+				Start: e.Start,
+				End:   e.End,
+				Price: e.Price + f.Price,
+			}
+			if f.Start.After(r.Start) {
+				r.Start = f.Start
+			}
+			if f.End.Before(r.End) {
+				r.End = f.End
+			}
+
+			res = append(res, r)
+		}
+	}
+
+	return res
+}
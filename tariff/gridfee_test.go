@@ -0,0 +1,49 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGridFee(t *testing.T) {
+	day := time.Date(2025, 6, 1, 0, 0, 0, 0, time.Local)
+	rate := func(from, to int, price float64) api.Rate {
+		return api.Rate{
+			Start: day.Add(time.Duration(from) * time.Hour),
+			End:   day.Add(time.Duration(to) * time.Hour),
+			Price: price,
+		}
+	}
+
+	// hourly energy prices
+	var energy ratesTariff
+	for i := range 24 {
+		energy = append(energy, rate(i, i+1, 0.2))
+	}
+
+	// module 3 fee windows
+	fee := ratesTariff{rate(0, 6, 0.02), rate(6, 17, 0.08), rate(17, 20, 0.12), rate(20, 24, 0.08)}
+
+	tf := NewGridFee(energy, fee)
+
+	rr, err := tf.Rates()
+	require.NoError(t, err)
+	require.Len(t, rr, 24)
+
+	assert.InDelta(t, 0.22, rr[2].Price, 1e-9)
+	assert.InDelta(t, 0.28, rr[12].Price, 1e-9)
+	assert.InDelta(t, 0.32, rr[18].Price, 1e-9)
+
+	// static energy price is split at fee windows
+	tf = NewGridFee(ratesTariff{rate(0, 24, 0.3)}, fee)
+
+	rr, err = tf.Rates()
+	require.NoError(t, err)
+	require.Len(t, rr, 4)
+	assert.Equal(t, day.Add(6*time.Hour), rr[1].Start)
+	assert.InDelta(t, 0.42, rr[2].Price, 1e-9)
+}
@@ -0,0 +1,71 @@
+package tariff
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// Grid state tariffs report the regional grid stress level as price
+const (
+	GridStateNormal   = 1 // green
+	GridStateStressed = 3 // orange, reduce consumption
+	GridStateCritical = 4 // red, avoid consumption
+)
+
+// GridStateAdjusted penalizes rates of the underlying tariff during grid stress
+// so that plans are shifted away from stressed periods
+type GridStateAdjusted struct {
+	tariff, gridState api.Tariff
+}
+
+var _ api.Tariff = (*GridStateAdjusted)(nil)
+
+// NewGridStateAdjusted creates a grid state adjusted tariff
+func NewGridStateAdjusted(tariff, gridState api.Tariff) api.Tariff {
+	return &GridStateAdjusted{
+		tariff:    tariff,
+		gridState: gridState,
+	}
+}
+
+// Rates implements the api.Tariff interface
+func (t *GridStateAdjusted) Rates() (api.Rates, error) {
+	rr, err := t.tariff.Rates()
+	if err != nil || len(rr) == 0 {
+		return rr, err
+	}
+
+	states, err := t.gridState.Rates()
+	if err != nil {
+		return rr, nil
+	}
+
+	minR := slices.MinFunc(rr, func(a, b api.Rate) int { return cmp.Compare(a.Price, b.Price) })
+	maxR := slices.MaxFunc(rr, func(a, b api.Rate) int { return cmp.Compare(a.Price, b.Price) })
+
+	// rank stressed slots after all others, critical after stressed
+	penalty := maxR.Price - minR.Price + 1
+
+	res := slices.Clone(rr)
+	for i, r := range res {
+		var state float64
+		for _, s := range states {
+			if s.Start.Before(r.End) && r.Start.Before(s.End) {
+				state = max(state, s.Price)
+			}
+		}
+
+		if state >= GridStateStressed {
+			res[i].Price += penalty * (state - GridStateStressed + 1)
+		}
+	}
+
+	return res, nil
+}
+
+// Type implements the api.Tariff interface
+func (t *GridStateAdjusted) Type() api.TariffType {
+	return t.tariff.Type()
+}
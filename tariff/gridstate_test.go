@@ -0,0 +1,37 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGridStateAdjusted(t *testing.T) {
+	clock := clock.NewMock()
+	rate := func(start int, val float64) api.Rate {
+		return api.Rate{
+			Start: clock.Now().Add(time.Duration(start) * time.Hour),
+			End:   clock.Now().Add(time.Duration(start+1) * time.Hour),
+			Price: val,
+		}
+	}
+
+	prices := &tariff{api.Rates{rate(0, 0.1), rate(1, 0.2), rate(2, 0.3), rate(3, 0.4)}}
+	states := &tariff{api.Rates{rate(0, GridStateCritical), rate(1, GridStateStressed), rate(2, GridStateNormal)}}
+
+	rr, err := NewGridStateAdjusted(prices, states).Rates()
+	require.NoError(t, err)
+
+	// penalty is price span + 1
+	assert.InDelta(t, 0.1+2*1.3, rr[0].Price, 1e-6)
+	assert.InDelta(t, 0.2+1.3, rr[1].Price, 1e-6)
+	assert.Equal(t, 0.3, rr[2].Price)
+	assert.Equal(t, 0.4, rr[3].Price)
+
+	// underlying rates unchanged
+	assert.Equal(t, 0.1, prices.rates[0].Price)
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/tariff/corrently"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/oauth"
 	"github.com/evcc-io/evcc/util/request"
 	"golang.org/x/oauth2"
 )
@@ -49,7 +50,7 @@ func NewGrünStromIndexFromConfig(other map[string]interface{}) (api.Tariff, err
 
 	t.Client.Transport = &oauth2.Transport{
 		Base:   t.Client.Transport,
-		Source: corrently.TokenSource(log, &oauth2.Token{AccessToken: cc.Token}),
+		Source: oauth.Monitor("tariff.grünstromindex", "GrünStromIndex", corrently.TokenSource(log, &oauth2.Token{AccessToken: cc.Token})),
 	}
 
 	done := make(chan error)
@@ -1,6 +1,7 @@
 package tariff
 
 import (
+	"context"
 	"errors"
 	"strings"
 	"time"
@@ -9,10 +10,44 @@ import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/config"
+	"github.com/evcc-io/evcc/util/ratelimit"
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/jinzhu/now"
 )
 
+type ctxName struct{}
+
+// WithName adds the configured tariff name to the context for keying persisted tariff state
+func WithName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, ctxName{}, name)
+}
+
+// contextName returns the configured tariff name or the given default
+func contextName(ctx context.Context, def string) string {
+	if name, ok := ctx.Value(ctxName{}).(string); ok && name != "" {
+		return name
+	}
+	return def
+}
+
+// As returns the first tariff implementing T, unwrapping tariff decorators like Cached or Converted
+func As[T any](t api.Tariff) (T, bool) {
+	for t != nil {
+		if res, ok := t.(T); ok {
+			return res, true
+		}
+
+		u, ok := t.(interface{ Unwrap() api.Tariff })
+		if !ok {
+			break
+		}
+		t = u.Unwrap()
+	}
+
+	var zero T
+	return zero, false
+}
+
 // Name returns the tariff type name
 func Name(conf config.Typed) string {
 	if conf.Other != nil && conf.Other["tariff"] != nil {
@@ -35,12 +70,36 @@ func backoffPermanentError(err error) error {
 			return backoff.Permanent(se)
 		}
 	}
+	if errors.Is(err, ratelimit.ErrQuotaExceeded) {
+		return backoff.Permanent(err)
+	}
 	if err != nil && strings.HasPrefix(err.Error(), "jq: query failed") {
 		return backoff.Permanent(err)
 	}
 	return err
 }
 
+// slotDuration is the finest market resolution, e.g. quarter-hourly spot market products
+const slotDuration = 15 * time.Minute
+
+// slotEnds sets the end of rates known by their start only, supporting both hourly and quarter-hourly resolution.
+// Each rate ends with the start of its successor, at most one hour after its start. The last rate follows its predecessor's duration.
+func slotEnds(rr api.Rates) api.Rates {
+	for i := range rr {
+		d := time.Hour
+		switch {
+		case i+1 < len(rr):
+			d = min(d, rr[i+1].Start.Sub(rr[i].Start))
+		case i > 0:
+			d = rr[i-1].End.Sub(rr[i-1].Start)
+		}
+
+		rr[i].End = rr[i].Start.Add(d)
+	}
+
+	return rr
+}
+
 // mergeRates blends new and existing rates, keeping existing rates after current hour
 func mergeRates(data *util.Monitor[api.Rates], new api.Rates) {
 	mergeRatesAfter(data, new, now.With(time.Now()).BeginningOfHour())
@@ -53,3 +53,26 @@ func TestMergeRatesAfter(t *testing.T) {
 		assert.Equal(t, tc.expected, res)
 	}
 }
+
+func TestSlotEnds(t *testing.T) {
+	ts := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	rate := func(offset time.Duration) api.Rate {
+		return api.Rate{Start: ts.Add(offset)}
+	}
+
+	// quarter-hourly
+	res := slotEnds(api.Rates{rate(0), rate(15 * time.Minute), rate(30 * time.Minute)})
+	for _, r := range res {
+		assert.Equal(t, 15*time.Minute, r.End.Sub(r.Start))
+	}
+
+	// hourly with gap
+	res = slotEnds(api.Rates{rate(0), rate(3 * time.Hour), rate(4 * time.Hour)})
+	for _, r := range res {
+		assert.Equal(t, time.Hour, r.End.Sub(r.Start))
+	}
+
+	// single rate
+	res = slotEnds(api.Rates{rate(0)})
+	assert.Equal(t, time.Hour, res[0].End.Sub(res[0].Start))
+}
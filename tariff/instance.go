@@ -0,0 +1,28 @@
+package tariff
+
+import (
+	"context"
+	"errors"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+)
+
+// NewInstance creates a named tariff whose rates are normalized and cached across restarts and outages.
+// Non-config errors are wrapped to not prevent startup.
+func NewInstance(log *util.Logger, name string, conf config.Typed) (api.Tariff, error) {
+	ctx := WithName(util.WithLogger(context.TODO(), util.NewLogger(name)), name)
+
+	instance, err := NewFromConfig(ctx, conf.Type, conf.Other)
+	if err != nil {
+		if ce := new(util.ConfigError); errors.As(err, &ce) {
+			return nil, err
+		}
+
+		log.ERROR.Printf("creating tariff %s failed: %v", name, err)
+		instance = NewWrapper(conf.Type, conf.Other, err)
+	}
+
+	return NewCached(name, instance), nil
+}
@@ -0,0 +1,23 @@
+package tariff
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewInstance(t *testing.T) {
+	log := util.NewLogger("test")
+
+	trf, err := NewInstance(log, "loadpoint", config.Typed{Type: "fixed", Other: map[string]any{"price": 0.3}})
+	require.NoError(t, err)
+	assert.Equal(t, api.TariffTypePriceStatic, trf.Type())
+
+	_, ok := As[*Cached](trf)
+	assert.True(t, ok, "tariff not cached")
+
+}
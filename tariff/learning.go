@@ -0,0 +1,250 @@
+package tariff
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/jinzhu/now"
+)
+
+// Learning is a solar forecast trained on the site's own production history
+// using open-meteo irradiance as weather input
+type Learning struct {
+	*request.Helper
+	log      *util.Logger
+	lat, lon float64
+	kwp      float64
+	key      string
+
+	mu     sync.Mutex
+	model  learningModel
+	hourly map[int64]learningSample
+
+	data *util.Monitor[api.Rates]
+}
+
+var (
+	_ api.Tariff             = (*Learning)(nil)
+	_ api.ProductionRecorder = (*Learning)(nil)
+)
+
+// learningDecay is applied per training sample to let the model follow seasonal changes
+const learningDecay = 0.97
+
+// learningModel is a per hour-of-day least squares fit of production against irradiance
+type learningModel struct {
+	Sxy [24]float64 `json:"sxy"`
+	Sxx [24]float64 `json:"sxx"`
+}
+
+type learningSample struct {
+	sum float64
+	n   int
+}
+
+func init() {
+	registry.Add("learning", NewLearningFromConfig)
+}
+
+func NewLearningFromConfig(other map[string]interface{}) (api.Tariff, error) {
+	cc := struct {
+		Lat, Lon float64
+		Kwp      float64
+		Interval time.Duration
+	}{
+		Interval: time.Hour,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Lat == 0 && cc.Lon == 0 {
+		return nil, errors.New("missing lat/lon")
+	}
+
+	log := util.NewLogger("learning")
+
+	t := &Learning{
+		log:    log,
+		lat:    cc.Lat,
+		lon:    cc.Lon,
+		kwp:    cc.Kwp,
+		key:    fmt.Sprintf("solar.learning.%.2f.%.2f", cc.Lat, cc.Lon),
+		Helper: request.NewHelper(log),
+		hourly: make(map[int64]learningSample),
+		data:   util.NewMonitor[api.Rates](2 * cc.Interval),
+	}
+
+	if err := settings.Json(t.key, &t.model); err != nil && !errors.Is(err, settings.ErrNotFound) {
+		log.WARN.Println("model:", err)
+	}
+
+	done := make(chan error)
+	go t.run(cc.Interval, done)
+	err := <-done
+
+	return t, err
+}
+
+func (t *Learning) run(interval time.Duration, done chan error) {
+	var once sync.Once
+
+	for ; true; <-time.Tick(interval) {
+		var res struct {
+			Hourly struct {
+				Time       []int64   `json:"time"`
+				Irradiance []float64 `json:"shortwave_radiation"`
+			} `json:"hourly"`
+		}
+
+		if err := backoff.Retry(func() error {
+			uri := fmt.Sprintf("https://api.open-meteo.com/v1/forecast?latitude=%.4f&longitude=%.4f&hourly=shortwave_radiation&past_days=1&forecast_days=3&timeformat=unixtime", t.lat, t.lon)
+			return backoffPermanentError(t.GetJSON(uri, &res))
+		}, bo()); err != nil {
+			once.Do(func() { done <- err })
+
+			t.log.ERROR.Println(err)
+			continue
+		}
+
+		if len(res.Hourly.Time) != len(res.Hourly.Irradiance) {
+			err := errors.New("invalid irradiance data")
+			once.Do(func() { done <- err })
+
+			t.log.ERROR.Println(err)
+			continue
+		}
+
+		// irradiance is the mean of the preceding hour
+		irradiance := make(map[int64]float64, len(res.Hourly.Time))
+		for i, ts := range res.Hourly.Time {
+			irradiance[ts-int64(time.Hour/time.Second)] = res.Hourly.Irradiance[i]
+		}
+
+		t.train(irradiance, now.With(time.Now()).BeginningOfHour())
+
+		data := t.forecast(irradiance)
+
+		mergeRatesAfter(t.data, data, BeginningOfDay())
+		once.Do(func() { close(done) })
+	}
+}
+
+// train fits the model with all completed production hours
+func (t *Learning) train(irradiance map[int64]float64, current time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var trained bool
+	for ts, s := range t.hourly {
+		start := time.Unix(ts, 0)
+		if !start.Before(current) {
+			continue
+		}
+
+		if g, ok := irradiance[ts]; ok && g > 0 && s.n > 0 {
+			t.model.add(start.Hour(), g, s.sum/float64(s.n))
+			trained = true
+		}
+
+		delete(t.hourly, ts)
+	}
+
+	if trained {
+		if err := settings.SetJson(t.key, t.model); err != nil {
+			t.log.ERROR.Println("model:", err)
+		}
+	}
+}
+
+// forecast converts irradiance to expected production
+func (t *Learning) forecast(irradiance map[int64]float64) api.Rates {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	today := BeginningOfDay()
+
+	res := make(api.Rates, 0, len(irradiance))
+	for ts, g := range irradiance {
+		start := time.Unix(ts, 0).Local()
+		if start.Before(today) {
+			continue
+		}
+
+		factor, ok := t.model.factor(start.Hour())
+		if !ok {
+			if t.kwp == 0 {
+				continue
+			}
+			// untrained, assume 85% system efficiency at 1000 W/m²
+			factor = t.kwp * 0.85
+		}
+
+		res = append(res, api.Rate{
+			Start: start,
+			End:   start.Add(time.Hour),
+			Price: max(0, factor*g),
+		})
+	}
+
+	return res
+}
+
+// RecordProduction implements the api.ProductionRecorder interface
+func (t *Learning) RecordProduction(ts time.Time, power float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	key := now.With(ts).BeginningOfHour().Unix()
+	s := t.hourly[key]
+	s.sum += max(0, power)
+	s.n++
+	t.hourly[key] = s
+}
+
+func (m *learningModel) add(hour int, irradiance, power float64) {
+	m.Sxy[hour] = learningDecay*m.Sxy[hour] + irradiance*power
+	m.Sxx[hour] = learningDecay*m.Sxx[hour] + irradiance*irradiance
+}
+
+// factor returns the production per irradiance for given hour, falling back to the daily average
+func (m *learningModel) factor(hour int) (float64, bool) {
+	if m.Sxx[hour] > 0 {
+		return m.Sxy[hour] / m.Sxx[hour], true
+	}
+
+	var sxy, sxx float64
+	for i := range m.Sxx {
+		sxy += m.Sxy[i]
+		sxx += m.Sxx[i]
+	}
+
+	if sxx > 0 {
+		return sxy / sxx, true
+	}
+
+	return 0, false
+}
+
+// Rates implements the api.Tariff interface
+func (t *Learning) Rates() (api.Rates, error) {
+	var res api.Rates
+	err := t.data.GetFunc(func(val api.Rates) {
+		res = slices.Clone(val)
+	})
+	return res, err
+}
+
+// Type implements the api.Tariff interface
+func (t *Learning) Type() api.TariffType {
+	return api.TariffTypeSolar
+}
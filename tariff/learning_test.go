@@ -0,0 +1,71 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/jinzhu/now"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLearningModel(t *testing.T) {
+	var m learningModel
+
+	_, ok := m.factor(12)
+	assert.False(t, ok, "untrained")
+
+	for range 10 {
+		m.add(12, 800, 4000)
+	}
+
+	f, ok := m.factor(12)
+	require.True(t, ok)
+	assert.InDelta(t, 5.0, f, 1e-6)
+
+	// other hours fall back to average
+	f, ok = m.factor(9)
+	require.True(t, ok)
+	assert.InDelta(t, 5.0, f, 1e-6)
+}
+
+func TestLearningTrainAndForecast(t *testing.T) {
+	log := util.NewLogger("foo")
+
+	l := &Learning{
+		log:    log,
+		Helper: request.NewHelper(log),
+		key:    "solar.learning.test",
+		hourly: make(map[int64]learningSample),
+	}
+
+	today := now.BeginningOfDay()
+	noon := today.Add(12 * time.Hour)
+
+	// hour in progress must not be used for training
+	l.RecordProduction(noon.Add(10*time.Minute), 2000)
+	l.RecordProduction(noon.Add(20*time.Minute), 4000)
+
+	irradiance := map[int64]float64{
+		noon.Unix():                      600,
+		today.Add(24 * time.Hour).Unix(): 500,
+	}
+
+	l.train(irradiance, noon)
+	assert.Len(t, l.hourly, 1)
+
+	rr := l.forecast(irradiance)
+	assert.Empty(t, rr, "untrained without kwp")
+
+	l.train(irradiance, noon.Add(time.Hour))
+	assert.Empty(t, l.hourly)
+
+	rr = l.forecast(irradiance)
+	require.Len(t, rr, 2)
+
+	for _, r := range rr {
+		assert.InDelta(t, irradiance[r.Start.Unix()]*5, r.Price, 1e-6)
+	}
+}
@@ -0,0 +1,97 @@
+package tariff
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// normalizeMaxGap is the maximum gap between consecutive rates closed by extending the preceding rate
+const normalizeMaxGap = 15 * time.Minute
+
+// Normalize sorts rates, removes invalid and duplicate rates, truncates overlapping rates, splits
+// rates enclosing other rates and closes small gaps. Invalid rates are dropped and reported as error.
+// Slot granularity is kept since the planner selects the latest of equally priced slots.
+func Normalize(rr api.Rates) (api.Rates, error) {
+	var errs []error
+
+	res := make(api.Rates, 0, len(rr))
+	for _, r := range rr {
+		switch {
+		case !r.End.After(r.Start):
+			errs = append(errs, fmt.Errorf("invalid rate %s: end before start", r.Start.Local().Format(time.RFC3339)))
+		case math.IsNaN(r.Price) || math.IsInf(r.Price, 0):
+			errs = append(errs, fmt.Errorf("invalid rate %s: price %v", r.Start.Local().Format(time.RFC3339), r.Price))
+		default:
+			res = append(res, r)
+		}
+	}
+
+	// stable sort keeps provider order for duplicates
+	slices.SortStableFunc(res, func(a, b api.Rate) int {
+		return a.Start.Compare(b.Start)
+	})
+
+	out := make(api.Rates, 0, len(res))
+	for i := 0; i < len(res); i++ {
+		r := res[i]
+
+		if len(out) == 0 {
+			out = append(out, r)
+			continue
+		}
+
+		prev := &out[len(out)-1]
+
+		// rate enclosed by preceding rate, keep the preceding rate's tail
+		if r.Start.Before(prev.End) && r.End.Before(prev.End) {
+			tail := *prev
+			tail.Start = r.End
+
+			idx, _ := slices.BinarySearchFunc(res[i+1:], tail, func(a, b api.Rate) int {
+				return a.Start.Compare(b.Start)
+			})
+			res = slices.Insert(res, i+1+idx, tail)
+		}
+
+		switch {
+		case r.Start.Equal(prev.Start):
+			// duplicate, latest wins
+			*prev = r
+			continue
+
+		case r.Start.Before(prev.End):
+			// overlap, truncate preceding rate
+			prev.End = r.Start
+
+		case r.Start.Sub(prev.End) <= normalizeMaxGap:
+			// small gap, extend preceding rate
+			prev.End = r.Start
+		}
+
+		out = append(out, r)
+	}
+
+	return out, errors.Join(errs...)
+}
+
+// Merge merges adjacent rates of equal price for display
+func Merge(rr api.Rates) api.Rates {
+	if len(rr) == 0 {
+		return rr
+	}
+
+	res := make(api.Rates, 0, len(rr))
+	for _, r := range rr {
+		if n := len(res); n > 0 && res[n-1].End.Equal(r.Start) && res[n-1].Price == r.Price {
+			res[n-1].End = r.End
+			continue
+		}
+		res = append(res, r)
+	}
+	return res
+}
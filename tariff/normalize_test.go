@@ -0,0 +1,64 @@
+package tariff
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalize(t *testing.T) {
+	ts := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	rate := func(from, to int, price float64) api.Rate {
+		return api.Rate{
+			Start: ts.Add(time.Duration(from) * 15 * time.Minute),
+			End:   ts.Add(time.Duration(to) * 15 * time.Minute),
+			Price: price,
+		}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		in, out api.Rates
+		err     bool
+	}{
+		{"empty", nil, api.Rates{}, false},
+		{"unsorted", api.Rates{rate(1, 2, 2), rate(0, 1, 1)}, api.Rates{rate(0, 1, 1), rate(1, 2, 2)}, false},
+		{"duplicate", api.Rates{rate(0, 1, 1), rate(0, 1, 2)}, api.Rates{rate(0, 1, 2)}, false},
+		{"overlap", api.Rates{rate(0, 2, 1), rate(1, 2, 2)}, api.Rates{rate(0, 1, 1), rate(1, 2, 2)}, false},
+		{"enclosed", api.Rates{rate(0, 16, 1), rate(4, 8, 2)}, api.Rates{rate(0, 4, 1), rate(4, 8, 2), rate(8, 16, 1)}, false},
+		{"enclosed duplicate", api.Rates{rate(0, 4, 1), rate(0, 1, 2)}, api.Rates{rate(0, 1, 2), rate(1, 4, 1)}, false},
+		{"enclosed followed", api.Rates{rate(0, 16, 1), rate(4, 8, 2), rate(12, 13, 3)}, api.Rates{rate(0, 4, 1), rate(4, 8, 2), rate(8, 12, 1), rate(12, 13, 3), rate(13, 16, 1)}, false},
+		{"keep equal", api.Rates{rate(0, 1, 1), rate(1, 2, 1), rate(2, 3, 2)}, api.Rates{rate(0, 1, 1), rate(1, 2, 1), rate(2, 3, 2)}, false},
+		{"small gap", api.Rates{rate(0, 1, 1), rate(2, 3, 2)}, api.Rates{rate(0, 2, 1), rate(2, 3, 2)}, false},
+		{"large gap", api.Rates{rate(0, 1, 1), rate(3, 4, 2)}, api.Rates{rate(0, 1, 1), rate(3, 4, 2)}, false},
+		{"invalid", api.Rates{rate(0, 1, 1), rate(1, 1, 2), rate(1, 2, math.NaN())}, api.Rates{rate(0, 1, 1)}, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := Normalize(tc.in)
+			if tc.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.out, res)
+		})
+	}
+}
+
+func TestMerge(t *testing.T) {
+	ts := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	rate := func(from, to int, price float64) api.Rate {
+		return api.Rate{
+			Start: ts.Add(time.Duration(from) * time.Hour),
+			End:   ts.Add(time.Duration(to) * time.Hour),
+			Price: price,
+		}
+	}
+
+	assert.Equal(t, api.Rates{rate(0, 2, 1), rate(2, 3, 2), rate(4, 5, 2)},
+		Merge(api.Rates{rate(0, 1, 1), rate(1, 2, 1), rate(2, 3, 2), rate(4, 5, 2)}))
+}
@@ -11,6 +11,7 @@ import (
 	"github.com/evcc-io/evcc/api"
 	octoGql "github.com/evcc-io/evcc/tariff/octopus/graphql"
 	octoRest "github.com/evcc-io/evcc/tariff/octopus/rest"
+	"github.com/evcc-io/evcc/tariff/zone"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/request"
 )
@@ -45,7 +46,7 @@ func NewOctopusFromConfig(other map[string]interface{}) (api.Tariff, error) {
 
 	// Allow ApiKey to be missing only if Region and Tariff are not.
 	if cc.ApiKey == "" {
-		if cc.Region == "" {
+		if cc.Region = zone.Select(logger, "octopus", cc.Region); cc.Region == "" {
 			return nil, errors.New("missing region")
 		}
 		if cc.Tariff != "" {
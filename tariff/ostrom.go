@@ -85,7 +85,7 @@ func NewOstromFromConfig(other map[string]interface{}) (api.Tariff, error) {
 
 	t.Client.Transport = &oauth2.Transport{
 		Base:   t.Client.Transport,
-		Source: oauth.RefreshTokenSource(nil, t),
+		Source: oauth.Monitor("tariff.ostrom", "Ostrom", oauth.RefreshTokenSource(nil, t)),
 	}
 
 	contracts, err := t.getContracts()
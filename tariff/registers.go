@@ -0,0 +1,214 @@
+package tariff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/plugin"
+	"github.com/evcc-io/evcc/tariff/fixed"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+	"github.com/jinzhu/now"
+)
+
+// Registers combines the tariffs of a dual-register meter (high/low tariff). The low tariff applies
+// within its time windows or while signalled by the meter's register switch.
+type Registers struct {
+	log       *util.Logger
+	clock     clock.Clock
+	high, low api.Tariff
+	windows   []registerWindow
+	calendar  *fixed.Calendar
+	registerG func() (bool, error)
+}
+
+// registerWindow is a time window of the low tariff
+type registerWindow struct {
+	days  []fixed.Day
+	hours fixed.TimeRange
+}
+
+var _ api.Tariff = (*Registers)(nil)
+
+func init() {
+	registry.AddCtx("registers", NewRegistersFromConfig)
+}
+
+// NewRegistersFromConfig creates a dual-register tariff
+func NewRegistersFromConfig(ctx context.Context, other map[string]interface{}) (api.Tariff, error) {
+	var cc struct {
+		High, Low  config.Typed
+		Windows    []struct{ Days, Hours string } // low tariff time windows
+		Holidays   string                         // country code, holidays are treated as sundays
+		Exceptions []string                       // additional dates treated as sundays
+		Register   *plugin.Config                 // low tariff register active
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.High.Type == "" || cc.Low.Type == "" {
+		return nil, errors.New("need high and low tariff")
+	}
+
+	if len(cc.Windows) == 0 && cc.Register == nil {
+		return nil, errors.New("need low tariff windows or register")
+	}
+
+	calendar, err := fixed.NewCalendar(cc.Holidays, cc.Exceptions)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Registers{
+		log:      util.NewLogger("registers"),
+		clock:    clock.New(),
+		calendar: calendar,
+	}
+
+	for _, w := range cc.Windows {
+		days, err := fixed.ParseDays(w.Days)
+		if err != nil {
+			return nil, err
+		}
+
+		hours := []fixed.TimeRange{{}}
+		if w.Hours != "" {
+			if hours, err = fixed.ParseTimeRanges(w.Hours); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, h := range hours {
+			t.windows = append(t.windows, registerWindow{days: days, hours: h})
+		}
+	}
+
+	if t.registerG, err = cc.Register.BoolGetter(ctx); err != nil {
+		return nil, fmt.Errorf("register: %w", err)
+	}
+
+	if t.high, err = NewFromConfig(ctx, cc.High.Type, cc.High.Other); err != nil {
+		return nil, fmt.Errorf("high: %w", err)
+	}
+
+	if t.low, err = NewFromConfig(ctx, cc.Low.Type, cc.Low.Other); err != nil {
+		return nil, fmt.Errorf("low: %w", err)
+	}
+
+	return t, nil
+}
+
+// lowWindow checks if the low tariff time windows apply at given time
+func (t *Registers) lowWindow(ts time.Time) bool {
+	dow := fixed.Day(ts.Weekday())
+	if t.calendar.IsHoliday(ts) {
+		dow = fixed.Sunday
+	}
+
+	hm := fixed.HourMin{Hour: ts.Hour(), Min: ts.Minute()}
+
+	for _, w := range t.windows {
+		if slices.Contains(w.days, dow) && w.hours.Contains(hm) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// boundaries returns the start and end times of the low tariff windows between from and to
+func (t *Registers) boundaries(from, to time.Time) []time.Time {
+	var res []time.Time
+
+	for day := now.With(from.Local()).BeginningOfDay(); day.Before(to); day = day.AddDate(0, 0, 1) {
+		for _, w := range t.windows {
+			res = append(res, day.Add(time.Duration(w.hours.From.Minutes())*time.Minute))
+			if !w.hours.To.IsNil() {
+				res = append(res, day.Add(time.Duration(w.hours.To.Minutes())*time.Minute))
+			}
+		}
+	}
+
+	return res
+}
+
+// Rates implements the api.Tariff interface. The register signal applies from now until the next
+// boundary, later rates follow the low tariff time windows.
+func (t *Registers) Rates() (api.Rates, error) {
+	high, err := t.high.Rates()
+	if err != nil {
+		return nil, fmt.Errorf("high: %w", err)
+	}
+
+	low, err := t.low.Rates()
+	if err != nil {
+		return nil, fmt.Errorf("low: %w", err)
+	}
+
+	var bounds []time.Time
+	for _, r := range append(slices.Clone(high), low...) {
+		bounds = append(bounds, r.Start, r.End)
+	}
+
+	if len(bounds) == 0 {
+		return nil, nil
+	}
+
+	from, to := slices.MinFunc(bounds, time.Time.Compare), slices.MaxFunc(bounds, time.Time.Compare)
+	bounds = append(bounds, t.boundaries(from, to)...)
+
+	ts := t.clock.Now().Truncate(time.Minute)
+
+	var register *bool
+	if t.registerG != nil {
+		if active, err := t.registerG(); err == nil {
+			register = &active
+			bounds = append(bounds, ts)
+		} else {
+			t.log.ERROR.Printf("register: %v", err)
+		}
+	}
+
+	slices.SortFunc(bounds, time.Time.Compare)
+	bounds = slices.CompactFunc(bounds, time.Time.Equal)
+
+	var res api.Rates
+	for i := 0; i+1 < len(bounds); i++ {
+		start, end := bounds[i], bounds[i+1]
+		if start.Before(from) || end.After(to) {
+			continue
+		}
+
+		isLow := t.lowWindow(start)
+		if register != nil && start.Equal(ts) {
+			isLow = *register
+		}
+
+		rr := high
+		if isLow {
+			rr = low
+		}
+
+		r, err := rr.At(start)
+		if err != nil {
+			continue
+		}
+
+		res = append(res, api.Rate{Start: start, End: end, Price: r.Price})
+	}
+
+	return res, nil
+}
+
+// Type implements the api.Tariff interface
+func (t *Registers) Type() api.TariffType {
+	// prices change with the active register
+	return max(t.high.Type(), t.low.Type(), api.TariffTypePriceDynamic)
+}
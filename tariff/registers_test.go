@@ -0,0 +1,81 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/tariff/fixed"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisters(t *testing.T) {
+	// wednesday
+	day := time.Date(2025, 7, 2, 0, 0, 0, 0, time.Local)
+
+	prices := func(price float64) ratesTariff {
+		var res ratesTariff
+		for i := range 24 {
+			ts := day.Add(time.Duration(i) * time.Hour)
+			res = append(res, api.Rate{Start: ts, End: ts.Add(time.Hour), Price: price})
+		}
+		return res
+	}
+
+	hours, err := fixed.ParseTimeRanges("0-6,22:30-0")
+	require.NoError(t, err)
+
+	clock := clock.NewMock()
+	clock.Set(day.Add(12*time.Hour + 10*time.Minute))
+
+	tf := &Registers{
+		clock: clock,
+		high:  prices(0.3),
+		low:   prices(0.2),
+	}
+
+	for _, h := range hours {
+		tf.windows = append(tf.windows, registerWindow{days: fixed.Week, hours: h})
+	}
+
+	rr, err := tf.Rates()
+	require.NoError(t, err)
+
+	for _, tc := range []struct {
+		ts    time.Duration
+		price float64
+	}{
+		{5 * time.Hour, 0.2},
+		{6 * time.Hour, 0.3},
+		{22 * time.Hour, 0.3},
+		{22*time.Hour + 30*time.Minute, 0.2},
+		{23 * time.Hour, 0.2},
+	} {
+		r, err := rr.At(day.Add(tc.ts))
+		require.NoError(t, err)
+		assert.Equal(t, tc.price, r.Price, tc.ts)
+	}
+
+	// register signals low tariff until next boundary
+	tf.registerG = func() (bool, error) { return true, nil }
+
+	rr, err = tf.Rates()
+	require.NoError(t, err)
+
+	r, err := rr.At(clock.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 0.2, r.Price)
+	assert.Equal(t, day.Add(13*time.Hour), r.End)
+
+	r, err = rr.At(day.Add(12 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0.3, r.Price, "before register signal")
+
+	r, err = rr.At(day.Add(13 * time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0.3, r.Price, "after register signal")
+
+	assert.Equal(t, api.TariffTypePriceForecast, tf.Type())
+}
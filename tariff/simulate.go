@@ -0,0 +1,47 @@
+package tariff
+
+import (
+	"errors"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+// ErrNotCovered is returned if rates do not cover the simulated period
+var ErrNotCovered = errors.New("rates do not cover the period")
+
+// PeriodRates is implemented by tariffs deriving the rates of arbitrary periods from their configuration
+type PeriodRates interface {
+	RatesBetween(from, to time.Time) (api.Rates, error)
+}
+
+// Covering returns the rates covering the period between from and to
+func Covering(rr api.Rates, from, to time.Time) (api.Rates, error) {
+	if len(rr) == 0 || rr[0].Start.After(from) || rr[len(rr)-1].End.Before(to) {
+		return nil, ErrNotCovered
+	}
+
+	return Between(rr, from, to), nil
+}
+
+// EnergyCost returns the cost of energy (kWh) consumed evenly between from and to at the given rates
+func EnergyCost(rr api.Rates, from, to time.Time, energy float64) (float64, error) {
+	if !to.After(from) {
+		return 0, errors.New("invalid period")
+	}
+
+	rr, err := Covering(rr, from, to)
+	if err != nil {
+		return 0, err
+	}
+
+	// constant power (W) consuming the energy within the period
+	power := api.Rates{{Start: from, End: to, Price: 1e3 * energy / to.Sub(from).Hours()}}
+
+	var res float64
+	for _, r := range rr {
+		res += r.Price * AccumulatedEnergy(power, r.Start, r.End)
+	}
+
+	return res, nil
+}
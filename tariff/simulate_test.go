@@ -0,0 +1,41 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnergyCost(t *testing.T) {
+	ts := time.Date(2024, 6, 3, 0, 0, 0, 0, time.UTC)
+
+	// one week of day/night rates
+	var rr api.Rates
+	for i := range 7 {
+		day := ts.AddDate(0, 0, i)
+		rr = append(rr,
+			api.Rate{Start: day, End: day.Add(6 * time.Hour), Price: 0.1},
+			api.Rate{Start: day.Add(6 * time.Hour), End: day.AddDate(0, 0, 1), Price: 0.3},
+		)
+	}
+
+	// covered by rates
+	res, err := EnergyCost(rr, ts.Add(4*time.Hour), ts.Add(8*time.Hour), 10)
+	require.NoError(t, err)
+	assert.InDelta(t, 5*0.1+5*0.3, res, 1e-6)
+
+	// spanning day boundary
+	res, err = EnergyCost(rr, ts.AddDate(0, 0, 1).Add(-2*time.Hour), ts.AddDate(0, 0, 1).Add(2*time.Hour), 8)
+	require.NoError(t, err)
+	assert.InDelta(t, 4*0.3+4*0.1, res, 1e-6)
+
+	// not covered by rates
+	_, err = EnergyCost(rr, ts.Add(-2*time.Hour), ts.Add(2*time.Hour), 8)
+	assert.ErrorIs(t, err, ErrNotCovered)
+
+	_, err = EnergyCost(rr, ts.AddDate(0, 0, 6), ts.AddDate(0, 0, 8), 8)
+	assert.ErrorIs(t, err, ErrNotCovered)
+}
@@ -68,19 +68,14 @@ func (t *SmartEnergy) run(done chan error) {
 
 		data := make(api.Rates, 0, len(res.Data))
 		for _, r := range res.Data {
-			if r.Date.Minute() != 0 {
-				continue
-			}
-
 			ar := api.Rate{
 				Start: r.Date.Local(),
-				End:   r.Date.Add(time.Hour).Local(),
 				Price: t.totalPrice(r.Value/100, r.Date),
 			}
 			data = append(data, ar)
 		}
 
-		mergeRates(t.data, data)
+		mergeRates(t.data, slotEnds(data))
 		once.Do(func() { close(done) })
 	}
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/tariff/solcast"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/ratelimit"
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/util/transport"
 	"github.com/jinzhu/now"
@@ -34,8 +35,10 @@ func NewSolcastFromConfig(other map[string]interface{}) (api.Tariff, error) {
 		Site     string
 		Token    string
 		Interval time.Duration
+		Quota    int // requests per day shared by all sites of the account
 	}{
 		Interval: 3 * time.Hour,
+		Quota:    10,
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
@@ -59,7 +62,8 @@ func NewSolcastFromConfig(other map[string]interface{}) (api.Tariff, error) {
 		data:   util.NewMonitor[api.Rates](2 * cc.Interval),
 	}
 
-	t.Client.Transport = transport.BearerAuth(cc.Token, t.Client.Transport)
+	limiter := ratelimit.New("solcast", cc.Token, cc.Quota, 24*time.Hour)
+	t.Client.Transport = limiter.Transport(transport.BearerAuth(cc.Token, t.Client.Transport), 0)
 
 	done := make(chan error)
 	go t.run(cc.Interval, done)
@@ -0,0 +1,113 @@
+package tariff
+
+import (
+	"fmt"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+// StromGedacht provides the regional grid state forecast of TransnetBW
+// https://www.stromgedacht.de/api-docs
+type StromGedacht struct {
+	*request.Helper
+	log  *util.Logger
+	zip  string
+	data *util.Monitor[api.Rates]
+}
+
+var _ api.Tariff = (*StromGedacht)(nil)
+
+func init() {
+	registry.Add("stromgedacht", NewStromGedachtFromConfig)
+}
+
+func NewStromGedachtFromConfig(other map[string]interface{}) (api.Tariff, error) {
+	var cc struct {
+		Zip string
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Zip == "" {
+		return nil, fmt.Errorf("missing zip")
+	}
+
+	log := util.NewLogger("stromgedacht").Redact(cc.Zip)
+
+	t := &StromGedacht{
+		log:    log,
+		zip:    cc.Zip,
+		Helper: request.NewHelper(log),
+		data:   util.NewMonitor[api.Rates](2 * time.Hour),
+	}
+
+	done := make(chan error)
+	go t.run(done)
+	err := <-done
+
+	return t, err
+}
+
+func (t *StromGedacht) run(done chan error) {
+	var once sync.Once
+
+	for tick := time.Tick(15 * time.Minute); ; <-tick {
+		var res struct {
+			States []struct {
+				From  time.Time `json:"from"`
+				To    time.Time `json:"to"`
+				State int       `json:"state"`
+			} `json:"states"`
+		}
+
+		uri := "https://api.stromgedacht.de/v1/states?" + url.Values{
+			"zip":           {t.zip},
+			"hoursInPast":   {"0"},
+			"hoursInFuture": {"48"},
+		}.Encode()
+
+		if err := backoff.Retry(func() error {
+			return backoffPermanentError(t.GetJSON(uri, &res))
+		}, bo()); err != nil {
+			once.Do(func() { done <- err })
+
+			t.log.ERROR.Println(err)
+			continue
+		}
+
+		data := make(api.Rates, 0, len(res.States))
+		for _, r := range res.States {
+			data = append(data, api.Rate{
+				Price: float64(r.State),
+				Start: r.From.Local(),
+				End:   r.To.Local(),
+			})
+		}
+
+		mergeRates(t.data, data)
+		once.Do(func() { close(done) })
+	}
+}
+
+// Rates implements the api.Tariff interface
+func (t *StromGedacht) Rates() (api.Rates, error) {
+	var res api.Rates
+	err := t.data.GetFunc(func(val api.Rates) {
+		res = slices.Clone(val)
+	})
+	return res, err
+}
+
+// Type implements the api.Tariff interface
+func (t *StromGedacht) Type() api.TariffType {
+	return api.TariffTypeGridState
+}
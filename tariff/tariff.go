@@ -137,14 +137,14 @@ func (t *Tariff) priceRates() (api.Rates, error) {
 		return nil, err
 	}
 
-	res := make(api.Rates, 48)
+	res := make(api.Rates, 48*time.Hour/slotDuration)
 	start := now.BeginningOfHour()
 
 	for i := range res {
-		slot := start.Add(time.Duration(i) * time.Hour)
+		slot := start.Add(time.Duration(i) * slotDuration)
 		res[i] = api.Rate{
 			Start: slot,
-			End:   slot.Add(time.Hour),
+			End:   slot.Add(slotDuration),
 			Price: t.totalPrice(price, slot),
 		}
 	}
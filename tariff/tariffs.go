@@ -9,10 +9,20 @@ import (
 )
 
 type Tariffs struct {
-	Currency                          currency.Unit
-	Grid, FeedIn, Co2, Planner, Solar api.Tariff
+	Currency                                                  currency.Unit
+	Grid, FeedIn, Co2, Planner, Solar, GridState, Temperature api.Tariff
+
+	Co2Marginal  api.Tariff // marginal co2 intensity
+	Co2Intensity string     // co2 intensity used for effective co2 and planning, average or marginal
+
+	Comparison map[string]api.Tariff // alternative grid tariffs by name
 }
 
+const (
+	Co2IntensityAverage  = "average"
+	Co2IntensityMarginal = "marginal"
+)
+
 // At returns the rate at the given time
 func At(t api.Tariff, ts time.Time) (api.Rate, error) {
 	if t != nil {
@@ -41,10 +51,67 @@ func Forecast(t api.Tariff) api.Rates {
 	return nil
 }
 
+// Between returns the rates overlapping the period between from and to. Zero times leave the period open.
+func Between(rr api.Rates, from, to time.Time) api.Rates {
+	var res api.Rates
+	for _, r := range rr {
+		if (from.IsZero() || r.End.After(from)) && (to.IsZero() || r.Start.Before(to)) {
+			res = append(res, r)
+		}
+	}
+	return res
+}
+
+// AccumulatedEnergy returns the energy (kWh) of a power (W) forecast between from and to
+func AccumulatedEnergy(rr api.Rates, from, to time.Time) float64 {
+	var res float64
+	for _, r := range Between(rr, from, to) {
+		start, end := r.Start, r.End
+		if !from.IsZero() && start.Before(from) {
+			start = from
+		}
+		if !to.IsZero() && end.After(to) {
+			end = to
+		}
+
+		res += r.Price * end.Sub(start).Hours() / 1e3
+	}
+	return res
+}
+
+// RawNow returns the raw price a composed tariff's current price is based on
+func RawNow(t api.Tariff) (float64, error) {
+	if pc, ok := As[api.PriceComposer](t); ok {
+		return pc.RawPrice(time.Now())
+	}
+	return 0, api.ErrNotAvailable
+}
+
+// RawForecast returns the forecast of a composed tariff with raw prices
+func RawForecast(t api.Tariff) api.Rates {
+	pc, ok := As[api.PriceComposer](t)
+	if !ok {
+		return nil
+	}
+
+	var res api.Rates
+	for _, r := range Forecast(t) {
+		if price, err := pc.RawPrice(r.Start); err == nil {
+			r.Price = price
+			res = append(res, r)
+		}
+	}
+
+	return res
+}
+
 func (t *Tariffs) Get(u api.TariffUsage) api.Tariff {
 	switch u {
 	case api.TariffUsageCo2:
-		return t.Co2
+		return t.co2()
+
+	case api.TariffUsageCo2Marginal:
+		return t.Co2Marginal
 
 	case api.TariffUsageFeedIn:
 		return t.FeedIn
@@ -63,9 +130,9 @@ func (t *Tariffs) Get(u api.TariffUsage) api.Tariff {
 			// prio 1: grid tariff with forecast
 			return t.Grid
 
-		case t.Co2 != nil:
+		case t.co2() != nil:
 			// prio 2: co2 tariff
-			return t.Co2
+			return t.co2()
 
 		default:
 			// prio 3: static grid tariff
@@ -75,7 +142,21 @@ func (t *Tariffs) Get(u api.TariffUsage) api.Tariff {
 	case api.TariffUsageSolar:
 		return t.Solar
 
+	case api.TariffUsageGridState:
+		return t.GridState
+
+	case api.TariffUsageTemperature:
+		return t.Temperature
+
 	default:
 		return nil
 	}
 }
+
+// co2 returns the co2 tariff selected for effective co2 and planning
+func (t *Tariffs) co2() api.Tariff {
+	if t.Co2Intensity == Co2IntensityMarginal && t.Co2Marginal != nil {
+		return t.Co2Marginal
+	}
+	return t.Co2
+}
@@ -0,0 +1,39 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTariffsCo2Intensity(t *testing.T) {
+	average, marginal := ratesTariff{{Price: 300}}, ratesTariff{{Price: 700}}
+
+	tt := Tariffs{Co2: average, Co2Marginal: marginal}
+	assert.Equal(t, average, tt.Get(api.TariffUsageCo2))
+	assert.Equal(t, marginal, tt.Get(api.TariffUsageCo2Marginal))
+
+	tt.Co2Intensity = Co2IntensityMarginal
+	assert.Equal(t, marginal, tt.Get(api.TariffUsageCo2))
+	assert.Equal(t, marginal, tt.Get(api.TariffUsagePlanner))
+}
+
+func TestAccumulatedEnergy(t *testing.T) {
+	ts := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	rr := api.Rates{
+		{Start: ts, End: ts.Add(time.Hour), Price: 1000},
+		{Start: ts.Add(time.Hour), End: ts.Add(2 * time.Hour), Price: 2000},
+		{Start: ts.Add(2 * time.Hour), End: ts.Add(3 * time.Hour), Price: 3000},
+	}
+
+	assert.Len(t, Between(rr, time.Time{}, time.Time{}), 3)
+	assert.Len(t, Between(rr, ts.Add(30*time.Minute), ts.Add(90*time.Minute)), 2)
+	assert.Len(t, Between(rr, ts.Add(time.Hour), ts.Add(2*time.Hour)), 1)
+
+	assert.InDelta(t, 6.0, AccumulatedEnergy(rr, time.Time{}, time.Time{}), 1e-6)
+	assert.InDelta(t, 1.5, AccumulatedEnergy(rr, ts.Add(30*time.Minute), ts.Add(90*time.Minute)), 1e-6)
+	assert.InDelta(t, 5.0, AccumulatedEnergy(rr, ts.Add(time.Hour), time.Time{}), 1e-6)
+}
@@ -103,7 +103,7 @@ func (t *Tibber) run(done chan error) {
 		}
 
 		pi := res.Viewer.Home.CurrentSubscription.PriceInfo
-		data := append(t.rates(pi.Today), t.rates(pi.Tomorrow)...)
+		data := slotEnds(append(t.rates(pi.Today), t.rates(pi.Tomorrow)...))
 
 		mergeRates(t.data, data)
 		once.Do(func() { close(done) })
@@ -119,7 +119,6 @@ func (t *Tibber) rates(pi []tibber.Price) api.Rates {
 		}
 		ar := api.Rate{
 			Start: r.StartsAt.Local(),
-			End:   r.StartsAt.Add(time.Hour).Local(),
 			Price: price,
 		}
 		data = append(data, ar)
@@ -0,0 +1,149 @@
+package tariff
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/jinzhu/now"
+)
+
+// Tiered is a tariff where the unit price depends on the cumulative grid import within the billing period
+type Tiered struct {
+	clock   clock.Clock
+	monthly bool
+	tiers   []tier
+
+	mu          sync.Mutex
+	consumption float64 // grid import of current billing period (kWh)
+}
+
+// tier applies its price up to the cumulative energy limit, unlimited if zero
+type tier struct {
+	Energy float64 // kWh
+	Price  float64
+}
+
+var (
+	_ api.Tariff       = (*Tiered)(nil)
+	_ api.TieredTariff = (*Tiered)(nil)
+)
+
+func init() {
+	registry.Add("tiered", NewTieredFromConfig)
+}
+
+// NewTieredFromConfig creates a tiered tariff
+func NewTieredFromConfig(other map[string]interface{}) (api.Tariff, error) {
+	cc := struct {
+		Period string // billing period, yearly or monthly
+		Tiers  []tier
+	}{
+		Period: "yearly",
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	var monthly bool
+	switch strings.ToLower(cc.Period) {
+	case "yearly":
+	case "monthly":
+		monthly = true
+	default:
+		return nil, fmt.Errorf("invalid period: %s", cc.Period)
+	}
+
+	return NewTiered(cc.Tiers, monthly)
+}
+
+// NewTiered creates a tiered tariff. All but the last tier require ascending energy limits.
+func NewTiered(tiers []tier, monthly bool) (*Tiered, error) {
+	if len(tiers) == 0 {
+		return nil, errors.New("missing tiers")
+	}
+
+	for i, t := range tiers[:len(tiers)-1] {
+		if t.Energy <= 0 || i > 0 && t.Energy <= tiers[i-1].Energy {
+			return nil, fmt.Errorf("tier %d: invalid energy limit", i+1)
+		}
+	}
+
+	return &Tiered{
+		clock:   clock.New(),
+		monthly: monthly,
+		tiers:   slices.Clone(tiers),
+	}, nil
+}
+
+// TierPeriod implements the api.TieredTariff interface
+func (t *Tiered) TierPeriod(ts time.Time) time.Time {
+	if t.monthly {
+		return now.With(ts).BeginningOfMonth()
+	}
+	return now.With(ts).BeginningOfYear()
+}
+
+// SetTierConsumption implements the api.TieredTariff interface
+func (t *Tiered) SetTierConsumption(energy float64) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.consumption = energy
+}
+
+// price returns the price of the active tier
+func (t *Tiered) price() float64 {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for _, tier := range t.tiers[:len(t.tiers)-1] {
+		if t.consumption < tier.Energy {
+			return tier.Price
+		}
+	}
+
+	return t.tiers[len(t.tiers)-1].Price
+}
+
+// Rates implements the api.Tariff interface. The active tier's price applies until the end of the billing period.
+func (t *Tiered) Rates() (api.Rates, error) {
+	price := t.price()
+
+	start := now.With(t.clock.Now().Local()).BeginningOfDay()
+	end := t.TierPeriod(start)
+	if t.monthly {
+		end = end.AddDate(0, 1, 0)
+	} else {
+		end = end.AddDate(1, 0, 0)
+	}
+
+	var res api.Rates
+	for i := range 7 {
+		dayStart := start.AddDate(0, 0, i)
+
+		// consumption restarts in first tier with next billing period
+		if !dayStart.Before(end) {
+			price = t.tiers[0].Price
+		}
+
+		res = append(res, api.Rate{
+			Price: price,
+			Start: dayStart,
+			End:   dayStart.AddDate(0, 0, 1),
+		})
+	}
+
+	return res, nil
+}
+
+// Type implements the api.Tariff interface
+func (t *Tiered) Type() api.TariffType {
+	return api.TariffTypePriceStatic
+}
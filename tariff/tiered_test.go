@@ -0,0 +1,44 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTiered(t *testing.T) {
+	_, err := NewTiered([]tier{{Energy: 2000, Price: 0.2}, {Energy: 1000, Price: 0.3}, {Price: 0.4}}, false)
+	assert.Error(t, err, "descending limits")
+
+	tt, err := NewTiered([]tier{{Energy: 2000, Price: 0.2}, {Energy: 4000, Price: 0.3}, {Price: 0.4}}, false)
+	require.NoError(t, err)
+
+	clock := clock.NewMock()
+	clock.Set(time.Date(2025, 12, 29, 12, 0, 0, 0, time.Local))
+	tt.clock = clock
+
+	assert.Equal(t, time.Date(2025, 1, 1, 0, 0, 0, 0, time.Local), tt.TierPeriod(clock.Now()))
+
+	for _, tc := range []struct {
+		energy, price float64
+	}{
+		{0, 0.2},
+		{1999, 0.2},
+		{2000, 0.3},
+		{5000, 0.4},
+	} {
+		tt.SetTierConsumption(tc.energy)
+
+		rr, err := tt.Rates()
+		require.NoError(t, err)
+		require.Len(t, rr, 7)
+		assert.Equal(t, tc.price, rr[0].Price, tc.energy)
+
+		// next billing period starts in first tier
+		assert.Equal(t, tc.price, rr[2].Price, tc.energy)
+		assert.Equal(t, 0.2, rr[3].Price, tc.energy)
+	}
+}
@@ -0,0 +1,189 @@
+package tariff
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/evcc-io/evcc/util/transport"
+)
+
+// WattTime provides the marginal operating emissions rate (MOER) of the grid region
+type WattTime struct {
+	*request.Helper
+	log            *util.Logger
+	user, password string
+	region         string
+	data           *util.Monitor[api.Rates]
+}
+
+const (
+	wattTimeURI = "https://api.watttime.org"
+
+	// lbsPerMWhToGramsPerKWh converts WattTime emission rates
+	lbsPerMWhToGramsPerKWh = 0.45359237
+)
+
+type wattTimeForecast struct {
+	Data []struct {
+		PointTime time.Time `json:"point_time"`
+		Value     float64   `json:"value"` // lbs/MWh
+	} `json:"data"`
+	Meta struct {
+		Period int `json:"data_point_period_seconds"`
+	} `json:"meta"`
+}
+
+var _ api.Tariff = (*WattTime)(nil)
+
+func init() {
+	registry.Add("watttime", NewWattTimeFromConfig)
+}
+
+// NewWattTimeFromConfig creates a marginal co2 intensity tariff
+func NewWattTimeFromConfig(other map[string]interface{}) (api.Tariff, error) {
+	var cc struct {
+		User, Password string
+		Region         string
+		Lat, Lon       float64 // region lookup if region is not configured
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.User == "" || cc.Password == "" {
+		return nil, api.ErrMissingCredentials
+	}
+
+	if cc.Region == "" && cc.Lat == 0 && cc.Lon == 0 {
+		return nil, errors.New("missing region or lat/lon")
+	}
+
+	log := util.NewLogger("watttime").Redact(cc.Password)
+
+	t := &WattTime{
+		log:      log,
+		Helper:   request.NewHelper(log),
+		user:     cc.User,
+		password: cc.Password,
+		region:   cc.Region,
+		data:     util.NewMonitor[api.Rates](2 * time.Hour),
+	}
+
+	if t.region == "" {
+		region, err := t.lookupRegion(cc.Lat, cc.Lon)
+		if err != nil {
+			return nil, fmt.Errorf("region: %w", err)
+		}
+		t.region = region
+	}
+
+	done := make(chan error)
+	go t.run(done)
+	err := <-done
+
+	return t, err
+}
+
+// login returns a bearer token valid for 30 minutes
+func (t *WattTime) login() (string, error) {
+	var res struct {
+		Token string `json:"token"`
+	}
+
+	req, err := request.New(http.MethodGet, wattTimeURI+"/login", nil, map[string]string{
+		"Authorization": transport.BasicAuthHeader(t.user, t.password),
+	})
+	if err == nil {
+		err = t.DoJSON(req, &res)
+	}
+
+	return res.Token, err
+}
+
+// get retrieves the api resource with fresh authorization
+func (t *WattTime) get(uri string, res any) error {
+	token, err := t.login()
+	if err != nil {
+		return fmt.Errorf("login: %w", err)
+	}
+
+	req, err := request.New(http.MethodGet, uri, nil, map[string]string{
+		"Authorization": "Bearer " + token,
+	}, request.AcceptJSON)
+	if err != nil {
+		return err
+	}
+
+	return t.DoJSON(req, res)
+}
+
+func (t *WattTime) lookupRegion(lat, lon float64) (string, error) {
+	var res struct {
+		Region string `json:"region"`
+	}
+
+	uri := fmt.Sprintf("%s/v3/region-from-loc?latitude=%.4f&longitude=%.4f&signal_type=co2_moer", wattTimeURI, lat, lon)
+	err := t.get(uri, &res)
+
+	return res.Region, err
+}
+
+func (t *WattTime) run(done chan error) {
+	var once sync.Once
+
+	uri := fmt.Sprintf("%s/v3/forecast?region=%s&signal_type=co2_moer", wattTimeURI, url.QueryEscape(t.region))
+
+	for tick := time.Tick(time.Hour); ; <-tick {
+		var res wattTimeForecast
+
+		if err := backoff.Retry(func() error {
+			return backoffPermanentError(t.get(uri, &res))
+		}, bo()); err != nil {
+			once.Do(func() { done <- err })
+
+			t.log.ERROR.Println(err)
+			continue
+		}
+
+		period := time.Duration(res.Meta.Period) * time.Second
+		if period <= 0 {
+			period = 5 * time.Minute
+		}
+
+		data := make(api.Rates, 0, len(res.Data))
+		for _, r := range res.Data {
+			data = append(data, api.Rate{
+				Start: r.PointTime.Local(),
+				End:   r.PointTime.Add(period).Local(),
+				Price: r.Value * lbsPerMWhToGramsPerKWh,
+			})
+		}
+
+		mergeRates(t.data, data)
+		once.Do(func() { close(done) })
+	}
+}
+
+// Rates implements the api.Tariff interface
+func (t *WattTime) Rates() (api.Rates, error) {
+	var res api.Rates
+	err := t.data.GetFunc(func(val api.Rates) {
+		res = slices.Clone(val)
+	})
+	return res, err
+}
+
+// Type implements the api.Tariff interface
+func (t *WattTime) Type() api.TariffType {
+	return api.TariffTypeCo2
+}
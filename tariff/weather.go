@@ -0,0 +1,225 @@
+package tariff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/config"
+	"github.com/evcc-io/evcc/util/request"
+	"github.com/jinzhu/now"
+)
+
+// Weather corrects the next hours of a solar forecast by the latest open-meteo cloud cover forecast.
+// The correction is relative to the cloud cover forecasted at the beginning of the day, which
+// the wrapped forecast is assumed to be based on.
+type Weather struct {
+	api.Tariff
+	*request.Helper
+	log      *util.Logger
+	lat, lon float64
+	horizon  time.Duration
+	key      string
+
+	mu        sync.Mutex
+	reference weatherReference
+	current   map[int64]float64 // latest cloud cover by hour (%)
+}
+
+// weatherReference is the cloud cover forecast at the beginning of the day
+type weatherReference struct {
+	Day   time.Time         `json:"day"`
+	Cloud map[int64]float64 `json:"cloud"` // cloud cover by hour (%)
+}
+
+var (
+	_ api.Tariff             = (*Weather)(nil)
+	_ api.ProductionRecorder = (*Weather)(nil)
+)
+
+// bounds of the applied correction
+const (
+	weatherMinFactor = 0.25
+	weatherMaxFactor = 2
+)
+
+func init() {
+	registry.AddCtx("weather", NewWeatherFromConfig)
+}
+
+// NewWeatherFromConfig creates a weather corrected solar forecast
+func NewWeatherFromConfig(ctx context.Context, other map[string]interface{}) (api.Tariff, error) {
+	cc := struct {
+		Lat, Lon float64
+		Hours    int // correction horizon
+		Interval time.Duration
+		Tariff   config.Typed
+	}{
+		Hours:    6,
+		Interval: 30 * time.Minute,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Lat == 0 && cc.Lon == 0 {
+		return nil, errors.New("missing lat/lon")
+	}
+
+	if cc.Tariff.Type == "" {
+		return nil, errors.New("missing tariff")
+	}
+
+	base, err := NewFromConfig(ctx, cc.Tariff.Type, cc.Tariff.Other)
+	if err != nil {
+		return nil, fmt.Errorf("tariff: %w", err)
+	}
+
+	log := util.NewLogger("weather")
+
+	t := NewWeather(base, time.Duration(cc.Hours)*time.Hour)
+	t.log = log
+	t.lat, t.lon = cc.Lat, cc.Lon
+	t.key = fmt.Sprintf("solar.weather.%.2f.%.2f", cc.Lat, cc.Lon)
+	t.Helper = request.NewHelper(log)
+
+	if err := settings.Json(t.key, &t.reference); err != nil && !errors.Is(err, settings.ErrNotFound) {
+		log.WARN.Println("reference:", err)
+	}
+
+	done := make(chan error)
+	go t.run(cc.Interval, done)
+	err = <-done
+
+	return t, err
+}
+
+// NewWeather creates a solar forecast corrected by cloud cover within the horizon
+func NewWeather(base api.Tariff, horizon time.Duration) *Weather {
+	return &Weather{
+		Tariff:  base,
+		horizon: horizon,
+	}
+}
+
+func (t *Weather) run(interval time.Duration, done chan error) {
+	var once sync.Once
+
+	for ; true; <-time.Tick(interval) {
+		var res struct {
+			Hourly struct {
+				Time  []int64   `json:"time"`
+				Cloud []float64 `json:"cloud_cover"`
+			} `json:"hourly"`
+		}
+
+		if err := backoff.Retry(func() error {
+			uri := fmt.Sprintf("https://api.open-meteo.com/v1/forecast?latitude=%.4f&longitude=%.4f&hourly=cloud_cover&forecast_days=2&timeformat=unixtime", t.lat, t.lon)
+			return backoffPermanentError(t.GetJSON(uri, &res))
+		}, bo()); err != nil {
+			once.Do(func() { done <- err })
+
+			t.log.ERROR.Println(err)
+			continue
+		}
+
+		if len(res.Hourly.Time) != len(res.Hourly.Cloud) {
+			err := errors.New("invalid cloud cover data")
+			once.Do(func() { done <- err })
+
+			t.log.ERROR.Println(err)
+			continue
+		}
+
+		cloud := make(map[int64]float64, len(res.Hourly.Time))
+		for i, ts := range res.Hourly.Time {
+			cloud[ts] = res.Hourly.Cloud[i]
+		}
+
+		if t.update(cloud, time.Now()) {
+			if err := settings.SetJson(t.key, t.reference); err != nil {
+				t.log.ERROR.Println("reference:", err)
+			}
+		}
+
+		once.Do(func() { close(done) })
+	}
+}
+
+// update stores the latest cloud cover forecast and returns true if a new day's reference was taken
+func (t *Weather) update(cloud map[int64]float64, ts time.Time) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.current = cloud
+
+	if day := now.With(ts).BeginningOfDay(); !day.Equal(t.reference.Day) {
+		t.reference = weatherReference{Day: day, Cloud: cloud}
+		return true
+	}
+
+	return false
+}
+
+// clearness is the share of clear sky irradiance at given cloud cover (Kasten & Czeplak)
+func clearness(cloud float64) float64 {
+	return 1 - 0.75*math.Pow(cloud/100, 3.4)
+}
+
+// factor returns the correction of the given hour
+func (t *Weather) factor(hour int64) float64 {
+	ref, ok := t.reference.Cloud[hour]
+	if !ok {
+		return 1
+	}
+
+	cur, ok := t.current[hour]
+	if !ok {
+		return 1
+	}
+
+	return min(max(clearness(cur)/clearness(ref), weatherMinFactor), weatherMaxFactor)
+}
+
+// rates returns the forecast corrected within the horizon from ts
+func (t *Weather) rates(ts time.Time) (api.Rates, error) {
+	rr, err := t.Tariff.Rates()
+	if err != nil {
+		return nil, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	end := now.With(ts).BeginningOfHour().Add(t.horizon)
+
+	res := slices.Clone(rr)
+	for i, r := range res {
+		if r.End.After(ts) && r.Start.Before(end) {
+			res[i].Price = r.Price * t.factor(now.With(r.Start).BeginningOfHour().Unix())
+		}
+	}
+
+	return res, nil
+}
+
+// Rates implements the api.Tariff interface
+func (t *Weather) Rates() (api.Rates, error) {
+	return t.rates(time.Now())
+}
+
+// RecordProduction implements the api.ProductionRecorder interface
+func (t *Weather) RecordProduction(ts time.Time, power float64) {
+	if pr, ok := t.Tariff.(api.ProductionRecorder); ok {
+		pr.RecordProduction(ts, power)
+	}
+}
@@ -0,0 +1,51 @@
+package tariff
+
+import (
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWeather(t *testing.T) {
+	start := time.Date(2025, 6, 1, 6, 0, 0, 0, time.Local)
+
+	var base ratesTariff
+	for i := range 12 {
+		ts := start.Add(time.Duration(i) * time.Hour)
+		base = append(base, api.Rate{Start: ts, End: ts.Add(time.Hour), Price: 1000})
+	}
+
+	cloud := func(cover float64) map[int64]float64 {
+		res := make(map[int64]float64)
+		for _, r := range base {
+			res[r.Start.Unix()] = cover
+		}
+		return res
+	}
+
+	w := NewWeather(base, 3*time.Hour)
+
+	// morning reference
+	assert.True(t, w.update(cloud(0), start))
+
+	rr, err := w.rates(start)
+	require.NoError(t, err)
+	assert.Equal(t, 1000.0, rr[0].Price)
+
+	// clouds coming in
+	ts := start.Add(4 * time.Hour)
+	assert.False(t, w.update(cloud(100), ts))
+
+	rr, err = w.rates(ts)
+	require.NoError(t, err)
+	assert.Equal(t, 1000.0, rr[3].Price, "past")
+	assert.Equal(t, 250.0, rr[4].Price)
+	assert.Equal(t, 250.0, rr[6].Price)
+	assert.Equal(t, 1000.0, rr[7].Price, "beyond horizon")
+
+	// new reference on next day
+	assert.True(t, w.update(cloud(100), start.AddDate(0, 0, 1)))
+}
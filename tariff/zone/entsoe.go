@@ -0,0 +1,106 @@
+package zone
+
+import (
+	"strings"
+
+	"github.com/evcc-io/evcc/tariff/entsoe"
+)
+
+// box is the approximate bounding box of a bidding zone
+type box struct {
+	zone                           string
+	minLat, maxLat, minLon, maxLon float64
+}
+
+func (b box) contains(lat, lon float64) bool {
+	return lat >= b.minLat && lat <= b.maxLat && lon >= b.minLon && lon <= b.maxLon
+}
+
+// biddingZones are approximate bounding boxes of ENTSO-E bidding zones. Zones with irregular borders
+// are composed of multiple boxes. Boxes of neighbouring zones overlap along borders, locations within
+// overlaps are not resolved.
+var biddingZones = []box{
+	{"DE-LU", 47.3, 55.1, 5.9, 15.0},
+	{"AT", 46.4, 47.6, 9.5, 13.0},  // Vorarlberg, Tyrol, Carinthia
+	{"AT", 46.4, 48.3, 13.0, 17.2}, // Salzburg to Vienna
+	{"AT", 48.3, 49.0, 13.8, 17.2}, // north of the Danube
+	{"CH", 45.8, 47.8, 5.9, 10.5},
+	{"NL", 51.8, 53.6, 3.3, 7.2},
+	{"NL", 50.7, 51.8, 3.3, 6.2}, // Limburg, along the Meuse
+	{"BE", 49.5, 51.5, 2.5, 6.4},
+	{"FR", 42.3, 49.5, -4.8, 7.0},
+	{"FR", 49.5, 50.8, -2.0, 4.2}, // Normandy to Nord
+	{"FR", 47.4, 49.2, 7.0, 7.8},  // Alsace, west of the Rhine
+	{"FR", 43.5, 45.8, 7.0, 7.7},  // Savoy to Nice
+	{"DK1", 54.5, 57.8, 8.0, 11.0},
+	{"DK2", 54.5, 56.2, 10.9, 12.75},
+	{"PL", 49.0, 54.9, 14.1, 24.2},
+	{"CZ", 48.5, 51.1, 12.1, 18.9},
+	{"ES", 36.0, 43.8, -9.3, 3.3},
+	{"PT", 36.9, 42.2, -9.5, -6.2},
+	{"SE1", 65.5, 69.1, 17.0, 24.2},
+	{"SE2", 61.5, 65.5, 12.1, 21.5},
+	{"SE3", 56.9, 61.5, 11.1, 19.0},
+	{"SE4", 55.3, 56.9, 12.65, 16.5},
+	{"FI", 59.8, 70.1, 20.5, 31.6},
+	{"EE", 57.5, 59.7, 21.8, 28.2},
+	{"LV", 55.7, 58.1, 20.9, 28.3},
+	{"LT", 53.9, 56.5, 20.9, 26.9},
+}
+
+// biddingZone returns the bidding zone if exactly one zone contains the location
+func biddingZone(lat, lon float64) (string, bool) {
+	var res string
+	for _, b := range biddingZones {
+		if b.contains(lat, lon) && b.zone != res {
+			if res != "" {
+				return "", false
+			}
+			res = b.zone
+		}
+	}
+	return res, res != ""
+}
+
+// subset returns a resolver restricted to the given bidding zones, mapped to the provider's zone names
+func subset(zones map[string]string) Resolver {
+	return func(lat, lon float64) (string, bool) {
+		z, ok := biddingZone(lat, lon)
+		if !ok {
+			return "", false
+		}
+		res, ok := zones[z]
+		return res, ok
+	}
+}
+
+// entsoeDomain returns the bidding zone in the entsoe domain notation, e.g. BZN|DE-LU
+func entsoeDomain(lat, lon float64) (string, bool) {
+	z, ok := biddingZone(lat, lon)
+	if !ok {
+		return "", false
+	}
+	return "BZN|" + z, true
+}
+
+// entsoeArea returns the area code of a domain given as area code, BZN|DE-LU or DE-LU
+func entsoeArea(domain string) (string, error) {
+	domain = strings.ToUpper(domain)
+	if code, err := entsoe.Area(entsoe.BZN, domain); err == nil {
+		return code, nil
+	}
+	return entsoe.Area(entsoe.BZN, "BZN|"+domain)
+}
+
+func init() {
+	Register("entsoe", entsoeDomain)
+	RegisterNormalizer("entsoe", entsoeArea)
+	Register("awattar", subset(map[string]string{"DE-LU": "DE", "AT": "AT"}))
+	Register("energinet", subset(map[string]string{"DK1": "DK1", "DK2": "DK2"}))
+
+	elering := make(map[string]string)
+	for _, z := range []string{"EE", "LV", "LT", "FI"} {
+		elering[z] = strings.ToLower(z)
+	}
+	Register("elering", subset(elering))
+}
@@ -0,0 +1,53 @@
+package zone
+
+import "math"
+
+// octopusRegions are approximate centres of the GB distribution network regions
+var octopusRegions = map[string][2]float64{
+	"A": {52.4, 0.9},  // East England
+	"B": {52.9, -1.0}, // East Midlands
+	"C": {51.5, -0.1}, // London
+	"D": {53.1, -3.4}, // Merseyside and North Wales
+	"E": {52.5, -2.1}, // West Midlands
+	"F": {54.9, -1.6}, // North East
+	"G": {53.9, -2.6}, // North West
+	"H": {51.1, -1.3}, // Southern
+	"J": {51.2, 0.7},  // South East
+	"K": {51.7, -3.5}, // South Wales
+	"L": {50.6, -3.9}, // South West
+	"M": {53.8, -1.2}, // Yorkshire
+	"N": {55.6, -3.8}, // South Scotland
+	"P": {57.4, -4.2}, // North Scotland
+}
+
+// octopusRegion returns the region with the nearest centre. Locations about equally distant
+// to two regions are not resolved.
+func octopusRegion(lat, lon float64) (string, bool) {
+	if !(box{"GB", 49.9, 60.9, -8.2, 1.8}).contains(lat, lon) {
+		return "", false
+	}
+
+	var (
+		res           string
+		first, second = math.Inf(1), math.Inf(1)
+	)
+
+	for region, c := range octopusRegions {
+		// equirectangular approximation is sufficient for comparing distances
+		dx := (lon - c[1]) * math.Cos(lat*math.Pi/180)
+		dy := lat - c[0]
+
+		switch d := math.Hypot(dx, dy); {
+		case d < first:
+			res, first, second = region, d, first
+		case d < second:
+			second = d
+		}
+	}
+
+	return res, second > 1.25*first
+}
+
+func init() {
+	Register("octopus", octopusRegion)
+}
@@ -0,0 +1,100 @@
+package zone
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/evcc-io/evcc/util"
+)
+
+// Resolver returns the provider's approximate price zone at the given location.
+// Locations that cannot be assigned unambiguously are not resolved.
+type Resolver func(lat, lon float64) (string, bool)
+
+// Normalizer maps a configured zone to the provider's canonical zone identifier
+type Normalizer func(string) (string, error)
+
+var (
+	mu          sync.Mutex
+	resolvers   = make(map[string]Resolver)
+	normalizers = make(map[string]Normalizer)
+	location    *[2]float64
+)
+
+// Register adds the zone resolver of a tariff provider
+func Register(provider string, r Resolver) {
+	mu.Lock()
+	defer mu.Unlock()
+	resolvers[provider] = r
+}
+
+// RegisterNormalizer adds the zone normalizer of a tariff provider accepting multiple names per zone
+func RegisterNormalizer(provider string, n Normalizer) {
+	mu.Lock()
+	defer mu.Unlock()
+	normalizers[provider] = n
+}
+
+// SetLocation sets the site location used for detecting zones
+func SetLocation(lat, lon float64) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if lat == 0 && lon == 0 {
+		location = nil
+		return
+	}
+
+	location = &[2]float64{lat, lon}
+}
+
+// Detect returns the provider's zone at the site location
+func Detect(provider string) (string, bool) {
+	mu.Lock()
+	r, ok := resolvers[provider]
+	loc := location
+	mu.Unlock()
+
+	if !ok || loc == nil {
+		return "", false
+	}
+
+	return r(loc[0], loc[1])
+}
+
+// Select returns the configured zone. Zones detected at the site location are approximate and only suggested,
+// they are never selected automatically.
+func Select(log *util.Logger, provider, configured string) string {
+	detected, ok := Detect(provider)
+	if !ok {
+		return configured
+	}
+
+	if configured == "" {
+		log.WARN.Printf("zone: not configured, site location suggests %s", detected)
+		return configured
+	}
+
+	if !equal(provider, configured, detected) {
+		log.INFO.Printf("zone: configured %s differs from %s suggested by site location", configured, detected)
+	}
+
+	return configured
+}
+
+// equal compares zones by their canonical identifier if the provider has a normalizer
+func equal(provider, a, b string) bool {
+	mu.Lock()
+	n, ok := normalizers[provider]
+	mu.Unlock()
+
+	if ok {
+		na, errA := n(a)
+		nb, errB := n(b)
+		if errA == nil && errB == nil {
+			return na == nb
+		}
+	}
+
+	return strings.EqualFold(a, b)
+}
@@ -0,0 +1,75 @@
+package zone
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/util"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBiddingZone(t *testing.T) {
+	for _, tc := range []struct {
+		lat, lon float64
+		zone     string
+		ok       bool
+	}{
+		{50.1, 8.7, "DE-LU", true},  // Frankfurt
+		{48.2, 16.4, "AT", true},    // Vienna
+		{55.7, 12.6, "DK2", true},   // Copenhagen
+		{56.2, 9.5, "DK1", true},    // Jutland
+		{59.3, 18.1, "SE3", true},   // Stockholm
+		{48.1, 11.6, "DE-LU", true}, // Munich
+		{50.9, 7.0, "DE-LU", true},  // Cologne
+		{48.0, 7.85, "DE-LU", true}, // Freiburg
+		{47.26, 11.4, "AT", true},   // Innsbruck
+		{52.4, 4.9, "NL", true},     // Amsterdam
+		{48.6, 7.75, "", false},     // Strasbourg, on the border
+		{40.7, -74.0, "", false},    // New York
+	} {
+		zone, ok := biddingZone(tc.lat, tc.lon)
+		assert.Equal(t, tc.ok, ok, tc)
+		assert.Equal(t, tc.zone, zone, tc)
+	}
+}
+
+func TestOctopusRegion(t *testing.T) {
+	region, ok := octopusRegion(51.5, -0.12) // London
+	assert.True(t, ok)
+	assert.Equal(t, "C", region)
+
+	_, ok = octopusRegion(48.9, 2.3) // Paris
+	assert.False(t, ok)
+}
+
+func TestSelect(t *testing.T) {
+	Register("test", func(lat, lon float64) (string, bool) { return "X", true })
+
+	SetLocation(0, 0)
+	assert.Equal(t, "", Select(nil, "test", ""))
+
+	SetLocation(1, 1)
+	defer SetLocation(0, 0)
+
+	// detected zones are never selected automatically
+	log := util.NewLogger("test")
+	assert.Equal(t, "", Select(log, "test", ""))
+	assert.Equal(t, "Y", Select(log, "test", "Y"))
+}
+
+func TestSelectNormalized(t *testing.T) {
+	SetLocation(50.1, 8.7) // Frankfurt
+	defer SetLocation(0, 0)
+
+	for _, domain := range []string{"DE-LU", "de-lu", "BZN|DE-LU", "10Y1001A1001A82H"} {
+		assert.True(t, equal("entsoe", domain, "DE-LU"), domain)
+	}
+	assert.False(t, equal("entsoe", "BZN|AT", "DE-LU"))
+
+	detected, ok := Detect("entsoe")
+	assert.True(t, ok)
+	assert.Equal(t, "BZN|DE-LU", detected)
+
+	log := util.NewLogger("test")
+	assert.Equal(t, "", Select(log, "entsoe", ""))
+	assert.Equal(t, "10Y1001A1001A82H", Select(log, "entsoe", "10Y1001A1001A82H"))
+}
@@ -0,0 +1,162 @@
+package chart
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+)
+
+var (
+	background = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	axis       = color.RGBA{0x99, 0x99, 0x99, 0xff}
+	cheap      = color.RGBA{0x0f, 0xde, 0x41, 0xff}
+	medium     = color.RGBA{0xfa, 0xc0, 0x00, 0xff}
+	expensive  = color.RGBA{0xfc, 0x44, 0x0c, 0xff}
+	solar      = color.RGBA{0xfa, 0xf0, 0x00, 0xff}
+	battery    = color.RGBA{0x03, 0xc1, 0xef, 0xff}
+	grid       = color.RGBA{0x4e, 0xb8, 0x4b, 0xff}
+	home       = color.RGBA{0x1c, 0x22, 0x26, 0xff}
+	charge     = color.RGBA{0x0f, 0xde, 0x41, 0xff}
+	export     = color.RGBA{0xaa, 0xaa, 0xaa, 0xff}
+)
+
+// Flow is the site's current power flow in W
+type Flow struct {
+	Pv         float64 // pv production
+	Battery    float64 // battery power, discharge positive
+	Grid       float64 // grid power, import positive
+	Home       float64 // home consumption
+	Loadpoints float64 // loadpoint consumption
+}
+
+// Prices renders the rates as bar chart. Bars are colored by price tercile.
+func Prices(rr api.Rates, width, height int) ([]byte, error) {
+	if len(rr) == 0 {
+		return nil, errors.New("no rates")
+	}
+
+	img := canvas(width, height)
+	drawPrices(img, img.Bounds(), rr)
+
+	return encode(img)
+}
+
+// Snapshot renders the power flow above the price curve. Prices are omitted if no rates are available.
+func Snapshot(flow Flow, rr api.Rates, width, height int) ([]byte, error) {
+	img := canvas(width, height)
+
+	if len(rr) == 0 {
+		drawFlow(img, img.Bounds(), flow)
+		return encode(img)
+	}
+
+	split := height / 3
+	drawFlow(img, image.Rect(0, 0, width, split), flow)
+	drawPrices(img, image.Rect(0, split, width, height), rr)
+
+	return encode(img)
+}
+
+func canvas(width, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), &image.Uniform{background}, image.Point{}, draw.Src)
+	return img
+}
+
+func encode(img image.Image) ([]byte, error) {
+	var b bytes.Buffer
+	err := png.Encode(&b, img)
+	return b.Bytes(), err
+}
+
+func fill(img draw.Image, r image.Rectangle, c color.Color) {
+	draw.Draw(img, r, &image.Uniform{c}, image.Point{}, draw.Src)
+}
+
+// drawFlow renders sources (pv, battery discharge, grid import) and consumers (home, loadpoints,
+// battery charge, grid export) as two stacked horizontal bars of equal scale
+func drawFlow(img draw.Image, rect image.Rectangle, f Flow) {
+	type segment struct {
+		power float64
+		color color.RGBA
+	}
+
+	sources := []segment{{f.Pv, solar}, {max(0, f.Battery), battery}, {max(0, f.Grid), grid}}
+	consumers := []segment{{f.Home, home}, {f.Loadpoints, charge}, {max(0, -f.Battery), battery}, {max(0, -f.Grid), export}}
+
+	var total float64
+	for _, ss := range [][]segment{sources, consumers} {
+		var sum float64
+		for _, s := range ss {
+			sum += max(0, s.power)
+		}
+		total = max(total, sum)
+	}
+
+	if total == 0 {
+		return
+	}
+
+	margin := rect.Dy() / 8
+	barHeight := (rect.Dy() - 3*margin) / 2
+
+	for i, ss := range [][]segment{sources, consumers} {
+		top := rect.Min.Y + margin + i*(barHeight+margin)
+		x := float64(rect.Min.X)
+
+		for _, s := range ss {
+			w := float64(rect.Dx()) * max(0, s.power) / total
+			fill(img, image.Rect(int(x), top, int(x+w), top+barHeight), s.color)
+			x += w
+		}
+	}
+}
+
+// drawPrices renders the rates as bar chart colored by price tercile
+func drawPrices(img draw.Image, rect image.Rectangle, rr api.Rates) {
+	if len(rr) == 0 {
+		return
+	}
+
+	from, to := rr[0].Start, rr[len(rr)-1].End
+	lo, hi := 0.0, 0.0
+	for _, r := range rr {
+		lo = min(lo, r.Price)
+		hi = max(hi, r.Price)
+	}
+
+	if hi == lo || !to.After(from) {
+		hi = lo + 1
+	}
+
+	width, height := rect.Dx(), rect.Dy()
+
+	x := func(ts time.Time) int {
+		return rect.Min.X + int(float64(width)*float64(ts.Sub(from))/float64(to.Sub(from)))
+	}
+	y := func(price float64) int {
+		return rect.Min.Y + height - 1 - int(float64(height-1)*(price-lo)/(hi-lo))
+	}
+
+	zero := y(0)
+	for _, r := range rr {
+		c := medium
+		switch rel := (r.Price - lo) / (hi - lo); {
+		case rel < 1./3:
+			c = cheap
+		case rel > 2./3:
+			c = expensive
+		}
+
+		top, bottom := min(y(r.Price), zero), max(y(r.Price), zero)
+		fill(img, image.Rect(x(r.Start), top, max(x(r.End)-1, x(r.Start)+1), bottom+1), c)
+	}
+
+	fill(img, image.Rect(rect.Min.X, zero, rect.Max.X, zero+1), axis)
+}
@@ -0,0 +1,58 @@
+package chart
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPrices(t *testing.T) {
+	_, err := Prices(nil, 240, 120)
+	assert.Error(t, err)
+
+	ts := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var rr api.Rates
+	for i, p := range []float64{0.3, 0.1, -0.05, 0.2} {
+		rr = append(rr, api.Rate{
+			Start: ts.Add(time.Duration(i) * time.Hour),
+			End:   ts.Add(time.Duration(i+1) * time.Hour),
+			Price: p,
+		})
+	}
+
+	b, err := Prices(rr, 240, 120)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(b))
+	require.NoError(t, err)
+	assert.Equal(t, 240, img.Bounds().Dx())
+	assert.Equal(t, 120, img.Bounds().Dy())
+
+	// most expensive bar reaches the top
+	assert.Equal(t, expensive, img.At(30, 0))
+	assert.Equal(t, background, img.At(90, 0))
+}
+
+func TestSnapshot(t *testing.T) {
+	flow := Flow{Pv: 6000, Grid: -2000, Home: 1000, Loadpoints: 3000}
+
+	b, err := Snapshot(flow, nil, 300, 80)
+	require.NoError(t, err)
+
+	img, err := png.Decode(bytes.NewReader(b))
+	require.NoError(t, err)
+
+	// sources: pv only
+	assert.Equal(t, solar, img.At(295, 20))
+
+	// consumers: home, loadpoints, export
+	assert.Equal(t, home, img.At(10, 50))
+	assert.Equal(t, charge, img.At(100, 50))
+	assert.Equal(t, export, img.At(295, 50))
+}
@@ -0,0 +1,381 @@
+package oauth
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/evcc-io/evcc/core/keys"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util"
+	"golang.org/x/oauth2"
+)
+
+const (
+	keyEnv         = "EVCC_OAUTH_KEY" // base64 token encryption key
+	legacySecret   = "oauth.secret"   // token encryption key previously kept in the database
+	storePrefix    = "enc:"           // prefix of encrypted tokens
+	refreshLead    = 5 * time.Minute
+	reauthFailures = 3 // consecutive refresh failures requiring re-authentication
+)
+
+// TokenSetter replaces the token of a token source, e.g. after re-authentication
+type TokenSetter interface {
+	SetToken(*oauth2.Token)
+}
+
+// ForcedRefresher refreshes a token before it expires
+type ForcedRefresher interface {
+	Refresh() (*oauth2.Token, error)
+}
+
+// Status is the refresh status of a registered provider
+type Status struct {
+	Title  string    `json:"title"`
+	Expiry time.Time `json:"expiry,omitzero"`
+	Error  string    `json:"error,omitempty"`
+	Reauth bool      `json:"reauth"` // refresh failed repeatedly, re-authentication required
+	Login  bool      `json:"login"`  // re-authentication by browser login supported
+}
+
+// provider is a registered token source persisted under key
+type provider struct {
+	ts       oauth2.TokenSource
+	oc       *oauth2.Config // authorization code config for browser login
+	key      string
+	status   Status
+	failures int
+	persist  bool
+	saved    string // access token last persisted
+}
+
+var (
+	mu        sync.Mutex
+	providers = make(map[string]*provider)
+	storeLog  = util.NewLogger("oauth")
+
+	keyMu   sync.Mutex
+	keyFile string // token encryption key file
+	key     []byte
+
+	redirectURL string // browser login callback
+)
+
+// SetRedirectURL sets the callback of browser logins. The url must be registered with the provider's oauth client.
+func SetRedirectURL(uri string) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	redirectURL = uri
+}
+
+// SetKeyFile sets the file holding the token encryption key. The key is kept outside
+// the database, a copy of the database alone does not expose the tokens.
+func SetKeyFile(file string) {
+	keyMu.Lock()
+	defer keyMu.Unlock()
+
+	keyFile = file
+	key = nil
+}
+
+// cipherKey returns the token encryption key from the environment or the key file, creating the file on first use
+func cipherKey() ([]byte, error) {
+	keyMu.Lock()
+	defer keyMu.Unlock()
+
+	if key != nil {
+		return key, nil
+	}
+
+	if s := os.Getenv(keyEnv); s != "" {
+		b, err := base64.StdEncoding.DecodeString(strings.TrimSpace(s))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", keyEnv, err)
+		}
+		key = b
+		return key, nil
+	}
+
+	if keyFile == "" {
+		return nil, errors.New("missing token encryption key")
+	}
+
+	if b, err := os.ReadFile(keyFile); err == nil {
+		if key, err = base64.StdEncoding.DecodeString(strings.TrimSpace(string(b))); err != nil {
+			return nil, fmt.Errorf("%s: %w", keyFile, err)
+		}
+		return key, nil
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+
+	// move key of previous versions out of the database
+	s, legacyErr := settings.String(legacySecret)
+	b, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(b) == 0 {
+		b = make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(b)), 0o600); err != nil {
+		return nil, err
+	}
+
+	// clear the key before deleting to not persist the cached value again
+	if legacyErr == nil {
+		settings.SetString(legacySecret, "")
+		_ = settings.Delete(legacySecret)
+	}
+	key = b
+
+	return key, nil
+}
+
+func aead() (cipher.AEAD, error) {
+	key, err := cipherKey()
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// SaveToken persists the token encrypted under key
+func SaveToken(key string, token *oauth2.Token) error {
+	b, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	gcm, err := aead()
+	if err != nil {
+		return err
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	settings.SetString(key, storePrefix+base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, b, nil)))
+
+	return nil
+}
+
+// LoadToken loads the token persisted under key. Unencrypted tokens of previous versions are accepted.
+func LoadToken(key string) (*oauth2.Token, error) {
+	s, err := settings.String(key)
+	if err != nil {
+		return nil, err
+	}
+
+	b := []byte(s)
+
+	if enc, ok := strings.CutPrefix(s, storePrefix); ok {
+		data, err := base64.StdEncoding.DecodeString(enc)
+		if err != nil {
+			return nil, err
+		}
+
+		gcm, err := aead()
+		if err != nil {
+			return nil, err
+		}
+
+		if len(data) < gcm.NonceSize() {
+			return nil, errors.New("invalid token")
+		}
+
+		if b, err = gcm.Open(nil, data[:gcm.NonceSize()], data[gcm.NonceSize():], nil); err != nil {
+			return nil, err
+		}
+	}
+
+	var res oauth2.Token
+	if err := json.Unmarshal(b, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// Register adds the token source to the store. The returned token source persists refreshed tokens
+// under key and records the refresh status. Registered tokens are refreshed proactively by Run.
+func Register(key, title string, ts oauth2.TokenSource) oauth2.TokenSource {
+	return register(key, title, nil, ts, true)
+}
+
+// RegisterLogin adds the token source to the store like Register. Additionally, the provider can be
+// re-authenticated by browser login using the authorization code flow of the given config.
+func RegisterLogin(key, title string, oc *oauth2.Config, ts oauth2.TokenSource) oauth2.TokenSource {
+	return register(key, title, oc, ts, true)
+}
+
+// Monitor adds the token source to the store like Register without persisting tokens,
+// e.g. for tokens obtained using client credentials
+func Monitor(key, title string, ts oauth2.TokenSource) oauth2.TokenSource {
+	return register(key, title, nil, ts, false)
+}
+
+func register(key, title string, oc *oauth2.Config, ts oauth2.TokenSource, persist bool) oauth2.TokenSource {
+	p := &provider{
+		ts:      ts,
+		oc:      oc,
+		key:     key,
+		status:  Status{Title: title, Login: oc != nil},
+		persist: persist,
+	}
+
+	mu.Lock()
+	providers[key] = p
+	mu.Unlock()
+
+	return p
+}
+
+// Token implements the oauth2.TokenSource interface
+func (p *provider) Token() (*oauth2.Token, error) {
+	return p.update(p.ts.Token())
+}
+
+// refresh refreshes the token ahead of expiry if supported by the token source
+func (p *provider) refresh() (*oauth2.Token, error) {
+	if fr, ok := p.ts.(ForcedRefresher); ok {
+		return p.update(fr.Refresh())
+	}
+	return p.Token()
+}
+
+// update records the refresh status and persists changed tokens
+func (p *provider) update(token *oauth2.Token, err error) (*oauth2.Token, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if err != nil {
+		p.failures++
+		p.status.Error = err.Error()
+
+		if p.failures >= reauthFailures && !p.status.Reauth {
+			storeLog.ERROR.Printf("%s: token refresh failed, re-authentication required: %v", p.status.Title, err)
+			p.status.Reauth = true
+		}
+
+		return token, err
+	}
+
+	p.failures = 0
+	p.status.Error = ""
+	p.status.Reauth = false
+	p.status.Expiry = token.Expiry
+
+	if p.persist && token.AccessToken != p.saved {
+		if err := SaveToken(p.key, token); err != nil {
+			storeLog.ERROR.Printf("%s: %v", p.status.Title, err)
+		} else {
+			p.saved = token.AccessToken
+		}
+	}
+
+	return token, nil
+}
+
+// SetToken replaces the token of a registered provider, re-authenticating it without configuration changes
+func SetToken(key string, token *oauth2.Token) error {
+	mu.Lock()
+	p, ok := providers[key]
+	mu.Unlock()
+
+	if !ok {
+		return fmt.Errorf("unknown provider: %s", key)
+	}
+
+	ts, ok := p.ts.(TokenSetter)
+	if !ok {
+		return fmt.Errorf("provider does not support setting tokens: %s", key)
+	}
+
+	ts.SetToken(token)
+
+	_, err := p.Token()
+	return err
+}
+
+// LoginConfig returns the authorization code config for browser login of a registered provider
+func LoginConfig(key string) (*oauth2.Config, error) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	p, ok := providers[key]
+	if !ok {
+		return nil, fmt.Errorf("unknown provider: %s", key)
+	}
+
+	if p.oc == nil || redirectURL == "" {
+		return nil, fmt.Errorf("provider does not support login: %s", key)
+	}
+
+	oc := *p.oc
+	oc.RedirectURL = redirectURL
+
+	return &oc, nil
+}
+
+// Providers returns the status of all registered providers by key
+func Providers() map[string]Status {
+	mu.Lock()
+	defer mu.Unlock()
+
+	res := make(map[string]Status, len(providers))
+	for k, p := range providers {
+		res[k] = p.status
+	}
+
+	return res
+}
+
+// Run refreshes registered tokens before they expire and publishes the provider status
+func Run(paramC chan<- util.Param) {
+	var published map[string]Status
+
+	for ; true; <-time.Tick(time.Minute) {
+		// expiry is unknown until the token has been used
+		mu.Lock()
+		due := make(map[*provider]bool, len(providers))
+		for _, p := range providers {
+			if !p.status.Reauth && time.Until(p.status.Expiry) < refreshLead {
+				due[p] = p.status.Expiry.IsZero()
+			}
+		}
+		mu.Unlock()
+
+		for p, unknown := range due {
+			if unknown {
+				_, _ = p.Token()
+				continue
+			}
+			_, _ = p.refresh()
+		}
+
+		if status := Providers(); !maps.Equal(status, published) {
+			published = status
+			paramC <- util.Param{Key: keys.OAuth, Val: status}
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package oauth
+
+import (
+	"encoding/base64"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/evcc-io/evcc/server/db"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+func TestStoreEncrypted(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "oauth.key")
+	SetKeyFile(file)
+
+	token := &oauth2.Token{AccessToken: "access", RefreshToken: "refresh"}
+	require.NoError(t, SaveToken("test.encrypted", token))
+
+	s, err := settings.String("test.encrypted")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(s, storePrefix))
+	assert.NotContains(t, s, "refresh")
+
+	res, err := LoadToken("test.encrypted")
+	require.NoError(t, err)
+	assert.Equal(t, token.AccessToken, res.AccessToken)
+	assert.Equal(t, token.RefreshToken, res.RefreshToken)
+
+	// key is kept outside the database
+	fi, err := os.Stat(file)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), fi.Mode().Perm())
+
+	// tokens cannot be decrypted with a different key
+	SetKeyFile(filepath.Join(t.TempDir(), "oauth.key"))
+	_, err = LoadToken("test.encrypted")
+	assert.Error(t, err)
+}
+
+func TestStoreKey(t *testing.T) {
+	SetKeyFile("")
+	_, err := cipherKey()
+	assert.Error(t, err, "missing key")
+
+	key := make([]byte, 32)
+	t.Setenv(keyEnv, base64.StdEncoding.EncodeToString(key))
+	res, err := cipherKey()
+	require.NoError(t, err)
+	assert.Equal(t, key, res)
+}
+
+func TestStoreLegacyKey(t *testing.T) {
+	instance, err := db.New("sqlite", ":memory:")
+	require.NoError(t, err)
+
+	prev := db.Instance
+	db.Instance = instance
+	t.Cleanup(func() { db.Instance = prev })
+
+	require.NoError(t, settings.Init())
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+	settings.SetString(legacySecret, base64.StdEncoding.EncodeToString(key))
+
+	SetKeyFile(filepath.Join(t.TempDir(), "oauth.key"))
+	res, err := cipherKey()
+	require.NoError(t, err)
+	assert.Equal(t, key, res)
+
+	// removed from database
+	s, _ := settings.String(legacySecret)
+	assert.Empty(t, s)
+}
+
+func TestStoreLegacy(t *testing.T) {
+	settings.SetString("test.legacy", `{"access_token":"access","refresh_token":"refresh"}`)
+
+	res, err := LoadToken("test.legacy")
+	require.NoError(t, err)
+	assert.Equal(t, "refresh", res.RefreshToken)
+}
+
+type failingSource struct{ err error }
+
+func (ts *failingSource) Token() (*oauth2.Token, error) {
+	return nil, ts.err
+}
+
+type staticSource struct{ token *oauth2.Token }
+
+func (ts *staticSource) Token() (*oauth2.Token, error) {
+	return ts.token, nil
+}
+
+func TestProviderMonitor(t *testing.T) {
+	ts := Monitor("test.monitor", "Test", &staticSource{&oauth2.Token{AccessToken: "access", Expiry: time.Now().Add(time.Hour)}})
+
+	_, err := ts.Token()
+	require.NoError(t, err)
+
+	assert.False(t, Providers()["test.monitor"].Expiry.IsZero())
+
+	_, err = settings.String("test.monitor")
+	assert.Error(t, err, "not persisted")
+}
+
+func TestProviderReauth(t *testing.T) {
+	ts := Register("test.reauth", "Test", &failingSource{errors.New("invalid_grant")})
+
+	for range reauthFailures {
+		_, err := ts.Token()
+		assert.Error(t, err)
+	}
+
+	status := Providers()["test.reauth"]
+	assert.True(t, status.Reauth)
+	assert.Equal(t, "invalid_grant", status.Error)
+}
@@ -54,6 +54,30 @@ func (ts *TokenSource) Token() (*oauth2.Token, error) {
 	return ts.token, err
 }
 
+// SetToken implements the TokenSetter interface
+func (ts *TokenSource) SetToken(token *oauth2.Token) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	ts.token = token
+}
+
+// Refresh implements the ForcedRefresher interface
+func (ts *TokenSource) Refresh() (*oauth2.Token, error) {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+
+	token, err := ts.refresher.RefreshToken(ts.token)
+	if err != nil {
+		return ts.token, err
+	}
+
+	if token.AccessToken == "" {
+		return ts.token, errors.New("token refresh failed to obtain access token")
+	}
+
+	return ts.token, ts.mergeToken(token)
+}
+
 // mergeToken updates a token while preventing wiping the refresh token
 func (ts *TokenSource) mergeToken(t *oauth2.Token) error {
 	return mergo.Merge(ts.token, t, mergo.WithOverride)
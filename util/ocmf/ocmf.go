@@ -0,0 +1,153 @@
+// Package ocmf implements parsing and signature verification of signed meter values
+// in Open Charge Metering Format (OCMF) as used for German calibration law (Eichrecht) compliance.
+package ocmf
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const header = "OCMF"
+
+// Reading is a single meter reading
+type Reading struct {
+	Time   string  `json:"TM"` // timestamp with synchronization status
+	Type   string  `json:"TX"` // transaction context, B=begin, E=end
+	Value  float64 `json:"RV"`
+	Id     string  `json:"RI"` // OBIS identifier
+	Unit   string  `json:"RU"`
+	Status string  `json:"ST"` // meter status, G=good
+}
+
+// Payload is the signed OCMF data
+type Payload struct {
+	FormatVersion string    `json:"FV"`
+	GatewayId     string    `json:"GI"`
+	GatewaySerial string    `json:"GS"`
+	Pagination    string    `json:"PG"`
+	MeterSerial   string    `json:"MS"`
+	Identifier    string    `json:"ID"`
+	Readings      []Reading `json:"RD"`
+}
+
+// Signature is the OCMF signature
+type Signature struct {
+	Algorithm string `json:"SA"`
+	Encoding  string `json:"SE"`
+	Mime      string `json:"SM"`
+	Data      string `json:"SD"`
+}
+
+// Document is a parsed OCMF document
+type Document struct {
+	Payload   Payload
+	Signature Signature
+	raw       string // signed payload
+}
+
+// Parse parses an OCMF string
+func Parse(s string) (*Document, error) {
+	segs := strings.SplitN(strings.TrimSpace(s), "|", 3)
+	if len(segs) != 3 || segs[0] != header {
+		return nil, errors.New("invalid format")
+	}
+
+	res := &Document{
+		raw: segs[1],
+		Signature: Signature{
+			Algorithm: "ECDSA-secp256r1-SHA256",
+			Encoding:  "hex",
+		},
+	}
+
+	if err := json.Unmarshal([]byte(segs[1]), &res.Payload); err != nil {
+		return nil, fmt.Errorf("payload: %w", err)
+	}
+
+	if err := json.Unmarshal([]byte(segs[2]), &res.Signature); err != nil {
+		return nil, fmt.Errorf("signature: %w", err)
+	}
+
+	return res, nil
+}
+
+func decode(encoding, s string) ([]byte, error) {
+	switch strings.ToLower(encoding) {
+	case "", "hex":
+		return hex.DecodeString(s)
+	case "base64":
+		return base64.StdEncoding.DecodeString(s)
+	default:
+		return nil, fmt.Errorf("unsupported encoding: %s", encoding)
+	}
+}
+
+// hash returns the hash function for the signature algorithm
+func (d *Document) hash() (crypto.Hash, error) {
+	switch {
+	case strings.HasSuffix(d.Signature.Algorithm, "SHA256"):
+		return crypto.SHA256, nil
+	case strings.HasSuffix(d.Signature.Algorithm, "SHA384"):
+		return crypto.SHA384, nil
+	case strings.HasSuffix(d.Signature.Algorithm, "SHA512"):
+		return crypto.SHA512, nil
+	default:
+		return 0, fmt.Errorf("unsupported algorithm: %s", d.Signature.Algorithm)
+	}
+}
+
+// Verify verifies the document signature using the hex or base64 encoded DER public key
+func (d *Document) Verify(publicKey string) error {
+	b, err := hex.DecodeString(publicKey)
+	if err != nil {
+		if b, err = base64.StdEncoding.DecodeString(publicKey); err != nil {
+			return errors.New("invalid public key encoding")
+		}
+	}
+
+	key, err := x509.ParsePKIXPublicKey(b)
+	if err != nil {
+		return fmt.Errorf("public key: %w", err)
+	}
+
+	pub, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return errors.New("public key: not an ecdsa key")
+	}
+
+	hash, err := d.hash()
+	if err != nil {
+		return err
+	}
+
+	sig, err := decode(d.Signature.Encoding, d.Signature.Data)
+	if err != nil {
+		return fmt.Errorf("signature: %w", err)
+	}
+
+	h := hash.New()
+	h.Write([]byte(d.raw))
+
+	if !ecdsa.VerifyASN1(pub, h.Sum(nil), sig) {
+		return errors.New("invalid signature")
+	}
+
+	return nil
+}
+
+// Verify parses the OCMF string and verifies its signature
+func Verify(s, publicKey string) error {
+	doc, err := Parse(s)
+	if err != nil {
+		return err
+	}
+
+	return doc.Verify(publicKey)
+}
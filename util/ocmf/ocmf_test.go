@@ -0,0 +1,44 @@
+package ocmf
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	require.NoError(t, err)
+	pub := hex.EncodeToString(der)
+
+	payload := `{"FV":"1.0","GI":"evcc","GS":"123","PG":"T1","RD":[{"TM":"2024-07-24T13:22:04,000+0200 S","TX":"B","RV":2935.6,"RI":"1-b:1.8.0","RU":"kWh","ST":"G"}]}`
+	hash := sha256.Sum256([]byte(payload))
+
+	sig, err := ecdsa.SignASN1(rand.Reader, key, hash[:])
+	require.NoError(t, err)
+
+	s := "OCMF|" + payload + `|{"SA":"ECDSA-secp256r1-SHA256","SD":"` + hex.EncodeToString(sig) + `"}`
+
+	doc, err := Parse(s)
+	require.NoError(t, err)
+	assert.Equal(t, 2935.6, doc.Payload.Readings[0].Value)
+	assert.Equal(t, "B", doc.Payload.Readings[0].Type)
+
+	assert.NoError(t, Verify(s, pub))
+
+	// tampered payload
+	assert.Error(t, Verify(`OCMF|{"FV":"1.0","RD":[{"RV":1}]}|{"SD":"`+hex.EncodeToString(sig)+`"}`, pub))
+
+	_, err = Parse("foo|bar")
+	assert.Error(t, err)
+}
@@ -0,0 +1,144 @@
+package ratelimit
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/evcc-io/evcc/server/db/settings"
+	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/transport"
+)
+
+// ErrQuotaExceeded is returned when the provider's request quota is used up
+var ErrQuotaExceeded = errors.New("request quota exceeded")
+
+// Limiter enforces a provider's request quota shared by all devices using the same account.
+// Quota windows are aligned to UTC, i.e. daily quotas restart at UTC midnight.
+type Limiter struct {
+	log    *util.Logger
+	clock  clock.Clock
+	key    string
+	limit  int
+	window time.Duration
+
+	mu    sync.Mutex
+	quota quota
+}
+
+// quota is the persisted quota usage of the current window
+type quota struct {
+	Start time.Time `json:"start"`
+	Used  int       `json:"used"`
+}
+
+var (
+	mu       sync.Mutex
+	limiters = make(map[string]*Limiter)
+)
+
+// New returns the limiter for the provider account allowing limit requests per window.
+// The account is only persisted as hash and may be any secret identifying the account, e.g. a token.
+func New(provider, account string, limit int, window time.Duration) *Limiter {
+	key := fmt.Sprintf("ratelimit.%s.%x", provider, sha256.Sum256([]byte(account)))
+
+	mu.Lock()
+	defer mu.Unlock()
+
+	if l, ok := limiters[key]; ok {
+		return l
+	}
+
+	l := &Limiter{
+		log:    util.NewLogger(provider),
+		clock:  clock.New(),
+		key:    key,
+		limit:  limit,
+		window: window,
+	}
+
+	if err := settings.Json(key, &l.quota); err != nil && !errors.Is(err, settings.ErrNotFound) {
+		l.log.WARN.Println("quota:", err)
+	}
+
+	limiters[key] = l
+
+	return l
+}
+
+// Allow consumes a request from the quota and returns ErrQuotaExceeded if the quota is used up
+func (l *Limiter) Allow() error {
+	_, err := l.reserve()
+	return err
+}
+
+// reserve consumes a request from the quota. If the quota is used up, it returns the delay until the next window.
+func (l *Limiter) reserve() (time.Duration, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	if start := now.UTC().Truncate(l.window); !start.Equal(l.quota.Start) {
+		l.quota = quota{Start: start}
+	}
+
+	if l.quota.Used >= l.limit {
+		next := l.quota.Start.Add(l.window)
+		return next.Sub(now), fmt.Errorf("%w: %d requests until %s", ErrQuotaExceeded, l.limit, next.Local().Format(time.RFC3339))
+	}
+
+	l.quota.Used++
+
+	if err := settings.SetJson(l.key, l.quota); err != nil {
+		l.log.ERROR.Println("quota:", err)
+	}
+
+	return 0, nil
+}
+
+// Wait consumes a request from the quota. Requests are delayed if the next window starts within maxDelay,
+// otherwise ErrQuotaExceeded is returned.
+func (l *Limiter) Wait(ctx context.Context, maxDelay time.Duration) error {
+	for {
+		delay, err := l.reserve()
+		if err == nil || delay > maxDelay {
+			return err
+		}
+
+		l.log.DEBUG.Printf("quota: delaying request by %v", delay.Round(time.Second))
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-l.clock.After(delay):
+		}
+	}
+}
+
+// Remaining returns the number of requests left in the current window
+func (l *Limiter) Remaining() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if start := l.clock.Now().UTC().Truncate(l.window); !start.Equal(l.quota.Start) {
+		return l.limit
+	}
+
+	return max(0, l.limit-l.quota.Used)
+}
+
+// Transport returns a transport consuming the quota for each request. Requests are delayed by up to
+// maxDelay to shape bursts within short quota windows.
+func (l *Limiter) Transport(base http.RoundTripper, maxDelay time.Duration) http.RoundTripper {
+	return &transport.Decorator{
+		Decorator: func(req *http.Request) error {
+			return l.Wait(req.Context(), maxDelay)
+		},
+		Base: base,
+	}
+}
@@ -0,0 +1,35 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLimiter(t *testing.T) {
+	clock := clock.NewMock()
+	clock.Set(time.Date(2025, 1, 1, 22, 0, 0, 0, time.UTC))
+
+	l := New("test", "token", 2, 24*time.Hour)
+	l.clock = clock
+
+	assert.Same(t, l, New("test", "token", 2, 24*time.Hour), "shared by account")
+	assert.NotSame(t, l, New("test", "other", 2, 24*time.Hour))
+
+	require.NoError(t, l.Allow())
+	require.NoError(t, l.Allow())
+	assert.ErrorIs(t, l.Allow(), ErrQuotaExceeded)
+	assert.Equal(t, 0, l.Remaining())
+
+	// next window is too far away for waiting
+	assert.ErrorIs(t, l.Wait(context.Background(), time.Hour), ErrQuotaExceeded)
+
+	// quota restarts at UTC midnight
+	clock.Add(2 * time.Hour)
+	assert.Equal(t, 2, l.Remaining())
+	require.NoError(t, l.Allow())
+}
@@ -86,8 +86,8 @@ func (c *State) Encrypt(key []byte) string {
 
 func (c *State) Validate() error {
 	if time.Since(c.Time) > stateValidity {
-		return nil
+		return ErrStateExpired
 	}
 
-	return ErrStateExpired
+	return nil
 }
@@ -43,6 +43,21 @@ func GetNextOccurrence(weekdays []int, timeStr string, tz string) (time.Time, er
 	return time.Time{}, fmt.Errorf("no valid weekday found")
 }
 
+// NextTimeOfDay returns the next occurrence of the given HH:MM time of day after now
+func NextTimeOfDay(now time.Time, hhmm string) (time.Time, error) {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid time format, expected HH:MM: %w", err)
+	}
+
+	ts := time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location())
+	if !ts.After(now) {
+		ts = ts.AddDate(0, 0, 1)
+	}
+
+	return ts, nil
+}
+
 // helper function to check if a slice contains a value
 func contains(slice []int, val int) bool {
 	for _, item := range slice {
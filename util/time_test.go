@@ -0,0 +1,28 @@
+package util
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextTimeOfDay(t *testing.T) {
+	now := time.Date(2024, 1, 1, 8, 0, 0, 0, time.Local)
+
+	ts, err := NextTimeOfDay(now, "07:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 2, 7, 0, 0, 0, time.Local), ts, "tomorrow")
+
+	ts, err = NextTimeOfDay(now, "09:30")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 1, 9, 30, 0, 0, time.Local), ts, "today")
+
+	ts, err = NextTimeOfDay(now, "08:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 1, 2, 8, 0, 0, 0, time.Local), ts, "now")
+
+	_, err = NextTimeOfDay(now, "7")
+	assert.Error(t, err)
+}
@@ -9,7 +9,6 @@ import (
 	"strings"
 	"time"
 
-	"github.com/evcc-io/evcc/server/db/settings"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/oauth"
 	"github.com/evcc-io/evcc/util/request"
@@ -46,13 +45,11 @@ func (v *Identity) Login(user, password, hcaptcha string) (oauth2.TokenSource, e
 	v.user = user
 
 	// database token
-	var tok oauth2.Token
-	if err := settings.Json(v.settingsKey(), &tok); err == nil {
+	if tok, err := oauth.LoadToken(v.settingsKey()); err == nil {
 		v.log.DEBUG.Println("identity.Login - database token found")
-		tok, err := v.RefreshToken(&tok)
+		tok, err := v.RefreshToken(tok)
 		if err == nil {
-			ts := oauth2.ReuseTokenSourceWithExpiry(tok, oauth.RefreshTokenSource(tok, v), 15*time.Minute)
-			return ts, nil
+			return v.tokenSource(tok), nil
 		}
 		v.log.DEBUG.Println("identity.Login - database token invalid. Proceeding to login via user, password and captcha.")
 	} else {
@@ -150,9 +147,13 @@ func (v *Identity) Login(user, password, hcaptcha string) (oauth2.TokenSource, e
 		return nil, err
 	}
 
-	ts := oauth2.ReuseTokenSourceWithExpiry(token, oauth.RefreshTokenSource(token, v), 15*time.Minute)
+	return v.tokenSource(token), nil
+}
 
-	return ts, nil
+// tokenSource returns the token source persisting refreshed tokens
+func (v *Identity) tokenSource(token *oauth2.Token) oauth2.TokenSource {
+	ts := oauth2.ReuseTokenSourceWithExpiry(token, oauth.RefreshTokenSource(token, v), 15*time.Minute)
+	return oauth.Register(v.settingsKey(), "BMW", ts)
 }
 
 func (v *Identity) retrieveToken(data url.Values) (*oauth2.Token, error) {
@@ -172,11 +173,7 @@ func (v *Identity) retrieveToken(data url.Values) (*oauth2.Token, error) {
 		return nil, err
 	}
 
-	tokex := util.TokenWithExpiry(&tok)
-
-	err = settings.SetJson(v.settingsKey(), tokex)
-
-	return tokex, err
+	return util.TokenWithExpiry(&tok), nil
 }
 
 func (v *Identity) RefreshToken(token *oauth2.Token) (*oauth2.Token, error) {
@@ -12,6 +12,7 @@ type embed struct {
 	Phases_      int              `mapstructure:"phases"`
 	Identifiers_ []string         `mapstructure:"identifiers"`
 	Features_    []api.Feature    `mapstructure:"features"`
+	Loadpoints_  []string         `mapstructure:"loadpoints"`
 	OnIdentify   api.ActionConfig `mapstructure:"onIdentify"`
 }
 
@@ -70,3 +71,10 @@ var _ api.FeatureDescriber = (*embed)(nil)
 func (v *embed) Features() []api.Feature {
 	return v.Features_
 }
+
+var _ api.LoadpointRestricter = (*embed)(nil)
+
+// Loadpoints implements the api.LoadpointRestricter interface
+func (v *embed) Loadpoints() []string {
+	return v.Loadpoints_
+}
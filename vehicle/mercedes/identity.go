@@ -9,7 +9,6 @@ import (
 	"sync"
 	"time"
 
-	"github.com/evcc-io/evcc/server/db/settings"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/oauth"
 	"github.com/evcc-io/evcc/util/request"
@@ -70,10 +69,9 @@ func NewIdentity(log *util.Logger, token *oauth2.Token, account string, region s
 
 	// database token
 	if !token.Valid() {
-		var tok oauth2.Token
-		if err := settings.Json(v.settingsKey(), &tok); err == nil {
+		if tok, err := oauth.LoadToken(v.settingsKey()); err == nil {
 			v.log.DEBUG.Println("identity.NewIdentity - database token found")
-			token = &tok
+			token = tok
 		}
 	}
 
@@ -88,7 +86,7 @@ func NewIdentity(log *util.Logger, token *oauth2.Token, account string, region s
 		return nil, errors.New("token expired")
 	}
 
-	v.TokenSource = oauth.RefreshTokenSource(token, v)
+	v.TokenSource = oauth.Register(v.settingsKey(), "Mercedes", oauth.RefreshTokenSource(token, v))
 
 	// add instance
 	addInstance(account, v)
@@ -121,10 +119,5 @@ func (v *Identity) RefreshToken(token *oauth2.Token) (*oauth2.Token, error) {
 		res.RefreshToken = token.RefreshToken
 	}
 
-	tok := util.TokenWithExpiry(&res)
-	v.TokenSource = oauth.RefreshTokenSource(tok, v)
-
-	err := settings.SetJson(v.settingsKey(), tok)
-
-	return tok, err
+	return util.TokenWithExpiry(&res), nil
 }
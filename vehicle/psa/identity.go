@@ -39,9 +39,8 @@ func NewIdentity(log *util.Logger, brand, user string, oc *oauth2.Config, token
 		subject: subject,
 	}
 
-	var tok oauth2.Token
-	if err := settings.Json(v.subject, &tok); err == nil {
-		token = &tok
+	if tok, err := oauth.LoadToken(v.subject); err == nil {
+		token = tok
 	}
 
 	if !token.Valid() {
@@ -54,7 +53,7 @@ func NewIdentity(log *util.Logger, brand, user string, oc *oauth2.Config, token
 		return nil, errors.New("token expired")
 	}
 
-	v.TokenSource = oauth.RefreshTokenSource(token, v)
+	v.TokenSource = oauth.Register(v.subject, "PSA", oauth.RefreshTokenSource(token, v))
 
 	// add instance
 	addInstance(v.subject, v)
@@ -77,8 +76,5 @@ func (v *Identity) RefreshToken(token *oauth2.Token) (*oauth2.Token, error) {
 		return nil, err
 	}
 
-	v.TokenSource = oauth.RefreshTokenSource(tok, v)
-	err = settings.SetJson(v.subject, tok)
-
-	return tok, err
+	return tok, nil
 }
@@ -7,6 +7,7 @@ import (
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util"
+	"github.com/evcc-io/evcc/util/ratelimit"
 	"github.com/evcc-io/evcc/util/request"
 	"github.com/evcc-io/evcc/util/transport"
 	"github.com/evcc-io/evcc/vehicle/tesla"
@@ -19,6 +20,7 @@ type Tesla struct {
 	*embed
 	*tesla.Provider
 	*tesla.Controller
+	*tesla.History
 }
 
 func init() {
@@ -65,10 +67,13 @@ func NewTeslaFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		return nil, err
 	}
 
+	// fleet api rate limits apply per account
+	limiter := ratelimit.New("tesla", cc.Credentials.ID, 60, time.Minute)
+
 	hc := request.NewClient(log)
 	hc.Transport = &oauth2.Transport{
 		Source: identity,
-		Base:   hc.Transport,
+		Base:   limiter.Transport(hc.Transport, time.Minute),
 	}
 
 	tc, err := teslaclient.NewClient(context.Background(), teslaclient.WithClient(hc))
@@ -112,6 +117,7 @@ func NewTeslaFromConfig(other map[string]interface{}) (api.Vehicle, error) {
 		embed:      &cc.embed,
 		Provider:   tesla.NewProvider(vehicle, cc.Cache),
 		Controller: tesla.NewController(vehicle.WithClient(tcc)),
+		History:    tesla.NewHistory(hc, region.FleetApiBaseUrl, vehicle.Vin),
 	}
 
 	v.fromVehicle(vehicle.DisplayName, 0)
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/evcc-io/evcc/api"
 	"github.com/evcc-io/evcc/util/sponsor"
@@ -42,3 +43,36 @@ func TestCommandResponse(t *testing.T) {
 
 	require.ErrorIs(t, NewController(v).ChargeEnable(true), api.ErrAsleep)
 }
+
+func TestChargingHistory(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/1/dx/charging/history", r.URL.Path)
+		require.Equal(t, "abc", r.URL.Query().Get("vin"))
+
+		if r.URL.Query().Get("pageNo") == "0" {
+			w.Write([]byte(`{"response": {"data": [{
+				"sessionId": 1,
+				"siteLocationName": "Truckee, CA - Soaring Way",
+				"chargeStartDateTime": "2023-07-27T11:43:45-07:00",
+				"chargeStopDateTime": "2023-07-27T12:08:35-07:00",
+				"fees": [
+					{"feeType": "CHARGING", "usageBase": 40, "usageTier1": 2.5, "totalDue": 18.4, "uom": "kwh"},
+					{"feeType": "PARKING", "usageBase": 5, "totalDue": 2, "uom": "min"}
+				]
+			}], "hasMoreData": true}}`))
+			return
+		}
+
+		w.Write([]byte(`{"response": {"data": [], "hasMoreData": false}}`))
+	}))
+	defer srv.Close()
+
+	res, err := NewHistory(http.DefaultClient, srv.URL, "abc").ChargingHistory(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+
+	require.Equal(t, 42.5, res[0].Energy)
+	require.Equal(t, 20.4, *res[0].Price)
+	require.Equal(t, "Truckee, CA - Soaring Way", res[0].Location)
+	require.Equal(t, 25*time.Minute-10*time.Second, res[0].End.Sub(res[0].Start))
+}
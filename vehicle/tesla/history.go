@@ -0,0 +1,57 @@
+package tesla
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util/request"
+)
+
+// History provides the vehicle's supercharger sessions from the fleet api
+type History struct {
+	*request.Helper
+	uri, vin string
+}
+
+// NewHistory creates a charging history provider
+func NewHistory(client *http.Client, baseUrl, vin string) *History {
+	return &History{
+		Helper: &request.Helper{Client: client},
+		uri:    strings.TrimSuffix(baseUrl, "/") + "/api/1/dx/charging/history",
+		vin:    vin,
+	}
+}
+
+var _ api.VehicleChargingHistory = (*History)(nil)
+
+// ChargingHistory implements the api.VehicleChargingHistory interface
+func (v *History) ChargingHistory(from time.Time) ([]api.ChargingRecord, error) {
+	var res []api.ChargingRecord
+
+	for page := 0; ; page++ {
+		params := url.Values{
+			"vin":       {v.vin},
+			"startTime": {from.Format(time.RFC3339)},
+			"pageNo":    {strconv.Itoa(page)},
+			"pageSize":  {"50"},
+		}
+
+		var hr HistoryResponse
+		if err := v.GetJSON(fmt.Sprintf("%s?%s", v.uri, params.Encode()), &hr); err != nil {
+			return nil, err
+		}
+
+		for _, s := range hr.Response.Data {
+			res = append(res, s.record())
+		}
+
+		if !hr.Response.HasMoreData || len(hr.Response.Data) == 0 {
+			return res, nil
+		}
+	}
+}
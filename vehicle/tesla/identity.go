@@ -6,7 +6,6 @@ import (
 	"fmt"
 	"sync"
 
-	"github.com/evcc-io/evcc/server/db/settings"
 	"github.com/evcc-io/evcc/util"
 	"github.com/evcc-io/evcc/util/oauth"
 	"github.com/evcc-io/evcc/util/request"
@@ -67,9 +66,8 @@ func NewIdentity(log *util.Logger, oc *oauth2.Config, token *oauth2.Token) (oaut
 
 	// database token
 	if !token.Valid() {
-		var tok oauth2.Token
-		if err := settings.Json(v.settingsKey(), &tok); err == nil {
-			token = &tok
+		if tok, err := oauth.LoadToken(v.settingsKey()); err == nil {
+			token = tok
 		}
 	}
 
@@ -83,7 +81,7 @@ func NewIdentity(log *util.Logger, oc *oauth2.Config, token *oauth2.Token) (oaut
 		return nil, errors.New("token expired")
 	}
 
-	v.TokenSource = oauth.RefreshTokenSource(token, v)
+	v.TokenSource = oauth.RegisterLogin(v.settingsKey(), "Tesla", oc, oauth.RefreshTokenSource(token, v))
 
 	// add instance
 	addInstance(claims.Subject, v)
@@ -101,12 +99,5 @@ func (v *Identity) RefreshToken(token *oauth2.Token) (*oauth2.Token, error) {
 
 	// refresh token source
 	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, request.NewClient(v.log))
-	token, err := v.oc.TokenSource(ctx, token).Token()
-	if err != nil {
-		return nil, err
-	}
-
-	err = settings.SetJson(v.settingsKey(), token)
-
-	return token, err
+	return v.oc.TokenSource(ctx, token).Token()
 }
@@ -1,6 +1,10 @@
 package tesla
 
 import (
+	"strings"
+	"time"
+
+	"github.com/evcc-io/evcc/api"
 	tesla "github.com/evcc-io/tesla-proxy-client"
 )
 
@@ -18,3 +22,50 @@ type Region struct {
 	Region          string
 	FleetApiBaseUrl string `json:"fleet_api_base_url"`
 }
+
+type HistoryResponse struct {
+	Response struct {
+		Data         []ChargingSession
+		TotalResults int
+		HasMoreData  bool
+	}
+}
+
+type ChargingSession struct {
+	SessionId           int64
+	SiteLocationName    string
+	ChargeStartDateTime time.Time
+	ChargeStopDateTime  time.Time
+	Fees                []ChargingFee
+}
+
+type ChargingFee struct {
+	FeeType      string
+	CurrencyCode string
+	UsageBase    float64
+	UsageTier1   float64
+	UsageTier2   float64
+	UsageTier3   float64
+	UsageTier4   float64
+	TotalDue     float64
+	Uom          string
+}
+
+// record converts the session into a charging record, energy is the usage of all kWh charging fees
+func (s ChargingSession) record() api.ChargingRecord {
+	var energy, price float64
+	for _, f := range s.Fees {
+		price += f.TotalDue
+		if f.FeeType == "CHARGING" && strings.EqualFold(f.Uom, "kwh") {
+			energy += f.UsageBase + f.UsageTier1 + f.UsageTier2 + f.UsageTier3 + f.UsageTier4
+		}
+	}
+
+	return api.ChargingRecord{
+		Start:    s.ChargeStartDateTime,
+		End:      s.ChargeStopDateTime,
+		Energy:   energy,
+		Price:    &price,
+		Location: s.SiteLocationName,
+	}
+}
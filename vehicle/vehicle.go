@@ -3,6 +3,7 @@ package vehicle
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/evcc-io/evcc/api"
@@ -10,12 +11,13 @@ import (
 	"github.com/evcc-io/evcc/util"
 )
 
-//go:generate go tool decorate -f decorateVehicle -b api.Vehicle -t "api.SocLimiter,GetLimitSoc,func() (int64, error)" -t "api.ChargeState,Status,func() (api.ChargeStatus, error)" -t "api.VehicleRange,Range,func() (int64, error)" -t "api.VehicleOdometer,Odometer,func() (float64, error)" -t "api.VehicleClimater,Climater,func() (bool, error)" -t "api.CurrentController,MaxCurrent,func(int64) error" -t "api.CurrentGetter,GetMaxCurrent,func() (float64, error)" -t "api.VehicleFinishTimer,FinishTime,func() (time.Time, error)" -t "api.Resurrector,WakeUp,func() error" -t "api.ChargeController,ChargeEnable,func(bool) error"
+//go:generate go tool decorate -f decorateVehicle -b *Vehicle -r api.Vehicle -t "api.SocLimiter,GetLimitSoc,func() (int64, error)" -t "api.ChargeState,Status,func() (api.ChargeStatus, error)" -t "api.VehicleRange,Range,func() (int64, error)" -t "api.VehicleOdometer,Odometer,func() (float64, error)" -t "api.VehicleClimater,Climater,func() (bool, error)" -t "api.CurrentController,MaxCurrent,func(int64) error" -t "api.CurrentGetter,GetMaxCurrent,func() (float64, error)" -t "api.VehicleFinishTimer,FinishTime,func() (time.Time, error)" -t "api.Resurrector,WakeUp,func() error" -t "api.ChargeController,ChargeEnable,func(bool) error"
 
 // Vehicle is an api.Vehicle implementation with configurable getters and setters.
 type Vehicle struct {
 	*embed
-	socG func() (float64, error)
+	socG       func() (float64, error)
+	auxiliaryG func() (float64, int64, error)
 }
 
 func init() {
@@ -37,6 +39,10 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 		FinishTime    *plugin.Config
 		Wakeup        *plugin.Config
 		ChargeEnable  *plugin.Config
+		Auxiliary     *struct {
+			Soc   plugin.Config
+			Range *plugin.Config
+		}
 	}
 
 	if err := util.DecodeOther(other, &cc); err != nil {
@@ -139,6 +145,28 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 		return nil, fmt.Errorf("chargeEnable: %w", err)
 	}
 
+	// auxiliary energy store
+	if cc.Auxiliary != nil {
+		socG, err := cc.Auxiliary.Soc.FloatGetter(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("auxiliary soc: %w", err)
+		}
+
+		rngG, err := cc.Auxiliary.Range.IntGetter(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("auxiliary range: %w", err)
+		}
+
+		v.auxiliaryG = func() (float64, int64, error) {
+			soc, err := socG()
+			if err != nil || rngG == nil {
+				return soc, 0, err
+			}
+			rng, err := rngG()
+			return soc, rng, err
+		}
+	}
+
 	return decorateVehicle(v, limitSoc, status, rng, odo, climater, maxCurrent, getMaxCurrent, finishTime, wakeup, chargeEnable), nil
 }
 
@@ -146,3 +174,22 @@ func NewConfigurableFromConfig(ctx context.Context, other map[string]interface{}
 func (v *Vehicle) Soc() (float64, error) {
 	return v.socG()
 }
+
+// Features implements the api.FeatureDescriber interface
+func (v *Vehicle) Features() []api.Feature {
+	res := slices.Clone(v.embed.Features())
+	if v.auxiliaryG != nil {
+		res = append(res, api.Auxiliary)
+	}
+	return res
+}
+
+var _ api.VehicleAuxiliary = (*Vehicle)(nil)
+
+// Auxiliary implements the api.VehicleAuxiliary interface
+func (v *Vehicle) Auxiliary() (float64, int64, error) {
+	if v.auxiliaryG == nil {
+		return 0, 0, api.ErrNotAvailable
+	}
+	return v.auxiliaryG()
+}
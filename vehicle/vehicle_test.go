@@ -0,0 +1,42 @@
+package vehicle
+
+import (
+	"context"
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVehicleAuxiliary(t *testing.T) {
+	v, err := NewConfigurableFromConfig(context.TODO(), map[string]any{
+		"soc": map[string]any{"source": "const", "value": 80},
+		"auxiliary": map[string]any{
+			"soc":   map[string]any{"source": "const", "value": 50},
+			"range": map[string]any{"source": "const", "value": 400},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Contains(t, v.(api.FeatureDescriber).Features(), api.Auxiliary)
+
+	soc, rng, err := v.(api.VehicleAuxiliary).Auxiliary()
+	require.NoError(t, err)
+	assert.Equal(t, 50.0, soc)
+	assert.Equal(t, int64(400), rng)
+
+	soc, err = v.Soc()
+	require.NoError(t, err)
+	assert.Equal(t, 80.0, soc, "traction battery soc")
+
+	v, err = NewConfigurableFromConfig(context.TODO(), map[string]any{
+		"soc": map[string]any{"source": "const", "value": 80},
+	})
+	require.NoError(t, err)
+
+	assert.NotContains(t, v.(api.FeatureDescriber).Features(), api.Auxiliary)
+
+	_, _, err = v.(api.VehicleAuxiliary).Auxiliary()
+	assert.ErrorIs(t, err, api.ErrNotAvailable)
+}
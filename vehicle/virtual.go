@@ -0,0 +1,86 @@
+package vehicle
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/evcc-io/evcc/util"
+)
+
+// Virtual is a vehicle without soc api. Its soc is tracked from the energy charged and
+// may be corrected manually, e.g. from the vehicle's display.
+type Virtual struct {
+	*embed
+	mu         sync.Mutex
+	soc        float64
+	efficiency float64
+}
+
+func init() {
+	registry.Add("virtual", NewVirtualFromConfig)
+}
+
+// NewVirtualFromConfig creates a new virtual vehicle
+func NewVirtualFromConfig(other map[string]interface{}) (api.Vehicle, error) {
+	cc := struct {
+		embed      `mapstructure:",squash"`
+		Soc        float64 // initial soc
+		Efficiency float64 // share of charged energy stored in the battery
+	}{
+		Efficiency: 0.9,
+	}
+
+	if err := util.DecodeOther(other, &cc); err != nil {
+		return nil, err
+	}
+
+	if cc.Capacity_ <= 0 {
+		return nil, errors.New("missing capacity")
+	}
+
+	if cc.Efficiency <= 0 || cc.Efficiency > 1 {
+		return nil, fmt.Errorf("invalid efficiency: %v", cc.Efficiency)
+	}
+
+	v := &Virtual{
+		embed:      &cc.embed,
+		efficiency: cc.Efficiency,
+	}
+
+	if err := v.SetSoc(cc.Soc); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+// Soc implements the api.Vehicle interface
+func (v *Virtual) Soc() (float64, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.soc, nil
+}
+
+var _ api.SocTracker = (*Virtual)(nil)
+
+// SetSoc implements the api.SocTracker interface
+func (v *Virtual) SetSoc(soc float64) error {
+	if soc < 0 || soc > 100 {
+		return fmt.Errorf("invalid soc: %v", soc)
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.soc = soc
+
+	return nil
+}
+
+// AddEnergy implements the api.SocTracker interface
+func (v *Virtual) AddEnergy(energy float64) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.soc = min(max(v.soc+100*energy*v.efficiency/v.Capacity(), 0), 100)
+}
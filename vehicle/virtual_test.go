@@ -0,0 +1,35 @@
+package vehicle
+
+import (
+	"testing"
+
+	"github.com/evcc-io/evcc/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVirtual(t *testing.T) {
+	_, err := NewVirtualFromConfig(map[string]any{"soc": 50})
+	assert.Error(t, err, "missing capacity")
+
+	v, err := NewVirtualFromConfig(map[string]any{"capacity": 50, "soc": 20, "efficiency": 1})
+	require.NoError(t, err)
+
+	vt, ok := v.(api.SocTracker)
+	require.True(t, ok)
+
+	vt.AddEnergy(10)
+	soc, err := v.Soc()
+	require.NoError(t, err)
+	assert.Equal(t, 40.0, soc)
+
+	// manual correction
+	require.NoError(t, vt.SetSoc(30))
+	assert.Error(t, vt.SetSoc(101))
+
+	// charging stops at full battery
+	vt.AddEnergy(100)
+	soc, err = v.Soc()
+	require.NoError(t, err)
+	assert.Equal(t, 100.0, soc)
+}